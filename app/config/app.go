@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 )
@@ -9,26 +12,85 @@ const (
 	envHttpPort            = "CONFIG_HTTP_PORT"
 	envLogLevel            = "CONFIG_LOG_LEVEL"
 	envJwtSecret           = "CONFIG_JWT_SECRET"
+	envJwtKeysDir          = "CONFIG_JWT_KEYS_DIR"
+	envJwtActiveKeyID      = "CONFIG_JWT_ACTIVE_KEY_ID"
+	envJwtAudience         = "CONFIG_JWT_AUDIENCE"
 	envCorsAllowedOrigins  = "CONFIG_CORS_ALLOWED_ORIGINS"
 	envCorsAllowedHeaders  = "CONFIG_CORS_ALLOWED_HEADERS"
 	envTimezone            = "CONFIG_TIMEZONE"
 	envSendGridApiKey      = "CONFIG_SENDGRID_API_KEY"
 	envSendGridTemplateDir = "CONFIG_SENDGRID_TEMPLATE_DIR"
+	envTemplateWatch       = "CONFIG_TEMPLATE_WATCH_INTERVAL"
 	envSendMail            = "CONFIG_SEND_MAIL"
 	envHost                = "CONFIG_HOST"
+	envAppName             = "CONFIG_APP_NAME"
+	envSenderName          = "CONFIG_SENDER_NAME"
+	envSenderAddress       = "CONFIG_SENDER_ADDRESS"
+	envSenderPostalAddress = "CONFIG_SENDER_POSTAL_ADDRESS"
+	envSenderDomains       = "CONFIG_SENDER_DOMAINS"
+	envSpfInclude          = "CONFIG_SPF_INCLUDE"
+	envSmtpHost            = "CONFIG_SMTP_HOST"
+	envSmtpPort            = "CONFIG_SMTP_PORT"
+	envSmtpUsername        = "CONFIG_SMTP_USERNAME"
+	envSmtpPassword        = "CONFIG_SMTP_PASSWORD"
+	envDkimSelector        = "CONFIG_DKIM_SELECTOR"
+	envDkimKeyFile         = "CONFIG_DKIM_KEY_FILE"
+	envTrackingSecret      = "CONFIG_TRACKING_SECRET"
+	envSendGridWebhookKey  = "CONFIG_SENDGRID_WEBHOOK_KEY"
+	envWebhookAllowPrivate = "CONFIG_WEBHOOK_ALLOW_PRIVATE_NETWORKS"
+	envCacheReconcile      = "CONFIG_CACHE_RECONCILE_INTERVAL"
+	envCacheBackend        = "CONFIG_CACHE_BACKEND"
+	envCacheMemoryTTL      = "CONFIG_CACHE_MEMORY_TTL"
+	envCacheMemoryMaxSize  = "CONFIG_CACHE_MEMORY_MAX_ENTRIES"
+	envMaintenanceToken    = "CONFIG_MAINTENANCE_TOKEN"
+	envRemovalTimeout      = "CONFIG_NEWSLETTER_REMOVAL_TIMEOUT"
+)
+
+// CacheBackend stores subscribed newsletters of subscribers, postgres stays source of truth for all of them.
+type CacheBackend string
+
+const (
+	CacheBackendFirebase CacheBackend = "firebase"
+	CacheBackendMemory   CacheBackend = "memory"
+	CacheBackendNone     CacheBackend = "none"
 )
 
 type AppConfig struct {
 	HttpPort            int
 	LogLevel            logrus.Level
 	JwtSecret           string
+	JwtKeysDir          string
+	JwtActiveKeyID      string
+	JwtAudience         string
 	CorsAllowedOrigins  []string
 	CorsAllowedHeaders  []string
 	Timezone            string
 	SendGridApiKey      string
 	SendGridTemplateDir string
+	TemplateWatch       time.Duration
 	SendMail            bool
 	Host                string
+	AppName             string
+	SenderName          string
+	SenderAddress       string
+	SenderPostalAddress string
+	SenderDomains       []string
+	SpfInclude          string
+	SmtpHost            string
+	SmtpPort            int
+	SmtpUsername        string
+	SmtpPassword        string
+	DkimSelector        string
+	DkimKeyFile         string
+	TrackingSecret      string
+	SendGridWebhookKey  string
+	WebhookAllowPrivate bool
+	CacheReconcile      time.Duration
+	CacheBackend        CacheBackend
+	CacheMemoryTTL      time.Duration
+	CacheMemoryMaxSize  int
+	MaintenanceToken    string
+	RemovalTimeout      time.Duration
 }
 
 func NewAppConfig() (*AppConfig, error) {
@@ -44,10 +106,20 @@ func NewAppConfig() (*AppConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	// shared secret is optional once asymmetric keys are configured, kept only to verify older tokens
 	jwtSecret := viper.GetString(envJwtSecret)
-	if jwtSecret == "" {
+	jwtKeysDir := viper.GetString(envJwtKeysDir)
+	if jwtSecret == "" && jwtKeysDir == "" {
 		return nil, getMissingError(envJwtSecret)
 	}
+	jwtActiveKeyID := viper.GetString(envJwtActiveKeyID)
+	if jwtKeysDir != "" && jwtActiveKeyID == "" {
+		return nil, getMissingError(envJwtActiveKeyID)
+	}
+	jwtAudience := viper.GetString(envJwtAudience)
+	if jwtAudience == "" {
+		return nil, getMissingError(envJwtAudience)
+	}
 	corsAllowedOrigins := viper.GetStringSlice(envCorsAllowedOrigins)
 	if len(corsAllowedOrigins) == 0 {
 		return nil, getMissingError(envCorsAllowedOrigins)
@@ -68,22 +140,125 @@ func NewAppConfig() (*AppConfig, error) {
 	if sendGridTemplateDir == "" {
 		return nil, getMissingError(envSendGridTemplateDir)
 	}
+	// templates are reloaded on change when set, meant for development
+	templateWatch := viper.GetDuration(envTemplateWatch)
 	sendMail := viper.GetBool(envSendMail)
 	host := viper.GetString(envHost)
 	if host == "" {
 		return nil, getMissingError(envHost)
 	}
+	appName := viper.GetString(envAppName)
+	if appName == "" {
+		return nil, getMissingError(envAppName)
+	}
+	senderName := viper.GetString(envSenderName)
+	if senderName == "" {
+		return nil, getMissingError(envSenderName)
+	}
+	senderAddress := viper.GetString(envSenderAddress)
+	if senderAddress == "" {
+		return nil, getMissingError(envSenderAddress)
+	}
+	// physical postal address is required in footer of every newsletter message by CAN-SPAM
+	senderPostalAddress := viper.GetString(envSenderPostalAddress)
+	if senderPostalAddress == "" {
+		return nil, getMissingError(envSenderPostalAddress)
+	}
+	// domains newsletters can send from, default sender is allowed only as exact address
+	senderDomains := viper.GetStringSlice(envSenderDomains)
+	// domain of mail provider which SPF record of owner's sending domain has to include
+	spfInclude := viper.GetString(envSpfInclude)
+	if spfInclude == "" {
+		return nil, getMissingError(envSpfInclude)
+	}
+	// own smtp relay replaces sendgrid when configured
+	smtpHost := viper.GetString(envSmtpHost)
+	smtpPort := viper.GetInt(envSmtpPort)
+	if smtpHost != "" && smtpPort == 0 {
+		return nil, getMissingError(envSmtpPort)
+	}
+	smtpUsername := viper.GetString(envSmtpUsername)
+	smtpPassword := viper.GetString(envSmtpPassword)
+	// key signing messages of application domains, sending domains of owners use their own keys
+	dkimKeyFile := viper.GetString(envDkimKeyFile)
+	dkimSelector := viper.GetString(envDkimSelector)
+	if dkimKeyFile != "" && dkimSelector == "" {
+		return nil, getMissingError(envDkimSelector)
+	}
+	// signs links of tracking pixels so job ids can not be forged
+	trackingSecret := viper.GetString(envTrackingSecret)
+	if trackingSecret == "" {
+		return nil, getMissingError(envTrackingSecret)
+	}
+	// verifies signed delivery events of SendGrid, they are rejected when not set
+	sendGridWebhookKey := viper.GetString(envSendGridWebhookKey)
+	// webhooks of owners can not reach internal services unless allowed, meant for development
+	webhookAllowPrivate := viper.GetBool(envWebhookAllowPrivate)
+	// subscription cache is compared with db and repaired periodically, hourly unless set
+	cacheReconcile := viper.GetDuration(envCacheReconcile)
+	if cacheReconcile == 0 {
+		cacheReconcile = time.Hour
+	}
+	// firebase is required only by its backend, memory one suits single instance and local runs
+	cacheBackend := CacheBackend(viper.GetString(envCacheBackend))
+	switch cacheBackend {
+	case "":
+		cacheBackend = CacheBackendFirebase
+	case CacheBackendFirebase, CacheBackendMemory, CacheBackendNone:
+	default:
+		return nil, fmt.Errorf("invalid cache backend %q in %s", cacheBackend, envCacheBackend)
+	}
+	cacheMemoryTTL := viper.GetDuration(envCacheMemoryTTL)
+	if cacheMemoryTTL == 0 {
+		cacheMemoryTTL = 5 * time.Minute
+	}
+	cacheMemoryMaxSize := viper.GetInt(envCacheMemoryMaxSize)
+	if cacheMemoryMaxSize == 0 {
+		cacheMemoryMaxSize = 10000
+	}
+	// maintenance endpoints are not registered when not set
+	maintenanceToken := viper.GetString(envMaintenanceToken)
+	// removal of newsletter touches every subscription and email job of it, 30s unless set
+	removalTimeout := viper.GetDuration(envRemovalTimeout)
+	if removalTimeout == 0 {
+		removalTimeout = 30 * time.Second
+	}
 
 	return &AppConfig{
 		HttpPort:            httpPort,
 		LogLevel:            lvl,
 		JwtSecret:           jwtSecret,
+		JwtKeysDir:          jwtKeysDir,
+		JwtActiveKeyID:      jwtActiveKeyID,
+		JwtAudience:         jwtAudience,
 		CorsAllowedOrigins:  corsAllowedOrigins,
 		CorsAllowedHeaders:  corsAllowedHeaders,
 		Timezone:            timezone,
 		SendGridApiKey:      sendGridApiKey,
 		SendGridTemplateDir: sendGridTemplateDir,
+		TemplateWatch:       templateWatch,
 		SendMail:            sendMail,
 		Host:                host,
+		AppName:             appName,
+		SenderName:          senderName,
+		SenderAddress:       senderAddress,
+		SenderPostalAddress: senderPostalAddress,
+		SenderDomains:       senderDomains,
+		SpfInclude:          spfInclude,
+		SmtpHost:            smtpHost,
+		SmtpPort:            smtpPort,
+		SmtpUsername:        smtpUsername,
+		SmtpPassword:        smtpPassword,
+		DkimSelector:        dkimSelector,
+		DkimKeyFile:         dkimKeyFile,
+		TrackingSecret:      trackingSecret,
+		SendGridWebhookKey:  sendGridWebhookKey,
+		WebhookAllowPrivate: webhookAllowPrivate,
+		CacheReconcile:      cacheReconcile,
+		CacheBackend:        cacheBackend,
+		CacheMemoryTTL:      cacheMemoryTTL,
+		CacheMemoryMaxSize:  cacheMemoryMaxSize,
+		MaintenanceToken:    maintenanceToken,
+		RemovalTimeout:      removalTimeout,
 	}, nil
 }
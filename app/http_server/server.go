@@ -34,7 +34,7 @@ func NewServer(lg logger.Logger, cfg *config.AppConfig) *Server {
 
 	cf := cors.DefaultConfig()
 	cf.AllowOrigins = cfg.CorsAllowedOrigins
-	cf.AllowMethods = []string{"GET", "POST"}
+	cf.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
 	cf.AllowHeaders = cfg.CorsAllowedHeaders
 	cf.AllowCredentials = true
 	cf.MaxAge = 12 * time.Hour
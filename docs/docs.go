@@ -17,6 +17,25 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public key"
+                ],
+                "summary": "Public keys for verification of issued tokens by other services",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.JWKS"
+                        }
+                    }
+                }
+            }
+        },
         "/api/health/liveness": {
             "get": {
                 "tags": [
@@ -49,108 +68,80 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/newsletters": {
+        "/api/maintenance/events/failed": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "newsletter"
+                    "maintenance"
                 ],
-                "summary": "Retrieve newsletter by creator's user ID",
+                "summary": "Lists domain events given up after their last dispatch attempt failed, most recently failed first",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "application/json",
-                        "description": "application/json",
-                        "name": "Content-Type",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "Bearer",
-                        "description": "Bearer \u003ctoken\u003e",
-                        "name": "Authorization",
+                        "description": "Maintenance token",
+                        "name": "X-Maintenance-Token",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items on page",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page_number",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved newsletters by user ID",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.InternalNewsletter"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.FailedEvent"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request with detail",
-                        "schema": {
-                            "$ref": "#/definitions/response.Error"
-                        }
+                    "401": {
+                        "description": "Invalid maintenance token"
                     },
                     "500": {
                         "description": "Unexpected exception"
                     }
                 }
-            },
+            }
+        },
+        "/api/maintenance/events/failed/{event_id}/retry": {
             "post": {
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "newsletter"
+                    "maintenance"
                 ],
-                "summary": "Create new newsletter",
+                "summary": "Schedules failed domain event to be dispatched again with fresh attempts",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "Bearer",
-                        "description": "Bearer \u003ctoken\u003e",
-                        "name": "Authorization",
+                        "description": "Maintenance token",
+                        "name": "X-Maintenance-Token",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Newsletter data to create",
-                        "name": "Newsletter",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CreateNewsletterRequest"
-                        }
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "event_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Newsletter was successfully created"
+                    "204": {
+                        "description": "Event was scheduled"
                     },
                     "400": {
-                        "description": "Invalid request with detail",
+                        "description": "Invalid event ID",
                         "schema": {
                             "$ref": "#/definitions/response.Error"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized"
+                        "description": "Invalid maintenance token"
+                    },
+                    "404": {
+                        "description": "Failed event not found"
                     },
                     "500": {
                         "description": "Unexpected exception"
@@ -158,57 +149,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/newsletters/{newsletter_public_id}/subscriptions": {
+        "/api/maintenance/subscription-cache/reconcile": {
             "post": {
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public subscription"
+                    "maintenance"
                 ],
-                "summary": "Used to subscribe to newsletter by email",
+                "summary": "Compares subscription cache with active subscriptions and repairs drift right away",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Public newsletter identifier",
-                        "name": "newsletter_public_id",
-                        "in": "path",
+                        "description": "Maintenance token",
+                        "name": "X-Maintenance-Token",
+                        "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Subscriber email address",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.SubscribeToNewsletter"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully subscribed to newsletter"
-                    },
-                    "400": {
-                        "description": "Invalid request with detail",
-                        "schema": {
-                            "$ref": "#/definitions/response.Error"
-                        }
-                    },
-                    "404": {
-                        "description": "Newsletter not found",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Error"
+                            "$ref": "#/definitions/response.CacheReconciliation"
                         }
                     },
-                    "409": {
-                        "description": "Already subscribed to newsletter",
-                        "schema": {
-                            "$ref": "#/definitions/response.Error"
-                        }
+                    "401": {
+                        "description": "Invalid maintenance token"
                     },
                     "500": {
                         "description": "Unexpected exception"
@@ -216,15 +183,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/newsletters/{public_id}": {
+        "/api/v1/api-keys": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public newsletter"
+                    "api key"
                 ],
-                "summary": "Retrieve newsletter by its public ID",
+                "summary": "List active API keys of user",
                 "parameters": [
                     {
                         "type": "string",
@@ -236,17 +203,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Newsletter public ID",
-                        "name": "public_id",
-                        "in": "path",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved newsletter by public ID",
+                        "description": "Successfully retrieved api keys",
                         "schema": {
-                            "$ref": "#/definitions/response.PublicNewsletter"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.APIKey"
+                            }
                         }
                     },
                     "400": {
@@ -255,63 +226,47 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
                     "500": {
                         "description": "Unexpected exception"
                     }
                 }
-            }
-        },
-        "/api/v1/subscriptions/{email}/newsletters": {
-            "get": {
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public subscription"
+                    "api key"
                 ],
-                "summary": "Retrieve newsletter by subscriber's email",
+                "summary": "Create API key, the key is returned only once",
                 "parameters": [
                     {
                         "type": "string",
-                        "default": "application/json",
-                        "description": "application/json",
-                        "name": "Content-Type",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of items on page",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page_number",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "test@test.com",
-                        "description": "Subscribers email",
-                        "name": "email",
-                        "in": "path",
-                        "required": true
+                        "description": "API key name, scopes and optional expiration",
+                        "name": "APIKey",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateAPIKeyRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully retrieved newsletters by subscriber email"
+                    "201": {
+                        "description": "API key was successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreatedAPIKey"
+                        }
                     },
                     "400": {
                         "description": "Invalid request with detail",
@@ -319,24 +274,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
                     "500": {
                         "description": "Unexpected exception"
                     }
                 }
             }
         },
-        "/api/v1/unsubscribe": {
-            "get": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/api-keys/{id}": {
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public subscription"
+                    "api key"
                 ],
-                "summary": "Used to unsubscribe from newsletter by email",
+                "summary": "Revoke API key, it stops working immediately",
                 "parameters": [
                     {
                         "type": "string",
@@ -348,22 +303,23 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Public newsletter identifier",
-                        "name": "newsletter_public_id",
-                        "in": "query",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Token to associate with subscription",
-                        "name": "token",
-                        "in": "query",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully unsubscribed from newsletter"
+                    "204": {
+                        "description": "API key was successfully revoked"
                     },
                     "400": {
                         "description": "Invalid request with detail",
@@ -371,35 +327,52 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Error"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "API key not found"
+                    },
                     "500": {
                         "description": "Unexpected exception"
                     }
                 }
             }
         },
-        "/api/v1/users/login": {
+        "/api/v1/invitations/accept": {
             "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public user"
+                    "member"
                 ],
-                "summary": "Login user, returning token for authorization",
+                "summary": "Accept invitation to newsletter, user has to be signed in with invited email",
                 "parameters": [
                     {
-                        "description": "Data for user login",
-                        "name": "data",
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Token from invitation email",
+                        "name": "Invitation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UserRequest"
+                            "$ref": "#/definitions/request.AcceptInvitationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User successfully logged in"
+                    "200": {
+                        "description": "Invitation was accepted",
+                        "schema": {
+                            "$ref": "#/definitions/response.AcceptedInvitation"
+                        }
                     },
                     "400": {
                         "description": "Invalid request with detail",
@@ -408,10 +381,16 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
-                        "schema": {
-                            "$ref": "#/definitions/response.Error"
-                        }
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Invitation was sent to different email"
+                    },
+                    "404": {
+                        "description": "Invitation not found or expired"
+                    },
+                    "409": {
+                        "description": "Already member"
                     },
                     "500": {
                         "description": "Unexpected exception"
@@ -419,29 +398,278 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/register": {
-            "post": {
+        "/api/v1/newsletters": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public user"
+                    "newsletter"
                 ],
-                "summary": "Register user, returning token for authorization",
+                "summary": "Retrieve newsletter by creator's user ID",
                 "parameters": [
                     {
-                        "description": "Data for registering user",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UserRequest"
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items on page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page_number",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved newsletters by user ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.InternalNewsletter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Create new newsletter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Newsletter data to create",
+                        "name": "Newsletter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateNewsletterRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "User was successfully registered"
+                        "description": "Newsletter was successfully created"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{newsletter_public_id}/invitations": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "member"
+                ],
+                "summary": "Invite collaborator to newsletter by email, only owner can invite",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "newsletter_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Email and role (editor or viewer)",
+                        "name": "Invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.InviteMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Invitation was successfully sent"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "409": {
+                        "description": "Already member"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{newsletter_public_id}/owner": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "member"
+                ],
+                "summary": "Transfer ownership to existing member, previous owner becomes editor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "newsletter_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User ID of new owner",
+                        "name": "Owner",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TransferOwnershipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Ownership was transferred"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or member not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{newsletter_public_id}/subscriptions": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public subscription"
+                ],
+                "summary": "Used to subscribe to newsletter by email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "en",
+                        "description": "Used when locale is not in body",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Public newsletter identifier",
+                        "name": "newsletter_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscriber email address and optional locale (en, cs)",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SubscribeToNewsletter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully subscribed to newsletter"
                     },
                     "400": {
                         "description": "Invalid request with detail",
@@ -449,8 +677,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.Error"
                         }
                     },
+                    "404": {
+                        "description": "Newsletter not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
                     "409": {
-                        "description": "Email taken",
+                        "description": "Already subscribed to newsletter",
                         "schema": {
                             "$ref": "#/definitions/response.Error"
                         }
@@ -460,134 +694,4486 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "request.CreateNewsletterRequest": {
+        },
+        "/api/v1/newsletters/{public_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public newsletter"
+                ],
+                "summary": "Retrieve newsletter by its public ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved newsletter by public ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.PublicNewsletter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Newsletter not found or archived",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "delete": {
+                "description": "Archived newsletter is hidden, accepts no new subscriptions, its pending emails are cancelled\nand subscriptions disabled. Hard delete removes newsletter with subscriptions and email jobs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Archive or permanently delete newsletter, only owner can do it",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Delete permanently instead of archiving",
+                        "name": "hard_delete",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Notify active subscribers by email",
+                        "name": "notify",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Newsletter was removed"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Newsletter not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "patch": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Change name or description of newsletter, only owner can do it",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changed newsletter data",
+                        "name": "Newsletter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateNewsletterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Newsletter was successfully updated",
+                        "schema": {
+                            "$ref": "#/definitions/response.InternalNewsletter"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Newsletter not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/archive": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public newsletter"
+                ],
+                "summary": "List published issues of newsletter, newest first",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of cached response",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items on page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page_number",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Published issues",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginatedResponse-array_response_ArchivedIssueSummary"
+                        }
+                    },
+                    "304": {
+                        "description": "Cached response is current"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Newsletter not found or archived",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/archive/{slug}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public newsletter"
+                ],
+                "summary": "Retrieve published issue by its slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of cached response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of cached response",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Issue slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Published issue",
+                        "schema": {
+                            "$ref": "#/definitions/response.ArchivedIssue"
+                        }
+                    },
+                    "304": {
+                        "description": "Cached response is current"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Newsletter or issue not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/issues": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "List drafts and published issues of newsletter, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved issues",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.Issue"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "Create draft issue written in markdown, editor role is required",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Slug is derived from title when empty",
+                        "name": "Issue",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Issue was created",
+                        "schema": {
+                            "$ref": "#/definitions/response.Issue"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "409": {
+                        "description": "Slug already used in newsletter",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/issues/{issue_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "Get issue of newsletter, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved issue",
+                        "schema": {
+                            "$ref": "#/definitions/response.Issue"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Newsletter or issue not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "Replace title and markdown body of issue, editor role is required",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Issue content",
+                        "name": "Issue",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Issue was updated",
+                        "schema": {
+                            "$ref": "#/definitions/response.Issue"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or issue not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/issues/{issue_id}/publish": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issue"
+                ],
+                "summary": "Publish issue and send it to active subscribers, editor role is required",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e or ApiKey \u003ckey\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Issue was published",
+                        "schema": {
+                            "$ref": "#/definitions/response.PublishedIssue"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or issue not found"
+                    },
+                    "409": {
+                        "description": "Issue already published",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/members": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "member"
+                ],
+                "summary": "List members of newsletter, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved members",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.Member"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/members/{user_id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "member"
+                ],
+                "summary": "Remove member from newsletter, owner can remove others and members can leave",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of member",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Member was removed"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or member not found"
+                    },
+                    "409": {
+                        "description": "Owner can not be removed"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/sender": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Get sender identity of newsletter, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved sender identity",
+                        "schema": {
+                            "$ref": "#/definitions/response.SenderIdentity"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Replace sender identity of newsletter, only owner can change it",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Empty values fall back to defaults",
+                        "name": "Sender",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateSenderIdentityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sender identity was updated",
+                        "schema": {
+                            "$ref": "#/definitions/response.SenderIdentity"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "422": {
+                        "description": "Sender domain is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/stats": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get statistics of newsletter in time buckets, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Bucket size",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of period (YYYY-MM-DD), 30 buckets before end by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of period (YYYY-MM-DD), today by default",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved statistics",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewsletterStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/stats/issues/{issue_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get delivery and engagement statistics of issue, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Issue ID",
+                        "name": "issue_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved statistics",
+                        "schema": {
+                            "$ref": "#/definitions/response.IssueStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or issue not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/stats/unsubscribe-comments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get comments of subscribers who unsubscribed in period, newest first, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of period (YYYY-MM-DD), 30 days before end by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of period (YYYY-MM-DD), today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items on page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page_number",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved comments",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginatedResponse-array_response_UnsubscribeComment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/templates": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "List email templates overridden by newsletter, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved template overrides",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TemplateOverride"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/templates/{name}": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Replace content of email template for newsletter, editor role is required",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "subscribed",
+                            "invitation"
+                        ],
+                        "type": "string",
+                        "description": "Template name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Content block of layout, text is converted from html when empty",
+                        "name": "Template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateTemplateOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template override was saved",
+                        "schema": {
+                            "$ref": "#/definitions/response.TemplateOverride"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "422": {
+                        "description": "Template is invalid or misses required variables",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Remove override so newsletter uses default template again, editor role is required",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Template name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Template override was removed"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or template override not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/templates/{name}/preview": {
+            "post": {
+                "description": "Issue template is rendered with issue given by issue_id, draft or published, as it would be delivered",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Render email template of newsletter with sample or subscriber data, editor role is required",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "subscribed",
+                            "invitation",
+                            "newsletter_removed",
+                            "issue"
+                        ],
+                        "type": "string",
+                        "description": "Template name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Draft content replacing stored template, locale and subscriber to render for",
+                        "name": "Preview",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.PreviewEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rendered email",
+                        "schema": {
+                            "$ref": "#/definitions/response.EmailPreview"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter, template, issue or subscription not found"
+                    },
+                    "422": {
+                        "description": "Draft template is invalid or misses required variables",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/templates/{name}/test": {
+            "post": {
+                "description": "Issue template is sent with issue given by issue_id, draft or published, as it would be delivered",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Send email template of newsletter rendered with sample data to own email, editor role is required",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "subscribed",
+                            "invitation",
+                            "newsletter_removed",
+                            "issue"
+                        ],
+                        "type": "string",
+                        "description": "Template name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Draft content replacing stored template and locale",
+                        "name": "Test",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.SendTestEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Test email was sent"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter, template or issue not found"
+                    },
+                    "422": {
+                        "description": "Draft template is invalid or misses required variables",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/tracking": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Get tracking settings of newsletter, available to every member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved tracking settings",
+                        "schema": {
+                            "$ref": "#/definitions/response.TrackingSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "newsletter"
+                ],
+                "summary": "Enable or disable tracking of newsletter, only owner can change it",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tracking settings",
+                        "name": "Tracking",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateTrackingSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tracking settings were updated",
+                        "schema": {
+                            "$ref": "#/definitions/response.TrackingSettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "List webhooks of newsletter, owner only",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhooks of newsletter",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.Webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Register webhook notified about selected events of newsletter, secret is returned only once, owner only",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Endpoint URL and event types (subscription.created, subscription.disabled, issue.published, email.bounced)",
+                        "name": "Webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook was registered",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreatedWebhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/webhooks/{webhook_id}": {
+            "delete": {
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Remove webhook, its pending deliveries are dropped, owner only",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Webhook was removed"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or webhook not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Delivery log of webhook, newest first, owner only",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items on page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page_number",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery log",
+                        "schema": {
+                            "$ref": "#/definitions/response.PaginatedResponse-array_response_WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or webhook not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/newsletters/{public_id}/webhooks/{webhook_id}/test": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Send webhook.test event to webhook right away, result of the attempt is returned and logged, owner only",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Test event was attempted, status tells the result",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Insufficient role"
+                    },
+                    "404": {
+                        "description": "Newsletter or webhook not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/sending-domains": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sending domain"
+                ],
+                "summary": "List sending domains of user with their DNS records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved sending domains",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.SendingDomain"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            },
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sending domain"
+                ],
+                "summary": "Register sending domain, response contains DNS records which have to be published before verification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Domain name",
+                        "name": "SendingDomain",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterSendingDomainRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Sending domain was registered",
+                        "schema": {
+                            "$ref": "#/definitions/response.SendingDomain"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Domain already registered"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/sending-domains/{id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sending domain"
+                ],
+                "summary": "Delete sending domain",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sending domain ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Sending domain was deleted"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Sending domain not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/sending-domains/{id}/verify": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sending domain"
+                ],
+                "summary": "Check DNS records of sending domain, domain can be used as from address once all records are verified",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sending domain ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result of the check",
+                        "schema": {
+                            "$ref": "#/definitions/response.SendingDomain"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Sending domain not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/subscriptions/{email}/newsletters": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public subscription"
+                ],
+                "summary": "Retrieve newsletter by subscriber's email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of items on page",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page_number",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "test@test.com",
+                        "description": "Subscribers email",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved newsletters by subscriber email"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/track/click/{token}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Redirect of tracked link in issue emails, records click and redirects to original address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed token of link",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Original address of link",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to original address"
+                    },
+                    "400": {
+                        "description": "Link was not issued by server",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/track/open/{token}": {
+            "get": {
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Tracking pixel embedded in issue emails, records open of email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed token of email",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transparent pixel, returned even when token is invalid"
+                    }
+                }
+            }
+        },
+        "/api/v1/unsubscribe": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public subscription"
+                ],
+                "summary": "Used to unsubscribe from newsletter by email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Public newsletter identifier",
+                        "name": "newsletter_public_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token to associate with subscription",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully unsubscribed from newsletter"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/unsubscribe/reason": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public subscription"
+                ],
+                "summary": "Optional survey after unsubscribing, stores why subscriber left",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "application/json",
+                        "description": "application/json",
+                        "name": "Content-Type",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Token from unsubscribe link, reason code and optional comment",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UnsubscribeReason"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Reason was saved"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid token"
+                    },
+                    "404": {
+                        "description": "Unsubscribed subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/email": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Request email change, verification link is sent to new email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "New email and current password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ChangeEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Verification email queued"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid password",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Email taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/email/verify": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public user"
+                ],
+                "summary": "Verify new email by token from verification link, new email replaces the current one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token from verification link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Email changed"
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Email taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/login": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public user"
+                ],
+                "summary": "Login user, returning token for authorization",
+                "parameters": [
+                    {
+                        "description": "Data for user login",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User successfully logged in"
+                    },
+                    "202": {
+                        "description": "Password verified, TOTP code required",
+                        "schema": {
+                            "$ref": "#/definitions/response.LoginChallenge"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/login/totp": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public user"
+                ],
+                "summary": "Finish login of user with TOTP enabled, returning token for authorization",
+                "parameters": [
+                    {
+                        "description": "Challenge token from login with TOTP or recovery code",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TOTPLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User successfully logged in"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid challenge token or code",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed codes, login is locked for a while",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/me": {
+            "delete": {
+                "description": "Owned newsletters are transferred to their longest standing editor,\nnewsletters without editor are archived and their subscriptions disabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete account of signed in user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Current password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.DeleteAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Account deleted"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid password",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/password": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change password of signed in user, current password is required",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Current and new password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Password changed"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid current password",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/register": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public user"
+                ],
+                "summary": "Register user, returning token for authorization",
+                "parameters": [
+                    {
+                        "description": "Data for registering user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User was successfully registered"
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Email taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/totp": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Start TOTP enrollment, returning secret for authenticator app",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Secret generated, has to be confirmed by code",
+                        "schema": {
+                            "$ref": "#/definitions/response.TOTPEnrollment"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "TOTP already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/users/totp/confirm": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Confirm TOTP enrollment by code, returning single use recovery codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer",
+                        "description": "Bearer \u003ctoken\u003e",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Code from authenticator app",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TOTPCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TOTP enabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.RecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request with detail",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "TOTP already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/sendgrid/events": {
+            "post": {
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Signed Event Webhook of SendGrid, records delivered and bounced emails",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ECDSA signature of payload",
+                        "name": "X-Twilio-Email-Event-Webhook-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timestamp of signature",
+                        "name": "X-Twilio-Email-Event-Webhook-Timestamp",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Events were recorded"
+                    },
+                    "401": {
+                        "description": "Invalid signature"
+                    },
+                    "500": {
+                        "description": "Unexpected exception, SendGrid retries request"
+                    }
+                }
+            }
+        },
+        "/newsletters/{public_id}": {
+            "get": {
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "public newsletter"
+                ],
+                "summary": "HTML page listing published issues of newsletter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rendered page"
+                    },
+                    "304": {
+                        "description": "Cached page is current"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "404": {
+                        "description": "Newsletter not found or archived"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/newsletters/{public_id}/atom.xml": {
+            "get": {
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public newsletter"
+                ],
+                "summary": "Atom 1.0 feed of latest published issues of newsletter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of cached feed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of cached feed",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Atom feed"
+                    },
+                    "304": {
+                        "description": "Cached feed is current"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "404": {
+                        "description": "Newsletter not found or archived"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/newsletters/{public_id}/issues/{slug}": {
+            "get": {
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "public newsletter"
+                ],
+                "summary": "HTML page with published issue, link meant for sharing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Issue slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rendered page"
+                    },
+                    "304": {
+                        "description": "Cached page is current"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "404": {
+                        "description": "Newsletter or issue not found"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        },
+        "/newsletters/{public_id}/rss.xml": {
+            "get": {
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public newsletter"
+                ],
+                "summary": "RSS 2.0 feed of latest published issues of newsletter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of cached feed",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified of cached feed",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Newsletter public ID",
+                        "name": "public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "RSS feed"
+                    },
+                    "304": {
+                        "description": "Cached feed is current"
+                    },
+                    "400": {
+                        "description": "Invalid request"
+                    },
+                    "404": {
+                        "description": "Newsletter not found or archived"
+                    },
+                    "500": {
+                        "description": "Unexpected exception"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "request.AcceptInvitationRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "mzxw6ytboi4dambqgiztkmzxw6ytboi"
+                }
+            }
+        },
+        "request.ChangeEmailRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "new@test.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Pa$$W0rD"
+                }
+            }
+        },
+        "request.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "Pa$$W0rD"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "N3w-Pa$$W0rD"
+                }
+            }
+        },
+        "request.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CI publisher"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "newsletters:read",
+                        "newsletters:write"
+                    ]
+                }
+            }
+        },
+        "request.CreateIssueRequest": {
+            "type": "object",
+            "required": [
+                "markdown",
+                "title"
+            ],
+            "properties": {
+                "markdown": {
+                    "type": "string",
+                    "example": "## Top stories\n\nRead [the full story](https://example.com/story)."
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "weekly-digest-42"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Weekly digest #42"
+                }
+            }
+        },
+        "request.CreateNewsletterRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Amazing news from the TikTok world. You would not believe number 4."
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Tiktok News 420"
+                }
+            }
+        },
+        "request.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscription.created",
+                        "subscription.disabled"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://crm.example.com/hooks/newsletter"
+                }
+            }
+        },
+        "request.DeleteAccountRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "Pa$$W0rD"
+                }
+            }
+        },
+        "request.InviteMemberRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "editor@test.com"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "editor"
+                }
+            }
+        },
+        "request.PreviewEmailRequest": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string",
+                    "example": "\u003ch1\u003eWelcome, {{.Recipient}}!\u003c/h1\u003e\u003ca href=\"{{.Link}}\"\u003eUnsubscribe\u003c/a\u003e"
+                },
+                "issue_id": {
+                    "type": "string",
+                    "example": "0b9c1a52-63f5-4c1e-9e34-5f2d3c2f4e7a"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "cs"
+                },
+                "subscriber_email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Welcome, {{.Recipient}}! Unsubscribe: {{.Link}}"
+                }
+            }
+        },
+        "request.RegisterSendingDomainRequest": {
+            "type": "object",
+            "required": [
+                "domain"
+            ],
+            "properties": {
+                "domain": {
+                    "type": "string",
+                    "example": "news.example.com"
+                }
+            }
+        },
+        "request.SendTestEmailRequest": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string",
+                    "example": "\u003ch1\u003eWelcome, {{.Recipient}}!\u003c/h1\u003e\u003ca href=\"{{.Link}}\"\u003eUnsubscribe\u003c/a\u003e"
+                },
+                "issue_id": {
+                    "type": "string",
+                    "example": "0b9c1a52-63f5-4c1e-9e34-5f2d3c2f4e7a"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "cs"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Welcome, {{.Recipient}}! Unsubscribe: {{.Link}}"
+                }
+            }
+        },
+        "request.SubscribeToNewsletter": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "test@test.com"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "cs"
+                }
+            }
+        },
+        "request.TOTPCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "request.TOTPLoginRequest": {
+            "type": "object",
+            "required": [
+                "challenge_token"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "recovery_code": {
+                    "type": "string",
+                    "example": "abcde-fghij"
+                }
+            }
+        },
+        "request.TransferOwnershipRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                }
+            }
+        },
+        "request.UnsubscribeReason": {
+            "type": "object",
+            "required": [
+                "newsletter_public_id",
+                "reason",
+                "token"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Too many emails in one week"
+                },
+                "newsletter_public_id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "too_frequent",
+                        "not_relevant",
+                        "too_long",
+                        "never_subscribed",
+                        "other"
+                    ],
+                    "example": "too_frequent"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateIssueRequest": {
+            "type": "object",
+            "required": [
+                "markdown",
+                "title"
+            ],
+            "properties": {
+                "markdown": {
+                    "type": "string",
+                    "example": "## Top stories\n\nRead [the full story](https://example.com/story)."
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Weekly digest #42"
+                }
+            }
+        },
+        "request.UpdateNewsletterRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Even more amazing news, empty value removes description."
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Tiktok News 421"
+                }
+            }
+        },
+        "request.UpdateSenderIdentityRequest": {
+            "type": "object",
+            "properties": {
+                "from_address": {
+                    "type": "string",
+                    "example": "news@example.com"
+                },
+                "from_name": {
+                    "type": "string",
+                    "example": "Tiktok News"
+                },
+                "postal_address": {
+                    "type": "string",
+                    "example": "Vodickova 1, 110 00 Prague, Czech Republic"
+                },
+                "reply_to": {
+                    "type": "string",
+                    "example": "editor@example.com"
+                }
+            }
+        },
+        "request.UpdateTemplateOverrideRequest": {
+            "type": "object",
+            "required": [
+                "html"
+            ],
+            "properties": {
+                "html": {
+                    "type": "string",
+                    "example": "\u003ch1\u003eWelcome, {{.Recipient}}!\u003c/h1\u003e\u003ca href=\"{{.Link}}\"\u003eUnsubscribe\u003c/a\u003e"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Welcome, {{.Recipient}}! Unsubscribe: {{.Link}}"
+                }
+            }
+        },
+        "request.UpdateTrackingSettingsRequest": {
+            "type": "object",
+            "required": [
+                "clicks",
+                "opens"
+            ],
+            "properties": {
+                "clicks": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "opens": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "request.UserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "test@test.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "Pa$$W0rD"
+                }
+            }
+        },
+        "response.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CI publisher"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "k3p2x7ab"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "newsletters:read"
+                    ]
+                }
+            }
+        },
+        "response.AcceptedInvitation": {
+            "type": "object",
+            "properties": {
+                "newsletter_public_id": {
+                    "type": "string",
+                    "example": "90c0a606-4429-44cc-9531-6f9cd038620a"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "editor"
+                }
+            }
+        },
+        "response.ArchivedIssue": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string",
+                    "example": "\u003ch2 style=\"font-size:22px;\"\u003eTop stories\u003c/h2\u003e"
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "weekly-digest-42"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Weekly digest #42"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/newsletters/90c0a606-4429-44cc-9531-6f9cd038620a/issues/weekly-digest-42"
+                }
+            }
+        },
+        "response.ArchivedIssueSummary": {
+            "type": "object",
+            "properties": {
+                "published_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "weekly-digest-42"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Weekly digest #42"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:8080/newsletters/90c0a606-4429-44cc-9531-6f9cd038620a/issues/weekly-digest-42"
+                }
+            }
+        },
+        "response.CacheReconciliation": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "cached": {
+                    "type": "integer",
+                    "example": 149
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "missing": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "removed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "repaired": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "stale": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2024-10-01T12:00:00Z"
+                },
+                "subscribers": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "subscriptions": {
+                    "type": "integer",
+                    "example": 150
+                }
+            }
+        },
+        "response.CreatedAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-09-20T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "nl_k3p2x7ab_mzxw6ytboi4dambqgiztkmzxw6ytboi"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CI publisher"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "k3p2x7ab"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "newsletters:read"
+                    ]
+                }
+            }
+        },
+        "response.CreatedWebhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscription.created",
+                        "subscription.disabled"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "mzxw6ytboi4dambqgiztkmzxw6ytboi4dambq"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://crm.example.com/hooks/newsletter"
+                }
+            }
+        },
+        "response.DNSRecord": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "nl202409._domainkey.news.example.com"
+                },
+                "purpose": {
+                    "type": "string",
+                    "example": "dkim"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "TXT"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA..."
+                },
+                "verified": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.EmailPreview": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string",
+                    "example": "\u003ch1\u003eWelcome, subscriber@example.com!\u003c/h1\u003e"
+                },
+                "subject": {
+                    "type": "string",
+                    "example": "You are subscribed to Tech News"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Welcome, subscriber@example.com!"
+                }
+            }
+        },
+        "response.Error": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Error description"
+                }
+            }
+        },
+        "response.FailedEvent": {
+            "type": "object",
+            "properties": {
+                "aggregate_key": {
+                    "type": "string",
+                    "example": "subscription:6f1c8e0e-7c3d-4b0a-9a58-0d4f8d1e2b3c:john@example.com"
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "failed_at": {
+                    "type": "string",
+                    "example": "2024-10-01T21:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6f1c8e0e-7c3d-4b0a-9a58-0d4f8d1e2b3c"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "failed to set subscription cache: connection refused"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "subscription.created"
+                },
+                "newsletter_public_id": {
+                    "type": "string",
+                    "example": "6f1c8e0e-7c3d-4b0a-9a58-0d4f8d1e2b3c"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2024-10-01T12:00:00Z"
+                }
+            }
+        },
+        "response.HealthStatus": {
+            "type": "object",
+            "properties": {
+                "indicators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.Indicator"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "healthy"
+                }
+            }
+        },
+        "response.Indicator": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "healthy"
+                }
+            }
+        },
+        "response.InternalNewsletter": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Some descriptive description"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Newsletter name"
+                },
+                "public_id": {
+                    "type": "string",
+                    "example": "90c0a606-4429-44cc-9531-6f9cd038620a"
+                }
+            }
+        },
+        "response.Issue": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "html": {
+                    "type": "string",
+                    "example": "\u003ch2 style=\"font-size:22px;\"\u003eTop stories\u003c/h2\u003e"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                },
+                "markdown": {
+                    "type": "string",
+                    "example": "## Top stories"
+                },
+                "published_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "weekly-digest-42"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Weekly digest #42"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                }
+            }
+        },
+        "response.IssueStats": {
+            "type": "object",
+            "properties": {
+                "bounced": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "clicked": {
+                    "type": "integer",
+                    "example": 210
+                },
+                "delivered": {
+                    "type": "integer",
+                    "example": 1180
+                },
+                "issue_id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                },
+                "opened": {
+                    "type": "integer",
+                    "example": 640
+                },
+                "sent": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "unsubscribed": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "response.JWK": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
+                "alg": {
                     "type": "string",
-                    "example": "Amazing news from the TikTok world. You would not believe number 4."
+                    "example": "RS256"
                 },
-                "name": {
+                "crv": {
                     "type": "string",
-                    "example": "Tiktok News 420"
+                    "example": "Ed25519"
+                },
+                "e": {
+                    "type": "string",
+                    "example": "AQAB"
+                },
+                "kid": {
+                    "type": "string",
+                    "example": "2024-10"
+                },
+                "kty": {
+                    "type": "string",
+                    "example": "RSA"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "type": "string"
                 }
             }
         },
-        "request.SubscribeToNewsletter": {
+        "response.JWKS": {
             "type": "object",
-            "required": [
-                "email"
-            ],
             "properties": {
-                "email": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.JWK"
+                    }
+                }
+            }
+        },
+        "response.LoginChallenge": {
+            "type": "object",
+            "properties": {
+                "challenge_token": {
                     "type": "string",
-                    "example": "test@test.com"
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 }
             }
         },
-        "request.UserRequest": {
+        "response.Member": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
                 "email": {
                     "type": "string",
-                    "example": "test@test.com"
+                    "example": "editor@test.com"
                 },
-                "password": {
+                "role": {
                     "type": "string",
-                    "example": "Pa$$W0rD"
+                    "example": "editor"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
                 }
             }
         },
-        "response.Error": {
+        "response.NewsletterStats": {
             "type": "object",
             "properties": {
-                "error": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.StatsBucket"
+                    }
+                },
+                "from": {
                     "type": "string",
-                    "example": "Error description"
+                    "example": "2024-07-01"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "week"
+                },
+                "subscribers": {
+                    "type": "integer",
+                    "example": 1239
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-09-20"
+                },
+                "totals": {
+                    "$ref": "#/definitions/response.StatsCounts"
+                },
+                "unsubscribe_reasons": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    },
+                    "example": {
+                        "other": 1,
+                        "too_frequent": 2
+                    }
                 }
             }
         },
-        "response.HealthStatus": {
+        "response.PaginatedResponse-array_response_ArchivedIssueSummary": {
             "type": "object",
             "properties": {
-                "indicators": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/response.Indicator"
+                        "$ref": "#/definitions/response.ArchivedIssueSummary"
                     }
                 },
-                "status": {
-                    "type": "string",
-                    "example": "healthy"
+                "pagination": {
+                    "$ref": "#/definitions/response.Pagination"
                 }
             }
         },
-        "response.Indicator": {
+        "response.PaginatedResponse-array_response_UnsubscribeComment": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.UnsubscribeComment"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.Pagination"
+                }
+            }
+        },
+        "response.PaginatedResponse-array_response_WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.WebhookDelivery"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.Pagination"
+                }
+            }
+        },
+        "response.Pagination": {
+            "type": "object",
+            "properties": {
+                "current_page": {
+                    "type": "integer"
+                },
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_previous": {
+                    "type": "boolean"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "response.PublicNewsletter": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-02T15:04:05.999999999Z07:00"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Some descriptive description"
+                },
                 "name": {
                     "type": "string",
-                    "example": "postgres"
+                    "example": "Newsletter name"
                 },
-                "status": {
+                "public_id": {
                     "type": "string",
-                    "example": "healthy"
+                    "example": "90c0a606-4429-44cc-9531-6f9cd038620a"
                 }
             }
         },
-        "response.InternalNewsletter": {
+        "response.PublishedIssue": {
+            "type": "object",
+            "properties": {
+                "issue": {
+                    "$ref": "#/definitions/response.Issue"
+                },
+                "recipients": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "response.RecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "abcde-fghij"
+                    ]
+                }
+            }
+        },
+        "response.SenderIdentity": {
+            "type": "object",
+            "properties": {
+                "from_address": {
+                    "type": "string",
+                    "example": "news@example.com"
+                },
+                "from_name": {
+                    "type": "string",
+                    "example": "Tiktok News"
+                },
+                "postal_address": {
+                    "type": "string",
+                    "example": "Vodickova 1, 110 00 Prague, Czech Republic"
+                },
+                "reply_to": {
+                    "type": "string",
+                    "example": "editor@example.com"
+                }
+            }
+        },
+        "response.SendingDomain": {
             "type": "object",
             "properties": {
+                "checked_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2024-09-20T23:16:32Z"
                 },
-                "description": {
+                "domain": {
                     "type": "string",
-                    "example": "Some descriptive description"
+                    "example": "news.example.com"
                 },
                 "id": {
                     "type": "string",
                     "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
                 },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.DNSRecord"
+                    }
+                },
+                "verified": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "verified_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                }
+            }
+        },
+        "response.StatsBucket": {
+            "type": "object",
+            "properties": {
+                "bounced": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "clicked": {
+                    "type": "integer",
+                    "example": 210
+                },
+                "delivered": {
+                    "type": "integer",
+                    "example": 1180
+                },
+                "opened": {
+                    "type": "integer",
+                    "example": 640
+                },
+                "sent": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2024-09-16"
+                },
+                "subscribed": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "subscribers": {
+                    "type": "integer",
+                    "example": 1239
+                },
+                "unsubscribed": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "response.StatsCounts": {
+            "type": "object",
+            "properties": {
+                "bounced": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "clicked": {
+                    "type": "integer",
+                    "example": 210
+                },
+                "delivered": {
+                    "type": "integer",
+                    "example": 1180
+                },
+                "opened": {
+                    "type": "integer",
+                    "example": 640
+                },
+                "sent": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "subscribed": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "unsubscribed": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "response.TOTPEnrollment": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "type": "string",
+                    "example": "otpauth://totp/newsletter-assignment:test@test.com?secret=JBSWY3DPEHPK3PXP"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "response.TemplateOverride": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string",
+                    "example": "\u003ch1\u003eWelcome, {{.Recipient}}!\u003c/h1\u003e\u003ca href=\"{{.Link}}\"\u003eUnsubscribe\u003c/a\u003e"
+                },
                 "name": {
                     "type": "string",
-                    "example": "Newsletter name"
+                    "example": "subscribed"
                 },
-                "public_id": {
+                "text": {
                     "type": "string",
-                    "example": "90c0a606-4429-44cc-9531-6f9cd038620a"
+                    "example": "Welcome, {{.Recipient}}! Unsubscribe: {{.Link}}"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
                 }
             }
         },
-        "response.PublicNewsletter": {
+        "response.TrackingSettings": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "opens": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.UnsubscribeComment": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Moved to competitor"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "other"
+                },
+                "unsubscribed_at": {
+                    "type": "string",
+                    "example": "2024-09-20T08:15:00Z"
+                }
+            }
+        },
+        "response.Webhook": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string",
                     "example": "2024-09-20T23:16:32Z"
                 },
-                "description": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscription.created",
+                        "subscription.disabled"
+                    ]
+                },
+                "id": {
                     "type": "string",
-                    "example": "Some descriptive description"
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
                 },
-                "name": {
+                "url": {
                     "type": "string",
-                    "example": "Newsletter name"
+                    "example": "https://crm.example.com/hooks/newsletter"
+                }
+            }
+        },
+        "response.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "public_id": {
+                "created_at": {
                     "type": "string",
-                    "example": "90c0a606-4429-44cc-9531-6f9cd038620a"
+                    "example": "2024-09-20T23:15:32Z"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "9a3f1c2e-7d4b-4c8a-9e6f-2b1d0c3a4e5f"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "subscription.created"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1541c9c1-e43e-4527-850a-77f4e5be9599"
+                },
+                "last_attempt_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:16:32Z"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "webhook responded with 503: "
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "next_attempt_at": {
+                    "type": "string",
+                    "example": "2024-09-20T23:21:32Z"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "failed"
+                    ],
+                    "example": "pending"
                 }
             }
         }
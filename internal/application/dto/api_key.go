@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/javor454/newsletter-assignment/internal/domain"
+
+// CreatedAPIKey carries plain key which is returned only on creation.
+type CreatedAPIKey struct {
+	APIKey *domain.APIKey
+	Key    string
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+// IssuePage is page of published issues, LastModified covers all published issues of newsletter, nil when none.
+type IssuePage struct {
+	Issues       []*domain.Issue
+	Pagination   *Pagination
+	LastModified *time.Time
+}
+
+// Archive is page of published issues of newsletter.
+type Archive struct {
+	Newsletter   *domain.Newsletter
+	Issues       []*domain.Issue
+	Pagination   *Pagination
+	LastModified time.Time
+}
+
+// ArchivedIssue is published issue with newsletter it belongs to.
+type ArchivedIssue struct {
+	Newsletter   *domain.Newsletter
+	Issue        *domain.Issue
+	LastModified time.Time
+}
+
+func NewArchive(newsletter *domain.Newsletter, page *IssuePage) *Archive {
+	lastModified := newsletter.CreatedAt()
+	if page.LastModified != nil && page.LastModified.After(lastModified) {
+		lastModified = *page.LastModified
+	}
+
+	return &Archive{
+		Newsletter:   newsletter,
+		Issues:       page.Issues,
+		Pagination:   page.Pagination,
+		LastModified: lastModified,
+	}
+}
+
+func NewArchivedIssue(newsletter *domain.Newsletter, issue *domain.Issue) *ArchivedIssue {
+	return &ArchivedIssue{
+		Newsletter:   newsletter,
+		Issue:        issue,
+		LastModified: issue.ModifiedAt(),
+	}
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// CacheReconciliation is report of single comparison of subscription cache with active subscriptions.
+type CacheReconciliation struct {
+	StartedAt time.Time
+	Duration  time.Duration
+	// Subscribers is number of emails with active subscription or cached entry
+	Subscribers   int
+	Subscriptions int
+	Cached        int
+	// Missing are active subscriptions absent from cache, Stale are cached entries without active subscription
+	Missing int
+	Stale   int
+	// Repaired is sum of Added missing entries and Removed stale ones
+	Repaired int
+	Added    int
+	Removed  int
+	Failed   int
+}
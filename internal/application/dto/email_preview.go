@@ -0,0 +1,8 @@
+package dto
+
+// EmailPreview is email rendered as recipient would receive it.
+type EmailPreview struct {
+	Subject string
+	HTML    string
+	Text    string
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// FailedEvent is domain event given up by dispatcher after its last attempt failed.
+type FailedEvent struct {
+	ID                 string
+	Name               string
+	AggregateKey       string
+	NewsletterPublicID string
+	OccurredAt         time.Time
+	Attempts           int
+	LastError          *string
+	FailedAt           time.Time
+}
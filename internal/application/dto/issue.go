@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/javor454/newsletter-assignment/internal/domain"
+
+// PublishedIssue is issue with number of subscribers it was sent to.
+type PublishedIssue struct {
+	Issue      *domain.Issue
+	Recipients int
+}
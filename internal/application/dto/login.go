@@ -0,0 +1,19 @@
+package dto
+
+// LoginResult contains either user token or challenge token, challenge has to be confirmed by TOTP code.
+type LoginResult struct {
+	Token             string
+	ChallengeRequired bool
+}
+
+// TOTPLoginState is state of second step of login, only the last issued challenge is accepted.
+type TOTPLoginState struct {
+	ChallengeID string
+	// Locked is set while login is locked after too many failed attempts
+	Locked bool
+}
+
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "crypto"
+
+type PublicKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
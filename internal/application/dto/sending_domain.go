@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/javor454/newsletter-assignment/internal/domain"
+
+const (
+	RecordPurposeOwnership = "ownership"
+	RecordPurposeSPF       = "spf"
+	RecordPurposeDKIM      = "dkim"
+	RecordPurposeDMARC     = "dmarc"
+)
+
+// DNSRecord is record owner has to publish, verified reflects result of last check.
+type DNSRecord struct {
+	Purpose  string
+	Type     string
+	Name     string
+	Value    string
+	Verified bool
+}
+
+type SendingDomain struct {
+	Domain  *domain.SendingDomain
+	Records []*DNSRecord
+}
+
+type SendingDomainCheck struct {
+	Ownership bool
+	SPF       bool
+	DKIM      bool
+	DMARC     bool
+}
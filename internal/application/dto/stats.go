@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type StatsCounts struct {
+	Subscribed   int
+	Unsubscribed int
+	Sent         int
+	Delivered    int
+	Bounced      int
+	Opened       int
+	Clicked      int
+}
+
+func (c *StatsCounts) add(o *StatsCounts) {
+	c.Subscribed += o.Subscribed
+	c.Unsubscribed += o.Unsubscribed
+	c.Sent += o.Sent
+	c.Delivered += o.Delivered
+	c.Bounced += o.Bounced
+	c.Opened += o.Opened
+	c.Clicked += o.Clicked
+}
+
+// StatsBucket holds counts of events in bucket and number of subscribers at its end.
+type StatsBucket struct {
+	Start       time.Time
+	Subscribers int
+	StatsCounts
+}
+
+// NewsletterStats is time series of newsletter statistics with totals of the whole period, unsubscribe reasons
+// count answers of subscribers who unsubscribed in the period.
+type NewsletterStats struct {
+	Period             *domain.StatsPeriod
+	Subscribers        int
+	Totals             StatsCounts
+	Buckets            []*StatsBucket
+	UnsubscribeReasons map[string]int
+}
+
+// NewNewsletterStats fills buckets without events with zeros and computes running number of subscribers from number
+// of subscribers before the period.
+func NewNewsletterStats(period *domain.StatsPeriod, baseline int, counts map[time.Time]*StatsCounts) *NewsletterStats {
+	stats := &NewsletterStats{Period: period, Subscribers: baseline}
+
+	for _, start := range period.Buckets() {
+		bucket := &StatsBucket{Start: start}
+		if c, ok := counts[start]; ok {
+			bucket.StatsCounts = *c
+		}
+		stats.Subscribers += bucket.Subscribed - bucket.Unsubscribed
+		bucket.Subscribers = stats.Subscribers
+		stats.Totals.add(&bucket.StatsCounts)
+		stats.Buckets = append(stats.Buckets, bucket)
+	}
+
+	return stats
+}
+
+// IssueStats counts sent emails of issue by their outcome.
+type IssueStats struct {
+	IssueID      *domain.ID
+	Sent         int
+	Delivered    int
+	Bounced      int
+	Opened       int
+	Clicked      int
+	Unsubscribed int
+}
+
+// UnsubscribeComment is free text answer of subscriber to unsubscribe survey, kept anonymous.
+type UnsubscribeComment struct {
+	Reason         string
+	Comment        string
+	UnsubscribedAt time.Time
+}
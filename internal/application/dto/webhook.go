@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is entry of delivery log, next attempt is set only to pending delivery.
+type WebhookDelivery struct {
+	ID             string
+	EventID        string
+	EventType      string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	LastStatusCode *int
+	LastError      *string
+	LastAttemptAt  *time.Time
+	NextAttemptAt  *time.Time
+	CreatedAt      time.Time
+}
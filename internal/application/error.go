@@ -11,4 +11,43 @@ var (
 	UnknownUserError                  = errors.New("unknown user")
 	InvalidUUIDError                  = errors.New("invalid uuid")
 	InvalidTokenError                 = errors.New("invalid token")
+	InvalidTOTPCodeError              = errors.New("invalid totp code")
+	TOTPNotEnrolledError              = errors.New("totp not enrolled")
+	TOTPAlreadyEnabledError           = errors.New("totp already enabled")
+	TOTPLockedError                   = errors.New("too many failed totp attempts, try again later")
+	InvalidAPIKeyError                = errors.New("invalid api key")
+	APIKeyNotFoundError               = errors.New("api key not found")
+	InvalidScopeError                 = errors.New("invalid scope")
+	InvalidExpirationError            = errors.New("expiration has to be in the future")
+	InvalidEmailError                 = errors.New("invalid email format")
+	InvalidRoleError                  = errors.New("invalid role")
+	InsufficientRoleError             = errors.New("insufficient role")
+	AlreadyMemberError                = errors.New("already member of newsletter")
+	MemberNotFoundError               = errors.New("member not found")
+	OwnerRemovalError                 = errors.New("owner can not be removed, transfer ownership first")
+	InvitationNotFoundError           = errors.New("invitation not found")
+	InvitationEmailMismatchError      = errors.New("invitation was sent to different email")
+	UnverifiedSenderDomainError       = errors.New("sender domain is not verified")
+	InvalidDomainError                = errors.New("invalid domain name")
+	SendingDomainNotFoundError        = errors.New("sending domain not found")
+	SendingDomainTakenError           = errors.New("sending domain already registered")
+	InvalidTemplateError              = errors.New("invalid template")
+	TemplateNotFoundError             = errors.New("template not found")
+	InvalidLocaleError                = errors.New("unsupported locale")
+	SubscriptionNotFoundError         = errors.New("subscription not found")
+	InvalidSlugError                  = errors.New("slug has to contain only lowercase letters, digits and dashes")
+	IssueNotFoundError                = errors.New("issue not found")
+	IssueSlugTakenError               = errors.New("issue slug already used in newsletter")
+	IssueAlreadyPublishedError        = errors.New("issue already published")
+	IssueRequiredError                = errors.New("issue id is required to render issue template")
+	InvalidTrackingTokenError         = errors.New("invalid tracking token")
+	EmailJobNotFoundError             = errors.New("email job not found")
+	InvalidStatsIntervalError         = errors.New("interval has to be one of day, week, month")
+	InvalidStatsPeriodError           = errors.New("invalid period of statistics")
+	InvalidWebhookSignatureError      = errors.New("invalid webhook signature")
+	InvalidUnsubscribeReasonError     = errors.New("invalid unsubscribe reason")
+	InvalidWebhookURLError            = errors.New("webhook url has to be absolute http or https url")
+	InvalidWebhookEventTypeError      = errors.New("invalid webhook event type")
+	WebhookNotFoundError              = errors.New("webhook not found")
+	FailedEventNotFoundError          = errors.New("failed event not found")
 )
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type InvitationRepository interface {
+	GetInvitationByToken(ctx context.Context, token string) (*domain.Invitation, error)
+	AcceptInvitation(ctx context.Context, invitation *domain.Invitation, userID *domain.ID) error
+}
+
+type AcceptInvitationHandler struct {
+	getUser              GetUserByID
+	invitationRepository InvitationRepository
+	now                  func() time.Time
+}
+
+func NewAcceptInvitationHandler(gu GetUserByID, ir InvitationRepository, now func() time.Time) *AcceptInvitationHandler {
+	return &AcceptInvitationHandler{getUser: gu, invitationRepository: ir, now: now}
+}
+
+// Handle makes user member of newsletter, invitation can be accepted only by account with invited email.
+func (h *AcceptInvitationHandler) Handle(ctx context.Context, userID, token string) (*domain.Invitation, error) {
+	id, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.getUser.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := h.invitationRepository.GetInvitationByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.IsExpired(h.now()) {
+		return nil, application.InvitationNotFoundError
+	}
+	if invitation.Email().String() != user.Email().String() {
+		return nil, application.InvitationEmailMismatchError
+	}
+
+	if err := h.invitationRepository.AcceptInvitation(ctx, invitation, id); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type APIKeyAuthenticator interface {
+	GetByKey(ctx context.Context, key string) (*domain.APIKey, error)
+	MarkUsed(ctx context.Context, id *domain.ID) error
+}
+
+type AuthenticateAPIKeyHandler struct {
+	apiKeyAuthenticator APIKeyAuthenticator
+	now                 func() time.Time
+}
+
+func NewAuthenticateAPIKeyHandler(aka APIKeyAuthenticator, now func() time.Time) *AuthenticateAPIKeyHandler {
+	return &AuthenticateAPIKeyHandler{apiKeyAuthenticator: aka, now: now}
+}
+
+// Handle returns key matching the plain key, revoked and expired keys are rejected.
+func (h *AuthenticateAPIKeyHandler) Handle(ctx context.Context, key string) (*domain.APIKey, error) {
+	apiKey, err := h.apiKeyAuthenticator.GetByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.IsExpired(h.now()) {
+		return nil, application.InvalidAPIKeyError
+	}
+
+	if err := h.apiKeyAuthenticator.MarkUsed(ctx, apiKey.ID()); err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type NewsletterRoleProvider interface {
+	GetRole(ctx context.Context, newsletterPublicID, userID *domain.ID) (domain.Role, error)
+}
+
+// authorizeNewsletter returns role of user in newsletter when it grants permission,
+// users which are not members get NewsletterNotFoundError so newsletter existence is not leaked.
+func authorizeNewsletter(
+	ctx context.Context,
+	rp NewsletterRoleProvider,
+	newsletterPublicID, userID *domain.ID,
+	permitted func(domain.Role) bool,
+) (domain.Role, error) {
+	role, err := rp.GetRole(ctx, newsletterPublicID, userID)
+	if err != nil {
+		return "", err
+	}
+	if !permitted(role) {
+		return "", application.InsufficientRoleError
+	}
+
+	return role, nil
+}
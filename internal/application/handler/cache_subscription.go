@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type SubscriptionCache interface {
+	RemoveSubscribedNewsletter(ctx context.Context, email *domain.Email, newsletterPublicID *domain.ID) error
+}
+
+type SubscribedNewsletterCache interface {
+	SubscriptionCache
+	AddSubscribedNewsletter(ctx context.Context, email, newsletterPublicID string) error
+}
+
+// CacheSubscriptionHandler keeps cached subscriptions of subscriber in sync with created and disabled subscriptions.
+type CacheSubscriptionHandler struct {
+	subscriptionCache SubscribedNewsletterCache
+}
+
+func NewCacheSubscriptionHandler(sc SubscribedNewsletterCache) *CacheSubscriptionHandler {
+	return &CacheSubscriptionHandler{subscriptionCache: sc}
+}
+
+// HandleEvent is called with EventSubscriptionCreated and EventSubscriptionDisabled, repeated event is no-op.
+func (h *CacheSubscriptionHandler) HandleEvent(ctx context.Context, event *domain.Event) error {
+	if event.Name() == domain.EventSubscriptionDisabled {
+		email, err := domain.NewEmail(event.Data()["email"])
+		if err != nil {
+			return err
+		}
+
+		return h.subscriptionCache.RemoveSubscribedNewsletter(ctx, email, event.NewsletterPublicID())
+	}
+
+	return h.subscriptionCache.AddSubscribedNewsletter(ctx, event.Data()["email"], event.NewsletterPublicID().String())
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type UpdatePassword interface {
+	UpdatePassword(ctx context.Context, userID *domain.ID, password *domain.Password) error
+}
+
+type ChangePasswordHandler struct {
+	getUserByID    GetUserByID
+	getUser        GetUser
+	updatePassword UpdatePassword
+}
+
+func NewChangePasswordHandler(gu GetUserByID, gp GetUser, up UpdatePassword) *ChangePasswordHandler {
+	return &ChangePasswordHandler{getUserByID: gu, getUser: gp, updatePassword: up}
+}
+
+// Handle replaces password of user, current password has to be confirmed.
+func (h *ChangePasswordHandler) Handle(ctx context.Context, userID, currentPassword, newPassword string) error {
+	id, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	if _, err := verifyPassword(ctx, h.getUserByID, h.getUser, id, currentPassword); err != nil {
+		return err
+	}
+	pass, err := domain.NewPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return h.updatePassword.UpdatePassword(ctx, id, pass)
+}
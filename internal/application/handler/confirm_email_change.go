@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type ConfirmEmailChange interface {
+	ConfirmEmailChange(ctx context.Context, token string) error
+}
+
+type ConfirmEmailChangeHandler struct {
+	confirmEmailChange ConfirmEmailChange
+}
+
+func NewConfirmEmailChangeHandler(cec ConfirmEmailChange) *ConfirmEmailChangeHandler {
+	return &ConfirmEmailChangeHandler{confirmEmailChange: cec}
+}
+
+func (h *ConfirmEmailChangeHandler) Handle(ctx context.Context, token string) error {
+	if token == "" {
+		return application.InvalidTokenError
+	}
+
+	return h.confirmEmailChange.ConfirmEmailChange(ctx, token)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+const recoveryCodeCount = 10
+
+type TOTPRepository interface {
+	GetTOTPSecret(ctx context.Context, userID *domain.ID) (string, bool, error)
+	EnableTOTP(ctx context.Context, userID *domain.ID, recoveryCodes []string) error
+	UseTOTPStep(ctx context.Context, userID *domain.ID, step int64) error
+}
+
+type TOTPValidator interface {
+	Validate(secret, code string) (int64, bool)
+}
+
+type RecoveryCodeGenerator func(count int) ([]string, error)
+
+type ConfirmTOTPHandler struct {
+	totpRepository        TOTPRepository
+	validator             TOTPValidator
+	generateRecoveryCodes RecoveryCodeGenerator
+}
+
+func NewConfirmTOTPHandler(tr TOTPRepository, v TOTPValidator, grc RecoveryCodeGenerator) *ConfirmTOTPHandler {
+	return &ConfirmTOTPHandler{totpRepository: tr, validator: v, generateRecoveryCodes: grc}
+}
+
+// Handle enables TOTP once user proves possession of secret, returned recovery codes are shown only once.
+func (h *ConfirmTOTPHandler) Handle(ctx context.Context, userID, code string) ([]string, error) {
+	id, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, enabled, err := h.totpRepository.GetTOTPSecret(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, application.TOTPAlreadyEnabledError
+	}
+	step, ok := h.validator.Validate(secret, code)
+	if !ok {
+		return nil, application.InvalidTOTPCodeError
+	}
+	if err := h.totpRepository.UseTOTPStep(ctx, id, step); err != nil {
+		return nil, err
+	}
+
+	codes, err := h.generateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.totpRepository.EnableTOTP(ctx, id, codes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
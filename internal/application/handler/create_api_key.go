@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type CreateAPIKey interface {
+	Create(ctx context.Context, apiKey *domain.APIKey, key string) error
+}
+
+// APIKeyGenerator returns lookup prefix and full key.
+type APIKeyGenerator func() (string, string, error)
+
+type CreateAPIKeyHandler struct {
+	createAPIKey   CreateAPIKey
+	generateAPIKey APIKeyGenerator
+}
+
+func NewCreateAPIKeyHandler(cak CreateAPIKey, gak APIKeyGenerator) *CreateAPIKeyHandler {
+	return &CreateAPIKeyHandler{createAPIKey: cak, generateAPIKey: gak}
+}
+
+func (h *CreateAPIKeyHandler) Handle(
+	ctx context.Context,
+	userID, name string,
+	scopes []string,
+	expiresAt *time.Time,
+) (*dto.CreatedAPIKey, error) {
+	id, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scopes) == 0 {
+		return nil, application.InvalidScopeError
+	}
+	domainScopes := make([]domain.Scope, 0, len(scopes))
+	for _, s := range scopes {
+		scope, err := domain.NewScope(s)
+		if err != nil {
+			return nil, err
+		}
+		domainScopes = append(domainScopes, scope)
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, application.InvalidExpirationError
+	}
+
+	prefix, key, err := h.generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := domain.NewAPIKey(id, name, prefix, domainScopes, expiresAt)
+	if err := h.createAPIKey.Create(ctx, apiKey, key); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreatedAPIKey{APIKey: apiKey, Key: key}, nil
+}
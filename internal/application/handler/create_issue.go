@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+// MarkdownRenderer converts markdown to sanitized HTML used by emails and web archive.
+type MarkdownRenderer interface {
+	Render(source string) (string, error)
+}
+
+type CreateIssue interface {
+	CreateIssue(ctx context.Context, issue *domain.Issue) error
+}
+
+type CreateIssueHandler struct {
+	roleProvider     NewsletterRoleProvider
+	markdownRenderer MarkdownRenderer
+	createIssue      CreateIssue
+}
+
+func NewCreateIssueHandler(rp NewsletterRoleProvider, mr MarkdownRenderer, ci CreateIssue) *CreateIssueHandler {
+	return &CreateIssueHandler{roleProvider: rp, markdownRenderer: mr, createIssue: ci}
+}
+
+// Handle creates draft issue, slug is derived from title when empty.
+func (h *CreateIssueHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, title, slug, markdown string,
+) (*domain.Issue, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	slugVo := domain.CreateSlugFromTitle(title)
+	if slug != "" {
+		if slugVo, err = domain.NewSlug(slug); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanEdit); err != nil {
+		return nil, err
+	}
+
+	html, err := h.markdownRenderer.Render(markdown)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := domain.NewIssue(pubID, slugVo, title, markdown, html)
+	if err := h.createIssue.CreateIssue(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
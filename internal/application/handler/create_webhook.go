@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type CreateWebhook interface {
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) error
+}
+
+type WebhookSecretGenerator func() (string, error)
+
+type CreateWebhookHandler struct {
+	roleProvider   NewsletterRoleProvider
+	createWebhook  CreateWebhook
+	generateSecret WebhookSecretGenerator
+}
+
+func NewCreateWebhookHandler(rp NewsletterRoleProvider, cw CreateWebhook, gs WebhookSecretGenerator) *CreateWebhookHandler {
+	return &CreateWebhookHandler{roleProvider: rp, createWebhook: cw, generateSecret: gs}
+}
+
+// Handle registers endpoint notified about selected events of newsletter, only owner can do it.
+func (h *CreateWebhookHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, url string,
+	eventTypes []string,
+) (*domain.Webhook, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]domain.WebhookEventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		eventType, err := domain.NewWebhookEventType(t)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, eventType)
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return nil, err
+	}
+
+	secret, err := h.generateSecret()
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := domain.NewWebhook(pubID, url, types, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.createWebhook.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type DeleteAccount interface {
+	Delete(ctx context.Context, userID *domain.ID) error
+}
+
+type DeleteAccountHandler struct {
+	getUserByID   GetUserByID
+	getUser       GetUser
+	deleteAccount DeleteAccount
+}
+
+func NewDeleteAccountHandler(gu GetUserByID, gp GetUser, da DeleteAccount) *DeleteAccountHandler {
+	return &DeleteAccountHandler{getUserByID: gu, getUser: gp, deleteAccount: da}
+}
+
+// Handle deletes user after password confirmation. Owned newsletters are handed over to their editor,
+// newsletters without editor are archived and their subscriptions disabled.
+func (h *DeleteAccountHandler) Handle(ctx context.Context, userID, password string) error {
+	id, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	if _, err := verifyPassword(ctx, h.getUserByID, h.getUser, id, password); err != nil {
+		return err
+	}
+
+	return h.deleteAccount.Delete(ctx, id)
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type DeleteSendingDomain interface {
+	Delete(ctx context.Context, userID, id *domain.ID) error
+}
+
+type DeleteSendingDomainHandler struct {
+	deleteSendingDomain DeleteSendingDomain
+}
+
+func NewDeleteSendingDomainHandler(dsd DeleteSendingDomain) *DeleteSendingDomainHandler {
+	return &DeleteSendingDomainHandler{deleteSendingDomain: dsd}
+}
+
+// Handle removes domain, newsletters sending from it fall back to default sender address unless their owner verified
+// the domain too.
+func (h *DeleteSendingDomainHandler) Handle(ctx context.Context, userID, sendingDomainID string) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	id, err := domain.CreateIDFromExisting(sendingDomainID)
+	if err != nil {
+		return err
+	}
+
+	return h.deleteSendingDomain.Delete(ctx, uID, id)
+}
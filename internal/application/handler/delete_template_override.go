@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type DeleteTemplateOverride interface {
+	DeleteTemplateOverride(ctx context.Context, newsletterPublicID *domain.ID, name string) error
+}
+
+type DeleteTemplateOverrideHandler struct {
+	roleProvider           NewsletterRoleProvider
+	deleteTemplateOverride DeleteTemplateOverride
+}
+
+func NewDeleteTemplateOverrideHandler(
+	rp NewsletterRoleProvider,
+	dto DeleteTemplateOverride,
+) *DeleteTemplateOverrideHandler {
+	return &DeleteTemplateOverrideHandler{roleProvider: rp, deleteTemplateOverride: dto}
+}
+
+// Handle removes override, newsletter falls back to default template.
+func (h *DeleteTemplateOverrideHandler) Handle(ctx context.Context, userID, newsletterPublicID, name string) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanEdit); err != nil {
+		return err
+	}
+
+	return h.deleteTemplateOverride.DeleteTemplateOverride(ctx, pubID, name)
+}
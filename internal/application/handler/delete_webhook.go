@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type DeleteWebhook interface {
+	DeleteWebhook(ctx context.Context, newsletterPublicID, webhookID *domain.ID) error
+}
+
+type DeleteWebhookHandler struct {
+	roleProvider  NewsletterRoleProvider
+	deleteWebhook DeleteWebhook
+}
+
+func NewDeleteWebhookHandler(rp NewsletterRoleProvider, dw DeleteWebhook) *DeleteWebhookHandler {
+	return &DeleteWebhookHandler{roleProvider: rp, deleteWebhook: dw}
+}
+
+// Handle removes webhook, its pending deliveries are dropped.
+func (h *DeleteWebhookHandler) Handle(ctx context.Context, userID, newsletterPublicID, webhookID string) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return err
+	}
+	whID, err := domain.CreateIDFromExisting(webhookID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return err
+	}
+
+	return h.deleteWebhook.DeleteWebhook(ctx, pubID, whID)
+}
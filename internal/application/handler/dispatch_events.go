@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+)
+
+type DispatchEventsService interface {
+	DispatchEvents(ctx context.Context) error
+}
+
+// DispatchEventsHandler passes stored domain events to their handlers repeatedly until context done is signalled
+type DispatchEventsHandler struct {
+	lg             logger.Logger
+	dispatchEvents DispatchEventsService
+}
+
+func NewDispatchEventsHandler(lg logger.Logger, dispatchEvents DispatchEventsService) *DispatchEventsHandler {
+	return &DispatchEventsHandler{
+		lg:             lg,
+		dispatchEvents: dispatchEvents,
+	}
+}
+
+func (h *DispatchEventsHandler) Handle(ctx context.Context) {
+	go func() {
+		h.lg.Info("[EVENT] Starting event dispatching...")
+		for {
+			select {
+			case <-ctx.Done():
+				h.lg.Debug("[EVENT] Dispatching stopped")
+				return
+			case <-time.After(time.Second):
+				if err := h.dispatchEvents.DispatchEvents(ctx); err != nil {
+					h.lg.WithError(err).Error("[EVENT] Error dispatching batch")
+				}
+			}
+		}
+	}()
+}
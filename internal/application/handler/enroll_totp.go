@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetUserByID interface {
+	GetByID(ctx context.Context, userID *domain.ID) (*domain.User, error)
+}
+
+type SetTOTPSecret interface {
+	SetTOTPSecret(ctx context.Context, userID *domain.ID, secret string) error
+}
+
+type TOTPSecretGenerator interface {
+	GenerateSecret() (string, error)
+	ProvisioningURI(account, secret string) string
+}
+
+type EnrollTOTPHandler struct {
+	getUser         GetUserByID
+	setTOTPSecret   SetTOTPSecret
+	secretGenerator TOTPSecretGenerator
+}
+
+func NewEnrollTOTPHandler(gu GetUserByID, sts SetTOTPSecret, sg TOTPSecretGenerator) *EnrollTOTPHandler {
+	return &EnrollTOTPHandler{getUser: gu, setTOTPSecret: sts, secretGenerator: sg}
+}
+
+// Handle generates new pending secret, TOTP is not required on login until enrollment is confirmed.
+func (h *EnrollTOTPHandler) Handle(ctx context.Context, userID string) (*dto.TOTPEnrollment, error) {
+	id, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := h.getUser.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled() {
+		return nil, application.TOTPAlreadyEnabledError
+	}
+
+	secret, err := h.secretGenerator.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.setTOTPSecret.SetTOTPSecret(ctx, id, secret); err != nil {
+		return nil, err
+	}
+
+	return &dto.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: h.secretGenerator.ProvisioningURI(user.Email().String(), secret),
+	}, nil
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetAPIKeysByUserID interface {
+	GetByUserID(ctx context.Context, userID *domain.ID) ([]*domain.APIKey, error)
+}
+
+type GetAPIKeysByUserIDHandler struct {
+	getAPIKeysByUserID GetAPIKeysByUserID
+}
+
+func NewGetAPIKeysByUserIDHandler(gakbui GetAPIKeysByUserID) *GetAPIKeysByUserIDHandler {
+	return &GetAPIKeysByUserIDHandler{getAPIKeysByUserID: gakbui}
+}
+
+func (h *GetAPIKeysByUserIDHandler) Handle(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	id, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.getAPIKeysByUserID.GetByUserID(ctx, id)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetPublishedIssues interface {
+	GetPublishedIssues(
+		ctx context.Context,
+		newsletterPublicID *domain.ID,
+		pageSize, pageNumber int,
+	) (*dto.IssuePage, error)
+}
+
+type GetArchiveHandler struct {
+	getNewsletterByPublicID GetNewsletterByPublicID
+	getPublishedIssues      GetPublishedIssues
+}
+
+func NewGetArchiveHandler(gnbpi GetNewsletterByPublicID, gpis GetPublishedIssues) *GetArchiveHandler {
+	return &GetArchiveHandler{getNewsletterByPublicID: gnbpi, getPublishedIssues: gpis}
+}
+
+// Handle returns page of published issues of active newsletter, no authentication is required.
+func (h *GetArchiveHandler) Handle(
+	ctx context.Context,
+	newsletterPublicID string,
+	pageSize, pageNumber int,
+) (*dto.Archive, error) {
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	newsletter, err := h.getNewsletterByPublicID.GetByPublicID(ctx, pubID)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := h.getPublishedIssues.GetPublishedIssues(ctx, pubID, pageSize, pageNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewArchive(newsletter, page), nil
+}
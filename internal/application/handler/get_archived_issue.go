@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetPublishedIssue interface {
+	GetPublishedIssue(ctx context.Context, newsletterPublicID *domain.ID, slug *domain.Slug) (*domain.Issue, error)
+}
+
+type GetArchivedIssueHandler struct {
+	getNewsletterByPublicID GetNewsletterByPublicID
+	getPublishedIssue       GetPublishedIssue
+}
+
+func NewGetArchivedIssueHandler(gnbpi GetNewsletterByPublicID, gpi GetPublishedIssue) *GetArchivedIssueHandler {
+	return &GetArchivedIssueHandler{getNewsletterByPublicID: gnbpi, getPublishedIssue: gpi}
+}
+
+// Handle returns published issue by slug, drafts are not found.
+func (h *GetArchivedIssueHandler) Handle(ctx context.Context, newsletterPublicID, slug string) (*dto.ArchivedIssue, error) {
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	s, err := domain.NewSlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	newsletter, err := h.getNewsletterByPublicID.GetByPublicID(ctx, pubID)
+	if err != nil {
+		return nil, err
+	}
+
+	issue, err := h.getPublishedIssue.GetPublishedIssue(ctx, pubID, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewArchivedIssue(newsletter, issue), nil
+}
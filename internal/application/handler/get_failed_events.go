@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+)
+
+// maxFailedEvents limits listing of failed events, operators are expected to retry or investigate them as they come.
+const maxFailedEvents = 100
+
+type GetFailedEvents interface {
+	GetFailedEvents(ctx context.Context, limit int) ([]*dto.FailedEvent, error)
+}
+
+type GetFailedEventsHandler struct {
+	getFailedEvents GetFailedEvents
+}
+
+func NewGetFailedEventsHandler(gfe GetFailedEvents) *GetFailedEventsHandler {
+	return &GetFailedEventsHandler{getFailedEvents: gfe}
+}
+
+// Handle returns domain events given up by dispatcher, most recently failed first.
+func (h *GetFailedEventsHandler) Handle(ctx context.Context) ([]*dto.FailedEvent, error) {
+	return h.getFailedEvents.GetFailedEvents(ctx, maxFailedEvents)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetIssueHandler struct {
+	roleProvider NewsletterRoleProvider
+	getIssue     GetIssue
+}
+
+func NewGetIssueHandler(rp NewsletterRoleProvider, gi GetIssue) *GetIssueHandler {
+	return &GetIssueHandler{roleProvider: rp, getIssue: gi}
+}
+
+func (h *GetIssueHandler) Handle(ctx context.Context, userID, newsletterPublicID, issueID string) (*domain.Issue, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	iID, err := domain.CreateIDFromExisting(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, err
+	}
+
+	return h.getIssue.GetIssue(ctx, pubID, iID)
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetIssueStats interface {
+	GetIssueStats(ctx context.Context, newsletterPublicID, issueID *domain.ID) (*dto.IssueStats, error)
+}
+
+type GetIssueStatsHandler struct {
+	roleProvider  NewsletterRoleProvider
+	getIssue      GetIssue
+	getIssueStats GetIssueStats
+}
+
+func NewGetIssueStatsHandler(rp NewsletterRoleProvider, gi GetIssue, gis GetIssueStats) *GetIssueStatsHandler {
+	return &GetIssueStatsHandler{roleProvider: rp, getIssue: gi, getIssueStats: gis}
+}
+
+func (h *GetIssueStatsHandler) Handle(ctx context.Context, userID, newsletterPublicID, issueID string) (*dto.IssueStats, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	iID, err := domain.CreateIDFromExisting(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, err
+	}
+	if _, err := h.getIssue.GetIssue(ctx, pubID, iID); err != nil {
+		return nil, err
+	}
+
+	return h.getIssueStats.GetIssueStats(ctx, pubID, iID)
+}
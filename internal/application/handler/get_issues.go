@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetIssues interface {
+	GetIssues(ctx context.Context, newsletterPublicID *domain.ID) ([]*domain.Issue, error)
+}
+
+type GetIssuesHandler struct {
+	roleProvider NewsletterRoleProvider
+	getIssues    GetIssues
+}
+
+func NewGetIssuesHandler(rp NewsletterRoleProvider, gis GetIssues) *GetIssuesHandler {
+	return &GetIssuesHandler{roleProvider: rp, getIssues: gis}
+}
+
+// Handle returns drafts and published issues of newsletter, available to every member.
+func (h *GetIssuesHandler) Handle(ctx context.Context, userID, newsletterPublicID string) ([]*domain.Issue, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, err
+	}
+
+	return h.getIssues.GetIssues(ctx, pubID)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetNewsletterMembers interface {
+	GetMembers(ctx context.Context, newsletterPublicID *domain.ID) ([]*domain.Member, error)
+}
+
+type GetNewsletterMembersHandler struct {
+	roleProvider         NewsletterRoleProvider
+	getNewsletterMembers GetNewsletterMembers
+}
+
+func NewGetNewsletterMembersHandler(rp NewsletterRoleProvider, gnm GetNewsletterMembers) *GetNewsletterMembersHandler {
+	return &GetNewsletterMembersHandler{roleProvider: rp, getNewsletterMembers: gnm}
+}
+
+func (h *GetNewsletterMembersHandler) Handle(ctx context.Context, userID, newsletterPublicID string) ([]*domain.Member, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, err
+	}
+
+	return h.getNewsletterMembers.GetMembers(ctx, pubID)
+}
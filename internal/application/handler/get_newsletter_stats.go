@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetNewsletterStats interface {
+	GetNewsletterStats(ctx context.Context, newsletterPublicID *domain.ID, period *domain.StatsPeriod) (*dto.NewsletterStats, error)
+}
+
+type GetNewsletterStatsHandler struct {
+	roleProvider       NewsletterRoleProvider
+	getNewsletterStats GetNewsletterStats
+	now                func() time.Time
+}
+
+func NewGetNewsletterStatsHandler(rp NewsletterRoleProvider, gns GetNewsletterStats, now func() time.Time) *GetNewsletterStatsHandler {
+	return &GetNewsletterStatsHandler{roleProvider: rp, getNewsletterStats: gns, now: now}
+}
+
+// Handle returns statistics of newsletter in buckets of interval, from and to are optional dates (YYYY-MM-DD).
+func (h *GetNewsletterStatsHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, interval, from, to string,
+) (*dto.NewsletterStats, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	statsInterval, err := domain.NewStatsInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	fromDate, err := parseDate(from)
+	if err != nil {
+		return nil, err
+	}
+	toDate, err := parseDate(to)
+	if err != nil {
+		return nil, err
+	}
+	period, err := domain.NewStatsPeriod(statsInterval, fromDate, toDate, h.now())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, err
+	}
+
+	return h.getNewsletterStats.GetNewsletterStats(ctx, pubID, period)
+}
+
+// parseDate parses optional date, empty value is nil.
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, application.InvalidStatsPeriodError
+	}
+
+	return &date, nil
+}
@@ -2,7 +2,10 @@ package handler
 
 import (
 	"context"
+	"math"
+	"slices"
 
+	"github.com/javor454/newsletter-assignment/app/logger"
 	"github.com/javor454/newsletter-assignment/internal/application/dto"
 	"github.com/javor454/newsletter-assignment/internal/domain"
 )
@@ -11,14 +14,36 @@ type GetNewslettersBySubscriptionEmail interface {
 	GetBySubscriptionEmail(ctx context.Context, email *domain.Email, pageSize, pageNumber int) ([]*domain.Newsletter, *dto.Pagination, error)
 }
 
+type CachedSubscribedNewsletters interface {
+	GetSubscribedNewslettersByEmail(ctx context.Context, email *domain.Email) ([]string, bool, error)
+}
+
+type GetNewslettersByPublicIDs interface {
+	GetByPublicIDs(ctx context.Context, publicIDs []*domain.ID) ([]*domain.Newsletter, error)
+}
+
 type GetNewslettersBySubscriptionEmailHandler struct {
+	lg                                logger.Logger
 	getNewslettersBySubscriptionEmail GetNewslettersBySubscriptionEmail
+	cachedSubscribedNewsletters       CachedSubscribedNewsletters
+	getNewslettersByPublicIDs         GetNewslettersByPublicIDs
 }
 
-func NewGetNewslettersBySubscriptionEmailHandler(gnbui GetNewslettersBySubscriptionEmail) *GetNewslettersBySubscriptionEmailHandler {
-	return &GetNewslettersBySubscriptionEmailHandler{getNewslettersBySubscriptionEmail: gnbui}
+func NewGetNewslettersBySubscriptionEmailHandler(
+	lg logger.Logger,
+	gnbse GetNewslettersBySubscriptionEmail,
+	csn CachedSubscribedNewsletters,
+	gnbpi GetNewslettersByPublicIDs,
+) *GetNewslettersBySubscriptionEmailHandler {
+	return &GetNewslettersBySubscriptionEmailHandler{
+		lg:                                lg,
+		getNewslettersBySubscriptionEmail: gnbse,
+		cachedSubscribedNewsletters:       csn,
+		getNewslettersByPublicIDs:         gnbpi,
+	}
 }
 
+// Handle pages subscribed newsletters from cache, postgres is queried when subscriber is not cached or cache fails.
 func (g *GetNewslettersBySubscriptionEmailHandler) Handle(
 	ctx context.Context,
 	email string,
@@ -29,6 +54,18 @@ func (g *GetNewslettersBySubscriptionEmailHandler) Handle(
 		return nil, nil, err
 	}
 
+	ids, ok, err := g.cachedSubscribedNewsletters.GetSubscribedNewslettersByEmail(ctx, emailVo)
+	if err != nil {
+		g.lg.WithError(err).Warn("[CACHE] Failed to get subscribed newsletters, falling back to postgres")
+	}
+	if err == nil && ok {
+		newsletters, pagination, err := g.getCachedPage(ctx, ids, pageSize, pageNumber)
+		if err == nil {
+			return newsletters, pagination, nil
+		}
+		g.lg.WithError(err).Warn("[CACHE] Failed to get cached newsletters, falling back to postgres")
+	}
+
 	newsletters, pagination, err := g.getNewslettersBySubscriptionEmail.GetBySubscriptionEmail(ctx, emailVo, pageSize, pageNumber)
 	if err != nil {
 		return nil, nil, err
@@ -36,3 +73,43 @@ func (g *GetNewslettersBySubscriptionEmailHandler) Handle(
 
 	return newsletters, pagination, nil
 }
+
+// getCachedPage loads newsletters of cached public ids in order of postgres query and pages them. Pagination is computed
+// from loaded newsletters, so archived or deleted newsletter still cached does not count.
+func (g *GetNewslettersBySubscriptionEmailHandler) getCachedPage(
+	ctx context.Context,
+	ids []string,
+	pageSize, pageNumber int,
+) ([]*domain.Newsletter, *dto.Pagination, error) {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+
+	pubIDs := make([]*domain.ID, 0, len(ids))
+	for _, id := range ids {
+		pubID, err := domain.CreateIDFromExisting(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		pubIDs = append(pubIDs, pubID)
+	}
+
+	if len(pubIDs) == 0 {
+		return []*domain.Newsletter{}, dto.NewPagination(pageNumber, pageSize, 0, 0), nil
+	}
+
+	newsletters, err := g.getNewslettersByPublicIDs.GetByPublicIDs(ctx, pubIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	totalItems := len(newsletters)
+	totalPages := int(math.Ceil(float64(totalItems) / float64(pageSize)))
+	pagination := dto.NewPagination(pageNumber, pageSize, totalPages, totalItems)
+
+	offset := (pageNumber - 1) * pageSize
+	if offset >= totalItems {
+		return []*domain.Newsletter{}, pagination, nil
+	}
+
+	return newsletters[offset:min(offset+pageSize, totalItems)], pagination, nil
+}
@@ -0,0 +1,19 @@
+package handler
+
+import "github.com/javor454/newsletter-assignment/internal/application/dto"
+
+type PublicKeyProvider interface {
+	PublicKeys() []*dto.PublicKey
+}
+
+type GetPublicKeysHandler struct {
+	publicKeyProvider PublicKeyProvider
+}
+
+func NewGetPublicKeysHandler(pkp PublicKeyProvider) *GetPublicKeysHandler {
+	return &GetPublicKeysHandler{publicKeyProvider: pkp}
+}
+
+func (h *GetPublicKeysHandler) Handle() []*dto.PublicKey {
+	return h.publicKeyProvider.PublicKeys()
+}
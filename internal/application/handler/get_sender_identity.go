@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetSenderIdentity interface {
+	GetSenderIdentity(ctx context.Context, newsletterPublicID *domain.ID) (*domain.SenderIdentity, error)
+}
+
+type GetSenderIdentityHandler struct {
+	roleProvider      NewsletterRoleProvider
+	getSenderIdentity GetSenderIdentity
+}
+
+func NewGetSenderIdentityHandler(rp NewsletterRoleProvider, gsi GetSenderIdentity) *GetSenderIdentityHandler {
+	return &GetSenderIdentityHandler{roleProvider: rp, getSenderIdentity: gsi}
+}
+
+func (h *GetSenderIdentityHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID string,
+) (*domain.SenderIdentity, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, err
+	}
+
+	return h.getSenderIdentity.GetSenderIdentity(ctx, pubID)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetSendingDomainsByUserID interface {
+	GetByUserID(ctx context.Context, userID *domain.ID) ([]*domain.SendingDomain, error)
+}
+
+type GetSendingDomainsHandler struct {
+	getSendingDomains    GetSendingDomainsByUserID
+	sendingDomainRecords SendingDomainRecords
+}
+
+func NewGetSendingDomainsHandler(gsd GetSendingDomainsByUserID, sdr SendingDomainRecords) *GetSendingDomainsHandler {
+	return &GetSendingDomainsHandler{getSendingDomains: gsd, sendingDomainRecords: sdr}
+}
+
+func (h *GetSendingDomainsHandler) Handle(ctx context.Context, userID string) ([]*dto.SendingDomain, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := h.getSendingDomains.GetByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.SendingDomain, 0, len(domains))
+	for _, sd := range domains {
+		res = append(res, &dto.SendingDomain{Domain: sd, Records: h.sendingDomainRecords.Records(sd)})
+	}
+
+	return res, nil
+}
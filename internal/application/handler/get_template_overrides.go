@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetTemplateOverrides interface {
+	GetTemplateOverrides(ctx context.Context, newsletterPublicID *domain.ID) ([]*domain.TemplateOverride, error)
+}
+
+type GetTemplateOverridesHandler struct {
+	roleProvider         NewsletterRoleProvider
+	getTemplateOverrides GetTemplateOverrides
+}
+
+func NewGetTemplateOverridesHandler(rp NewsletterRoleProvider, gto GetTemplateOverrides) *GetTemplateOverridesHandler {
+	return &GetTemplateOverridesHandler{roleProvider: rp, getTemplateOverrides: gto}
+}
+
+func (h *GetTemplateOverridesHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID string,
+) ([]*domain.TemplateOverride, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, err
+	}
+
+	return h.getTemplateOverrides.GetTemplateOverrides(ctx, pubID)
+}
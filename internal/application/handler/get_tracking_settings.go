@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetTrackingSettings interface {
+	GetTrackingSettings(ctx context.Context, newsletterPublicID *domain.ID) (*domain.TrackingSettings, error)
+}
+
+type GetTrackingSettingsHandler struct {
+	roleProvider        NewsletterRoleProvider
+	getTrackingSettings GetTrackingSettings
+}
+
+func NewGetTrackingSettingsHandler(rp NewsletterRoleProvider, gts GetTrackingSettings) *GetTrackingSettingsHandler {
+	return &GetTrackingSettingsHandler{roleProvider: rp, getTrackingSettings: gts}
+}
+
+func (h *GetTrackingSettingsHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID string,
+) (*domain.TrackingSettings, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, err
+	}
+
+	return h.getTrackingSettings.GetTrackingSettings(ctx, pubID)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetUnsubscribeComments interface {
+	GetUnsubscribeComments(
+		ctx context.Context,
+		newsletterPublicID *domain.ID,
+		period *domain.StatsPeriod,
+		pageSize, pageNumber int,
+	) ([]*dto.UnsubscribeComment, *dto.Pagination, error)
+}
+
+type GetUnsubscribeCommentsHandler struct {
+	roleProvider           NewsletterRoleProvider
+	getUnsubscribeComments GetUnsubscribeComments
+	now                    func() time.Time
+}
+
+func NewGetUnsubscribeCommentsHandler(
+	rp NewsletterRoleProvider,
+	guc GetUnsubscribeComments,
+	now func() time.Time,
+) *GetUnsubscribeCommentsHandler {
+	return &GetUnsubscribeCommentsHandler{roleProvider: rp, getUnsubscribeComments: guc, now: now}
+}
+
+// Handle returns comments of subscribers who unsubscribed in period, from and to are optional dates (YYYY-MM-DD)
+// with the same defaults as daily statistics.
+func (h *GetUnsubscribeCommentsHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, from, to string,
+	pageSize, pageNumber int,
+) ([]*dto.UnsubscribeComment, *dto.Pagination, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	fromDate, err := parseDate(from)
+	if err != nil {
+		return nil, nil, err
+	}
+	toDate, err := parseDate(to)
+	if err != nil {
+		return nil, nil, err
+	}
+	period, err := domain.NewStatsPeriod(domain.StatsIntervalDay, fromDate, toDate, h.now())
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanView); err != nil {
+		return nil, nil, err
+	}
+
+	return h.getUnsubscribeComments.GetUnsubscribeComments(ctx, pubID, period, pageSize, pageNumber)
+}
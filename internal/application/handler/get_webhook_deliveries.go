@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetWebhook interface {
+	GetWebhook(ctx context.Context, newsletterPublicID, webhookID *domain.ID) (*domain.Webhook, error)
+}
+
+type GetWebhookDeliveries interface {
+	GetWebhookDeliveries(
+		ctx context.Context,
+		webhookID *domain.ID,
+		pageSize, pageNumber int,
+	) ([]*dto.WebhookDelivery, *dto.Pagination, error)
+}
+
+type GetWebhookDeliveriesHandler struct {
+	roleProvider         NewsletterRoleProvider
+	getWebhook           GetWebhook
+	getWebhookDeliveries GetWebhookDeliveries
+}
+
+func NewGetWebhookDeliveriesHandler(
+	rp NewsletterRoleProvider,
+	gw GetWebhook,
+	gwd GetWebhookDeliveries,
+) *GetWebhookDeliveriesHandler {
+	return &GetWebhookDeliveriesHandler{roleProvider: rp, getWebhook: gw, getWebhookDeliveries: gwd}
+}
+
+// Handle returns delivery log of webhook, newest first.
+func (h *GetWebhookDeliveriesHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, webhookID string,
+	pageSize, pageNumber int,
+) ([]*dto.WebhookDelivery, *dto.Pagination, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	whID, err := domain.CreateIDFromExisting(webhookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return nil, nil, err
+	}
+	if _, err := h.getWebhook.GetWebhook(ctx, pubID, whID); err != nil {
+		return nil, nil, err
+	}
+
+	return h.getWebhookDeliveries.GetWebhookDeliveries(ctx, whID, pageSize, pageNumber)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetWebhooks interface {
+	GetWebhooks(ctx context.Context, newsletterPublicID *domain.ID) ([]*domain.Webhook, error)
+}
+
+type GetWebhooksHandler struct {
+	roleProvider NewsletterRoleProvider
+	getWebhooks  GetWebhooks
+}
+
+func NewGetWebhooksHandler(rp NewsletterRoleProvider, gw GetWebhooks) *GetWebhooksHandler {
+	return &GetWebhooksHandler{roleProvider: rp, getWebhooks: gw}
+}
+
+func (h *GetWebhooksHandler) Handle(ctx context.Context, userID, newsletterPublicID string) ([]*domain.Webhook, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return nil, err
+	}
+
+	return h.getWebhooks.GetWebhooks(ctx, pubID)
+}
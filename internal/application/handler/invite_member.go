@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type InviteMember interface {
+	Invite(ctx context.Context, invitation *domain.Invitation, token string) error
+}
+
+type InvitationTokenGenerator func() (string, error)
+
+type InviteMemberHandler struct {
+	roleProvider            NewsletterRoleProvider
+	getNewsletterByPublicID GetNewsletterByPublicID
+	inviteMember            InviteMember
+	generateToken           InvitationTokenGenerator
+}
+
+func NewInviteMemberHandler(
+	rp NewsletterRoleProvider,
+	gnbpi GetNewsletterByPublicID,
+	im InviteMember,
+	gt InvitationTokenGenerator,
+) *InviteMemberHandler {
+	return &InviteMemberHandler{
+		roleProvider:            rp,
+		getNewsletterByPublicID: gnbpi,
+		inviteMember:            im,
+		generateToken:           gt,
+	}
+}
+
+// Handle invites user by email, ownership can not be granted by invitation.
+func (h *InviteMemberHandler) Handle(ctx context.Context, userID, newsletterPublicID, email, role string) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return err
+	}
+	emailVo, err := domain.NewEmail(email)
+	if err != nil {
+		return err
+	}
+	r, err := domain.NewRole(role)
+	if err != nil {
+		return err
+	}
+	if r == domain.RoleOwner {
+		return application.InvalidRoleError
+	}
+
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return err
+	}
+
+	newsletter, err := h.getNewsletterByPublicID.GetByPublicID(ctx, pubID)
+	if err != nil {
+		return err
+	}
+
+	token, err := h.generateToken()
+	if err != nil {
+		return err
+	}
+
+	return h.inviteMember.Invite(ctx, domain.NewInvitation(newsletter, emailVo, r, uID), token)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
 	"github.com/javor454/newsletter-assignment/internal/domain"
 )
 
@@ -14,34 +15,64 @@ type GenerateToken interface {
 	GenerateUserToken(user *domain.User) (string, error)
 }
 
+type GenerateChallengeToken interface {
+	GenerateChallengeToken(user *domain.User, challengeID *domain.ID) (string, error)
+}
+
+type StartTOTPChallenge interface {
+	StartTOTPChallenge(ctx context.Context, userID, challengeID *domain.ID) error
+}
+
 type LoginUserHandler struct {
-	getUser       GetUser
-	generateToken GenerateToken
+	getUser                GetUser
+	generateToken          GenerateToken
+	generateChallengeToken GenerateChallengeToken
+	startTOTPChallenge     StartTOTPChallenge
 }
 
-func NewLoginUserHandler(ur GetUser, ts GenerateToken) *LoginUserHandler {
-	return &LoginUserHandler{getUser: ur, generateToken: ts}
+func NewLoginUserHandler(
+	ur GetUser,
+	ts GenerateToken,
+	cts GenerateChallengeToken,
+	stc StartTOTPChallenge,
+) *LoginUserHandler {
+	return &LoginUserHandler{getUser: ur, generateToken: ts, generateChallengeToken: cts, startTOTPChallenge: stc}
 }
 
-func (r *LoginUserHandler) Handle(ctx context.Context, email string, password string) (string, error) {
+// Handle returns short-lived challenge token instead of user token when user has TOTP enabled. Only the last issued
+// challenge of user can be finished.
+func (r *LoginUserHandler) Handle(ctx context.Context, email string, password string) (*dto.LoginResult, error) {
 	emailVo, err := domain.NewEmail(email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	pass, err := domain.NewPassword(password)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	user, err := r.getUser.GetByEmailAndPassword(ctx, emailVo, pass)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	if user.TOTPEnabled() {
+		challengeID := domain.NewID()
+		if err := r.startTOTPChallenge.StartTOTPChallenge(ctx, user.ID(), challengeID); err != nil {
+			return nil, err
+		}
+		token, err := r.generateChallengeToken.GenerateChallengeToken(user, challengeID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &dto.LoginResult{Token: token, ChallengeRequired: true}, nil
 	}
 
 	token, err := r.generateToken.GenerateUserToken(user)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return token, nil
+	return &dto.LoginResult{Token: token}, nil
 }
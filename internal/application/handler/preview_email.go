@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+// sampleRecipient stands in for subscriber when preview is not rendered for specific one.
+const sampleRecipient = "subscriber@example.com"
+
+type GetSubscriptionLocale interface {
+	GetSubscriptionLocale(ctx context.Context, newsletterPublicID *domain.ID, email *domain.Email) (*domain.Locale, error)
+}
+
+type PreviewEmail interface {
+	PreviewEmail(
+		ctx context.Context,
+		newsletterPublicID *domain.ID,
+		name string,
+		draft *domain.TemplateOverride,
+		issueID *domain.ID,
+		recipient *domain.Email,
+		locale *domain.Locale,
+	) (*dto.EmailPreview, error)
+}
+
+type PreviewEmailHandler struct {
+	roleProvider          NewsletterRoleProvider
+	templateValidator     TemplateValidator
+	getSubscriptionLocale GetSubscriptionLocale
+	previewEmail          PreviewEmail
+}
+
+func NewPreviewEmailHandler(
+	rp NewsletterRoleProvider,
+	tv TemplateValidator,
+	gsl GetSubscriptionLocale,
+	pe PreviewEmail,
+) *PreviewEmailHandler {
+	return &PreviewEmailHandler{
+		roleProvider:          rp,
+		templateValidator:     tv,
+		getSubscriptionLocale: gsl,
+		previewEmail:          pe,
+	}
+}
+
+// Handle renders template of newsletter with sample data, or with data of subscriber when email is given.
+// Draft html replaces stored override, locale defaults to preferred locale of subscriber, then to default one.
+// Issue template is rendered with issue of given ID exactly as it would be delivered.
+func (h *PreviewEmailHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, name, html, text, issueID, subscriberEmail, locale string,
+) (*dto.EmailPreview, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanEdit); err != nil {
+		return nil, err
+	}
+
+	draft, err := createDraft(h.templateValidator, name, html, text)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := previewIssueID(issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := domain.NewEmail(sampleRecipient)
+	if err != nil {
+		return nil, err
+	}
+	var subscriberLocale *domain.Locale
+	if subscriberEmail != "" {
+		if recipient, err = domain.NewEmail(subscriberEmail); err != nil {
+			return nil, err
+		}
+		if subscriberLocale, err = h.getSubscriptionLocale.GetSubscriptionLocale(ctx, pubID, recipient); err != nil {
+			return nil, err
+		}
+	}
+
+	localeVo, err := previewLocale(locale, subscriberLocale)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.previewEmail.PreviewEmail(ctx, pubID, name, draft, issue, recipient, localeVo)
+}
+
+// createDraft returns validated unsaved override, nil when html is empty and stored template is used.
+func createDraft(tv TemplateValidator, name, html, text string) (*domain.TemplateOverride, error) {
+	if html == "" {
+		return nil, nil
+	}
+	if err := tv.ValidateTemplate(name, html, text); err != nil {
+		return nil, err
+	}
+
+	return domain.NewTemplateOverride(name, html, text), nil
+}
+
+// previewIssueID returns ID of issue to render, nil when none is given.
+func previewIssueID(issueID string) (*domain.ID, error) {
+	if issueID == "" {
+		return nil, nil
+	}
+
+	return domain.CreateIDFromExisting(issueID)
+}
+
+// previewLocale prefers explicitly requested locale over fallback, default locale is used when neither is set.
+func previewLocale(locale string, fallback *domain.Locale) (*domain.Locale, error) {
+	if locale != "" {
+		return domain.NewLocale(locale)
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+
+	return domain.DefaultLocale(), nil
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type ProcessEmailJobsService interface {
-	ProcessSubscribeEmailJobs(ctx context.Context) error
+	ProcessEmailJobs(ctx context.Context) error
 }
 
 // ProcessEmailJobsHandler processes new email jobs repeatedly until context done is signalled
@@ -34,7 +34,7 @@ func (h *ProcessEmailJobsHandler) Handle(ctx context.Context) {
 				return
 			case <-time.After(1 * time.Minute):
 				h.lg.Debug("[EMAIL] Processing new job batch...")
-				if err := h.processEmailJobs.ProcessSubscribeEmailJobs(ctx); err != nil {
+				if err := h.processEmailJobs.ProcessEmailJobs(ctx); err != nil {
 					// TODO: if one job fails it keeps running infinitely - can happen only in case of INTERNAL
 					h.lg.WithError(err).Error("[EMAIL] Error processing batch")
 				}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+)
+
+type ProcessWebhookDeliveriesService interface {
+	ProcessWebhookDeliveries(ctx context.Context) error
+}
+
+// ProcessWebhookDeliveriesHandler sends due webhook deliveries repeatedly until context done is signalled
+type ProcessWebhookDeliveriesHandler struct {
+	lg                       logger.Logger
+	processWebhookDeliveries ProcessWebhookDeliveriesService
+}
+
+func NewProcessWebhookDeliveriesHandler(
+	lg logger.Logger,
+	processWebhookDeliveries ProcessWebhookDeliveriesService,
+) *ProcessWebhookDeliveriesHandler {
+	return &ProcessWebhookDeliveriesHandler{
+		lg:                       lg,
+		processWebhookDeliveries: processWebhookDeliveries,
+	}
+}
+
+func (h *ProcessWebhookDeliveriesHandler) Handle(ctx context.Context) {
+	go func() {
+		h.lg.Info("[WEBHOOK] Starting webhook delivery processing...")
+		for {
+			select {
+			case <-ctx.Done():
+				h.lg.Debug("[WEBHOOK] Processing stopped")
+				return
+			case <-time.After(10 * time.Second):
+				if err := h.processWebhookDeliveries.ProcessWebhookDeliveries(ctx); err != nil {
+					h.lg.WithError(err).Error("[WEBHOOK] Error processing batch")
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type PublishIssue interface {
+	Publish(ctx context.Context, issue *domain.Issue) (int, error)
+}
+
+type PublishIssueHandler struct {
+	roleProvider NewsletterRoleProvider
+	getIssue     GetIssue
+	publishIssue PublishIssue
+}
+
+func NewPublishIssueHandler(rp NewsletterRoleProvider, gi GetIssue, pi PublishIssue) *PublishIssueHandler {
+	return &PublishIssueHandler{roleProvider: rp, getIssue: gi, publishIssue: pi}
+}
+
+// Handle publishes issue and sends it to active subscribers, every issue is published only once.
+func (h *PublishIssueHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, issueID string,
+) (*dto.PublishedIssue, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	iID, err := domain.CreateIDFromExisting(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanEdit); err != nil {
+		return nil, err
+	}
+
+	issue, err := h.getIssue.GetIssue(ctx, pubID, iID)
+	if err != nil {
+		return nil, err
+	}
+	if err := issue.Publish(); err != nil {
+		return nil, err
+	}
+
+	recipients, err := h.publishIssue.Publish(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PublishedIssue{Issue: issue, Recipients: recipients}, nil
+}
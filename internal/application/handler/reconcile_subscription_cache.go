@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+// SubscribedNewsletters returns public ids of newsletters by email of subscriber.
+type SubscribedNewsletters interface {
+	GetSubscribedNewsletters(ctx context.Context) (map[string][]string, error)
+}
+
+type ReconciledSubscriptionCache interface {
+	SubscribedNewsletters
+	SubscribedNewsletterCache
+}
+
+// ReconcileSubscriptionCacheHandler repairs drift between active subscriptions and their cache in both directions,
+// runs are serialized. Every run logs its counts together with counters accumulated since start, log based metrics
+// read them from fields of the entry.
+type ReconcileSubscriptionCacheHandler struct {
+	lg                    logger.Logger
+	subscribedNewsletters SubscribedNewsletters
+	subscriptionCache     ReconciledSubscriptionCache
+	now                   func() time.Time
+	mu                    sync.Mutex
+	runs                  int
+	totalAdded            int
+	totalRemoved          int
+	totalFailed           int
+}
+
+func NewReconcileSubscriptionCacheHandler(
+	lg logger.Logger,
+	sn SubscribedNewsletters,
+	sc ReconciledSubscriptionCache,
+	now func() time.Time,
+) *ReconcileSubscriptionCacheHandler {
+	return &ReconcileSubscriptionCacheHandler{lg: lg, subscribedNewsletters: sn, subscriptionCache: sc, now: now}
+}
+
+// Handle compares cache with active subscriptions and repairs every difference. Cache is read first, so subscription
+// changed meanwhile is found in db and its cache entry is not reverted.
+func (h *ReconcileSubscriptionCacheHandler) Handle(ctx context.Context) (*dto.CacheReconciliation, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	report := &dto.CacheReconciliation{StartedAt: h.now()}
+
+	cached, err := h.subscriptionCache.GetSubscribedNewsletters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active, err := h.subscribedNewsletters.GetSubscribedNewsletters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedSet := toSubscriptionSet(cached)
+	activeSet := toSubscriptionSet(active)
+	subscribers := make(map[string]struct{}, len(active))
+
+	for email, ids := range active {
+		subscribers[email] = struct{}{}
+		report.Subscriptions += len(ids)
+		for _, id := range ids {
+			if _, ok := cachedSet[email][id]; ok {
+				continue
+			}
+			report.Missing++
+			if h.repair(report, id, h.subscriptionCache.AddSubscribedNewsletter(ctx, email, id)) {
+				report.Added++
+			}
+		}
+	}
+	for email, ids := range cached {
+		subscribers[email] = struct{}{}
+		report.Cached += len(ids)
+		for _, id := range ids {
+			if _, ok := activeSet[email][id]; ok {
+				continue
+			}
+			report.Stale++
+			if h.repair(report, id, h.removeSubscribedNewsletter(ctx, email, id)) {
+				report.Removed++
+			}
+		}
+	}
+
+	report.Subscribers = len(subscribers)
+	report.Duration = h.now().Sub(report.StartedAt)
+
+	h.runs++
+	h.totalAdded += report.Added
+	h.totalRemoved += report.Removed
+	h.totalFailed += report.Failed
+
+	h.lg.WithFields(map[string]interface{}{
+		"subscribers":   report.Subscribers,
+		"subscriptions": report.Subscriptions,
+		"cached":        report.Cached,
+		"missing":       report.Missing,
+		"stale":         report.Stale,
+		"repaired":      report.Repaired,
+		"added":         report.Added,
+		"removed":       report.Removed,
+		"failed":        report.Failed,
+		"duration_ms":   report.Duration.Milliseconds(),
+		"runs":          h.runs,
+		"total_added":   h.totalAdded,
+		"total_removed": h.totalRemoved,
+		"total_failed":  h.totalFailed,
+	}).Info("[CACHE] Subscription cache reconciled")
+
+	return report, nil
+}
+
+func (h *ReconcileSubscriptionCacheHandler) removeSubscribedNewsletter(ctx context.Context, email, id string) error {
+	emailVo, err := domain.NewEmail(email)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(id)
+	if err != nil {
+		return err
+	}
+
+	return h.subscriptionCache.RemoveSubscribedNewsletter(ctx, emailVo, pubID)
+}
+
+// repair counts result of single repair, it reports whether repair succeeded.
+func (h *ReconcileSubscriptionCacheHandler) repair(report *dto.CacheReconciliation, id string, err error) bool {
+	if err != nil {
+		report.Failed++
+		h.lg.WithField("newsletter_public_id", id).WithError(err).Error("[CACHE] Failed to repair subscription cache")
+
+		return false
+	}
+	report.Repaired++
+
+	return true
+}
+
+func toSubscriptionSet(subscriptions map[string][]string) map[string]map[string]struct{} {
+	set := make(map[string]map[string]struct{}, len(subscriptions))
+	for email, ids := range subscriptions {
+		set[email] = make(map[string]struct{}, len(ids))
+		for _, id := range ids {
+			set[email][id] = struct{}{}
+		}
+	}
+
+	return set
+}
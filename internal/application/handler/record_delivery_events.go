@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type DeliveryEventParser interface {
+	ParseDeliveryEvents(signature, timestamp string, payload []byte) ([]*domain.DeliveryEvent, error)
+}
+
+type RecordDeliveryEvent interface {
+	RecordDeliveryEvent(ctx context.Context, event *domain.DeliveryEvent) error
+}
+
+type RecordDeliveryEventsHandler struct {
+	eventParser         DeliveryEventParser
+	recordDeliveryEvent RecordDeliveryEvent
+}
+
+func NewRecordDeliveryEventsHandler(dep DeliveryEventParser, rde RecordDeliveryEvent) *RecordDeliveryEventsHandler {
+	return &RecordDeliveryEventsHandler{eventParser: dep, recordDeliveryEvent: rde}
+}
+
+// Handle records delivery events reported by mail provider in signed payload, events of deleted email jobs are
+// skipped. Events are recorded again when provider retries failed request, repeated ones only bump counters.
+func (h *RecordDeliveryEventsHandler) Handle(ctx context.Context, signature, timestamp string, payload []byte) error {
+	events, err := h.eventParser.ParseDeliveryEvents(signature, timestamp, payload)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err := h.recordDeliveryEvent.RecordDeliveryEvent(ctx, e); err != nil {
+			if errors.Is(err, application.EmailJobNotFoundError) {
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
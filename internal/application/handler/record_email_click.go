@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type ClickTokenParser interface {
+	ParseClickToken(token, target string) (string, int, error)
+}
+
+type RecordEmailClick interface {
+	RecordEmailClick(ctx context.Context, click *domain.EmailClick) error
+}
+
+type RecordEmailClickHandler struct {
+	tokenParser      ClickTokenParser
+	recordEmailClick RecordEmailClick
+	now              func() time.Time
+}
+
+func NewRecordEmailClickHandler(ctp ClickTokenParser, rec RecordEmailClick, now func() time.Time) *RecordEmailClickHandler {
+	return &RecordEmailClickHandler{tokenParser: ctp, recordEmailClick: rec, now: now}
+}
+
+// Handle records click on tracked link, token has to be signed for target so only links from sent emails redirect.
+func (h *RecordEmailClickHandler) Handle(ctx context.Context, token, target string) error {
+	emailJobID, linkIndex, err := h.tokenParser.ParseClickToken(token, target)
+	if err != nil {
+		return err
+	}
+	jobID, err := domain.CreateIDFromExisting(emailJobID)
+	if err != nil {
+		return err
+	}
+
+	return h.recordEmailClick.RecordEmailClick(ctx, domain.NewEmailClick(jobID, linkIndex, h.now()))
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type OpenTokenParser interface {
+	ParseOpenToken(token string) (string, time.Time, error)
+}
+
+type RecordEmailOpen interface {
+	RecordEmailOpen(ctx context.Context, open *domain.EmailOpen) error
+}
+
+type RecordEmailOpenHandler struct {
+	tokenParser     OpenTokenParser
+	recordEmailOpen RecordEmailOpen
+	now             func() time.Time
+}
+
+func NewRecordEmailOpenHandler(otp OpenTokenParser, reo RecordEmailOpen, now func() time.Time) *RecordEmailOpenHandler {
+	return &RecordEmailOpenHandler{tokenParser: otp, recordEmailOpen: reo, now: now}
+}
+
+// Handle records open of email identified by signed token of tracking pixel.
+func (h *RecordEmailOpenHandler) Handle(ctx context.Context, token, userAgent string) error {
+	emailJobID, sentAt, err := h.tokenParser.ParseOpenToken(token)
+	if err != nil {
+		return err
+	}
+	jobID, err := domain.CreateIDFromExisting(emailJobID)
+	if err != nil {
+		return err
+	}
+
+	return h.recordEmailOpen.RecordEmailOpen(ctx, domain.NewEmailOpen(jobID, userAgent, sentAt, h.now()))
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+)
+
+type RefreshStatsService interface {
+	RefreshStats(ctx context.Context, now time.Time) error
+}
+
+// RefreshStatsHandler refreshes pre-aggregated statistics on start and then repeatedly until context done is
+// signalled.
+type RefreshStatsHandler struct {
+	lg           logger.Logger
+	refreshStats RefreshStatsService
+	now          func() time.Time
+}
+
+func NewRefreshStatsHandler(lg logger.Logger, rs RefreshStatsService, now func() time.Time) *RefreshStatsHandler {
+	return &RefreshStatsHandler{
+		lg:           lg,
+		refreshStats: rs,
+		now:          now,
+	}
+}
+
+func (h *RefreshStatsHandler) Handle(ctx context.Context) {
+	go func() {
+		h.lg.Info("[STATS] Starting stats refresh...")
+		for {
+			if err := h.refreshStats.RefreshStats(ctx, h.now()); err != nil {
+				h.lg.WithError(err).Error("[STATS] Error refreshing stats")
+			}
+
+			select {
+			case <-ctx.Done():
+				h.lg.Debug("[STATS] Refresh stopped")
+				return
+			case <-time.After(1 * time.Minute):
+				h.lg.Debug("[STATS] Refreshing stats...")
+			}
+		}
+	}()
+}
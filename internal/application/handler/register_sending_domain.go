@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type CreateSendingDomain interface {
+	Create(ctx context.Context, sd *domain.SendingDomain) error
+}
+
+// DKIMKeyGenerator returns PEM encoded private key and base64 encoded public key.
+type DKIMKeyGenerator func() (string, string, error)
+
+type DomainVerificationTokenGenerator func() (string, error)
+
+type SendingDomainRecords interface {
+	Records(sd *domain.SendingDomain) []*dto.DNSRecord
+}
+
+type RegisterSendingDomainHandler struct {
+	createSendingDomain       CreateSendingDomain
+	sendingDomainRecords      SendingDomainRecords
+	generateDKIMKey           DKIMKeyGenerator
+	generateVerificationToken DomainVerificationTokenGenerator
+}
+
+func NewRegisterSendingDomainHandler(
+	csd CreateSendingDomain,
+	sdr SendingDomainRecords,
+	gdk DKIMKeyGenerator,
+	gvt DomainVerificationTokenGenerator,
+) *RegisterSendingDomainHandler {
+	return &RegisterSendingDomainHandler{
+		createSendingDomain:       csd,
+		sendingDomainRecords:      sdr,
+		generateDKIMKey:           gdk,
+		generateVerificationToken: gvt,
+	}
+}
+
+// Handle registers unverified domain and returns DNS records owner has to publish before verification.
+func (h *RegisterSendingDomainHandler) Handle(ctx context.Context, userID, name string) (*dto.SendingDomain, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := h.generateVerificationToken()
+	if err != nil {
+		return nil, err
+	}
+	privateKey, publicKey, err := h.generateDKIMKey()
+	if err != nil {
+		return nil, err
+	}
+
+	sd, err := domain.NewSendingDomain(uID, name, token, privateKey, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.createSendingDomain.Create(ctx, sd); err != nil {
+		return nil, err
+	}
+
+	return &dto.SendingDomain{Domain: sd, Records: h.sendingDomainRecords.Records(sd)}, nil
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type RemoveMember interface {
+	RemoveMember(ctx context.Context, newsletterPublicID, userID *domain.ID) error
+}
+
+type RemoveMemberHandler struct {
+	roleProvider NewsletterRoleProvider
+	removeMember RemoveMember
+}
+
+func NewRemoveMemberHandler(rp NewsletterRoleProvider, rm RemoveMember) *RemoveMemberHandler {
+	return &RemoveMemberHandler{roleProvider: rp, removeMember: rm}
+}
+
+// Handle removes member from newsletter, owner can remove anyone else and other members can only leave.
+func (h *RemoveMemberHandler) Handle(ctx context.Context, userID, newsletterPublicID, memberUserID string) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return err
+	}
+	memberID, err := domain.CreateIDFromExisting(memberUserID)
+	if err != nil {
+		return err
+	}
+
+	leaving := uID.String() == memberID.String()
+	permitted := domain.Role.CanManage
+	if leaving {
+		permitted = domain.Role.CanView
+	}
+
+	role, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, permitted)
+	if err != nil {
+		return err
+	}
+	if leaving && role == domain.RoleOwner {
+		return application.OwnerRemovalError
+	}
+
+	return h.removeMember.RemoveMember(ctx, pubID, memberID)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type RemoveNewsletter interface {
+	Archive(ctx context.Context, newsletterPublicID *domain.ID, notify bool) error
+	Delete(ctx context.Context, newsletterPublicID *domain.ID, notify bool) error
+}
+
+type RemoveNewsletterHandler struct {
+	roleProvider     NewsletterRoleProvider
+	removeNewsletter RemoveNewsletter
+}
+
+func NewRemoveNewsletterHandler(rp NewsletterRoleProvider, rn RemoveNewsletter) *RemoveNewsletterHandler {
+	return &RemoveNewsletterHandler{roleProvider: rp, removeNewsletter: rn}
+}
+
+// Handle archives newsletter or deletes it permanently, subscribers are optionally notified by email.
+func (h *RemoveNewsletterHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID string,
+	hardDelete, notify bool,
+) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return err
+	}
+
+	remove := h.removeNewsletter.Archive
+	if hardDelete {
+		remove = h.removeNewsletter.Delete
+	}
+
+	return remove(ctx, pubID, notify)
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type RequestEmailChange interface {
+	RequestEmailChange(ctx context.Context, change *domain.EmailChange, token string) error
+}
+
+type EmailChangeTokenGenerator func() (string, error)
+
+type RequestEmailChangeHandler struct {
+	getUserByID        GetUserByID
+	getUser            GetUser
+	requestEmailChange RequestEmailChange
+	generateToken      EmailChangeTokenGenerator
+}
+
+func NewRequestEmailChangeHandler(
+	gu GetUserByID,
+	gp GetUser,
+	rec RequestEmailChange,
+	gt EmailChangeTokenGenerator,
+) *RequestEmailChangeHandler {
+	return &RequestEmailChangeHandler{
+		getUserByID:        gu,
+		getUser:            gp,
+		requestEmailChange: rec,
+		generateToken:      gt,
+	}
+}
+
+// Handle sends verification link to new email, current email stays in use until the link is opened.
+func (h *RequestEmailChangeHandler) Handle(ctx context.Context, userID, email, password string) error {
+	id, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	emailVo, err := domain.NewEmail(email)
+	if err != nil {
+		return err
+	}
+	user, err := verifyPassword(ctx, h.getUserByID, h.getUser, id, password)
+	if err != nil {
+		return err
+	}
+	if user.Email().String() == emailVo.String() {
+		return application.EmailTakenError
+	}
+
+	token, err := h.generateToken()
+	if err != nil {
+		return err
+	}
+
+	return h.requestEmailChange.RequestEmailChange(ctx, domain.NewEmailChange(id, emailVo), token)
+}
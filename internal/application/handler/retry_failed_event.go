@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type RetryFailedEvent interface {
+	RetryFailedEvent(ctx context.Context, eventID *domain.ID) error
+}
+
+type RetryFailedEventHandler struct {
+	retryFailedEvent RetryFailedEvent
+}
+
+func NewRetryFailedEventHandler(rfe RetryFailedEvent) *RetryFailedEventHandler {
+	return &RetryFailedEventHandler{retryFailedEvent: rfe}
+}
+
+// Handle schedules failed event to be dispatched again once its cause is fixed. Later events of its aggregate may have
+// been dispatched meanwhile, so retried event can arrive out of order.
+func (h *RetryFailedEventHandler) Handle(ctx context.Context, eventID string) error {
+	id, err := domain.CreateIDFromExisting(eventID)
+	if err != nil {
+		return err
+	}
+
+	return h.retryFailedEvent.RetryFailedEvent(ctx, id)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type RevokeAPIKey interface {
+	Revoke(ctx context.Context, userID, id *domain.ID) error
+}
+
+type RevokeAPIKeyHandler struct {
+	revokeAPIKey RevokeAPIKey
+}
+
+func NewRevokeAPIKeyHandler(rak RevokeAPIKey) *RevokeAPIKeyHandler {
+	return &RevokeAPIKeyHandler{revokeAPIKey: rak}
+}
+
+func (h *RevokeAPIKeyHandler) Handle(ctx context.Context, userID, apiKeyID string) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	id, err := domain.CreateIDFromExisting(apiKeyID)
+	if err != nil {
+		return err
+	}
+
+	return h.revokeAPIKey.Revoke(ctx, uID, id)
+}
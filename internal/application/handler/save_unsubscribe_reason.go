@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type SaveUnsubscribeReason interface {
+	SaveUnsubscribeReason(
+		ctx context.Context,
+		email *domain.Email,
+		newsletterPublicID *domain.ID,
+		reason *domain.UnsubscribeReason,
+	) error
+}
+
+type SaveUnsubscribeReasonHandler struct {
+	tokenParser           TokenParser
+	saveUnsubscribeReason SaveUnsubscribeReason
+}
+
+func NewSaveUnsubscribeReasonHandler(tp TokenParser, sur SaveUnsubscribeReason) *SaveUnsubscribeReasonHandler {
+	return &SaveUnsubscribeReasonHandler{tokenParser: tp, saveUnsubscribeReason: sur}
+}
+
+// Handle stores answer to survey shown after unsubscribing, token is the same as in unsubscribe link.
+func (h *SaveUnsubscribeReasonHandler) Handle(ctx context.Context, newsletterPublicID, token, code, comment string) error {
+	parsed, err := h.tokenParser.ParseToken(token)
+	if err != nil {
+		return application.InvalidTokenError
+	}
+	emailVo, err := domain.NewEmail(parsed)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return err
+	}
+	reason, err := domain.NewUnsubscribeReason(code, comment)
+	if err != nil {
+		return err
+	}
+
+	return h.saveUnsubscribeReason.SaveUnsubscribeReason(ctx, emailVo, pubID, reason)
+}
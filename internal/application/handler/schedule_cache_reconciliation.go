@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+)
+
+type ReconcileSubscriptionCache interface {
+	Handle(ctx context.Context) (*dto.CacheReconciliation, error)
+}
+
+// ScheduleCacheReconciliationHandler reconciles subscription cache repeatedly until context done is signalled
+type ScheduleCacheReconciliationHandler struct {
+	lg                         logger.Logger
+	reconcileSubscriptionCache ReconcileSubscriptionCache
+	interval                   time.Duration
+}
+
+func NewScheduleCacheReconciliationHandler(
+	lg logger.Logger,
+	rsc ReconcileSubscriptionCache,
+	interval time.Duration,
+) *ScheduleCacheReconciliationHandler {
+	return &ScheduleCacheReconciliationHandler{lg: lg, reconcileSubscriptionCache: rsc, interval: interval}
+}
+
+func (h *ScheduleCacheReconciliationHandler) Handle(ctx context.Context) {
+	go func() {
+		h.lg.Info("[CACHE] Starting subscription cache reconciliation...")
+		for {
+			select {
+			case <-ctx.Done():
+				h.lg.Debug("[CACHE] Reconciliation stopped")
+				return
+			case <-time.After(h.interval):
+				if _, err := h.reconcileSubscriptionCache.Handle(ctx); err != nil {
+					h.lg.WithError(err).Error("[CACHE] Error reconciling subscription cache")
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type SendTestEmail interface {
+	SendTestEmail(
+		ctx context.Context,
+		newsletterPublicID *domain.ID,
+		name string,
+		draft *domain.TemplateOverride,
+		issueID *domain.ID,
+		recipient *domain.Email,
+		locale *domain.Locale,
+	) error
+}
+
+type SendTestEmailHandler struct {
+	roleProvider      NewsletterRoleProvider
+	templateValidator TemplateValidator
+	getUserByID       GetUserByID
+	sendTestEmail     SendTestEmail
+}
+
+func NewSendTestEmailHandler(
+	rp NewsletterRoleProvider,
+	tv TemplateValidator,
+	gu GetUserByID,
+	ste SendTestEmail,
+) *SendTestEmailHandler {
+	return &SendTestEmailHandler{
+		roleProvider:      rp,
+		templateValidator: tv,
+		getUserByID:       gu,
+		sendTestEmail:     ste,
+	}
+}
+
+// Handle sends template of newsletter rendered with sample data to verified email of caller, other recipients
+// are not accepted so the endpoint can not be used to send arbitrary emails. Issue template is sent with issue of given
+// ID exactly as it would be delivered.
+func (h *SendTestEmailHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, name, html, text, issueID, locale string,
+) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanEdit); err != nil {
+		return err
+	}
+
+	draft, err := createDraft(h.templateValidator, name, html, text)
+	if err != nil {
+		return err
+	}
+	issue, err := previewIssueID(issueID)
+	if err != nil {
+		return err
+	}
+	localeVo, err := previewLocale(locale, nil)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.getUserByID.GetByID(ctx, uID)
+	if err != nil {
+		return err
+	}
+
+	return h.sendTestEmail.SendTestEmail(ctx, pubID, name, draft, issue, user.Email(), localeVo)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type SendTestWebhookEvent interface {
+	SendTestEvent(ctx context.Context, webhook *domain.Webhook) (*dto.WebhookDelivery, error)
+}
+
+type SendTestWebhookEventHandler struct {
+	roleProvider  NewsletterRoleProvider
+	getWebhook    GetWebhook
+	sendTestEvent SendTestWebhookEvent
+}
+
+func NewSendTestWebhookEventHandler(
+	rp NewsletterRoleProvider,
+	gw GetWebhook,
+	ste SendTestWebhookEvent,
+) *SendTestWebhookEventHandler {
+	return &SendTestWebhookEventHandler{roleProvider: rp, getWebhook: gw, sendTestEvent: ste}
+}
+
+// Handle sends test event to webhook right away, failed delivery is result of the attempt, not an error.
+func (h *SendTestWebhookEventHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, webhookID string,
+) (*dto.WebhookDelivery, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	whID, err := domain.CreateIDFromExisting(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return nil, err
+	}
+
+	webhook, err := h.getWebhook.GetWebhook(ctx, pubID, whID)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.sendTestEvent.SendTestEvent(ctx, webhook)
+}
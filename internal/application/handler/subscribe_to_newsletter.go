@@ -26,7 +26,8 @@ func NewSubscribeToNewsletterHandler(
 	return &SubscribeToNewsletterHandler{tokenGenerator: tg, subscribeToNewsletter: stn}
 }
 
-func (r *SubscribeToNewsletterHandler) Handle(ctx context.Context, newsletterPublicID, email string) error {
+// Handle subscribes email to newsletter, empty locale falls back to default one.
+func (r *SubscribeToNewsletterHandler) Handle(ctx context.Context, newsletterPublicID, email, locale string) error {
 	emailVo, err := domain.NewEmail(email)
 	if err != nil {
 		return err
@@ -35,13 +36,19 @@ func (r *SubscribeToNewsletterHandler) Handle(ctx context.Context, newsletterPub
 	if err != nil {
 		return err
 	}
+	localeVo := domain.DefaultLocale()
+	if locale != "" {
+		if localeVo, err = domain.NewLocale(locale); err != nil {
+			return err
+		}
+	}
 
 	token, err := r.tokenGenerator.GenerateSubscriptionToken(emailVo)
 	if err != nil {
 		return err
 	}
 
-	subscription := domain.NewSubscription(pubID, emailVo, token)
+	subscription := domain.NewSubscription(pubID, emailVo, token, localeVo)
 
 	if err := r.subscribeToNewsletter.Subscribe(ctx, subscription); err != nil {
 		return err
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type TransferOwnership interface {
+	TransferOwnership(ctx context.Context, newsletterPublicID, ownerID, newOwnerID *domain.ID) error
+}
+
+type TransferOwnershipHandler struct {
+	roleProvider      NewsletterRoleProvider
+	transferOwnership TransferOwnership
+}
+
+func NewTransferOwnershipHandler(rp NewsletterRoleProvider, to TransferOwnership) *TransferOwnershipHandler {
+	return &TransferOwnershipHandler{roleProvider: rp, transferOwnership: to}
+}
+
+// Handle makes existing member owner, previous owner stays as editor.
+func (h *TransferOwnershipHandler) Handle(ctx context.Context, userID, newsletterPublicID, newOwnerUserID string) error {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return err
+	}
+	newOwnerID, err := domain.CreateIDFromExisting(newOwnerUserID)
+	if err != nil {
+		return err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return err
+	}
+	if uID.String() == newOwnerID.String() {
+		return nil
+	}
+
+	return h.transferOwnership.TransferOwnership(ctx, pubID, uID, newOwnerID)
+}
@@ -8,31 +8,23 @@ import (
 )
 
 type UnsubscribeNewsletterRepository interface {
-	Unsubscribe(ctx context.Context, email *domain.Email, newsletterPublicID *domain.ID) error
+	Unsubscribe(ctx context.Context, unsubscription *domain.Unsubscription) error
 }
 
 type TokenParser interface {
 	ParseToken(tokenStr string) (string, error)
 }
 
-type SubscriptionCache interface {
-	RemoveSubscribedNewsletter(ctx context.Context, email *domain.Email, newsletterPublicID *domain.ID) error
-}
-
 type UnsubscribeNewsletterHandler struct {
 	unsubscribeNewsletter UnsubscribeNewsletterRepository
 	tokenParser           TokenParser
-	subscriptionCache     SubscriptionCache
 }
 
-func NewUnsubscribeNewsletterHandler(
-	unr UnsubscribeNewsletterRepository,
-	tp TokenParser,
-	sc SubscriptionCache,
-) *UnsubscribeNewsletterHandler {
-	return &UnsubscribeNewsletterHandler{unsubscribeNewsletter: unr, tokenParser: tp, subscriptionCache: sc}
+func NewUnsubscribeNewsletterHandler(unr UnsubscribeNewsletterRepository, tp TokenParser) *UnsubscribeNewsletterHandler {
+	return &UnsubscribeNewsletterHandler{unsubscribeNewsletter: unr, tokenParser: tp}
 }
 
+// Handle disables subscription, cache of subscriber is updated by handler of raised event.
 func (r *UnsubscribeNewsletterHandler) Handle(ctx context.Context, newsletterPublicID, token string) error {
 	parsed, err := r.tokenParser.ParseToken(token)
 	if err != nil {
@@ -47,13 +39,5 @@ func (r *UnsubscribeNewsletterHandler) Handle(ctx context.Context, newsletterPub
 		return err
 	}
 
-	if err := r.unsubscribeNewsletter.Unsubscribe(ctx, emailVo, pubID); err != nil {
-		return err
-	}
-
-	if err := r.subscriptionCache.RemoveSubscribedNewsletter(ctx, emailVo, pubID); err != nil {
-		return err
-	}
-
-	return nil
+	return r.unsubscribeNewsletter.Unsubscribe(ctx, domain.NewUnsubscription(pubID, emailVo))
 }
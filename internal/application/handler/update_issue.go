@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetIssue interface {
+	GetIssue(ctx context.Context, newsletterPublicID, issueID *domain.ID) (*domain.Issue, error)
+}
+
+type UpdateIssue interface {
+	UpdateIssue(ctx context.Context, issue *domain.Issue) error
+}
+
+type UpdateIssueHandler struct {
+	roleProvider     NewsletterRoleProvider
+	markdownRenderer MarkdownRenderer
+	getIssue         GetIssue
+	updateIssue      UpdateIssue
+}
+
+func NewUpdateIssueHandler(
+	rp NewsletterRoleProvider,
+	mr MarkdownRenderer,
+	gi GetIssue,
+	ui UpdateIssue,
+) *UpdateIssueHandler {
+	return &UpdateIssueHandler{roleProvider: rp, markdownRenderer: mr, getIssue: gi, updateIssue: ui}
+}
+
+// Handle replaces title and body of issue, published issue can be corrected too but it is not sent again.
+func (h *UpdateIssueHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, issueID, title, markdown string,
+) (*domain.Issue, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	iID, err := domain.CreateIDFromExisting(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanEdit); err != nil {
+		return nil, err
+	}
+
+	issue, err := h.getIssue.GetIssue(ctx, pubID, iID)
+	if err != nil {
+		return nil, err
+	}
+	html, err := h.markdownRenderer.Render(markdown)
+	if err != nil {
+		return nil, err
+	}
+	issue.Update(title, markdown, html)
+
+	if err := h.updateIssue.UpdateIssue(ctx, issue); err != nil {
+		return nil, err
+	}
+
+	return issue, nil
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type UpdateNewsletter interface {
+	Update(ctx context.Context, newsletter *domain.Newsletter) error
+}
+
+type UpdateNewsletterHandler struct {
+	roleProvider            NewsletterRoleProvider
+	getNewsletterByPublicID GetNewsletterByPublicID
+	updateNewsletter        UpdateNewsletter
+}
+
+func NewUpdateNewsletterHandler(
+	rp NewsletterRoleProvider,
+	gnbpi GetNewsletterByPublicID,
+	un UpdateNewsletter,
+) *UpdateNewsletterHandler {
+	return &UpdateNewsletterHandler{roleProvider: rp, getNewsletterByPublicID: gnbpi, updateNewsletter: un}
+}
+
+// Handle changes name or description of newsletter, archived newsletter can not be changed.
+func (h *UpdateNewsletterHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID string,
+	name, description *string,
+) (*domain.Newsletter, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return nil, err
+	}
+
+	newsletter, err := h.getNewsletterByPublicID.GetByPublicID(ctx, pubID)
+	if err != nil {
+		return nil, err
+	}
+	newsletter.Update(name, description)
+
+	if err := h.updateNewsletter.Update(ctx, newsletter); err != nil {
+		return nil, err
+	}
+
+	return newsletter, nil
+}
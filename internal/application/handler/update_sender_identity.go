@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type UpdateSenderIdentity interface {
+	UpdateSenderIdentity(ctx context.Context, newsletterPublicID *domain.ID, identity *domain.SenderIdentity) error
+}
+
+// SenderAddressVerifier reports whether user may send from address.
+type SenderAddressVerifier interface {
+	IsVerifiedSenderAddress(ctx context.Context, userID *domain.ID, address *domain.Email) (bool, error)
+}
+
+type UpdateSenderIdentityHandler struct {
+	roleProvider         NewsletterRoleProvider
+	updateSenderIdentity UpdateSenderIdentity
+	senderVerifier       SenderAddressVerifier
+}
+
+func NewUpdateSenderIdentityHandler(
+	rp NewsletterRoleProvider,
+	usi UpdateSenderIdentity,
+	sv SenderAddressVerifier,
+) *UpdateSenderIdentityHandler {
+	return &UpdateSenderIdentityHandler{roleProvider: rp, updateSenderIdentity: usi, senderVerifier: sv}
+}
+
+// Handle replaces sender identity of newsletter, from address has to be default sender or belong to verified sending domain.
+func (h *UpdateSenderIdentityHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, fromName, fromAddress, replyTo, postalAddress string,
+) (*domain.SenderIdentity, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromEmail, replyToEmail *domain.Email
+	if fromAddress != "" {
+		if fromEmail, err = domain.NewEmail(fromAddress); err != nil {
+			return nil, err
+		}
+	}
+	if replyTo != "" {
+		if replyToEmail, err = domain.NewEmail(replyTo); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return nil, err
+	}
+
+	if fromEmail != nil {
+		verified, err := h.senderVerifier.IsVerifiedSenderAddress(ctx, uID, fromEmail)
+		if err != nil {
+			return nil, err
+		}
+		if !verified {
+			return nil, application.UnverifiedSenderDomainError
+		}
+	}
+
+	identity := domain.NewSenderIdentity(fromName, fromEmail, replyToEmail, postalAddress)
+	if err := h.updateSenderIdentity.UpdateSenderIdentity(ctx, pubID, identity); err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type SaveTemplateOverride interface {
+	SaveTemplateOverride(ctx context.Context, newsletterPublicID *domain.ID, override *domain.TemplateOverride) error
+}
+
+// TemplateValidator checks that template can be rendered with data of its message.
+type TemplateValidator interface {
+	ValidateTemplate(name, html, text string) error
+}
+
+type UpdateTemplateOverrideHandler struct {
+	roleProvider         NewsletterRoleProvider
+	saveTemplateOverride SaveTemplateOverride
+	templateValidator    TemplateValidator
+}
+
+func NewUpdateTemplateOverrideHandler(
+	rp NewsletterRoleProvider,
+	sto SaveTemplateOverride,
+	tv TemplateValidator,
+) *UpdateTemplateOverrideHandler {
+	return &UpdateTemplateOverrideHandler{roleProvider: rp, saveTemplateOverride: sto, templateValidator: tv}
+}
+
+// Handle replaces content of template for newsletter, template is validated before it is stored.
+func (h *UpdateTemplateOverrideHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID, name, html, text string,
+) (*domain.TemplateOverride, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanEdit); err != nil {
+		return nil, err
+	}
+
+	if err := h.templateValidator.ValidateTemplate(name, html, text); err != nil {
+		return nil, err
+	}
+
+	override := domain.NewTemplateOverride(name, html, text)
+	if err := h.saveTemplateOverride.SaveTemplateOverride(ctx, pubID, override); err != nil {
+		return nil, err
+	}
+
+	return override, nil
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type UpdateTrackingSettings interface {
+	UpdateTrackingSettings(ctx context.Context, newsletterPublicID *domain.ID, settings *domain.TrackingSettings) error
+}
+
+type UpdateTrackingSettingsHandler struct {
+	roleProvider           NewsletterRoleProvider
+	updateTrackingSettings UpdateTrackingSettings
+}
+
+func NewUpdateTrackingSettingsHandler(rp NewsletterRoleProvider, uts UpdateTrackingSettings) *UpdateTrackingSettingsHandler {
+	return &UpdateTrackingSettingsHandler{roleProvider: rp, updateTrackingSettings: uts}
+}
+
+// Handle replaces tracking settings of newsletter, only owner decides about privacy of subscribers.
+func (h *UpdateTrackingSettingsHandler) Handle(
+	ctx context.Context,
+	userID, newsletterPublicID string,
+	opens, clicks bool,
+) (*domain.TrackingSettings, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	pubID, err := domain.CreateIDFromExisting(newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeNewsletter(ctx, h.roleProvider, pubID, uID, domain.Role.CanManage); err != nil {
+		return nil, err
+	}
+
+	settings := domain.NewTrackingSettings(opens, clicks)
+	if err := h.updateTrackingSettings.UpdateTrackingSettings(ctx, pubID, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+// verifyPassword returns signed in user when password matches, account changes always require it.
+func verifyPassword(
+	ctx context.Context,
+	gu GetUserByID,
+	gp GetUser,
+	userID *domain.ID,
+	password string,
+) (*domain.User, error) {
+	user, err := gu.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	pass, err := domain.NewPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	return gp.GetByEmailAndPassword(ctx, user.Email(), pass)
+}
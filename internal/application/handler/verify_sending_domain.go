@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type GetSendingDomain interface {
+	Get(ctx context.Context, userID, id *domain.ID) (*domain.SendingDomain, error)
+}
+
+type UpdateSendingDomainCheck interface {
+	UpdateCheck(ctx context.Context, sd *domain.SendingDomain) error
+}
+
+type SendingDomainChecker interface {
+	SendingDomainRecords
+	Check(ctx context.Context, sd *domain.SendingDomain) (*dto.SendingDomainCheck, error)
+}
+
+type VerifySendingDomainHandler struct {
+	getSendingDomain         GetSendingDomain
+	updateSendingDomainCheck UpdateSendingDomainCheck
+	checker                  SendingDomainChecker
+	now                      func() time.Time
+}
+
+func NewVerifySendingDomainHandler(
+	gsd GetSendingDomain,
+	usdc UpdateSendingDomainCheck,
+	sdc SendingDomainChecker,
+	now func() time.Time,
+) *VerifySendingDomainHandler {
+	return &VerifySendingDomainHandler{
+		getSendingDomain:         gsd,
+		updateSendingDomainCheck: usdc,
+		checker:                  sdc,
+		now:                      now,
+	}
+}
+
+// Handle resolves DNS records of domain and stores result, domain which lost any record becomes unverified.
+func (h *VerifySendingDomainHandler) Handle(ctx context.Context, userID, sendingDomainID string) (*dto.SendingDomain, error) {
+	uID, err := domain.CreateIDFromExisting(userID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := domain.CreateIDFromExisting(sendingDomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	sd, err := h.getSendingDomain.Get(ctx, uID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	check, err := h.checker.Check(ctx, sd)
+	if err != nil {
+		return nil, err
+	}
+	sd.ApplyCheck(check.Ownership, check.SPF, check.DKIM, check.DMARC, h.now())
+
+	if err := h.updateSendingDomainCheck.UpdateCheck(ctx, sd); err != nil {
+		return nil, err
+	}
+
+	return &dto.SendingDomain{Domain: sd, Records: h.checker.Records(sd)}, nil
+}
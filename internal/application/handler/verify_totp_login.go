@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+const (
+	// maxTOTPAttempts is number of failed codes after which totp login is locked
+	maxTOTPAttempts = 5
+	totpLockout     = 15 * time.Minute
+)
+
+type ChallengeTokenParser interface {
+	// ParseChallengeToken returns subject and id of challenge
+	ParseChallengeToken(tokenStr string) (string, string, error)
+}
+
+type TOTPLoginRepository interface {
+	GetByID(ctx context.Context, userID *domain.ID) (*domain.User, error)
+	GetTOTPSecret(ctx context.Context, userID *domain.ID) (string, bool, error)
+	UseRecoveryCode(ctx context.Context, userID *domain.ID, code string) error
+	UseTOTPStep(ctx context.Context, userID *domain.ID, step int64) error
+	GetTOTPLoginState(ctx context.Context, userID *domain.ID) (*dto.TOTPLoginState, error)
+	FailTOTPLogin(ctx context.Context, userID *domain.ID, maxAttempts int, lockout time.Duration) error
+	FinishTOTPLogin(ctx context.Context, userID, challengeID *domain.ID) error
+}
+
+type VerifyTOTPLoginHandler struct {
+	challengeParser ChallengeTokenParser
+	userRepository  TOTPLoginRepository
+	validator       TOTPValidator
+	generateToken   GenerateToken
+}
+
+func NewVerifyTOTPLoginHandler(
+	cp ChallengeTokenParser,
+	ur TOTPLoginRepository,
+	v TOTPValidator,
+	gt GenerateToken,
+) *VerifyTOTPLoginHandler {
+	return &VerifyTOTPLoginHandler{challengeParser: cp, userRepository: ur, validator: v, generateToken: gt}
+}
+
+// Handle finishes two-step login, either TOTP code or single use recovery code is accepted. TOTP code is accepted only
+// once, replayed code is rejected even while it is still valid. Challenge is finished only once and only the last one
+// issued to user is accepted, after maxTOTPAttempts failed codes login is locked for totpLockout.
+func (h *VerifyTOTPLoginHandler) Handle(ctx context.Context, challengeToken, code, recoveryCode string) (string, error) {
+	subject, challenge, err := h.challengeParser.ParseChallengeToken(challengeToken)
+	if err != nil {
+		return "", application.InvalidTokenError
+	}
+	id, err := domain.CreateIDFromExisting(subject)
+	if err != nil {
+		return "", err
+	}
+	challengeID, err := domain.CreateIDFromExisting(challenge)
+	if err != nil {
+		return "", application.InvalidTokenError
+	}
+
+	user, err := h.userRepository.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := h.userRepository.GetTOTPLoginState(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if state.Locked {
+		return "", application.TOTPLockedError
+	}
+	if state.ChallengeID != challengeID.String() {
+		return "", application.InvalidTokenError
+	}
+
+	if err := h.verify(ctx, id, code, recoveryCode); err != nil {
+		if errors.Is(err, application.InvalidTOTPCodeError) {
+			if err := h.userRepository.FailTOTPLogin(ctx, id, maxTOTPAttempts, totpLockout); err != nil {
+				return "", err
+			}
+		}
+
+		return "", err
+	}
+
+	if err := h.userRepository.FinishTOTPLogin(ctx, id, challengeID); err != nil {
+		return "", err
+	}
+
+	token, err := h.generateToken.GenerateUserToken(user)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (h *VerifyTOTPLoginHandler) verify(ctx context.Context, id *domain.ID, code, recoveryCode string) error {
+	if code == "" {
+		if recoveryCode == "" {
+			return application.InvalidTOTPCodeError
+		}
+
+		return h.userRepository.UseRecoveryCode(ctx, id, recoveryCode)
+	}
+
+	secret, enabled, err := h.userRepository.GetTOTPSecret(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return application.InvalidTOTPCodeError
+	}
+	step, ok := h.validator.Validate(secret, code)
+	if !ok {
+		return application.InvalidTOTPCodeError
+	}
+
+	return h.userRepository.UseTOTPStep(ctx, id, step)
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type Scope string
+
+const (
+	ScopeNewslettersRead  Scope = "newsletters:read"
+	ScopeNewslettersWrite Scope = "newsletters:write"
+	ScopeIssuesRead       Scope = "issues:read"
+	ScopeIssuesWrite      Scope = "issues:write"
+)
+
+var scopes = []Scope{
+	ScopeNewslettersRead,
+	ScopeNewslettersWrite,
+	ScopeIssuesRead,
+	ScopeIssuesWrite,
+}
+
+func NewScope(value string) (Scope, error) {
+	s := Scope(value)
+	if !slices.Contains(scopes, s) {
+		return "", application.InvalidScopeError
+	}
+
+	return s, nil
+}
+
+type APIKey struct {
+	id         *ID
+	userID     *ID
+	name       string
+	prefix     string
+	scopes     []Scope
+	lastUsedAt *time.Time
+	expiresAt  *time.Time
+	createdAt  time.Time
+}
+
+func NewAPIKey(userID *ID, name, prefix string, scopes []Scope, expiresAt *time.Time) *APIKey {
+	return &APIKey{
+		id:        NewID(),
+		userID:    userID,
+		name:      name,
+		prefix:    prefix,
+		scopes:    scopes,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+	}
+}
+
+func CreateAPIKeyFromExisting(
+	id, userID *ID,
+	name, prefix string,
+	scopes []Scope,
+	lastUsedAt, expiresAt *time.Time,
+	createdAt time.Time,
+) *APIKey {
+	return &APIKey{
+		id:         id,
+		userID:     userID,
+		name:       name,
+		prefix:     prefix,
+		scopes:     scopes,
+		lastUsedAt: lastUsedAt,
+		expiresAt:  expiresAt,
+		createdAt:  createdAt,
+	}
+}
+
+func (k *APIKey) ID() *ID {
+	return k.id
+}
+
+func (k *APIKey) UserID() *ID {
+	return k.userID
+}
+
+func (k *APIKey) Name() string {
+	return k.name
+}
+
+// Prefix is public part of key used for lookup and for recognizing key in listing.
+func (k *APIKey) Prefix() string {
+	return k.prefix
+}
+
+func (k *APIKey) Scopes() []Scope {
+	return k.scopes
+}
+
+func (k *APIKey) LastUsedAt() *time.Time {
+	return k.lastUsedAt
+}
+
+func (k *APIKey) ExpiresAt() *time.Time {
+	return k.expiresAt
+}
+
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.expiresAt != nil && !now.Before(*k.expiresAt)
+}
+
+func (k *APIKey) HasScope(scope Scope) bool {
+	return slices.Contains(k.scopes, scope)
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+type DeliveryEventType string
+
+const (
+	DeliveryEventDelivered DeliveryEventType = "DELIVERED"
+	DeliveryEventBounced   DeliveryEventType = "BOUNCE"
+)
+
+// DeliveryEvent is outcome of delivery of sent email reported by mail provider.
+type DeliveryEvent struct {
+	events
+	emailJobID *ID
+	eventType  DeliveryEventType
+	occurredAt time.Time
+}
+
+func NewDeliveryEvent(emailJobID *ID, eventType DeliveryEventType, occurredAt time.Time) *DeliveryEvent {
+	return &DeliveryEvent{emailJobID: emailJobID, eventType: eventType, occurredAt: occurredAt}
+}
+
+func (d *DeliveryEvent) EmailJobID() *ID {
+	return d.emailJobID
+}
+
+func (d *DeliveryEvent) Type() DeliveryEventType {
+	return d.eventType
+}
+
+func (d *DeliveryEvent) OccurredAt() time.Time {
+	return d.occurredAt
+}
+
+// AttributeToNewsletter assigns email to newsletter it was sent on behalf of, recipient is known only from the stored
+// job. Bounce raises EventEmailBounced identified by the job, repeated report of the bounce raises the same event.
+func (d *DeliveryEvent) AttributeToNewsletter(newsletterPublicID *ID, recipient string) {
+	if d.eventType != DeliveryEventBounced {
+		return
+	}
+
+	d.raise(CreateEventFromExisting(
+		d.emailJobID,
+		EventEmailBounced,
+		"email_job/"+d.emailJobID.String(),
+		newsletterPublicID,
+		d.occurredAt,
+		map[string]string{
+			"email":        recipient,
+			"email_job_id": d.emailJobID.String(),
+		},
+	))
+}
@@ -1,8 +1,10 @@
 package domain
 
 import (
-	"fmt"
 	"regexp"
+	"strings"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
 )
 
 type Email struct {
@@ -12,7 +14,7 @@ type Email struct {
 func NewEmail(value string) (*Email, error) {
 	regex := regexp.MustCompile(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)
 	if !regex.MatchString(value) {
-		return nil, fmt.Errorf("invalid email format")
+		return nil, application.InvalidEmailError
 	}
 
 	return &Email{value: value}, nil
@@ -21,3 +23,8 @@ func NewEmail(value string) (*Email, error) {
 func (e *Email) String() string {
 	return e.value
 }
+
+// Domain returns part of address after @.
+func (e *Email) Domain() string {
+	return e.value[strings.LastIndex(e.value, "@")+1:]
+}
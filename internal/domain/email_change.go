@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// EmailChangeExpiration is time in which new email has to be verified.
+const EmailChangeExpiration = 24 * time.Hour
+
+// EmailChange is pending change of user email, it takes effect once new address is verified.
+type EmailChange struct {
+	userID    *ID
+	email     *Email
+	expiresAt time.Time
+}
+
+func NewEmailChange(userID *ID, email *Email) *EmailChange {
+	return &EmailChange{
+		userID:    userID,
+		email:     email,
+		expiresAt: time.Now().Add(EmailChangeExpiration),
+	}
+}
+
+func (e *EmailChange) UserID() *ID {
+	return e.userID
+}
+
+func (e *EmailChange) Email() *Email {
+	return e.email
+}
+
+func (e *EmailChange) ExpiresAt() time.Time {
+	return e.expiresAt
+}
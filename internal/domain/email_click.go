@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// EmailClick is single follow of tracked link in sent email.
+type EmailClick struct {
+	id         *ID
+	emailJobID *ID
+	linkIndex  int
+	clickedAt  time.Time
+}
+
+func NewEmailClick(emailJobID *ID, linkIndex int, clickedAt time.Time) *EmailClick {
+	return &EmailClick{
+		id:         NewID(),
+		emailJobID: emailJobID,
+		linkIndex:  linkIndex,
+		clickedAt:  clickedAt,
+	}
+}
+
+func (e *EmailClick) ID() *ID {
+	return e.id
+}
+
+func (e *EmailClick) EmailJobID() *ID {
+	return e.emailJobID
+}
+
+func (e *EmailClick) LinkIndex() int {
+	return e.linkIndex
+}
+
+func (e *EmailClick) ClickedAt() time.Time {
+	return e.clickedAt
+}
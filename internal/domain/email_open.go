@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+type UserAgentClass string
+
+const (
+	UserAgentDesktop UserAgentClass = "desktop"
+	UserAgentMobile  UserAgentClass = "mobile"
+	// UserAgentProxy is image proxy of webmail (e.g. Gmail), it fetches images when recipient opens message.
+	UserAgentProxy UserAgentClass = "proxy"
+	// UserAgentPrivacyProxy fetches images on delivery regardless of reading, e.g. Apple Mail Privacy Protection.
+	UserAgentPrivacyProxy UserAgentClass = "privacy_proxy"
+	UserAgentBot          UserAgentClass = "bot"
+	UserAgentUnknown      UserAgentClass = "unknown"
+)
+
+// prefetchWindow is time after sending in which opens are considered automated, nobody reads message that fast.
+const prefetchWindow = 10 * time.Second
+
+var (
+	webmailProxies = []string{"googleimageproxy", "ggpht.com", "yahoomailproxy", "ymailproxy"}
+	bots           = []string{"bot", "crawler", "spider", "curl", "wget", "python", "go-http-client", "headless", "scanner"}
+	mobiles        = []string{"iphone", "ipad", "android", "mobile"}
+)
+
+// EmailOpen is single fetch of tracking pixel of sent email.
+type EmailOpen struct {
+	emailJobID     *ID
+	userAgentClass UserAgentClass
+	prefetched     bool
+	openedAt       time.Time
+}
+
+func NewEmailOpen(emailJobID *ID, userAgent string, sentAt, openedAt time.Time) *EmailOpen {
+	class := ClassifyUserAgent(userAgent)
+
+	return &EmailOpen{
+		emailJobID:     emailJobID,
+		userAgentClass: class,
+		prefetched: class == UserAgentPrivacyProxy ||
+			class == UserAgentBot ||
+			openedAt.Sub(sentAt) < prefetchWindow,
+		openedAt: openedAt,
+	}
+}
+
+// ClassifyUserAgent sorts user agent fetching tracking pixel into coarse class, full user agent is never stored.
+func ClassifyUserAgent(userAgent string) UserAgentClass {
+	// Apple Mail Privacy Protection prefetches through proxy with bare user agent
+	if strings.TrimSpace(userAgent) == "Mozilla/5.0" {
+		return UserAgentPrivacyProxy
+	}
+
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return UserAgentUnknown
+	case containsAny(ua, webmailProxies):
+		return UserAgentProxy
+	case containsAny(ua, bots):
+		return UserAgentBot
+	case containsAny(ua, mobiles):
+		return UserAgentMobile
+	case strings.Contains(ua, "mozilla"), strings.Contains(ua, "outlook"), strings.Contains(ua, "thunderbird"):
+		return UserAgentDesktop
+	default:
+		return UserAgentUnknown
+	}
+}
+
+func containsAny(value string, substrings []string) bool {
+	for _, s := range substrings {
+		if strings.Contains(value, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *EmailOpen) EmailJobID() *ID {
+	return e.emailJobID
+}
+
+func (e *EmailOpen) UserAgentClass() UserAgentClass {
+	return e.userAgentClass
+}
+
+// Prefetched reports whether open was most likely made by machine, such opens do not prove message was read.
+func (e *EmailOpen) Prefetched() bool {
+	return e.prefetched
+}
+
+func (e *EmailOpen) OpenedAt() time.Time {
+	return e.openedAt
+}
@@ -0,0 +1,95 @@
+package domain
+
+import "time"
+
+type EventName string
+
+const (
+	EventSubscriptionCreated  EventName = "subscription.created"
+	EventSubscriptionDisabled EventName = "subscription.disabled"
+	EventIssuePublished       EventName = "issue.published"
+	EventEmailBounced         EventName = "email.bounced"
+)
+
+// Event is fact raised by aggregate, it is stored to outbox in transaction of the change and dispatched to handlers
+// afterwards.
+type Event struct {
+	id                 *ID
+	name               EventName
+	aggregateKey       string
+	newsletterPublicID *ID
+	occurredAt         time.Time
+	data               map[string]string
+}
+
+func NewEvent(
+	name EventName,
+	aggregateKey string,
+	newsletterPublicID *ID,
+	occurredAt time.Time,
+	data map[string]string,
+) *Event {
+	return CreateEventFromExisting(NewID(), name, aggregateKey, newsletterPublicID, occurredAt, data)
+}
+
+func CreateEventFromExisting(
+	id *ID,
+	name EventName,
+	aggregateKey string,
+	newsletterPublicID *ID,
+	occurredAt time.Time,
+	data map[string]string,
+) *Event {
+	return &Event{
+		id:                 id,
+		name:               name,
+		aggregateKey:       aggregateKey,
+		newsletterPublicID: newsletterPublicID,
+		occurredAt:         occurredAt,
+		data:               data,
+	}
+}
+
+// ID identifies event, handlers deduplicate repeated dispatch by it.
+func (e *Event) ID() *ID {
+	return e.id
+}
+
+func (e *Event) Name() EventName {
+	return e.name
+}
+
+// AggregateKey identifies aggregate which raised event, events of the same aggregate are dispatched one by one in
+// order they occurred.
+func (e *Event) AggregateKey() string {
+	return e.aggregateKey
+}
+
+func (e *Event) NewsletterPublicID() *ID {
+	return e.newsletterPublicID
+}
+
+func (e *Event) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e *Event) Data() map[string]string {
+	return e.data
+}
+
+// events collects events raised by aggregate until repository stores them together with the change.
+type events struct {
+	raised []*Event
+}
+
+func (e *events) raise(event *Event) {
+	e.raised = append(e.raised, event)
+}
+
+// PullEvents returns events raised since last pull, every event is handed over only once.
+func (e *events) PullEvents() []*Event {
+	raised := e.raised
+	e.raised = nil
+
+	return raised
+}
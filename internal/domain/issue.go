@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+// Issue is single edition of newsletter, body is written in markdown and stored together with its rendered html.
+type Issue struct {
+	events
+	id                 *ID
+	newsletterPublicID *ID
+	slug               *Slug
+	title              string
+	markdown           string
+	html               string
+	publishedAt        *time.Time
+	createdAt          time.Time
+	updatedAt          time.Time
+}
+
+func NewIssue(newsletterPublicID *ID, slug *Slug, title, markdown, html string) *Issue {
+	now := time.Now()
+
+	return &Issue{
+		id:                 NewID(),
+		newsletterPublicID: newsletterPublicID,
+		slug:               slug,
+		title:              title,
+		markdown:           markdown,
+		html:               html,
+		createdAt:          now,
+		updatedAt:          now,
+	}
+}
+
+func CreateIssueFromExisting(
+	id, newsletterPublicID *ID,
+	slug *Slug,
+	title, markdown, html string,
+	publishedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) *Issue {
+	return &Issue{
+		id:                 id,
+		newsletterPublicID: newsletterPublicID,
+		slug:               slug,
+		title:              title,
+		markdown:           markdown,
+		html:               html,
+		publishedAt:        publishedAt,
+		createdAt:          createdAt,
+		updatedAt:          updatedAt,
+	}
+}
+
+func (i *Issue) ID() *ID {
+	return i.id
+}
+
+func (i *Issue) NewsletterPublicID() *ID {
+	return i.newsletterPublicID
+}
+
+func (i *Issue) Slug() *Slug {
+	return i.slug
+}
+
+func (i *Issue) Title() string {
+	return i.title
+}
+
+// Markdown is source of body as written by editor.
+func (i *Issue) Markdown() string {
+	return i.markdown
+}
+
+// HTML is sanitized body rendered from markdown, it is used by email and web archive.
+func (i *Issue) HTML() string {
+	return i.html
+}
+
+func (i *Issue) PublishedAt() *time.Time {
+	return i.publishedAt
+}
+
+func (i *Issue) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+func (i *Issue) UpdatedAt() time.Time {
+	return i.updatedAt
+}
+
+// ModifiedAt returns time of last change visible to readers, publication counts as change.
+func (i *Issue) ModifiedAt() time.Time {
+	if i.publishedAt != nil && i.publishedAt.After(i.updatedAt) {
+		return *i.publishedAt
+	}
+
+	return i.updatedAt
+}
+
+func (i *Issue) IsPublished() bool {
+	return i.publishedAt != nil
+}
+
+// Update replaces title and body, slug of issue stays the same so shared links keep working.
+func (i *Issue) Update(title, markdown, html string) {
+	i.title = title
+	i.markdown = markdown
+	i.html = html
+	i.updatedAt = time.Now()
+}
+
+// Publish marks issue as published and raises EventIssuePublished, issue is published only once.
+func (i *Issue) Publish() error {
+	if i.IsPublished() {
+		return application.IssueAlreadyPublishedError
+	}
+	now := time.Now()
+	i.publishedAt = &now
+	i.raise(NewEvent(EventIssuePublished, "issue/"+i.id.String(), i.newsletterPublicID, now, map[string]string{
+		"issue_id": i.id.String(),
+		"title":    i.title,
+		"slug":     i.slug.String(),
+	}))
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+const defaultLocale = "en"
+
+var supportedLocales = []string{"en", "cs"}
+
+// Locale is language emails are sent in.
+type Locale struct {
+	value string
+}
+
+func NewLocale(value string) (*Locale, error) {
+	value = strings.ToLower(value)
+	if !slices.Contains(supportedLocales, value) {
+		return nil, application.InvalidLocaleError
+	}
+
+	return &Locale{value: value}, nil
+}
+
+func DefaultLocale() *Locale {
+	return &Locale{value: defaultLocale}
+}
+
+// SupportedLocales returns locales emails are translated to, default one is first.
+func SupportedLocales() []string {
+	return slices.Clone(supportedLocales)
+}
+
+func (l *Locale) String() string {
+	return l.value
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+// InvitationExpiration is time in which invitation has to be accepted.
+const InvitationExpiration = 7 * 24 * time.Hour
+
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+func NewRole(value string) (Role, error) {
+	r := Role(value)
+	switch r {
+	case RoleOwner, RoleEditor, RoleViewer:
+		return r, nil
+	default:
+		return "", application.InvalidRoleError
+	}
+}
+
+// CanView allows reading newsletter internals, every member can do it.
+func (r Role) CanView() bool {
+	return r == RoleOwner || r == RoleEditor || r == RoleViewer
+}
+
+// CanEdit allows changing newsletter content.
+func (r Role) CanEdit() bool {
+	return r == RoleOwner || r == RoleEditor
+}
+
+// CanManage allows managing members and newsletter itself.
+func (r Role) CanManage() bool {
+	return r == RoleOwner
+}
+
+type Member struct {
+	userID    *ID
+	email     *Email
+	role      Role
+	createdAt time.Time
+}
+
+func CreateMemberFromExisting(userID *ID, email *Email, role Role, createdAt time.Time) *Member {
+	return &Member{
+		userID:    userID,
+		email:     email,
+		role:      role,
+		createdAt: createdAt,
+	}
+}
+
+func (m *Member) UserID() *ID {
+	return m.userID
+}
+
+func (m *Member) Email() *Email {
+	return m.email
+}
+
+func (m *Member) Role() Role {
+	return m.role
+}
+
+func (m *Member) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+type Invitation struct {
+	id                 *ID
+	newsletterPublicID *ID
+	newsletterName     string
+	email              *Email
+	role               Role
+	invitedBy          *ID
+	expiresAt          time.Time
+}
+
+func NewInvitation(newsletter *Newsletter, email *Email, role Role, invitedBy *ID) *Invitation {
+	return &Invitation{
+		id:                 NewID(),
+		newsletterPublicID: newsletter.PublicID(),
+		newsletterName:     newsletter.Name(),
+		email:              email,
+		role:               role,
+		invitedBy:          invitedBy,
+		expiresAt:          time.Now().Add(InvitationExpiration),
+	}
+}
+
+func CreateInvitationFromExisting(
+	id, newsletterPublicID *ID,
+	newsletterName string,
+	email *Email,
+	role Role,
+	invitedBy *ID,
+	expiresAt time.Time,
+) *Invitation {
+	return &Invitation{
+		id:                 id,
+		newsletterPublicID: newsletterPublicID,
+		newsletterName:     newsletterName,
+		email:              email,
+		role:               role,
+		invitedBy:          invitedBy,
+		expiresAt:          expiresAt,
+	}
+}
+
+func (i *Invitation) ID() *ID {
+	return i.id
+}
+
+func (i *Invitation) NewsletterPublicID() *ID {
+	return i.newsletterPublicID
+}
+
+func (i *Invitation) NewsletterName() string {
+	return i.newsletterName
+}
+
+func (i *Invitation) Email() *Email {
+	return i.email
+}
+
+func (i *Invitation) Role() Role {
+	return i.role
+}
+
+func (i *Invitation) InvitedBy() *ID {
+	return i.invitedBy
+}
+
+func (i *Invitation) ExpiresAt() time.Time {
+	return i.expiresAt
+}
+
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.expiresAt)
+}
@@ -49,3 +49,17 @@ func (u *Newsletter) Description() *string {
 func (u *Newsletter) CreatedAt() time.Time {
 	return u.createdAt
 }
+
+// Update changes name and description, nil keeps current value and empty description removes it.
+func (u *Newsletter) Update(name, description *string) {
+	if name != nil {
+		u.name = *name
+	}
+	if description != nil {
+		if *description == "" {
+			u.description = nil
+		} else {
+			u.description = description
+		}
+	}
+}
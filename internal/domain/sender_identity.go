@@ -0,0 +1,35 @@
+package domain
+
+// SenderIdentity is sender of messages rendered for newsletter, empty values fall back to application defaults.
+type SenderIdentity struct {
+	fromName      string
+	fromAddress   *Email
+	replyTo       *Email
+	postalAddress string
+}
+
+func NewSenderIdentity(fromName string, fromAddress, replyTo *Email, postalAddress string) *SenderIdentity {
+	return &SenderIdentity{
+		fromName:      fromName,
+		fromAddress:   fromAddress,
+		replyTo:       replyTo,
+		postalAddress: postalAddress,
+	}
+}
+
+func (s *SenderIdentity) FromName() string {
+	return s.fromName
+}
+
+func (s *SenderIdentity) FromAddress() *Email {
+	return s.fromAddress
+}
+
+func (s *SenderIdentity) ReplyTo() *Email {
+	return s.replyTo
+}
+
+// PostalAddress is physical address shown in footer of every message.
+func (s *SenderIdentity) PostalAddress() string {
+	return s.postalAddress
+}
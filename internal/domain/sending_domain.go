@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+var domainNameRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9\-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// SendingDomain is domain registered by user to send newsletters from, it can be used as from address only
+// after ownership, SPF, DKIM and DMARC records are confirmed.
+type SendingDomain struct {
+	id                *ID
+	userID            *ID
+	name              string
+	verificationToken string
+	dkimSelector      string
+	dkimPrivateKey    string
+	dkimPublicKey     string
+	ownershipVerified bool
+	spfVerified       bool
+	dkimVerified      bool
+	dmarcVerified     bool
+	verifiedAt        *time.Time
+	checkedAt         *time.Time
+	createdAt         time.Time
+}
+
+// NewSendingDomain creates unverified domain, DKIM selector is derived from creation month so keys can be rotated.
+func NewSendingDomain(
+	userID *ID,
+	name, verificationToken, dkimPrivateKey, dkimPublicKey string,
+) (*SendingDomain, error) {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	if len(name) > 253 || !domainNameRegex.MatchString(name) {
+		return nil, application.InvalidDomainError
+	}
+
+	now := time.Now()
+
+	return &SendingDomain{
+		id:                NewID(),
+		userID:            userID,
+		name:              name,
+		verificationToken: verificationToken,
+		dkimSelector:      "nl" + now.Format("200601"),
+		dkimPrivateKey:    dkimPrivateKey,
+		dkimPublicKey:     dkimPublicKey,
+		createdAt:         now,
+	}, nil
+}
+
+func CreateSendingDomainFromExisting(
+	id, userID *ID,
+	name, verificationToken, dkimSelector, dkimPrivateKey, dkimPublicKey string,
+	ownershipVerified, spfVerified, dkimVerified, dmarcVerified bool,
+	verifiedAt, checkedAt *time.Time,
+	createdAt time.Time,
+) *SendingDomain {
+	return &SendingDomain{
+		id:                id,
+		userID:            userID,
+		name:              name,
+		verificationToken: verificationToken,
+		dkimSelector:      dkimSelector,
+		dkimPrivateKey:    dkimPrivateKey,
+		dkimPublicKey:     dkimPublicKey,
+		ownershipVerified: ownershipVerified,
+		spfVerified:       spfVerified,
+		dkimVerified:      dkimVerified,
+		dmarcVerified:     dmarcVerified,
+		verifiedAt:        verifiedAt,
+		checkedAt:         checkedAt,
+		createdAt:         createdAt,
+	}
+}
+
+func (d *SendingDomain) ID() *ID {
+	return d.id
+}
+
+func (d *SendingDomain) UserID() *ID {
+	return d.userID
+}
+
+func (d *SendingDomain) Name() string {
+	return d.name
+}
+
+func (d *SendingDomain) VerificationToken() string {
+	return d.verificationToken
+}
+
+func (d *SendingDomain) DKIMSelector() string {
+	return d.dkimSelector
+}
+
+// DKIMPrivateKey is PEM encoded key used for signing messages, it never leaves the server.
+func (d *SendingDomain) DKIMPrivateKey() string {
+	return d.dkimPrivateKey
+}
+
+// DKIMPublicKey is base64 encoded DER public key published in DKIM DNS record.
+func (d *SendingDomain) DKIMPublicKey() string {
+	return d.dkimPublicKey
+}
+
+func (d *SendingDomain) OwnershipVerified() bool {
+	return d.ownershipVerified
+}
+
+func (d *SendingDomain) SPFVerified() bool {
+	return d.spfVerified
+}
+
+func (d *SendingDomain) DKIMVerified() bool {
+	return d.dkimVerified
+}
+
+func (d *SendingDomain) DMARCVerified() bool {
+	return d.dmarcVerified
+}
+
+func (d *SendingDomain) VerifiedAt() *time.Time {
+	return d.verifiedAt
+}
+
+func (d *SendingDomain) CheckedAt() *time.Time {
+	return d.checkedAt
+}
+
+func (d *SendingDomain) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *SendingDomain) IsVerified() bool {
+	return d.verifiedAt != nil
+}
+
+// ApplyCheck stores result of DNS check, domain stays verified only while all records are in place.
+func (d *SendingDomain) ApplyCheck(ownership, spf, dkim, dmarc bool, now time.Time) {
+	d.ownershipVerified = ownership
+	d.spfVerified = spf
+	d.dkimVerified = dkim
+	d.dmarcVerified = dmarc
+	d.checkedAt = &now
+
+	switch {
+	case !(ownership && spf && dkim && dmarc):
+		d.verifiedAt = nil
+	case d.verifiedAt == nil:
+		d.verifiedAt = &now
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const maxSlugLength = 100
+
+var (
+	slugRegex          = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	slugSeparatorRegex = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Slug identifies issue in urls of newsletter, e.g. "weekly-digest-42".
+type Slug struct {
+	value string
+}
+
+func NewSlug(value string) (*Slug, error) {
+	if len(value) > maxSlugLength || !slugRegex.MatchString(value) {
+		return nil, application.InvalidSlugError
+	}
+
+	return &Slug{value: value}, nil
+}
+
+// CreateSlugFromTitle lowercases title, strips diacritics and joins remaining words by dash.
+func CreateSlugFromTitle(title string) *Slug {
+	ascii, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn))), title)
+	if err != nil {
+		ascii = title
+	}
+
+	value := strings.Trim(slugSeparatorRegex.ReplaceAllString(strings.ToLower(ascii), "-"), "-")
+	if len(value) > maxSlugLength {
+		value = strings.TrimRight(value[:maxSlugLength], "-")
+	}
+	if value == "" {
+		value = "issue"
+	}
+
+	return &Slug{value: value}
+}
+
+func (s *Slug) String() string {
+	return s.value
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type StatsInterval string
+
+const (
+	StatsIntervalDay   StatsInterval = "day"
+	StatsIntervalWeek  StatsInterval = "week"
+	StatsIntervalMonth StatsInterval = "month"
+)
+
+const (
+	// maxStatsBuckets bounds length of time series, two years of days
+	maxStatsBuckets = 731
+	// defaultStatsBuckets is length of time series when start of period is not given
+	defaultStatsBuckets = 30
+)
+
+func NewStatsInterval(value string) (StatsInterval, error) {
+	switch interval := StatsInterval(value); interval {
+	case StatsIntervalDay, StatsIntervalWeek, StatsIntervalMonth:
+		return interval, nil
+	case "":
+		return StatsIntervalDay, nil
+	default:
+		return "", application.InvalidStatsIntervalError
+	}
+}
+
+// Start returns start of bucket containing day, weeks start on Monday.
+func (i StatsInterval) Start(day time.Time) time.Time {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch i {
+	case StatsIntervalWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case StatsIntervalMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day
+	}
+}
+
+// Next returns start of bucket following bucket starting at start.
+func (i StatsInterval) Next(start time.Time) time.Time {
+	switch i {
+	case StatsIntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case StatsIntervalMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// StatsPeriod is range of days in UTC aggregated to buckets of interval, both ends are included.
+type StatsPeriod struct {
+	interval StatsInterval
+	from     time.Time
+	to       time.Time
+}
+
+// NewStatsPeriod aligns start of period to start of its bucket. Missing end is today, missing start is 30 buckets
+// before end.
+func NewStatsPeriod(interval StatsInterval, from, to *time.Time, now time.Time) (*StatsPeriod, error) {
+	end := StatsIntervalDay.Start(now.UTC())
+	if to != nil {
+		end = StatsIntervalDay.Start(*to)
+	}
+
+	start := interval.Start(end)
+	if from != nil {
+		start = interval.Start(*from)
+	} else {
+		for range defaultStatsBuckets - 1 {
+			start = interval.Start(start.AddDate(0, 0, -1))
+		}
+	}
+	if start.After(end) {
+		return nil, application.InvalidStatsPeriodError
+	}
+
+	p := &StatsPeriod{interval: interval, from: start, to: end}
+	if len(p.Buckets()) > maxStatsBuckets {
+		return nil, application.InvalidStatsPeriodError
+	}
+
+	return p, nil
+}
+
+func (p *StatsPeriod) Interval() StatsInterval {
+	return p.interval
+}
+
+func (p *StatsPeriod) From() time.Time {
+	return p.from
+}
+
+func (p *StatsPeriod) To() time.Time {
+	return p.to
+}
+
+// Buckets returns starts of all buckets of period in order.
+func (p *StatsPeriod) Buckets() []time.Time {
+	var buckets []time.Time
+	for start := p.from; !start.After(p.to) && len(buckets) <= maxStatsBuckets; start = p.interval.Next(start) {
+		buckets = append(buckets, start)
+	}
+
+	return buckets
+}
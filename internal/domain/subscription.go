@@ -1,19 +1,37 @@
 package domain
 
+import "time"
+
+// Subscription raises EventSubscriptionCreated, it is stored only when subscriber was not subscribed already.
 type Subscription struct {
+	events
 	id                 *ID
 	newsletterPublicID *ID
 	email              *Email
 	token              string
+	locale             *Locale
 }
 
-func NewSubscription(newsletterPublicID *ID, email *Email, token string) *Subscription {
-	return &Subscription{
+func NewSubscription(newsletterPublicID *ID, email *Email, token string, locale *Locale) *Subscription {
+	s := &Subscription{
 		id:                 NewID(),
 		newsletterPublicID: newsletterPublicID,
 		email:              email,
 		token:              token,
+		locale:             locale,
 	}
+	s.raise(NewEvent(
+		EventSubscriptionCreated,
+		subscriptionKey(newsletterPublicID, email),
+		newsletterPublicID,
+		time.Now(),
+		map[string]string{
+			"email":  email.String(),
+			"locale": locale.String(),
+		},
+	))
+
+	return s
 }
 
 func (s *Subscription) ID() *ID {
@@ -31,3 +49,43 @@ func (s *Subscription) Email() *Email {
 func (s *Subscription) Token() string {
 	return s.token
 }
+
+// Locale is preferred language of subscriber, emails of newsletter are sent in it.
+func (s *Subscription) Locale() *Locale {
+	return s.locale
+}
+
+// Unsubscription is request of subscriber to stop receiving newsletter, it raises EventSubscriptionDisabled which
+// is stored only when subscription was active.
+type Unsubscription struct {
+	events
+	newsletterPublicID *ID
+	email              *Email
+}
+
+func NewUnsubscription(newsletterPublicID *ID, email *Email) *Unsubscription {
+	u := &Unsubscription{newsletterPublicID: newsletterPublicID, email: email}
+	u.raise(NewEvent(
+		EventSubscriptionDisabled,
+		subscriptionKey(newsletterPublicID, email),
+		newsletterPublicID,
+		time.Now(),
+		map[string]string{"email": email.String()},
+	))
+
+	return u
+}
+
+func (u *Unsubscription) NewsletterPublicID() *ID {
+	return u.newsletterPublicID
+}
+
+func (u *Unsubscription) Email() *Email {
+	return u.email
+}
+
+// subscriptionKey is aggregate key shared by subscription and unsubscription, subscription may be started and disabled
+// repeatedly so its id does not identify it across events.
+func subscriptionKey(newsletterPublicID *ID, email *Email) string {
+	return "subscription/" + newsletterPublicID.String() + "/" + email.String()
+}
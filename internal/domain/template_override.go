@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// TemplateOverride replaces content of email template for single newsletter, shared layout stays in place.
+type TemplateOverride struct {
+	name      string
+	html      string
+	text      string
+	updatedAt time.Time
+}
+
+func NewTemplateOverride(name, html, text string) *TemplateOverride {
+	return &TemplateOverride{
+		name: name,
+		html: html,
+		text: text,
+	}
+}
+
+func CreateTemplateOverrideFromExisting(name, html, text string, updatedAt time.Time) *TemplateOverride {
+	return &TemplateOverride{
+		name:      name,
+		html:      html,
+		text:      text,
+		updatedAt: updatedAt,
+	}
+}
+
+func (t *TemplateOverride) Name() string {
+	return t.name
+}
+
+func (t *TemplateOverride) HTML() string {
+	return t.html
+}
+
+// Text is optional plain-text alternative, when empty it is converted from html.
+func (t *TemplateOverride) Text() string {
+	return t.text
+}
+
+func (t *TemplateOverride) UpdatedAt() time.Time {
+	return t.updatedAt
+}
@@ -0,0 +1,19 @@
+package domain
+
+// TrackingSettings controls which engagement of subscribers newsletter records, owner can opt out for privacy.
+type TrackingSettings struct {
+	opens  bool
+	clicks bool
+}
+
+func NewTrackingSettings(opens, clicks bool) *TrackingSettings {
+	return &TrackingSettings{opens: opens, clicks: clicks}
+}
+
+func (t *TrackingSettings) Opens() bool {
+	return t.opens
+}
+
+func (t *TrackingSettings) Clicks() bool {
+	return t.clicks
+}
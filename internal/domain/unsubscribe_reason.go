@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+const maxUnsubscribeCommentLength = 1000
+
+var unsubscribeReasonCodes = []string{"too_frequent", "not_relevant", "too_long", "never_subscribed", "other"}
+
+// UnsubscribeReason is answer of subscriber to survey shown after unsubscribing.
+type UnsubscribeReason struct {
+	code    string
+	comment string
+}
+
+// NewUnsubscribeReason accepts one of predefined codes with optional free text comment.
+func NewUnsubscribeReason(code, comment string) (*UnsubscribeReason, error) {
+	comment = strings.TrimSpace(comment)
+	if !slices.Contains(unsubscribeReasonCodes, code) || utf8.RuneCountInString(comment) > maxUnsubscribeCommentLength {
+		return nil, application.InvalidUnsubscribeReasonError
+	}
+
+	return &UnsubscribeReason{code: code, comment: comment}, nil
+}
+
+// UnsubscribeReasonCodes returns predefined codes of unsubscribe reasons.
+func UnsubscribeReasonCodes() []string {
+	return slices.Clone(unsubscribeReasonCodes)
+}
+
+func (u *UnsubscribeReason) Code() string {
+	return u.code
+}
+
+// Comment returns free text of subscriber, empty when none was given.
+func (u *UnsubscribeReason) Comment() string {
+	return u.comment
+}
@@ -1,9 +1,10 @@
 package domain
 
 type User struct {
-	id       *ID
-	email    *Email
-	password *Password
+	id          *ID
+	email       *Email
+	password    *Password
+	totpEnabled bool
 }
 
 func NewUser(email *Email, password *Password) *User {
@@ -14,11 +15,12 @@ func NewUser(email *Email, password *Password) *User {
 	}
 }
 
-func CreateUserFromExisting(id *ID, email *Email, password *Password) *User {
+func CreateUserFromExisting(id *ID, email *Email, password *Password, totpEnabled bool) *User {
 	return &User{
-		id:       id,
-		email:    email,
-		password: password,
+		id:          id,
+		email:       email,
+		password:    password,
+		totpEnabled: totpEnabled,
 	}
 }
 
@@ -33,3 +35,8 @@ func (u *User) Email() *Email {
 func (u *User) Password() *Password {
 	return u.password
 }
+
+// TOTPEnabled reports whether login requires a second factor.
+func (u *User) TOTPEnabled() bool {
+	return u.totpEnabled
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+const maxWebhookURLLength = 2048
+
+type WebhookEventType string
+
+const (
+	WebhookEventSubscriptionCreated  WebhookEventType = "subscription.created"
+	WebhookEventSubscriptionDisabled WebhookEventType = "subscription.disabled"
+	WebhookEventIssuePublished       WebhookEventType = "issue.published"
+	WebhookEventEmailBounced         WebhookEventType = "email.bounced"
+	// WebhookEventTest is sent only on demand of owner, webhooks can not subscribe to it
+	WebhookEventTest WebhookEventType = "webhook.test"
+)
+
+var webhookEventTypes = []WebhookEventType{
+	WebhookEventSubscriptionCreated,
+	WebhookEventSubscriptionDisabled,
+	WebhookEventIssuePublished,
+	WebhookEventEmailBounced,
+}
+
+func NewWebhookEventType(value string) (WebhookEventType, error) {
+	t := WebhookEventType(value)
+	if !slices.Contains(webhookEventTypes, t) {
+		return "", application.InvalidWebhookEventTypeError
+	}
+
+	return t, nil
+}
+
+// webhookRetryDelays are waits before repeated attempts, delivery fails after the last one.
+var webhookRetryDelays = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	6 * time.Hour,
+	12 * time.Hour,
+	24 * time.Hour,
+}
+
+// WebhookRetryDelay returns wait before next attempt after given number of failed attempts, false means delivery
+// gave up.
+func WebhookRetryDelay(attempts int) (time.Duration, bool) {
+	if attempts < 1 || attempts > len(webhookRetryDelays) {
+		return 0, false
+	}
+
+	return webhookRetryDelays[attempts-1], true
+}
+
+// Webhook is endpoint of newsletter owner notified about selected events.
+type Webhook struct {
+	id                 *ID
+	newsletterPublicID *ID
+	url                string
+	secret             string
+	eventTypes         []WebhookEventType
+	createdAt          time.Time
+}
+
+func NewWebhook(newsletterPublicID *ID, rawURL string, eventTypes []WebhookEventType, secret string) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil ||
+		len(rawURL) > maxWebhookURLLength {
+		return nil, application.InvalidWebhookURLError
+	}
+	if len(eventTypes) == 0 {
+		return nil, application.InvalidWebhookEventTypeError
+	}
+	types := slices.Clone(eventTypes)
+	slices.Sort(types)
+
+	return &Webhook{
+		id:                 NewID(),
+		newsletterPublicID: newsletterPublicID,
+		url:                rawURL,
+		secret:             secret,
+		eventTypes:         slices.Compact(types),
+		createdAt:          time.Now(),
+	}, nil
+}
+
+func CreateWebhookFromExisting(
+	id, newsletterPublicID *ID,
+	url, secret string,
+	eventTypes []WebhookEventType,
+	createdAt time.Time,
+) *Webhook {
+	return &Webhook{
+		id:                 id,
+		newsletterPublicID: newsletterPublicID,
+		url:                url,
+		secret:             secret,
+		eventTypes:         eventTypes,
+		createdAt:          createdAt,
+	}
+}
+
+func (w *Webhook) ID() *ID {
+	return w.id
+}
+
+func (w *Webhook) NewsletterPublicID() *ID {
+	return w.newsletterPublicID
+}
+
+func (w *Webhook) URL() string {
+	return w.url
+}
+
+// Secret signs payloads, it is shown to owner only once after registration.
+func (w *Webhook) Secret() string {
+	return w.secret
+}
+
+func (w *Webhook) EventTypes() []WebhookEventType {
+	return w.eventTypes
+}
+
+func (w *Webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// WebhookEvent is delivered to every webhook of newsletter subscribed to its type.
+type WebhookEvent struct {
+	id                 *ID
+	eventType          WebhookEventType
+	newsletterPublicID *ID
+	occurredAt         time.Time
+	data               map[string]string
+}
+
+// NewWebhookEvent creates event, id is idempotency key receivers deduplicate repeated deliveries by.
+func NewWebhookEvent(
+	id *ID,
+	eventType WebhookEventType,
+	newsletterPublicID *ID,
+	occurredAt time.Time,
+	data map[string]string,
+) *WebhookEvent {
+	return &WebhookEvent{
+		id:                 id,
+		eventType:          eventType,
+		newsletterPublicID: newsletterPublicID,
+		occurredAt:         occurredAt,
+		data:               data,
+	}
+}
+
+func (e *WebhookEvent) ID() *ID {
+	return e.id
+}
+
+func (e *WebhookEvent) Type() WebhookEventType {
+	return e.eventType
+}
+
+func (e *WebhookEvent) NewsletterPublicID() *ID {
+	return e.newsletterPublicID
+}
+
+func (e *WebhookEvent) OccurredAt() time.Time {
+	return e.occurredAt
+}
+
+func (e *WebhookEvent) Data() map[string]string {
+	return e.data
+}
@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/secret"
+)
+
+// keyPrefix makes keys recognizable by secret scanners.
+const keyPrefix = "nl"
+
+// Generate returns lookup prefix and full key in format nl_<prefix>_<secret>, key is shown to user only once.
+func Generate() (string, string, error) {
+	prefix, err := secret.Generate(5)
+	if err != nil {
+		return "", "", err
+	}
+	s, err := secret.Generate(20)
+	if err != nil {
+		return "", "", err
+	}
+
+	return prefix, keyPrefix + "_" + prefix + "_" + s, nil
+}
+
+// Parse returns lookup prefix of key.
+func Parse(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+func Hash(key string) string {
+	return secret.Hash(key)
+}
+
+// Matches compares key with stored hash in constant time.
+func Matches(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/config"
+	"github.com/javor454/newsletter-assignment/app/firebase"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	firebaseinfra "github.com/javor454/newsletter-assignment/internal/infrastructure/firebase"
+)
+
+// SubscriptionCache stores public ids of newsletters by email of their subscriber, postgres is source of truth and
+// reads fall back to it when subscriber is not cached.
+type SubscriptionCache interface {
+	GetSubscribedNewsletters(ctx context.Context) (map[string][]string, error)
+	GetSubscribedNewslettersByEmail(ctx context.Context, email *domain.Email) ([]string, bool, error)
+	AddSubscribedNewsletter(ctx context.Context, email, newsletterPublicID string) error
+	RemoveSubscribedNewsletter(ctx context.Context, email *domain.Email, newsletterPublicID *domain.ID) error
+}
+
+var (
+	_ SubscriptionCache = (*firebaseinfra.SubscriptionCacheManager)(nil)
+	_ SubscriptionCache = (*MemorySubscriptionCache)(nil)
+	_ SubscriptionCache = (*NoopSubscriptionCache)(nil)
+)
+
+// NewSubscriptionCache returns cache of configured backend, firebase client is used only by firebase one.
+func NewSubscriptionCache(
+	conf *config.AppConfig,
+	fbClient *firebase.Client,
+	loader SubscribedNewslettersLoader,
+	now func() time.Time,
+) SubscriptionCache {
+	switch conf.CacheBackend {
+	case config.CacheBackendMemory:
+		return NewMemorySubscriptionCache(loader, conf.CacheMemoryTTL, conf.CacheMemoryMaxSize, now)
+	case config.CacheBackendNone:
+		return NewNoopSubscriptionCache()
+	default:
+		return firebaseinfra.NewSubscriptionCacheManager(fbClient)
+	}
+}
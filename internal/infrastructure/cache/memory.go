@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type SubscribedNewslettersLoader interface {
+	GetSubscribedNewslettersByEmail(ctx context.Context, email string) ([]string, error)
+}
+
+type memoryEntry struct {
+	email     string
+	ids       map[string]struct{}
+	expiresAt time.Time
+}
+
+// MemorySubscriptionCache is read-through cache of single process. Entry of subscriber is loaded whole from postgres
+// on miss, changes are applied only to loaded entries so every entry stays complete. Entries expire after ttl and the
+// least recently used ones are evicted above size, other instances see changes once their entries expire.
+type MemorySubscriptionCache struct {
+	loader     SubscribedNewslettersLoader
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// recent holds entries from the most recently used one
+	recent *list.List
+	// changes counts every change, entry loaded while it moved may miss the change and is not stored
+	changes uint64
+}
+
+func NewMemorySubscriptionCache(
+	loader SubscribedNewslettersLoader,
+	ttl time.Duration,
+	maxEntries int,
+	now func() time.Time,
+) *MemorySubscriptionCache {
+	return &MemorySubscriptionCache{
+		loader:     loader,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        now,
+		entries:    make(map[string]*list.Element),
+		recent:     list.New(),
+	}
+}
+
+// GetSubscribedNewsletters returns loaded entries which did not expire.
+func (m *MemorySubscriptionCache) GetSubscribedNewsletters(_ context.Context) (map[string][]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	subscribed := make(map[string][]string, len(m.entries))
+	for email := range m.entries {
+		if entry := m.entry(email); entry != nil {
+			subscribed[email] = entryIDs(entry)
+		}
+	}
+
+	return subscribed, nil
+}
+
+// GetSubscribedNewslettersByEmail loads subscriber from postgres on miss, so it misses only when loading fails.
+func (m *MemorySubscriptionCache) GetSubscribedNewslettersByEmail(
+	ctx context.Context,
+	email *domain.Email,
+) ([]string, bool, error) {
+	m.mu.Lock()
+	if entry := m.entry(email.String()); entry != nil {
+		m.recent.MoveToFront(m.entries[email.String()])
+		ids := entryIDs(entry)
+		m.mu.Unlock()
+
+		return ids, true, nil
+	}
+	changes := m.changes
+	m.mu.Unlock()
+
+	ids, err := m.loader.GetSubscribedNewslettersByEmail(ctx, email.String())
+	if err != nil {
+		return nil, false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if changes == m.changes {
+		m.store(email.String(), ids)
+	}
+
+	return ids, true, nil
+}
+
+func (m *MemorySubscriptionCache) AddSubscribedNewsletter(_ context.Context, email, newsletterPublicID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.changes++
+	if entry := m.entry(email); entry != nil {
+		entry.ids[newsletterPublicID] = struct{}{}
+	}
+
+	return nil
+}
+
+func (m *MemorySubscriptionCache) RemoveSubscribedNewsletter(
+	_ context.Context,
+	email *domain.Email,
+	newsletterPublicID *domain.ID,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.changes++
+	if entry := m.entry(email.String()); entry != nil {
+		delete(entry.ids, newsletterPublicID.String())
+	}
+
+	return nil
+}
+
+// entry returns entry of subscriber unless it expired, expired one is dropped.
+func (m *MemorySubscriptionCache) entry(email string) *memoryEntry {
+	element, ok := m.entries[email]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.recent.Remove(element)
+		delete(m.entries, email)
+
+		return nil
+	}
+
+	return entry
+}
+
+func (m *MemorySubscriptionCache) store(email string, ids []string) {
+	entry := &memoryEntry{email: email, ids: make(map[string]struct{}, len(ids)), expiresAt: m.now().Add(m.ttl)}
+	for _, id := range ids {
+		entry.ids[id] = struct{}{}
+	}
+
+	if element, ok := m.entries[email]; ok {
+		element.Value = entry
+		m.recent.MoveToFront(element)
+
+		return
+	}
+	m.entries[email] = m.recent.PushFront(entry)
+
+	for m.recent.Len() > m.maxEntries {
+		oldest := m.recent.Back()
+		m.recent.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryEntry).email)
+	}
+}
+
+func entryIDs(entry *memoryEntry) []string {
+	ids := make([]string, 0, len(entry.ids))
+	for id := range entry.ids {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
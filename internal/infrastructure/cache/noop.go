@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+// NoopSubscriptionCache caches nothing, every read falls back to postgres.
+type NoopSubscriptionCache struct{}
+
+func NewNoopSubscriptionCache() *NoopSubscriptionCache {
+	return &NoopSubscriptionCache{}
+}
+
+func (n *NoopSubscriptionCache) GetSubscribedNewsletters(_ context.Context) (map[string][]string, error) {
+	return map[string][]string{}, nil
+}
+
+func (n *NoopSubscriptionCache) GetSubscribedNewslettersByEmail(_ context.Context, _ *domain.Email) ([]string, bool, error) {
+	return nil, false, nil
+}
+
+func (n *NoopSubscriptionCache) AddSubscribedNewsletter(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (n *NoopSubscriptionCache) RemoveSubscribedNewsletter(_ context.Context, _ *domain.Email, _ *domain.ID) error {
+	return nil
+}
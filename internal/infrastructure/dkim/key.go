@@ -0,0 +1,31 @@
+package dkim
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+)
+
+// keyBits is size of RSA key, 2048 bits is minimum recommended by RFC 8301. Its public key has about 400 base64
+// characters, more than 255 of single TXT record string, so DNS record splits it into multiple strings.
+const keyBits = 2048
+
+// GenerateKey returns PEM encoded private key and base64 encoded public key for DKIM DNS record.
+func GenerateKey() (string, string, error) {
+	pk, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate dkim key: %w", err)
+	}
+
+	pub, err := x509.MarshalPKIXPublicKey(&pk.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal dkim public key: %w", err)
+	}
+
+	private := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(pk)})
+
+	return string(private), base64.StdEncoding.EncodeToString(pub), nil
+}
@@ -0,0 +1,270 @@
+package dkim
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignedHeaders are signed when present in message, From is mandatory by RFC 6376.
+var SignedHeaders = []string{
+	"From",
+	"To",
+	"Reply-To",
+	"Subject",
+	"Date",
+	"Message-ID",
+	"MIME-Version",
+	"Content-Type",
+}
+
+var (
+	whitespaceRegex = regexp.MustCompile(`[ \t]+`)
+	signatureRegex  = regexp.MustCompile(`(^|;)(\s*)b=[^;]*`)
+)
+
+// Signer signs messages of single domain with rsa-sha256 and relaxed/relaxed canonicalization.
+type Signer struct {
+	domain   string
+	selector string
+	key      *rsa.PrivateKey
+}
+
+func NewSigner(domain, selector, privateKeyPEM string) (*Signer, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode dkim private key of %s", domain)
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse dkim private key of %s: %w", domain, err)
+		}
+		key = k
+	case "PRIVATE KEY":
+		k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse dkim private key of %s: %w", domain, err)
+		}
+		rsaKey, ok := k.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("dkim private key of %s is not rsa key", domain)
+		}
+		key = rsaKey
+	default:
+		return nil, fmt.Errorf("unsupported dkim private key type %q of %s", block.Type, domain)
+	}
+
+	return &Signer{domain: domain, selector: selector, key: key}, nil
+}
+
+func (s *Signer) Domain() string {
+	return s.domain
+}
+
+// Sign returns message with DKIM-Signature header prepended, message has to use CRLF line endings.
+func (s *Signer) Sign(message []byte, now time.Time) ([]byte, error) {
+	headers, body, err := split(message)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := make([]string, 0, len(SignedHeaders))
+	for _, name := range SignedHeaders {
+		if _, ok := lastHeader(headers, name, nil); ok {
+			signed = append(signed, name)
+		}
+	}
+	if len(signed) == 0 || !strings.EqualFold(signed[0], "From") {
+		return nil, errors.New("message without from header can not be signed")
+	}
+
+	bodyHash := sha256.Sum256(canonicalBody(body))
+	value := strings.Join([]string{
+		"v=1",
+		"a=rsa-sha256",
+		"c=relaxed/relaxed",
+		"d=" + s.domain,
+		"s=" + s.selector,
+		"t=" + strconv.FormatInt(now.Unix(), 10),
+		"h=" + strings.ToLower(strings.Join(signed, ":")),
+		"bh=" + base64.StdEncoding.EncodeToString(bodyHash[:]),
+		"b=",
+	}, "; ")
+
+	hash := headerHash(headers, signed, "DKIM-Signature: "+value)
+	signature, err := rsa.SignPKCS1v15(nil, s.key, crypto.SHA256, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	// folded after every tag, relaxed canonicalization of verifier unfolds it back to signed form
+	header := "DKIM-Signature: " + strings.ReplaceAll(value, "; ", ";\r\n\t") +
+		base64.StdEncoding.EncodeToString(signature) + "\r\n"
+
+	return append([]byte(header), message...), nil
+}
+
+// Verify checks the first DKIM-Signature of message against base64 encoded public key from DNS record.
+func Verify(message []byte, publicKey string) error {
+	headers, body, err := split(message)
+	if err != nil {
+		return err
+	}
+
+	var raw string
+	for _, h := range headers {
+		if strings.EqualFold(headerName(h), "DKIM-Signature") {
+			raw = h
+			break
+		}
+	}
+	if raw == "" {
+		return errors.New("message is not signed")
+	}
+
+	tags := make(map[string]string)
+	for _, part := range strings.Split(headerValue(raw), ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		tags[strings.TrimSpace(name)] = strings.Join(strings.Fields(value), "")
+	}
+	if tags["v"] != "1" || tags["a"] != "rsa-sha256" || tags["c"] != "relaxed/relaxed" {
+		return fmt.Errorf("unsupported signature v=%s a=%s c=%s", tags["v"], tags["a"], tags["c"])
+	}
+
+	bodyHash := sha256.Sum256(canonicalBody(body))
+	if base64.StdEncoding.EncodeToString(bodyHash[:]) != tags["bh"] {
+		return errors.New("body hash does not match")
+	}
+
+	hash := headerHash(headers, strings.Split(tags["h"], ":"), signatureRegex.ReplaceAllString(raw, "${1}${2}b="))
+
+	der, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to decode public key: %w", err)
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %w", err)
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("public key is not rsa key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(tags["b"])
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if err := rsa.VerifyPKCS1v15(rsaPub, crypto.SHA256, hash, signature); err != nil {
+		return fmt.Errorf("signature does not match: %w", err)
+	}
+
+	return nil
+}
+
+// headerHash hashes signed headers picked from bottom of header block followed by signature header without CRLF.
+func headerHash(headers, signed []string, signatureHeader string) []byte {
+	h := sha256.New()
+	used := make(map[int]struct{})
+	for _, name := range signed {
+		header, ok := lastHeader(headers, name, used)
+		if !ok {
+			// nonexistent header contributes nothing to hash
+			continue
+		}
+		h.Write([]byte(canonicalHeader(header) + "\r\n"))
+	}
+	h.Write([]byte(canonicalHeader(signatureHeader)))
+
+	return h.Sum(nil)
+}
+
+// lastHeader returns last not yet used header of given name, RFC 6376 signs instances from bottom up.
+func lastHeader(headers []string, name string, used map[int]struct{}) (string, bool) {
+	for i := len(headers) - 1; i >= 0; i-- {
+		if _, ok := used[i]; ok {
+			continue
+		}
+		if strings.EqualFold(headerName(headers[i]), name) {
+			if used != nil {
+				used[i] = struct{}{}
+			}
+
+			return headers[i], true
+		}
+	}
+
+	return "", false
+}
+
+// split returns header fields with their folded lines and body of message.
+func split(message []byte) ([]string, []byte, error) {
+	end := bytes.Index(message, []byte("\r\n\r\n"))
+	if end < 0 {
+		return nil, nil, errors.New("message without header and body separator")
+	}
+
+	var headers []string
+	for _, line := range strings.Split(string(message[:end]), "\r\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(headers) > 0 {
+			headers[len(headers)-1] += "\r\n" + line
+			continue
+		}
+		headers = append(headers, line)
+	}
+
+	return headers, message[end+4:], nil
+}
+
+func headerName(header string) string {
+	name, _, _ := strings.Cut(header, ":")
+
+	return strings.TrimSpace(name)
+}
+
+func headerValue(header string) string {
+	_, value, _ := strings.Cut(header, ":")
+
+	return value
+}
+
+// canonicalHeader implements relaxed header canonicalization of RFC 6376 section 3.4.2.
+func canonicalHeader(header string) string {
+	value := strings.ReplaceAll(headerValue(header), "\r\n", "")
+	value = whitespaceRegex.ReplaceAllString(value, " ")
+
+	return strings.ToLower(headerName(header)) + ":" + strings.TrimSpace(value)
+}
+
+// canonicalBody implements relaxed body canonicalization of RFC 6376 section 3.4.4.
+func canonicalBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(whitespaceRegex.ReplaceAllString(line, " "), " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
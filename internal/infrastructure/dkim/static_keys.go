@@ -0,0 +1,36 @@
+package dkim
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StaticKeys signs messages of application domains by single key published under the same selector in each of them.
+type StaticKeys struct {
+	signers map[string]*Signer
+}
+
+func NewStaticKeys(domains []string, selector, keyFile string) (*StaticKeys, error) {
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dkim key file: %w", err)
+	}
+
+	s := &StaticKeys{signers: make(map[string]*Signer, len(domains))}
+	for _, d := range domains {
+		d = strings.ToLower(d)
+		signer, err := NewSigner(d, selector, string(key))
+		if err != nil {
+			return nil, err
+		}
+		s.signers[d] = signer
+	}
+
+	return s, nil
+}
+
+func (s *StaticKeys) Signer(_ context.Context, domain string) (*Signer, error) {
+	return s.signers[strings.ToLower(domain)], nil
+}
@@ -0,0 +1,187 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+const (
+	verificationPrefix = "_newsletter-verification"
+	verificationKey    = "newsletter-verification="
+	// maxTXTStringLength is limit of single character string of TXT record, longer value is published as multiple
+	// strings which resolvers join back
+	maxTXTStringLength = 255
+)
+
+// Resolver is satisfied by *net.Resolver, tests replace it by fake.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Checker confirms that sending domain publishes records required for delivery on behalf of its owner.
+type Checker struct {
+	resolver   Resolver
+	spfInclude string
+}
+
+func NewChecker(resolver Resolver, spfInclude string) *Checker {
+	return &Checker{resolver: resolver, spfInclude: spfInclude}
+}
+
+// Records returns records owner has to publish with verified state of the last check.
+func (c *Checker) Records(sd *domain.SendingDomain) []*dto.DNSRecord {
+	return []*dto.DNSRecord{
+		{
+			Purpose:  dto.RecordPurposeOwnership,
+			Type:     "TXT",
+			Name:     verificationPrefix + "." + sd.Name(),
+			Value:    verificationKey + sd.VerificationToken(),
+			Verified: sd.OwnershipVerified(),
+		},
+		{
+			Purpose:  dto.RecordPurposeSPF,
+			Type:     "TXT",
+			Name:     sd.Name(),
+			Value:    "v=spf1 include:" + c.spfInclude + " ~all",
+			Verified: sd.SPFVerified(),
+		},
+		{
+			Purpose:  dto.RecordPurposeDKIM,
+			Type:     "TXT",
+			Name:     sd.DKIMSelector() + "._domainkey." + sd.Name(),
+			Value:    txtValue("v=DKIM1; k=rsa; p=" + sd.DKIMPublicKey()),
+			Verified: sd.DKIMVerified(),
+		},
+		{
+			Purpose:  dto.RecordPurposeDMARC,
+			Type:     "TXT",
+			Name:     "_dmarc." + sd.Name(),
+			Value:    "v=DMARC1; p=quarantine",
+			Verified: sd.DMARCVerified(),
+		},
+	}
+}
+
+// txtValue splits value longer than single TXT string into quoted strings of at most 255 characters, shorter value is
+// returned as it is.
+func txtValue(value string) string {
+	if len(value) <= maxTXTStringLength {
+		return value
+	}
+
+	var parts []string
+	for len(value) > maxTXTStringLength {
+		parts = append(parts, strconv.Quote(value[:maxTXTStringLength]))
+		value = value[maxTXTStringLength:]
+	}
+	parts = append(parts, strconv.Quote(value))
+
+	return strings.Join(parts, " ")
+}
+
+// Check resolves all records, missing record is reported as unverified and only resolver failures are errors.
+func (c *Checker) Check(ctx context.Context, sd *domain.SendingDomain) (*dto.SendingDomainCheck, error) {
+	ownership, err := c.lookup(ctx, verificationPrefix+"."+sd.Name())
+	if err != nil {
+		return nil, err
+	}
+	spf, err := c.lookup(ctx, sd.Name())
+	if err != nil {
+		return nil, err
+	}
+	dkim, err := c.lookup(ctx, sd.DKIMSelector()+"._domainkey."+sd.Name())
+	if err != nil {
+		return nil, err
+	}
+	dmarc, err := c.lookup(ctx, "_dmarc."+sd.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SendingDomainCheck{
+		Ownership: slices.Contains(ownership, verificationKey+sd.VerificationToken()),
+		SPF:       c.hasSPF(spf),
+		DKIM:      hasDKIM(dkim, sd.DKIMPublicKey()),
+		DMARC:     hasDMARC(dmarc),
+	}, nil
+}
+
+func (c *Checker) lookup(ctx context.Context, name string) ([]string, error) {
+	records, err := c.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to lookup txt record %s: %w", name, err)
+	}
+
+	for i, r := range records {
+		records[i] = strings.TrimSpace(r)
+	}
+
+	return records, nil
+}
+
+// hasSPF requires exactly one SPF policy which authorizes mail provider, multiple policies are invalid by RFC 7208.
+func (c *Checker) hasSPF(records []string) bool {
+	var policies []string
+	for _, r := range records {
+		if strings.HasPrefix(strings.ToLower(r), "v=spf1 ") {
+			policies = append(policies, r)
+		}
+	}
+	if len(policies) != 1 {
+		return false
+	}
+
+	return slices.ContainsFunc(strings.Fields(policies[0]), func(term string) bool {
+		return strings.EqualFold(strings.TrimLeft(term, "+"), "include:"+c.spfInclude)
+	})
+}
+
+func hasDKIM(records []string, publicKey string) bool {
+	return slices.ContainsFunc(records, func(r string) bool {
+		tags := parseTags(r)
+		if v, ok := tags["v"]; ok && v != "DKIM1" {
+			return false
+		}
+		if k, ok := tags["k"]; ok && k != "rsa" {
+			return false
+		}
+
+		return strings.Join(strings.Fields(tags["p"]), "") == publicKey
+	})
+}
+
+// hasDMARC accepts any policy, also p=none used while owner monitors reports.
+func hasDMARC(records []string) bool {
+	return slices.ContainsFunc(records, func(r string) bool {
+		tags := parseTags(r)
+
+		return tags["v"] == "DMARC1" && slices.Contains([]string{"none", "quarantine", "reject"}, tags["p"])
+	})
+}
+
+// parseTags parses tag=value list used by DKIM and DMARC records.
+func parseTags(record string) map[string]string {
+	tags := make(map[string]string)
+	for _, part := range strings.Split(record, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		tags[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	return tags
+}
@@ -6,10 +6,14 @@ import (
 	"fmt"
 	"time"
 
+	"firebase.google.com/go/v4/db"
 	"github.com/javor454/newsletter-assignment/app/firebase"
 	"github.com/javor454/newsletter-assignment/internal/domain"
 )
 
+// cacheTimeout bounds single node operation, cache is never worth waiting longer for.
+const cacheTimeout = 500 * time.Millisecond
+
 type SubscriptionCacheManager struct {
 	client *firebase.Client
 }
@@ -18,50 +22,91 @@ func NewSubscriptionCacheManager(client *firebase.Client) *SubscriptionCacheMana
 	return &SubscriptionCacheManager{client: client}
 }
 
-func (s *SubscriptionCacheManager) AddSubscribedNewsletter(ctx context.Context, email, newsletterPublicID string) error {
-	encodedEmail := base64.URLEncoding.EncodeToString([]byte(email))
-
-	getSubsCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond) // TODO: add exponential backoff or smth
+// GetSubscribedNewsletters returns cached public ids of newsletters by email of subscriber.
+func (s *SubscriptionCacheManager) GetSubscribedNewsletters(ctx context.Context) (map[string][]string, error) {
+	// whole cache is read at once, it takes longer than single node operations
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	var records map[string]bool
-	if err := s.client.NewRef("subscriptions").Child(encodedEmail).Get(getSubsCtx, &records); err != nil {
-		return fmt.Errorf("could not get subscription records: %w", err)
+	var nodes map[string]map[string]bool
+	if err := s.client.NewRef("subscriptions").Get(ctx, &nodes); err != nil {
+		return nil, fmt.Errorf("could not get subscription records: %w", err)
 	}
 
-	if records == nil {
-		records = make(map[string]bool)
+	subscribed := make(map[string][]string, len(nodes))
+	for encodedEmail, records := range nodes {
+		email, err := base64.URLEncoding.DecodeString(encodedEmail)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode email of subscription records: %w", err)
+		}
+		for newsletterPublicID, subscribedTo := range records {
+			if subscribedTo {
+				subscribed[string(email)] = append(subscribed[string(email)], newsletterPublicID)
+			}
+		}
 	}
 
-	setSubsCtx, cancel := context.WithTimeout(ctx, 500*time.Second) // TODO: add exponential backoff or smth
+	return subscribed, nil
+}
+
+// GetSubscribedNewslettersByEmail returns cached public ids of newsletters of subscriber, false means subscriber has
+// no node in cache.
+func (s *SubscriptionCacheManager) GetSubscribedNewslettersByEmail(
+	ctx context.Context,
+	email *domain.Email,
+) ([]string, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, cacheTimeout)
 	defer cancel()
 
-	records[newsletterPublicID] = true
-	if err := s.client.NewRef("subscriptions").Child(encodedEmail).Set(setSubsCtx, &records); err != nil {
-		return fmt.Errorf("could not set subscription records: %w", err)
+	encodedEmail := base64.URLEncoding.EncodeToString([]byte(email.String()))
+
+	var records map[string]bool
+	if err := s.client.NewRef("subscriptions").Child(encodedEmail).Get(ctx, &records); err != nil {
+		return nil, false, fmt.Errorf("could not get subscription records: %w", err)
 	}
 
-	return nil
-}
+	subscribed := make([]string, 0, len(records))
+	for newsletterPublicID, subscribedTo := range records {
+		if subscribedTo {
+			subscribed = append(subscribed, newsletterPublicID)
+		}
+	}
 
-func (s *SubscriptionCacheManager) RemoveSubscribedNewsletter(ctx context.Context, email *domain.Email, newsletterPublicID *domain.ID) error {
-	encodedEmail := base64.URLEncoding.EncodeToString([]byte(email.String()))
+	return subscribed, len(subscribed) > 0, nil
+}
 
-	ctx, cancel := context.WithTimeout(ctx, 500*time.Second) // TODO: add exponential backoff or smth
+// AddSubscribedNewsletter writes only child of the newsletter, concurrent changes of other newsletters of the same
+// subscriber are kept.
+func (s *SubscriptionCacheManager) AddSubscribedNewsletter(ctx context.Context, email, newsletterPublicID string) error {
+	ctx, cancel := context.WithTimeout(ctx, cacheTimeout)
 	defer cancel()
-	var records map[string]bool
-	if err := s.client.NewRef("subscriptions").Child(encodedEmail).Get(ctx, &records); err != nil {
-		return fmt.Errorf("could not get subscription records: %w", err)
+
+	if err := s.subscriptionRef(email, newsletterPublicID).Set(ctx, true); err != nil {
+		return fmt.Errorf("could not set subscription record: %w", err)
 	}
 
-	delete(records, newsletterPublicID.String())
+	return nil
+}
 
-	ctx, cancel = context.WithTimeout(ctx, 500*time.Second) // TODO: add exponential backoff or smth
+// RemoveSubscribedNewsletter deletes only child of the newsletter, node of subscriber disappears with the last one.
+func (s *SubscriptionCacheManager) RemoveSubscribedNewsletter(
+	ctx context.Context,
+	email *domain.Email,
+	newsletterPublicID *domain.ID,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, cacheTimeout)
 	defer cancel()
 
-	if err := s.client.NewRef("subscriptions").Child(encodedEmail).Set(ctx, &records); err != nil {
-		return fmt.Errorf("could not set subscription records: %w", err)
+	if err := s.subscriptionRef(email.String(), newsletterPublicID.String()).Delete(ctx); err != nil {
+		return fmt.Errorf("could not delete subscription record: %w", err)
 	}
 
 	return nil
 }
+
+// subscriptionRef is path subscriptions/<base64 email>/<newsletter public id>.
+func (s *SubscriptionCacheManager) subscriptionRef(email, newsletterPublicID string) *db.Ref {
+	encodedEmail := base64.URLEncoding.EncodeToString([]byte(email))
+
+	return s.client.NewRef("subscriptions").Child(encodedEmail).Child(newsletterPublicID)
+}
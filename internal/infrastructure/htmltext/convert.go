@@ -0,0 +1,151 @@
+package htmltext
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var blankLinesRegex = regexp.MustCompile(`\n{3,}`)
+
+// Convert renders HTML as readable plain text, links are replaced by numbered references listed at the end.
+func Convert(document string) (string, error) {
+	root, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse html: %w", err)
+	}
+
+	c := &converter{}
+	c.walk(root)
+
+	lines := strings.Split(c.b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text := strings.TrimSpace(blankLinesRegex.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+
+	if len(c.links) > 0 {
+		text += "\n\n"
+		for i, link := range c.links {
+			text += fmt.Sprintf("[%d] %s\n", i+1, link)
+		}
+	} else {
+		text += "\n"
+	}
+
+	return text, nil
+}
+
+type converter struct {
+	b     strings.Builder
+	links []string
+}
+
+func (c *converter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		c.text(n.Data)
+
+		return
+	case html.ElementNode:
+		switch n.DataAtom {
+		case atom.Head, atom.Script, atom.Style, atom.Title:
+			return
+		case atom.Br:
+			c.b.WriteString("\n")
+
+			return
+		case atom.Hr:
+			c.b.WriteString("\n\n---\n\n")
+
+			return
+		case atom.Img:
+			if alt := attr(n, "alt"); alt != "" {
+				c.text(alt)
+			}
+
+			return
+		case atom.A:
+			c.link(n)
+
+			return
+		case atom.Li:
+			c.b.WriteString("\n- ")
+		case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+			atom.Ul, atom.Ol, atom.Table, atom.Blockquote:
+			c.b.WriteString("\n\n")
+		case atom.Tr:
+			c.b.WriteString("\n")
+		case atom.Td, atom.Th:
+			c.b.WriteString(" ")
+		}
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.walk(child)
+	}
+
+	if n.Type == html.ElementNode {
+		switch n.DataAtom {
+		case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6,
+			atom.Ul, atom.Ol, atom.Table, atom.Blockquote:
+			c.b.WriteString("\n\n")
+		}
+	}
+}
+
+// link writes link text followed by reference, link which text is the URL itself is written as is.
+func (c *converter) link(n *html.Node) {
+	inner := &converter{links: c.links}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		inner.walk(child)
+	}
+	c.links = inner.links
+	text := strings.Join(strings.Fields(inner.b.String()), " ")
+	href := strings.TrimSpace(attr(n, "href"))
+
+	switch {
+	case href == "" || strings.HasPrefix(href, "#"):
+		c.text(text)
+	case text == "" || text == href || "mailto:"+text == href:
+		c.text(strings.TrimPrefix(href, "mailto:"))
+	default:
+		c.links = append(c.links, href)
+		c.text(fmt.Sprintf("%s [%d]", text, len(c.links)))
+	}
+}
+
+// text writes text with collapsed whitespace, as browser would render it.
+func (c *converter) text(data string) {
+	collapsed := strings.Join(strings.Fields(data), " ")
+	if collapsed == "" || strings.TrimLeft(data, " \t\n\r") != data {
+		c.space()
+	}
+	if collapsed == "" {
+		return
+	}
+	c.b.WriteString(collapsed)
+	if strings.TrimRight(data, " \t\n\r") != data {
+		c.space()
+	}
+}
+
+func (c *converter) space() {
+	s := c.b.String()
+	if s != "" && !strings.HasSuffix(s, " ") && !strings.HasSuffix(s, "\n") {
+		c.b.WriteString(" ")
+	}
+}
+
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+
+	return ""
+}
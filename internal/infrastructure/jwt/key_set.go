@@ -0,0 +1,178 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+)
+
+// legacyKeyID identifies shared secret, tokens signed by it carry no kid header.
+const legacyKeyID = ""
+
+type key struct {
+	id      string
+	method  jwt.SigningMethod
+	signKey any
+	public  crypto.PublicKey
+}
+
+// KeySet holds active signing key and all keys still accepted for verification.
+type KeySet struct {
+	active *key
+	keys   map[string]*key
+}
+
+// NewKeySet loads asymmetric keys from dir, file name without .pem extension is used as kid.
+// Public-only keys are accepted for verification of tokens signed by retired keys.
+// When secret is set, HS256 tokens without kid keep working until they expire,
+// without dir the secret is used for signing as well.
+func NewKeySet(secret, dir, activeKeyID string) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*key)}
+
+	if secret != "" {
+		ks.keys[legacyKeyID] = &key{
+			id:      legacyKeyID,
+			method:  jwt.SigningMethodHS256,
+			signKey: []byte(secret),
+			public:  []byte(secret),
+		}
+	}
+
+	if dir == "" {
+		legacy, ok := ks.keys[legacyKeyID]
+		if !ok {
+			return nil, fmt.Errorf("either jwt secret or keys directory is required")
+		}
+		ks.active = legacy
+
+		return ks, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys in %s: %w", dir, err)
+	}
+
+	for _, path := range paths {
+		k, err := loadKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if existing, ok := ks.keys[k.id]; ok && existing.signKey != nil {
+			continue
+		}
+		ks.keys[k.id] = k
+	}
+
+	active, ok := ks.keys[activeKeyID]
+	if !ok || activeKeyID == legacyKeyID {
+		return nil, fmt.Errorf("active key %q not found in %s", activeKeyID, dir)
+	}
+	if active.signKey == nil {
+		return nil, fmt.Errorf("active key %q has no private key", activeKeyID)
+	}
+	ks.active = active
+
+	return ks, nil
+}
+
+// PublicKeys returns asymmetric keys safe to publish, shared secret is never included.
+func (ks *KeySet) PublicKeys() []*dto.PublicKey {
+	res := make([]*dto.PublicKey, 0, len(ks.keys))
+	for _, k := range ks.keys {
+		if k.id == legacyKeyID {
+			continue
+		}
+		res = append(res, &dto.PublicKey{ID: k.id, Algorithm: k.method.Alg(), Key: k.public})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID < res[j].ID })
+
+	return res
+}
+
+func (ks *KeySet) verificationKey(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	k, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %q", kid)
+	}
+	if token.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return k.public, nil
+}
+
+func loadKey(path string) (*key, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %w", path, err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no pem block in key file %s", path)
+	}
+
+	kid := strings.TrimSuffix(filepath.Base(path), ".pem")
+	kid = strings.TrimSuffix(kid, ".pub")
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		pk, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key file %s: %w", path, err)
+		}
+
+		return newPrivateKey(kid, pk)
+	case "PRIVATE KEY":
+		pk, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key file %s: %w", path, err)
+		}
+
+		return newPrivateKey(kid, pk)
+	case "PUBLIC KEY":
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key file %s: %w", path, err)
+		}
+
+		return newPublicKey(kid, pub)
+	default:
+		return nil, fmt.Errorf("unsupported pem block %q in key file %s", block.Type, path)
+	}
+}
+
+func newPrivateKey(kid string, pk any) (*key, error) {
+	switch pk := pk.(type) {
+	case *rsa.PrivateKey:
+		return &key{id: kid, method: jwt.SigningMethodRS256, signKey: pk, public: &pk.PublicKey}, nil
+	case ed25519.PrivateKey:
+		return &key{id: kid, method: jwt.SigningMethodEdDSA, signKey: pk, public: pk.Public()}, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T for key %q", pk, kid)
+	}
+}
+
+func newPublicKey(kid string, pub any) (*key, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return &key{id: kid, method: jwt.SigningMethodRS256, public: pub}, nil
+	case ed25519.PublicKey:
+		return &key{id: kid, method: jwt.SigningMethodEdDSA, public: pub}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T for key %q", pub, kid)
+	}
+}
@@ -2,43 +2,71 @@ package jwt
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/javor454/newsletter-assignment/internal/domain"
 )
 
+const (
+	typeClaim = "typ"
+
+	userTokenType         = "user"
+	subscriptionTokenType = "subscription"
+	challengeTokenType    = "mfa_challenge"
+)
+
 type TokenManager struct {
-	secret string
-	host   string
+	keySet   *KeySet
+	issuer   string
+	audience string
 }
 
-func NewTokenManager(secret string, host string) *TokenManager {
+func NewTokenManager(keySet *KeySet, issuer, audience string) *TokenManager {
 	return &TokenManager{
-		secret: secret,
-		host:   host,
+		keySet:   keySet,
+		issuer:   issuer,
+		audience: audience,
 	}
 }
 
 func (t *TokenManager) GenerateUserToken(user *domain.User) (string, error) {
-	return t.generateToken(user.ID().String(), 1*time.Hour)
+	return t.generateToken(user.ID().String(), userTokenType, 1*time.Hour, "")
 }
 
 func (t *TokenManager) GenerateSubscriptionToken(email *domain.Email) (string, error) {
-	return t.generateToken(email.String(), 0)
+	return t.generateToken(email.String(), subscriptionTokenType, 0, "")
+}
+
+// GenerateChallengeToken issues token which can only be exchanged for user token after TOTP verification, id of
+// challenge is carried in jti claim.
+func (t *TokenManager) GenerateChallengeToken(user *domain.User, challengeID *domain.ID) (string, error) {
+	return t.generateToken(user.ID().String(), challengeTokenType, 5*time.Minute, challengeID.String())
 }
 
-func (t *TokenManager) generateToken(subject string, expiration time.Duration) (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
+// generateToken signs token of given type, empty id leaves jti claim out.
+func (t *TokenManager) generateToken(subject, tokenType string, expiration time.Duration, id string) (string, error) {
+	active := t.keySet.active
+
+	token := jwt.New(active.method)
+	if active.id != legacyKeyID {
+		token.Header["kid"] = active.id
+	}
 	claims := token.Claims.(jwt.MapClaims)
 	claims["sub"] = subject
-	claims["iss"] = t.host
+	claims["iss"] = t.issuer
+	claims["aud"] = t.audience
 	claims["iat"] = time.Now().Unix()
+	claims[typeClaim] = tokenType
+	if id != "" {
+		claims["jti"] = id
+	}
 	if expiration > 0 {
 		claims["exp"] = time.Now().Add(expiration).Unix()
 	}
 
-	tokenStr, err := token.SignedString([]byte(t.secret))
+	tokenStr, err := token.SignedString(active.signKey)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -46,33 +74,80 @@ func (t *TokenManager) generateToken(subject string, expiration time.Duration) (
 	return tokenStr, nil
 }
 
+// ParseToken returns subject of user or subscription token, challenge tokens are rejected.
 func (t *TokenManager) ParseToken(tokenStr string) (string, error) {
-	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
+	claims, err := t.parse(tokenStr)
+	if err != nil {
+		return "", err
+	}
+
+	if tokenType, _ := claims[typeClaim].(string); tokenType == challengeTokenType {
+		return "", fmt.Errorf("challenge token not allowed")
+	}
 
-		return []byte(t.secret), nil
-	})
+	return subject(claims)
+}
+
+// ParseChallengeToken returns subject and id of challenge.
+func (t *TokenManager) ParseChallengeToken(tokenStr string) (string, string, error) {
+	claims, err := t.parse(tokenStr)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse token: %w", err)
+		return "", "", err
+	}
+
+	if tokenType, _ := claims[typeClaim].(string); tokenType != challengeTokenType {
+		return "", "", fmt.Errorf("not a challenge token")
+	}
+	challengeID, _ := claims["jti"].(string)
+	if challengeID == "" {
+		return "", "", fmt.Errorf("challenge id missing")
+	}
+
+	sub, err := subject(claims)
+	if err != nil {
+		return "", "", err
+	}
+
+	return sub, challengeID, nil
+}
+
+// parse verifies signature by key selected by kid header together with exp, iss and aud claims.
+func (t *TokenManager) parse(tokenStr string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(
+		tokenStr,
+		t.keySet.verificationKey,
+		jwt.WithIssuer(t.issuer),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || !token.Valid {
-		return "", fmt.Errorf("invalid token")
+		return nil, fmt.Errorf("invalid token")
 	}
 
-	if exp, ok := claims["exp"].(float64); ok {
-		if time.Now().Unix() > int64(exp) {
-			return "", fmt.Errorf("token expired")
-		}
+	audience, err := claims.GetAudience()
+	if err != nil {
+		return nil, fmt.Errorf("invalid audience: %w", err)
 	}
+	// subscription tokens in unsubscribe links never expire, the ones signed by shared secret
+	// before audience was introduced are accepted without it
+	_, hasKeyID := token.Header["kid"]
+	legacy := !hasKeyID && len(audience) == 0
+	if !legacy && !slices.Contains(audience, t.audience) {
+		return nil, fmt.Errorf("invalid audience: %v", audience)
+	}
+
+	return claims, nil
+}
 
-	subject, exists := claims["sub"].(string)
-	if !exists || subject == "" {
+func subject(claims jwt.MapClaims) (string, error) {
+	sub, exists := claims["sub"].(string)
+	if !exists || sub == "" {
 		return "", fmt.Errorf("subject missing")
 	}
 
-	return subject, nil
+	return sub, nil
 }
@@ -0,0 +1,117 @@
+package mailtemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Catalog holds localized messages (e.g. subjects) loaded from <locale>.json files, values are text templates.
+type Catalog struct {
+	defaultLocale string
+	messages      map[string]map[string]*texttemplate.Template
+}
+
+// LoadCatalog reads every .json file of dir as catalog of locale named by the file, missing dir is empty catalog.
+func LoadCatalog(dir, defaultLocale string) (*Catalog, error) {
+	c := &Catalog{defaultLocale: defaultLocale, messages: make(map[string]map[string]*texttemplate.Template)}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+
+		return nil, fmt.Errorf("failed to load catalogs from %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog file %s: %w", entry.Name(), err)
+		}
+
+		var raw map[string]string
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return nil, fmt.Errorf("failed to decode catalog %s: %w", entry.Name(), err)
+		}
+
+		locale := strings.TrimSuffix(entry.Name(), ".json")
+		messages := make(map[string]*texttemplate.Template, len(raw))
+		for key, value := range raw {
+			tmpl, err := texttemplate.New(key).Option("missingkey=error").Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse message %s of catalog %s: %w", key, locale, err)
+			}
+			messages[key] = tmpl
+		}
+		c.messages[locale] = messages
+	}
+
+	return c, nil
+}
+
+// Locales returns sorted locales with catalog.
+func (c *Catalog) Locales() []string {
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	return locales
+}
+
+// MissingKeys returns keys defined by some catalog but missing in other one, keyed by locale.
+func (c *Catalog) MissingKeys() map[string][]string {
+	keys := make(map[string]bool)
+	for _, messages := range c.messages {
+		for key := range messages {
+			keys[key] = true
+		}
+	}
+
+	missing := make(map[string][]string)
+	for locale, messages := range c.messages {
+		for key := range keys {
+			if _, ok := messages[key]; !ok {
+				missing[locale] = append(missing[locale], key)
+			}
+		}
+		sort.Strings(missing[locale])
+	}
+	for locale, keys := range missing {
+		if len(keys) == 0 {
+			delete(missing, locale)
+		}
+	}
+
+	return missing
+}
+
+// Translate renders message of locale, message missing in locale falls back to default locale.
+func (c *Catalog) Translate(locale, key string, data map[string]any) (string, error) {
+	tmpl, ok := c.messages[locale][key]
+	if !ok {
+		if tmpl, ok = c.messages[c.defaultLocale][key]; !ok {
+			return "", fmt.Errorf("message %s is missing in catalog %s", key, c.defaultLocale)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render message %s: %w", key, err)
+	}
+
+	return b.String(), nil
+}
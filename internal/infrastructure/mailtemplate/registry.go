@@ -0,0 +1,480 @@
+package mailtemplate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/htmltext"
+)
+
+const (
+	// LayoutTemplateName is executed for every html message when layout/base.html is present,
+	// messages fill its blocks by {{define "content"}}.
+	LayoutTemplateName = "base"
+	// ContentBlockName is block of layout replaced by html of override.
+	ContentBlockName = "content"
+	layoutDir        = "layout"
+	partialDir       = "partial"
+	catalogDir       = "locale"
+)
+
+// Override replaces content block of message template for single newsletter, it is rendered inside shared layout.
+type Override struct {
+	HTML string
+	Text string
+}
+
+type set struct {
+	catalog   *Catalog
+	hasLayout bool
+	// layouts and partials, cloned for every message
+	htmlBase *template.Template
+	textBase *texttemplate.Template
+	html     map[string]*template.Template
+	text     map[string]*texttemplate.Template
+}
+
+// Registry holds parsed message templates, reload swaps them only when whole directory is valid.
+type Registry struct {
+	lg    logger.Logger
+	dir   string
+	specs map[string]*Variables
+	// templates without locale suffix and catalog of this locale are used as fallback
+	defaultLocale string
+
+	mu          sync.RWMutex
+	set         *set
+	fingerprint string
+}
+
+// NewRegistry loads templates from dir, every template of specs has to exist and reference its required variables.
+// Localized variants are named <name>.<locale>.html, e.g. subscribed.cs.html.
+func NewRegistry(
+	lg logger.Logger,
+	dir, defaultLocale string,
+	specs map[string]*Variables,
+) (*Registry, error) {
+	r := &Registry{lg: lg, dir: dir, defaultLocale: defaultLocale, specs: specs}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload parses template directory again, on error previously loaded templates stay in use.
+func (r *Registry) Reload() error {
+	fingerprint, err := r.dirFingerprint()
+	if err != nil {
+		return err
+	}
+
+	s, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.set = s
+	r.fingerprint = fingerprint
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Watch polls template directory and reloads it on change until ctx is done.
+func (r *Registry) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fingerprint, err := r.dirFingerprint()
+				if err != nil {
+					r.lg.WithError(err).Error("[EMAIL] Failed to check templates")
+					continue
+				}
+
+				r.mu.RLock()
+				changed := fingerprint != r.fingerprint
+				r.mu.RUnlock()
+				if !changed {
+					continue
+				}
+
+				if err := r.Reload(); err != nil {
+					r.lg.WithError(err).Error("[EMAIL] Failed to reload templates, keeping previous ones")
+
+					r.mu.Lock()
+					r.fingerprint = fingerprint
+					r.mu.Unlock()
+
+					continue
+				}
+				r.lg.Info("[EMAIL] Templates reloaded")
+			}
+		}
+	}()
+}
+
+// Translate renders message of catalog in locale, falling back to default locale.
+func (r *Registry) Translate(locale, key string, data map[string]any) (string, error) {
+	r.mu.RLock()
+	s := r.set
+	r.mu.RUnlock()
+
+	return s.catalog.Translate(locale, key, data)
+}
+
+// Render returns text and html of message in locale, template without locale suffix is used when locale has none.
+// Override replaces content of file template when given. Text comes from .txt template of the same locale when
+// present, otherwise it is converted from html.
+func (r *Registry) Render(name, locale string, override *Override, data map[string]any) (string, string, error) {
+	r.mu.RLock()
+	s := r.set
+	r.mu.RUnlock()
+
+	if override != nil {
+		text, html, err := s.renderOverride(name, override, data)
+		if err == nil {
+			return text, html, nil
+		}
+		r.lg.WithError(err).Errorf("[EMAIL] Failed to render override of template %s, using default", name)
+	}
+
+	key := name
+	if _, ok := s.html[name+"."+locale]; ok && locale != "" {
+		key = name + "." + locale
+	}
+
+	tmpl, ok := s.html[key]
+	if !ok {
+		return "", "", fmt.Errorf("template \"%s\" not loaded", key)
+	}
+
+	html, err := s.executeHTML(tmpl, data)
+	if err != nil {
+		return "", "", fmt.Errorf("template \"%s\" execute error: %w", key, err)
+	}
+
+	textTmpl, ok := s.text[key]
+	if !ok {
+		text, err := htmltext.Convert(html)
+		if err != nil {
+			return "", "", fmt.Errorf("template \"%s\" text conversion error: %w", key, err)
+		}
+
+		return text, html, nil
+	}
+
+	var text bytes.Buffer
+	if err := textTmpl.Execute(&text, data); err != nil {
+		return "", "", fmt.Errorf("text template \"%s\" execute error: %w", key, err)
+	}
+
+	return text.String(), html, nil
+}
+
+// Validate checks that override of template parses and references only known variables.
+func (r *Registry) Validate(name string, override *Override) error {
+	spec, ok := r.specs[name]
+	if !ok || !spec.Overridable {
+		return fmt.Errorf("template %s can not be overridden", name)
+	}
+
+	r.mu.RLock()
+	s := r.set
+	r.mu.RUnlock()
+
+	html, text, err := s.parseOverride(name, override)
+	if err != nil {
+		return err
+	}
+
+	return s.check(name, spec, html, text)
+}
+
+// check validates variables of html template reached from layout and of text template when present.
+func (s *set) check(name string, spec *Variables, html *template.Template, text *texttemplate.Template) error {
+	entry := html
+	if s.hasLayout {
+		entry = html.Lookup(LayoutTemplateName)
+	}
+	lookupHTML := func(name string) *parse.Tree {
+		if t := html.Lookup(name); t != nil {
+			return t.Tree
+		}
+
+		return nil
+	}
+	if err := spec.check(name, entry.Tree, lookupHTML); err != nil {
+		return err
+	}
+
+	if text == nil {
+		return nil
+	}
+	lookupText := func(name string) *parse.Tree {
+		if t := text.Lookup(name); t != nil {
+			return t.Tree
+		}
+
+		return nil
+	}
+
+	return spec.check(name+".txt", text.Tree, lookupText)
+}
+
+func (s *set) executeHTML(tmpl *template.Template, data map[string]any) (string, error) {
+	var b bytes.Buffer
+	if s.hasLayout {
+		if err := tmpl.ExecuteTemplate(&b, LayoutTemplateName, data); err != nil {
+			return "", err
+		}
+
+		return b.String(), nil
+	}
+
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func (s *set) renderOverride(name string, override *Override, data map[string]any) (string, string, error) {
+	htmlTmpl, textTmpl, err := s.parseOverride(name, override)
+	if err != nil {
+		return "", "", err
+	}
+
+	html, err := s.executeHTML(htmlTmpl, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	if textTmpl == nil {
+		text, err := htmltext.Convert(html)
+		if err != nil {
+			return "", "", err
+		}
+
+		return text, html, nil
+	}
+
+	var text bytes.Buffer
+	if err := textTmpl.Execute(&text, data); err != nil {
+		return "", "", err
+	}
+
+	return text.String(), html, nil
+}
+
+// parseOverride parses override html on top of layout and partials, text is parsed only when set.
+func (s *set) parseOverride(name string, override *Override) (*template.Template, *texttemplate.Template, error) {
+	if strings.TrimSpace(override.HTML) == "" {
+		return nil, nil, errors.New("html of template can not be empty")
+	}
+
+	htmlName := name
+	if s.hasLayout {
+		htmlName = ContentBlockName
+	}
+	htmlTmpl, err := s.parseHTML(htmlName, override.HTML)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if strings.TrimSpace(override.Text) == "" {
+		return htmlTmpl, nil, nil
+	}
+
+	textTmpl, err := s.parseText(name, override.Text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return htmlTmpl, textTmpl, nil
+}
+
+func (s *set) parseHTML(name, content string) (*template.Template, error) {
+	tmpl, err := s.htmlBase.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone layout: %w", err)
+	}
+
+	if _, err := tmpl.New(name).Parse(content); err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+
+	return tmpl.Lookup(name), nil
+}
+
+func (s *set) parseText(name, content string) (*texttemplate.Template, error) {
+	tmpl, err := s.textBase.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone text partials: %w", err)
+	}
+
+	if _, err := tmpl.New(name).Parse(content); err != nil {
+		return nil, fmt.Errorf("failed to parse text template %s: %w", name, err)
+	}
+
+	return tmpl.Lookup(name), nil
+}
+
+// load parses layouts and partials first, then every message template in root of directory on top of them.
+func (r *Registry) load() (*set, error) {
+	s := &set{
+		htmlBase: template.New("root"),
+		textBase: texttemplate.New("root"),
+		html:     make(map[string]*template.Template),
+		text:     make(map[string]*texttemplate.Template),
+	}
+
+	for _, dir := range []string{layoutDir, partialDir} {
+		files, err := readDir(filepath.Join(r.dir, dir))
+		if err != nil {
+			return nil, err
+		}
+
+		for key, file := range files {
+			name := strings.TrimSuffix(key, file.ext)
+			if file.ext == ".txt" {
+				if _, err := s.textBase.New(name).Parse(file.content); err != nil {
+					return nil, fmt.Errorf("failed to parse text %s %s: %w", dir, name, err)
+				}
+
+				continue
+			}
+
+			if _, err := s.htmlBase.New(name).Parse(file.content); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %s: %w", dir, name, err)
+			}
+		}
+	}
+	s.hasLayout = s.htmlBase.Lookup(LayoutTemplateName) != nil
+
+	files, err := readDir(r.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, file := range files {
+		name := strings.TrimSuffix(key, file.ext)
+		if file.ext == ".txt" {
+			tmpl, err := s.parseText(name, file.content)
+			if err != nil {
+				return nil, err
+			}
+			s.text[name] = tmpl
+
+			continue
+		}
+
+		tmpl, err := s.parseHTML(name, file.content)
+		if err != nil {
+			return nil, err
+		}
+		s.html[name] = tmpl
+	}
+
+	for name := range r.specs {
+		if _, ok := s.html[name]; !ok {
+			return nil, fmt.Errorf("template %s is missing", name)
+		}
+	}
+	// localized variants are checked against variables of their template
+	for key, tmpl := range s.html {
+		name, _, _ := strings.Cut(key, ".")
+		spec, ok := r.specs[name]
+		if !ok {
+			continue
+		}
+		if err := s.check(key, spec, tmpl, s.text[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.catalog, err = LoadCatalog(filepath.Join(r.dir, catalogDir), r.defaultLocale); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+type file struct {
+	ext     string
+	content string
+}
+
+// readDir returns .html and .txt files of directory keyed by file name, missing directory is empty.
+func readDir(dir string) (map[string]*file, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to load templates from %s: %w", dir, err)
+	}
+
+	files := make(map[string]*file)
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".html" && ext != ".txt") {
+			continue
+		}
+
+		b, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file %s: %w", entry.Name(), err)
+		}
+
+		files[entry.Name()] = &file{ext: ext, content: string(b)}
+	}
+
+	return files, nil
+}
+
+// dirFingerprint changes whenever any template file is added, removed or modified.
+func (r *Registry) dirFingerprint() (string, error) {
+	var b strings.Builder
+	err := filepath.WalkDir(r.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to read template dir %s: %w", r.dir, err)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,133 @@
+package mailtemplate
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+// Variables describes data message template is rendered with.
+type Variables struct {
+	// Required have to be referenced by template, e.g. unsubscribe link
+	Required []string
+	Optional []string
+	// Overridable templates can be replaced per newsletter
+	Overridable bool
+}
+
+// check fails when required variable is not referenced or unknown variable is referenced by template
+// reachable from entry, lookup resolves trees of templates invoked by {{template}}.
+func (v *Variables) check(name string, entry *parse.Tree, lookup func(name string) *parse.Tree) error {
+	c := &collector{lookup: lookup, used: make(map[string]bool), visited: make(map[string]bool)}
+	c.tree(entry, true)
+
+	var missing, unknown []string
+	for _, variable := range v.Required {
+		if !c.used[variable] {
+			missing = append(missing, variable)
+		}
+	}
+	for variable := range c.used {
+		if !slices.Contains(v.Required, variable) && !slices.Contains(v.Optional, variable) {
+			unknown = append(unknown, variable)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("template %s is missing required variables: %s", name, strings.Join(missing, ", "))
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("template %s references unknown variables: %s", name, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+type collector struct {
+	lookup  func(name string) *parse.Tree
+	used    map[string]bool
+	visited map[string]bool
+}
+
+func (c *collector) tree(tree *parse.Tree, dot bool) {
+	if tree == nil || tree.Root == nil {
+		return
+	}
+	c.node(tree.Root, dot)
+}
+
+// node stores top level fields referenced as .Field or $.Field, dot is false inside range and with
+// because it does not point to message data there.
+func (c *collector) node(node parse.Node, dot bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.node(child, dot)
+		}
+	case *parse.ActionNode:
+		c.node(n.Pipe, dot)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			c.node(cmd, dot)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			c.node(arg, dot)
+		}
+	case *parse.FieldNode:
+		if dot {
+			c.used[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			c.used[n.Ident[1]] = true
+		}
+	case *parse.ChainNode:
+		c.node(n.Node, dot)
+	case *parse.IfNode:
+		c.node(n.Pipe, dot)
+		c.node(n.List, dot)
+		c.node(n.ElseList, dot)
+	case *parse.RangeNode:
+		c.node(n.Pipe, dot)
+		c.node(n.List, false)
+		c.node(n.ElseList, dot)
+	case *parse.WithNode:
+		c.node(n.Pipe, dot)
+		c.node(n.List, false)
+		c.node(n.ElseList, dot)
+	case *parse.TemplateNode:
+		c.node(n.Pipe, dot)
+		// invoked template sees message data only when dot is passed to it
+		passesDot := n.Pipe != nil && dot && isDot(n.Pipe)
+		key := fmt.Sprintf("%s:%t", n.Name, passesDot)
+		if c.visited[key] {
+			return
+		}
+		c.visited[key] = true
+		c.tree(c.lookup(n.Name), passesDot)
+	}
+}
+
+func isDot(pipe *parse.PipeNode) bool {
+	if len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	switch arg := pipe.Cmds[0].Args[0].(type) {
+	case *parse.DotNode:
+		return true
+	case *parse.VariableNode:
+		return len(arg.Ident) == 1 && arg.Ident[0] == "$"
+	default:
+		return false
+	}
+}
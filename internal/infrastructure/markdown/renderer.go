@@ -0,0 +1,92 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// styles are inlined into rendered elements because most email clients ignore <style> blocks.
+var styles = map[atom.Atom]string{
+	atom.H1:         "font-size:26px;line-height:1.3;margin:0 0 16px;",
+	atom.H2:         "font-size:22px;line-height:1.3;margin:24px 0 12px;",
+	atom.H3:         "font-size:18px;line-height:1.3;margin:20px 0 8px;",
+	atom.P:          "margin:0 0 16px;line-height:1.6;",
+	atom.A:          "color:#1a73e8;text-decoration:underline;",
+	atom.Blockquote: "margin:0 0 16px;padding:0 0 0 12px;border-left:4px solid #dddddd;color:#555555;",
+	atom.Pre:        "margin:0 0 16px;padding:12px;background-color:#f6f8fa;overflow:auto;",
+	atom.Code:       "font-family:Menlo,Consolas,monospace;font-size:90%;",
+	atom.Ul:         "margin:0 0 16px;padding-left:24px;",
+	atom.Ol:         "margin:0 0 16px;padding-left:24px;",
+	atom.Li:         "margin:0 0 4px;line-height:1.6;",
+	atom.Img:        "max-width:100%;height:auto;border:0;",
+	atom.Table:      "border-collapse:collapse;margin:0 0 16px;",
+	atom.Th:         "border:1px solid #dddddd;padding:6px 12px;text-align:left;",
+	atom.Td:         "border:1px solid #dddddd;padding:6px 12px;",
+	atom.Hr:         "border:0;border-top:1px solid #dddddd;margin:24px 0;",
+}
+
+// Renderer converts markdown to HTML safe to embed into email and web pages.
+type Renderer struct {
+	md     goldmark.Markdown
+	policy *bluemonday.Policy
+}
+
+func NewRenderer() *Renderer {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowRelativeURLs(false)
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.RequireNoFollowOnLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+
+	return &Renderer{
+		// raw html in source is omitted by goldmark, sanitizer guards the rest (e.g. javascript: links)
+		md:     goldmark.New(goldmark.WithExtensions(extension.Table, extension.Strikethrough, extension.Linkify)),
+		policy: policy,
+	}
+}
+
+// Render converts markdown source to HTML fragment, output is sanitized by allowlist and styles are inlined.
+func (r *Renderer) Render(source string) (string, error) {
+	var b bytes.Buffer
+	if err := r.md.Convert([]byte(source), &b); err != nil {
+		return "", fmt.Errorf("failed to convert markdown: %w", err)
+	}
+
+	return inlineStyles(r.policy.Sanitize(b.String()))
+}
+
+func inlineStyles(fragment string) (string, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse rendered html: %w", err)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		applyStyles(n)
+		if err := html.Render(&b, n); err != nil {
+			return "", fmt.Errorf("failed to render html: %w", err)
+		}
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+func applyStyles(n *html.Node) {
+	if n.Type == html.ElementNode {
+		if style, ok := styles[n.DataAtom]; ok {
+			n.Attr = append(n.Attr, html.Attribute{Key: "style", Val: style})
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		applyStyles(c)
+	}
+}
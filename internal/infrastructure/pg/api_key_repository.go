@@ -0,0 +1,140 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/apikey"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type APIKeyRepository struct {
+	createAPIKey         *operation.CreateAPIKey
+	getAPIKeysByUserID   *operation.GetAPIKeysByUserID
+	getAPIKeyByPrefix    *operation.GetAPIKeyByPrefix
+	updateAPIKeyLastUsed *operation.UpdateAPIKeyLastUsed
+	updateRevokeAPIKey   *operation.UpdateRevokeAPIKey
+}
+
+func NewAPIKeyRepository(
+	cak *operation.CreateAPIKey,
+	gakbui *operation.GetAPIKeysByUserID,
+	gakbp *operation.GetAPIKeyByPrefix,
+	uaklu *operation.UpdateAPIKeyLastUsed,
+	urak *operation.UpdateRevokeAPIKey,
+) *APIKeyRepository {
+	return &APIKeyRepository{
+		createAPIKey:         cak,
+		getAPIKeysByUserID:   gakbui,
+		getAPIKeyByPrefix:    gakbp,
+		updateAPIKeyLastUsed: uaklu,
+		updateRevokeAPIKey:   urak,
+	}
+}
+
+// Create stores api key, only hash of the key is persisted.
+func (a *APIKeyRepository) Create(ctx context.Context, apiKey *domain.APIKey, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	scopes := make([]string, 0, len(apiKey.Scopes()))
+	for _, s := range apiKey.Scopes() {
+		scopes = append(scopes, string(s))
+	}
+
+	return a.createAPIKey.Execute(ctx, &operation.CreateAPIKeyParams{
+		ID:        apiKey.ID().String(),
+		UserID:    apiKey.UserID().String(),
+		Name:      apiKey.Name(),
+		Prefix:    apiKey.Prefix(),
+		KeyHash:   apikey.Hash(key),
+		Scopes:    scopes,
+		ExpiresAt: apiKey.ExpiresAt(),
+		CreatedAt: apiKey.CreatedAt(),
+	})
+}
+
+func (a *APIKeyRepository) GetByUserID(ctx context.Context, userID *domain.ID) ([]*domain.APIKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, err := a.getAPIKeysByUserID.Execute(ctx, &operation.GetAPIKeysByUserIDParams{UserID: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*domain.APIKey, 0, len(rows))
+	for _, r := range rows {
+		k, err := createAPIKeyFromRow(r)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+// GetByKey looks key up by its prefix and verifies the rest of it against stored hash.
+func (a *APIKeyRepository) GetByKey(ctx context.Context, key string) (*domain.APIKey, error) {
+	prefix, ok := apikey.Parse(key)
+	if !ok {
+		return nil, application.InvalidAPIKeyError
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	r, err := a.getAPIKeyByPrefix.Execute(ctx, &operation.GetAPIKeyByPrefixParams{Prefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+	if !apikey.Matches(key, r.KeyHash) {
+		return nil, application.InvalidAPIKeyError
+	}
+
+	return createAPIKeyFromRow(r)
+}
+
+func (a *APIKeyRepository) MarkUsed(ctx context.Context, id *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return a.updateAPIKeyLastUsed.Execute(ctx, &operation.UpdateAPIKeyLastUsedParams{ID: id.String()})
+}
+
+func (a *APIKeyRepository) Revoke(ctx context.Context, userID, id *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return a.updateRevokeAPIKey.Execute(ctx, &operation.UpdateRevokeAPIKeyParams{
+		ID:     id.String(),
+		UserID: userID.String(),
+	})
+}
+
+func createAPIKeyFromRow(r *row.APIKey) (*domain.APIKey, error) {
+	id, err := domain.CreateIDFromExisting(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uuid format in db %w", err)
+	}
+	userID, err := domain.CreateIDFromExisting(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uuid format in db %w", err)
+	}
+
+	scopes := make([]domain.Scope, 0, len(r.Scopes))
+	for _, s := range r.Scopes {
+		scope, err := domain.NewScope(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scope %q in db %w", s, err)
+		}
+		scopes = append(scopes, scope)
+	}
+
+	return domain.CreateAPIKeyFromExisting(id, userID, r.Name, r.Prefix, scopes, r.LastUsedAt, r.ExpiresAt, r.CreatedAt), nil
+}
@@ -0,0 +1,179 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type IssueRepository struct {
+	createIssue        *operation.CreateIssue
+	updateIssue        *operation.UpdateIssue
+	getIssue           *operation.GetIssue
+	getIssues          *operation.GetIssues
+	getPublishedIssues *operation.GetPublishedIssues
+	getPublishedIssue  *operation.GetPublishedIssue
+}
+
+func NewIssueRepository(
+	ci *operation.CreateIssue,
+	ui *operation.UpdateIssue,
+	gi *operation.GetIssue,
+	gis *operation.GetIssues,
+	gpis *operation.GetPublishedIssues,
+	gpi *operation.GetPublishedIssue,
+) *IssueRepository {
+	return &IssueRepository{
+		createIssue:        ci,
+		updateIssue:        ui,
+		getIssue:           gi,
+		getIssues:          gis,
+		getPublishedIssues: gpis,
+		getPublishedIssue:  gpi,
+	}
+}
+
+func (i *IssueRepository) CreateIssue(ctx context.Context, issue *domain.Issue) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return i.createIssue.Execute(ctx, &operation.CreateIssueParams{
+		ID:                 issue.ID().String(),
+		NewsletterPublicID: issue.NewsletterPublicID().String(),
+		Slug:               issue.Slug().String(),
+		Title:              issue.Title(),
+		Markdown:           issue.Markdown(),
+		HTML:               issue.HTML(),
+		CreatedAt:          issue.CreatedAt(),
+	})
+}
+
+func (i *IssueRepository) UpdateIssue(ctx context.Context, issue *domain.Issue) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return i.updateIssue.Execute(ctx, &operation.UpdateIssueParams{
+		ID:                 issue.ID().String(),
+		NewsletterPublicID: issue.NewsletterPublicID().String(),
+		Title:              issue.Title(),
+		Markdown:           issue.Markdown(),
+		HTML:               issue.HTML(),
+		UpdatedAt:          issue.UpdatedAt(),
+	})
+}
+
+func (i *IssueRepository) GetIssue(ctx context.Context, newsletterPublicID, issueID *domain.ID) (*domain.Issue, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	r, err := i.getIssue.Execute(ctx, &operation.GetIssueParams{
+		ID:                 issueID.String(),
+		NewsletterPublicID: newsletterPublicID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createIssueFromRow(r)
+}
+
+func (i *IssueRepository) GetIssues(ctx context.Context, newsletterPublicID *domain.ID) ([]*domain.Issue, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, err := i.getIssues.Execute(ctx, &operation.GetIssuesParams{NewsletterPublicID: newsletterPublicID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]*domain.Issue, 0, len(rows))
+	for _, r := range rows {
+		issue, err := createIssueFromRow(r)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+func (i *IssueRepository) GetPublishedIssues(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	pageSize, pageNumber int,
+) (*dto.IssuePage, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, pagination, lastModified, err := i.getPublishedIssues.Execute(ctx, &operation.GetPublishedIssuesParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		PageSize:           pageSize,
+		PageNumber:         pageNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]*domain.Issue, 0, len(rows))
+	for _, r := range rows {
+		issue, err := createIssueFromRow(r)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, issue)
+	}
+
+	return &dto.IssuePage{Issues: issues, Pagination: pagination, LastModified: lastModified}, nil
+}
+
+func (i *IssueRepository) GetPublishedIssue(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	slug *domain.Slug,
+) (*domain.Issue, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	r, err := i.getPublishedIssue.Execute(ctx, &operation.GetPublishedIssueParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		Slug:               slug.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createIssueFromRow(r)
+}
+
+func createIssueFromRow(r *row.Issue) (*domain.Issue, error) {
+	id, err := domain.CreateIDFromExisting(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uuid format in db %w", err)
+	}
+	publicID, err := domain.CreateIDFromExisting(r.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uuid format in db %w", err)
+	}
+	slug, err := domain.NewSlug(r.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slug format in db %w", err)
+	}
+
+	return domain.CreateIssueFromExisting(
+		id,
+		publicID,
+		slug,
+		r.Title,
+		r.Markdown,
+		r.HTML,
+		r.PublishedAt,
+		r.CreatedAt,
+		r.UpdatedAt,
+	), nil
+}
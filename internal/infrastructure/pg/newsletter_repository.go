@@ -15,6 +15,8 @@ type NewsletterRepository struct {
 	getNewslettersByUserID            *operation.GetNewslettersByUserID
 	getNewslettersBySubscriptionEmail *operation.GetNewslettersBySubscriptionEmail
 	getNewsletterByPublicID           *operation.GetNewslettersByPublicID
+	updateNewsletter                  *operation.UpdateNewsletter
+	getNewslettersByPublicIDs         *operation.GetNewslettersByPublicIDs
 }
 
 func NewNewsletterRepository(
@@ -22,12 +24,16 @@ func NewNewsletterRepository(
 	gn *operation.GetNewslettersByUserID,
 	gns *operation.GetNewslettersBySubscriptionEmail,
 	gnbpi *operation.GetNewslettersByPublicID,
+	un *operation.UpdateNewsletter,
+	gnbpis *operation.GetNewslettersByPublicIDs,
 ) *NewsletterRepository {
 	return &NewsletterRepository{
 		createNewsletter:                  cn,
 		getNewslettersByUserID:            gn,
 		getNewslettersBySubscriptionEmail: gns,
 		getNewsletterByPublicID:           gnbpi,
+		updateNewsletter:                  un,
+		getNewslettersByPublicIDs:         gnbpis,
 	}
 }
 
@@ -49,6 +55,17 @@ func (u *NewsletterRepository) Create(ctx context.Context, userID *domain.ID, ne
 	return nil
 }
 
+func (u *NewsletterRepository) Update(ctx context.Context, newsletter *domain.Newsletter) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateNewsletter.Execute(ctx, &operation.UpdateNewsletterParams{
+		PublicID:    newsletter.PublicID().String(),
+		Name:        newsletter.Name(),
+		Description: newsletter.Description(),
+	})
+}
+
 func (u *NewsletterRepository) GetBySubscriptionEmail(ctx context.Context, email *domain.Email, pageSize, pageNumber int) ([]*domain.Newsletter, *dto.Pagination, error) {
 	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
@@ -78,6 +95,36 @@ func (u *NewsletterRepository) GetBySubscriptionEmail(ctx context.Context, email
 	return newsletters, pagination, nil
 }
 
+// GetByPublicIDs returns newsletters which are not archived ordered by public id.
+func (u *NewsletterRepository) GetByPublicIDs(ctx context.Context, publicIDs []*domain.ID) ([]*domain.Newsletter, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	ids := make([]string, 0, len(publicIDs))
+	for _, id := range publicIDs {
+		ids = append(ids, id.String())
+	}
+	rows, err := u.getNewslettersByPublicIDs.Execute(ctx, &operation.GetNewslettersByPublicIDsParams{PublicIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	newsletters := make([]*domain.Newsletter, 0, len(rows))
+	for _, row := range rows {
+		id, err := domain.CreateIDFromExisting(row.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid format in db %w", err)
+		}
+		publicID, err := domain.CreateIDFromExisting(row.PublicID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid format in db %w", err)
+		}
+		newsletters = append(newsletters, domain.CreateNewsletterFromExisting(id, publicID, row.Name, row.Description, row.CreatedAt))
+	}
+
+	return newsletters, nil
+}
+
 func (u *NewsletterRepository) GetByUserID(ctx context.Context, userID *domain.ID, pageSize, pageNumber int) ([]*domain.Newsletter, *dto.Pagination, error) {
 	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond) // TODO: scale with pageSize?
 	defer cancel()
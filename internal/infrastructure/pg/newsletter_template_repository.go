@@ -0,0 +1,80 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+type NewsletterTemplateRepository struct {
+	createOrUpdateNewsletterTemplate *operation.CreateOrUpdateNewsletterTemplate
+	getNewsletterTemplates           *operation.GetNewsletterTemplates
+	deleteNewsletterTemplate         *operation.DeleteNewsletterTemplate
+}
+
+func NewNewsletterTemplateRepository(
+	cunt *operation.CreateOrUpdateNewsletterTemplate,
+	gnt *operation.GetNewsletterTemplates,
+	dnt *operation.DeleteNewsletterTemplate,
+) *NewsletterTemplateRepository {
+	return &NewsletterTemplateRepository{
+		createOrUpdateNewsletterTemplate: cunt,
+		getNewsletterTemplates:           gnt,
+		deleteNewsletterTemplate:         dnt,
+	}
+}
+
+func (n *NewsletterTemplateRepository) GetTemplateOverrides(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+) ([]*domain.TemplateOverride, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, err := n.getNewsletterTemplates.Execute(ctx, &operation.GetNewsletterTemplatesParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make([]*domain.TemplateOverride, len(rows))
+	for i, r := range rows {
+		overrides[i] = domain.CreateTemplateOverrideFromExisting(r.Name, r.HTML, valueOrEmpty(r.Text), r.UpdatedAt)
+	}
+
+	return overrides, nil
+}
+
+// SaveTemplateOverride creates or replaces override, empty text is stored as NULL so it is converted from html.
+func (n *NewsletterTemplateRepository) SaveTemplateOverride(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	override *domain.TemplateOverride,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return n.createOrUpdateNewsletterTemplate.Execute(ctx, &operation.CreateOrUpdateNewsletterTemplateParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		Name:               override.Name(),
+		HTML:               override.HTML(),
+		Text:               nilIfEmpty(override.Text()),
+	})
+}
+
+func (n *NewsletterTemplateRepository) DeleteTemplateOverride(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	name string,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return n.deleteNewsletterTemplate.Execute(ctx, &operation.DeleteNewsletterTemplateParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		Name:               name,
+	})
+}
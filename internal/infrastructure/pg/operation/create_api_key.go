@@ -0,0 +1,64 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/lib/pq"
+)
+
+type CreateAPIKey struct {
+	pgConn *sql.DB
+}
+
+type CreateAPIKeyParams struct {
+	ID        string
+	UserID    string
+	Name      string
+	Prefix    string
+	KeyHash   string
+	Scopes    []string
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+func NewCreateAPIKey(pgConn *sql.DB) *CreateAPIKey {
+	return &CreateAPIKey{
+		pgConn: pgConn,
+	}
+}
+
+func (o *CreateAPIKey) Execute(ctx context.Context, p *CreateAPIKeyParams) error {
+	const (
+		unknownUserConstraint = "api_keys_user_id_fkey"
+		query                 = `
+			INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+		`
+	)
+	_, err := o.pgConn.ExecContext(
+		ctx,
+		query,
+		p.ID,
+		p.UserID,
+		p.Name,
+		p.Prefix,
+		p.KeyHash,
+		pq.Array(p.Scopes),
+		p.ExpiresAt,
+		p.CreatedAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), unknownUserConstraint) {
+			return application.UnknownUserError
+		}
+
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
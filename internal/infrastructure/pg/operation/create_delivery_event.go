@@ -0,0 +1,41 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateDeliveryEventParams struct {
+	EmailJobID string
+	Type       string
+	OccurredAt time.Time
+}
+
+// CreateDeliveryEventTx records delivery event of email, event repeated by provider only bumps counter of existing
+// one. Returns true when event of the type was recorded for the first time.
+func CreateDeliveryEventTx(ctx context.Context, tx *sql.Tx, p *CreateDeliveryEventParams) (bool, error) {
+	const query = `
+		INSERT INTO email_events (email_job_id, event_type, first_at, last_at, count)
+		VALUES ($1, $2, $3, $3, 1)
+		ON CONFLICT (email_job_id, event_type) DO UPDATE SET
+			last_at = GREATEST(email_events.last_at, EXCLUDED.last_at),
+			count = email_events.count + 1
+		RETURNING count = 1;
+	`
+
+	var first bool
+	if err := tx.QueryRowContext(ctx, query, p.EmailJobID, p.Type, p.OccurredAt).Scan(&first); err != nil {
+		if strings.Contains(err.Error(), "email_events_email_job_id_fkey") {
+			return false, application.EmailJobNotFoundError
+		}
+
+		return false, fmt.Errorf("failed to create delivery event: %w", err)
+	}
+
+	return first, nil
+}
@@ -0,0 +1,46 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateEmailChangeParams struct {
+	UserID    string
+	NewEmail  string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// CreateEmailChangeTx replaces pending email change of user, email already used by another account is rejected.
+func CreateEmailChangeTx(ctx context.Context, tx *sql.Tx, p *CreateEmailChangeParams) error {
+	const query = `
+		INSERT INTO user_email_changes (user_id, new_email, token_hash, expires_at)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (SELECT 1 FROM users WHERE email = $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			new_email = EXCLUDED.new_email,
+			token_hash = EXCLUDED.token_hash,
+			expires_at = EXCLUDED.expires_at,
+			created_at = CURRENT_TIMESTAMP;
+	`
+
+	res, err := tx.ExecContext(ctx, query, p.UserID, p.NewEmail, p.TokenHash, p.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create email change: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.EmailTakenError
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateEmailClick struct {
+	pgConn *sql.DB
+}
+
+type CreateEmailClickParams struct {
+	ID         string
+	EmailJobID string
+	LinkIndex  int
+	ClickedAt  time.Time
+}
+
+func NewCreateEmailClick(pgConn *sql.DB) *CreateEmailClick {
+	return &CreateEmailClick{
+		pgConn: pgConn,
+	}
+}
+
+func (o *CreateEmailClick) Execute(ctx context.Context, p *CreateEmailClickParams) error {
+	const query = "INSERT INTO email_clicks (id, email_job_id, link_index, clicked_at) VALUES ($1, $2, $3, $4);"
+
+	if _, err := o.pgConn.ExecContext(ctx, query, p.ID, p.EmailJobID, p.LinkIndex, p.ClickedAt); err != nil {
+		if strings.Contains(err.Error(), "email_clicks_email_job_id_fkey") {
+			return application.EmailJobNotFoundError
+		}
+
+		return fmt.Errorf("failed to create email click: %w", err)
+	}
+
+	return nil
+}
@@ -12,17 +12,19 @@ type CreateEmailJobParams struct {
 	ID     string
 	Type   row.MailType
 	Params []byte
+	// NewsletterPublicID binds job to newsletter, pending job is cancelled once newsletter is archived or deleted
+	NewsletterPublicID *string
 }
 
 func CreateEmailJobTx(ctx context.Context, tx *sql.Tx, p *CreateEmailJobParams) error {
 	const (
 		query = `
-			INSERT INTO email_jobs (id, message_type, params)
-			VALUES ($1, $2, $3);
+			INSERT INTO email_jobs (id, message_type, params, newsletter_id)
+			VALUES ($1, $2, $3, (SELECT id FROM newsletters WHERE public_id = $4));
 		`
 	)
 
-	_, err := tx.ExecContext(ctx, query, p.ID, p.Type, p.Params)
+	_, err := tx.ExecContext(ctx, query, p.ID, p.Type, p.Params, p.NewsletterPublicID)
 	if err != nil {
 		return fmt.Errorf("failed to enqueue mail job: %w", err)
 	}
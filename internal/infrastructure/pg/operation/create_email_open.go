@@ -0,0 +1,64 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateEmailOpen struct {
+	pgConn *sql.DB
+}
+
+type CreateEmailOpenParams struct {
+	EmailJobID     string
+	UserAgentClass string
+	Prefetched     bool
+	OpenedAt       time.Time
+}
+
+func NewCreateEmailOpen(pgConn *sql.DB) *CreateEmailOpen {
+	return &CreateEmailOpen{
+		pgConn: pgConn,
+	}
+}
+
+// Execute records open of email, first open creates event and following ones bump its counters. User agent class
+// of prefetch is replaced by class of first open made by reader, time of that open is kept as read_at.
+func (o *CreateEmailOpen) Execute(ctx context.Context, p *CreateEmailOpenParams) error {
+	const query = `
+		INSERT INTO email_events (
+			email_job_id, event_type, first_at, last_at, count, prefetch_count, user_agent_class, read_at
+		)
+		VALUES ($1, 'OPEN', $2, $2, 1, $3, $4, CASE WHEN $3 = 0 THEN $2::timestamptz END)
+		ON CONFLICT (email_job_id, event_type) DO UPDATE SET
+			last_at = GREATEST(email_events.last_at, EXCLUDED.last_at),
+			read_at = COALESCE(email_events.read_at, EXCLUDED.read_at),
+			count = email_events.count + 1,
+			prefetch_count = email_events.prefetch_count + EXCLUDED.prefetch_count,
+			user_agent_class = CASE
+				WHEN email_events.count = email_events.prefetch_count AND EXCLUDED.prefetch_count = 0
+				THEN EXCLUDED.user_agent_class
+				ELSE email_events.user_agent_class
+			END;
+	`
+
+	prefetchCount := 0
+	if p.Prefetched {
+		prefetchCount = 1
+	}
+
+	if _, err := o.pgConn.ExecContext(ctx, query, p.EmailJobID, p.OpenedAt, prefetchCount, p.UserAgentClass); err != nil {
+		if strings.Contains(err.Error(), "email_events_email_job_id_fkey") {
+			return application.EmailJobNotFoundError
+		}
+
+		return fmt.Errorf("failed to create email open: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateInvitationParams struct {
+	ID                 string
+	NewsletterPublicID string
+	Email              string
+	Role               string
+	TokenHash          string
+	InvitedBy          string
+	ExpiresAt          time.Time
+}
+
+// CreateInvitationTx creates invitation or renews pending one, existing members can not be invited.
+func CreateInvitationTx(ctx context.Context, tx *sql.Tx, p *CreateInvitationParams) error {
+	const query = `
+		INSERT INTO newsletter_invitations (id, newsletter_id, email, role, token_hash, invited_by, expires_at)
+		SELECT $1, n.id, $3, $4, $5, $6, $7
+		FROM newsletters n
+		WHERE n.public_id = $2 AND NOT EXISTS (
+			SELECT 1
+			FROM newsletter_members m
+			JOIN users u ON u.id = m.user_id
+			WHERE m.newsletter_id = n.id AND u.email = $3
+		)
+		ON CONFLICT (newsletter_id, email) DO UPDATE SET
+			role = EXCLUDED.role,
+			token_hash = EXCLUDED.token_hash,
+			invited_by = EXCLUDED.invited_by,
+			expires_at = EXCLUDED.expires_at,
+			accepted_at = NULL,
+			created_at = CURRENT_TIMESTAMP;
+	`
+
+	res, err := tx.ExecContext(ctx, query, p.ID, p.NewsletterPublicID, p.Email, p.Role, p.TokenHash, p.InvitedBy, p.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.AlreadyMemberError
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateIssue struct {
+	pgConn *sql.DB
+}
+
+type CreateIssueParams struct {
+	ID                 string
+	NewsletterPublicID string
+	Slug               string
+	Title              string
+	Markdown           string
+	HTML               string
+	CreatedAt          time.Time
+}
+
+func NewCreateIssue(pgConn *sql.DB) *CreateIssue {
+	return &CreateIssue{
+		pgConn: pgConn,
+	}
+}
+
+// Execute stores draft issue of newsletter, archived newsletter does not accept new issues.
+func (o *CreateIssue) Execute(ctx context.Context, p *CreateIssueParams) error {
+	const (
+		takenConstraint = "issues_newsletter_id_slug_key"
+		query           = `
+			INSERT INTO issues (id, newsletter_id, slug, title, body_markdown, body_html, created_at, updated_at)
+			SELECT $1, id, $3, $4, $5, $6, $7, $7 FROM newsletters WHERE public_id = $2 AND archived_at IS NULL;
+		`
+	)
+
+	res, err := o.pgConn.ExecContext(
+		ctx,
+		query,
+		p.ID,
+		p.NewsletterPublicID,
+		p.Slug,
+		p.Title,
+		p.Markdown,
+		p.HTML,
+		p.CreatedAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), takenConstraint) {
+			return application.IssueSlugTakenError
+		}
+
+		return fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.NewsletterNotFoundError
+	}
+
+	return nil
+}
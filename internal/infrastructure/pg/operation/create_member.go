@@ -0,0 +1,46 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateMemberParams struct {
+	ID                 string
+	NewsletterPublicID string
+	UserID             string
+	Role               string
+}
+
+func CreateMemberTx(ctx context.Context, tx *sql.Tx, p *CreateMemberParams) error {
+	const (
+		alreadyMemberConstraint = "newsletter_members_newsletter_id_user_id_key"
+		query                   = `
+			INSERT INTO newsletter_members (id, newsletter_id, user_id, role)
+			SELECT $1, id, $3, $4 FROM newsletters WHERE public_id = $2;
+		`
+	)
+
+	res, err := tx.ExecContext(ctx, query, p.ID, p.NewsletterPublicID, p.UserID, p.Role)
+	if err != nil {
+		if strings.Contains(err.Error(), alreadyMemberConstraint) {
+			return application.AlreadyMemberError
+		}
+
+		return fmt.Errorf("failed to create member: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.NewsletterNotFoundError
+	}
+
+	return nil
+}
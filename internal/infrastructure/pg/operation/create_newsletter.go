@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/javor454/newsletter-assignment/internal/application"
 )
 
@@ -31,15 +32,33 @@ func NewCreateNewsletter(pgConn *sql.DB) *CreateNewsletter {
 
 func (o *CreateNewsletter) Execute(ctx context.Context, p *CreateNewsletterParams) error {
 	const (
-		unknownUserConstraint = "newsletters_user_id_fkey"
-		query                 = `
-			INSERT INTO newsletters (user_id, id, public_id, name, description, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6);
+		unknownUserConstraint       = "newsletters_user_id_fkey"
+		unknownMemberUserConstraint = "newsletter_members_user_id_fkey"
+		// creator becomes owner in the same statement
+		query = `
+			WITH newsletter AS (
+				INSERT INTO newsletters (user_id, id, public_id, name, description, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				RETURNING id, user_id
+			)
+			INSERT INTO newsletter_members (id, newsletter_id, user_id, role)
+			SELECT $7, id, user_id, 'owner' FROM newsletter;
 		`
 	)
-	_, err := o.pgConn.ExecContext(ctx, query, p.UserID, p.ID, p.PublicID, p.Name, p.Description, p.CreatedAt)
+	_, err := o.pgConn.ExecContext(
+		ctx,
+		query,
+		p.UserID,
+		p.ID,
+		p.PublicID,
+		p.Name,
+		p.Description,
+		p.CreatedAt,
+		uuid.New().String(),
+	)
 	if err != nil {
-		if strings.Contains(err.Error(), unknownUserConstraint) {
+		if strings.Contains(err.Error(), unknownUserConstraint) ||
+			strings.Contains(err.Error(), unknownMemberUserConstraint) {
 			return application.UnknownUserError
 		}
 
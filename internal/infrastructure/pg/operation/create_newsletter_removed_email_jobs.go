@@ -0,0 +1,43 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type CreateNewsletterRemovedEmailJobsParams struct {
+	NewsletterPublicID string
+	// Sender is json of sender identity of newsletter, unset fields are filled from default sender
+	Sender []byte
+}
+
+// CreateNewsletterRemovedEmailJobsTx enqueues notification for every active subscriber of newsletter in single
+// statement, it has to run before removal disables subscriptions. Jobs are not bound to newsletter so they outlive its
+// deletion.
+func CreateNewsletterRemovedEmailJobsTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	p *CreateNewsletterRemovedEmailJobsParams,
+) error {
+	const query = `
+		INSERT INTO email_jobs (id, message_type, params)
+		SELECT gen_random_uuid(), $2::varchar, jsonb_strip_nulls(jsonb_build_object(
+			'email', s.subscriber_email,
+			'newsletter_name', n.name,
+			'sender', $3::jsonb,
+			'locale', s.locale
+		))
+		FROM subscriptions s
+		JOIN newsletters n ON n.id = s.newsletter_id
+		WHERE n.public_id = $1 AND s.disabled_at IS NULL;
+	`
+
+	if _, err := tx.ExecContext(ctx, query, p.NewsletterPublicID, row.NewsletterRemovedType, p.Sender); err != nil {
+		return fmt.Errorf("failed to enqueue newsletter removed mail jobs: %w", err)
+	}
+
+	return nil
+}
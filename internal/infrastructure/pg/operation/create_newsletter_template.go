@@ -0,0 +1,54 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateOrUpdateNewsletterTemplate struct {
+	pgConn *sql.DB
+}
+
+type CreateOrUpdateNewsletterTemplateParams struct {
+	NewsletterPublicID string
+	Name               string
+	HTML               string
+	Text               *string
+}
+
+func NewCreateOrUpdateNewsletterTemplate(pgConn *sql.DB) *CreateOrUpdateNewsletterTemplate {
+	return &CreateOrUpdateNewsletterTemplate{
+		pgConn: pgConn,
+	}
+}
+
+// Execute stores override of template for newsletter, existing override is replaced.
+func (o *CreateOrUpdateNewsletterTemplate) Execute(
+	ctx context.Context,
+	p *CreateOrUpdateNewsletterTemplateParams,
+) error {
+	const query = `
+		INSERT INTO newsletter_templates (newsletter_id, name, html, text)
+		SELECT id, $2, $3, $4 FROM newsletters WHERE public_id = $1 AND archived_at IS NULL
+		ON CONFLICT (newsletter_id, name)
+		DO UPDATE SET html = EXCLUDED.html, text = EXCLUDED.text, updated_at = CURRENT_TIMESTAMP;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.NewsletterPublicID, p.Name, p.HTML, p.Text)
+	if err != nil {
+		return fmt.Errorf("failed to create newsletter template: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.NewsletterNotFoundError
+	}
+
+	return nil
+}
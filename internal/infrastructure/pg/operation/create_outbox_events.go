@@ -0,0 +1,63 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type CreateOutboxEventParams struct {
+	ID                 string
+	Name               string
+	AggregateKey       string
+	NewsletterPublicID string
+	OccurredAt         time.Time
+	Data               []byte
+}
+
+// CreateOutboxEventsTx stores events in transaction of the change which raised them in single statement, event stored
+// again is ignored.
+func CreateOutboxEventsTx(ctx context.Context, tx *sql.Tx, p []*CreateOutboxEventParams) error {
+	const query = `
+		INSERT INTO outbox (id, name, aggregate_key, newsletter_public_id, occurred_at, data)
+		SELECT * FROM unnest($1::uuid[], $2::varchar[], $3::varchar[], $4::uuid[], $5::timestamptz[], $6::jsonb[])
+		ON CONFLICT (id) DO NOTHING;
+	`
+
+	if len(p) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(p))
+	names := make([]string, 0, len(p))
+	keys := make([]string, 0, len(p))
+	pubIDs := make([]string, 0, len(p))
+	occurredAts := make([]time.Time, 0, len(p))
+	data := make([]string, 0, len(p))
+	for _, e := range p {
+		ids = append(ids, e.ID)
+		names = append(names, e.Name)
+		keys = append(keys, e.AggregateKey)
+		pubIDs = append(pubIDs, e.NewsletterPublicID)
+		occurredAts = append(occurredAts, e.OccurredAt)
+		data = append(data, string(e.Data))
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		query,
+		pq.Array(ids),
+		pq.Array(names),
+		pq.Array(keys),
+		pq.Array(pubIDs),
+		pq.Array(occurredAts),
+		pq.Array(data),
+	); err != nil {
+		return fmt.Errorf("failed to create outbox events: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type CreateRecoveryCodesParams struct {
+	UserID     string
+	CodeHashes []string
+}
+
+// CreateRecoveryCodesTx replaces all recovery codes of user.
+func CreateRecoveryCodesTx(ctx context.Context, tx *sql.Tx, p *CreateRecoveryCodesParams) error {
+	const (
+		deleteQuery = "DELETE FROM user_recovery_codes WHERE user_id = $1;"
+		insertQuery = `
+			INSERT INTO user_recovery_codes (id, user_id, code_hash)
+			VALUES ($1, $2, $3);
+		`
+	)
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, p.UserID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	for _, hash := range p.CodeHashes {
+		if _, err := tx.ExecContext(ctx, insertQuery, uuid.New().String(), p.UserID, hash); err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	return nil
+}
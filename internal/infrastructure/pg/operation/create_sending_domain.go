@@ -0,0 +1,69 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type CreateSendingDomain struct {
+	pgConn *sql.DB
+}
+
+type CreateSendingDomainParams struct {
+	ID                string
+	UserID            string
+	Domain            string
+	VerificationToken string
+	DKIMSelector      string
+	DKIMPrivateKey    string
+	DKIMPublicKey     string
+	CreatedAt         time.Time
+}
+
+func NewCreateSendingDomain(pgConn *sql.DB) *CreateSendingDomain {
+	return &CreateSendingDomain{
+		pgConn: pgConn,
+	}
+}
+
+func (o *CreateSendingDomain) Execute(ctx context.Context, p *CreateSendingDomainParams) error {
+	const (
+		unknownUserConstraint = "sending_domains_user_id_fkey"
+		takenConstraint       = "sending_domains_user_id_domain_key"
+		query                 = `
+			INSERT INTO sending_domains (
+				id, user_id, domain, verification_token, dkim_selector, dkim_private_key, dkim_public_key, created_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+		`
+	)
+	_, err := o.pgConn.ExecContext(
+		ctx,
+		query,
+		p.ID,
+		p.UserID,
+		p.Domain,
+		p.VerificationToken,
+		p.DKIMSelector,
+		p.DKIMPrivateKey,
+		p.DKIMPublicKey,
+		p.CreatedAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), unknownUserConstraint) {
+			return application.UnknownUserError
+		}
+		if strings.Contains(err.Error(), takenConstraint) {
+			return application.SendingDomainTakenError
+		}
+
+		return fmt.Errorf("failed to create sending domain: %w", err)
+	}
+
+	return nil
+}
@@ -11,21 +11,38 @@ type CreateSubscriptionParams struct {
 	SubscriberEmail   string
 	NewsletterID      string
 	SubscriptionToken string
+	Locale            string
 }
 
 // TODO: get newsletter ID can be probably merged with this
-func CreateOrUpdateSubscriptionTx(ctx context.Context, tx *sql.Tx, p *CreateSubscriptionParams) error {
+// Resubscription starts new subscription, so it is counted in statistics like the first one. Returns true when
+// subscription started, subscribing again while active only changes locale.
+func CreateOrUpdateSubscriptionTx(ctx context.Context, tx *sql.Tx, p *CreateSubscriptionParams) (bool, error) {
 	const query = `
-			INSERT INTO subscriptions (id, subscriber_email, newsletter_id, token)
-        	VALUES ($1, $2, $3, $4)
+			INSERT INTO subscriptions (id, subscriber_email, newsletter_id, token, locale)
+        	VALUES ($1, $2, $3, $4, $5)
         	ON CONFLICT (subscriber_email, newsletter_id)
-        	DO UPDATE SET disabled_at = NULL;
+        	DO UPDATE SET
+        		created_at = CASE WHEN subscriptions.disabled_at IS NULL THEN subscriptions.created_at ELSE CURRENT_TIMESTAMP END,
+        		disabled_at = NULL,
+        		unsubscribe_reason = NULL,
+        		unsubscribe_comment = NULL,
+        		locale = EXCLUDED.locale
+        	RETURNING created_at = CURRENT_TIMESTAMP;
 		`
 
-	_, err := tx.ExecContext(ctx, query, p.ID, p.SubscriberEmail, p.NewsletterID, p.SubscriptionToken)
-	if err != nil {
-		return fmt.Errorf("failed to create subscription: %w", err)
+	var started bool
+	if err := tx.QueryRowContext(
+		ctx,
+		query,
+		p.ID,
+		p.SubscriberEmail,
+		p.NewsletterID,
+		p.SubscriptionToken,
+		p.Locale,
+	).Scan(&started); err != nil {
+		return false, fmt.Errorf("failed to create subscription: %w", err)
 	}
 
-	return nil
+	return started, nil
 }
@@ -0,0 +1,51 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type CreateWebhook struct {
+	pgConn *sql.DB
+}
+
+type CreateWebhookParams struct {
+	ID                 string
+	NewsletterPublicID string
+	URL                string
+	Secret             string
+	EventTypes         []string
+	CreatedAt          time.Time
+}
+
+func NewCreateWebhook(pgConn *sql.DB) *CreateWebhook {
+	return &CreateWebhook{
+		pgConn: pgConn,
+	}
+}
+
+func (o *CreateWebhook) Execute(ctx context.Context, p *CreateWebhookParams) error {
+	const query = `
+		INSERT INTO webhooks (id, newsletter_id, url, secret, event_types, created_at)
+		VALUES ($1, (SELECT id FROM newsletters WHERE public_id = $2), $3, $4, $5, $6);
+	`
+
+	if _, err := o.pgConn.ExecContext(
+		ctx,
+		query,
+		p.ID,
+		p.NewsletterPublicID,
+		p.URL,
+		p.Secret,
+		pq.Array(p.EventTypes),
+		p.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type CreateWebhookDeliveries struct {
+	pgConn *sql.DB
+}
+
+type CreateWebhookDeliveriesParams struct {
+	NewsletterPublicID string
+	EventID            string
+	EventType          string
+	Payload            []byte
+}
+
+func NewCreateWebhookDeliveries(pgConn *sql.DB) *CreateWebhookDeliveries {
+	return &CreateWebhookDeliveries{
+		pgConn: pgConn,
+	}
+}
+
+// Execute enqueues event for every webhook of newsletter subscribed to its type, event enqueued again is ignored.
+func (o *CreateWebhookDeliveries) Execute(ctx context.Context, p *CreateWebhookDeliveriesParams) error {
+	const query = `
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload)
+		SELECT gen_random_uuid(), w.id, $2::uuid, $3::varchar, $4::jsonb
+		FROM webhooks w
+		JOIN newsletters n ON n.id = w.newsletter_id
+		WHERE n.public_id = $1 AND $3::text = ANY(w.event_types)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING;
+	`
+
+	if _, err := o.pgConn.ExecContext(ctx, query, p.NewsletterPublicID, p.EventID, p.EventType, p.Payload); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type CreateWebhookDelivery struct {
+	pgConn *sql.DB
+}
+
+// CreateWebhookDeliveryParams describe delivery which was already attempted, it is stored only to be shown in log.
+type CreateWebhookDeliveryParams struct {
+	ID         string
+	WebhookID  string
+	EventID    string
+	EventType  string
+	Payload    []byte
+	StatusCode *int
+	Error      *string
+	AttemptAt  time.Time
+	Delivered  bool
+}
+
+func NewCreateWebhookDelivery(pgConn *sql.DB) *CreateWebhookDelivery {
+	return &CreateWebhookDelivery{
+		pgConn: pgConn,
+	}
+}
+
+func (o *CreateWebhookDelivery) Execute(ctx context.Context, p *CreateWebhookDeliveryParams) error {
+	const query = `
+		INSERT INTO webhook_deliveries (
+			id, webhook_id, event_id, event_type, payload, attempts, next_attempt_at, last_attempt_at,
+			last_status_code, last_error, delivered_at, failed_at, created_at
+		)
+		VALUES (
+			$1, $2, $3, $4, $5, 1, NULL, $6, $7, $8,
+			CASE WHEN $9 THEN $6::timestamptz END, CASE WHEN NOT $9 THEN $6::timestamptz END, $6
+		);
+	`
+
+	if _, err := o.pgConn.ExecContext(
+		ctx,
+		query,
+		p.ID,
+		p.WebhookID,
+		p.EventID,
+		p.EventType,
+		p.Payload,
+		p.AttemptAt,
+		p.StatusCode,
+		p.Error,
+		p.Delivered,
+	); err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type DeleteMember struct {
+	pgConn *sql.DB
+}
+
+type DeleteMemberParams struct {
+	NewsletterPublicID string
+	UserID             string
+}
+
+func NewDeleteMember(pgConn *sql.DB) *DeleteMember {
+	return &DeleteMember{
+		pgConn: pgConn,
+	}
+}
+
+// Execute removes member, owner is never removed.
+func (o *DeleteMember) Execute(ctx context.Context, p *DeleteMemberParams) error {
+	const query = `
+		DELETE FROM newsletter_members m
+		USING newsletters n
+		WHERE n.id = m.newsletter_id AND n.public_id = $1 AND m.user_id = $2 AND m.role <> 'owner';
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.NewsletterPublicID, p.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete member: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.MemberNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type DeleteNewsletterParams struct {
+	PublicID string
+}
+
+// DeleteNewsletterTx removes newsletter with its subscriptions, email jobs, members and invitations are removed
+// by cascade. Name of newsletter and emails of subscriptions active until now are returned.
+func DeleteNewsletterTx(ctx context.Context, tx *sql.Tx, p *DeleteNewsletterParams) (string, []string, error) {
+	const (
+		lockQuery          = "SELECT id, name FROM newsletters WHERE public_id = $1 FOR UPDATE;"
+		subscriptionsQuery = `
+			DELETE FROM subscriptions
+			WHERE newsletter_id = $1
+			RETURNING subscriber_email, disabled_at IS NULL;
+		`
+		deleteQuery = "DELETE FROM newsletters WHERE id = $1;"
+	)
+
+	var newsletterID, name string
+	if err := tx.QueryRowContext(ctx, lockQuery, p.PublicID).Scan(&newsletterID, &name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, application.NewsletterNotFoundError
+		}
+
+		return "", nil, fmt.Errorf("failed to get newsletter: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, subscriptionsQuery, newsletterID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to delete subscriptions: %w", err)
+	}
+
+	emails := make([]string, 0)
+	for rows.Next() {
+		var (
+			email  string
+			active bool
+		)
+		if err := rows.Scan(&email, &active); err != nil {
+			if err := rows.Close(); err != nil {
+				return "", nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return "", nil, fmt.Errorf("failed to scan row on delete subscriptions: %w", err)
+		}
+		if active {
+			emails = append(emails, email)
+		}
+	}
+
+	if err := rows.Close(); err != nil {
+		return "", nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, newsletterID); err != nil {
+		return "", nil, fmt.Errorf("failed to delete newsletter: %w", err)
+	}
+
+	return name, emails, nil
+}
@@ -0,0 +1,48 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type DeleteNewsletterTemplate struct {
+	pgConn *sql.DB
+}
+
+type DeleteNewsletterTemplateParams struct {
+	NewsletterPublicID string
+	Name               string
+}
+
+func NewDeleteNewsletterTemplate(pgConn *sql.DB) *DeleteNewsletterTemplate {
+	return &DeleteNewsletterTemplate{
+		pgConn: pgConn,
+	}
+}
+
+// Execute removes override so message falls back to default template.
+func (o *DeleteNewsletterTemplate) Execute(ctx context.Context, p *DeleteNewsletterTemplateParams) error {
+	const query = `
+		DELETE FROM newsletter_templates t
+		USING newsletters n
+		WHERE n.id = t.newsletter_id AND n.public_id = $1 AND t.name = $2;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.NewsletterPublicID, p.Name)
+	if err != nil {
+		return fmt.Errorf("failed to delete newsletter template: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.TemplateNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type DeleteSendingDomainParams struct {
+	ID     string
+	UserID string
+}
+
+// DeleteSendingDomainTx deletes domain of user and returns its name, domains of other users are reported as not found.
+func DeleteSendingDomainTx(ctx context.Context, tx *sql.Tx, p *DeleteSendingDomainParams) (string, error) {
+	const query = "DELETE FROM sending_domains WHERE id = $1 AND user_id = $2 RETURNING domain;"
+
+	var name string
+	if err := tx.QueryRowContext(ctx, query, p.ID, p.UserID).Scan(&name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", application.SendingDomainNotFoundError
+		}
+
+		return "", fmt.Errorf("failed to delete sending domain: %w", err)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,33 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type DeleteUserParams struct {
+	UserID string
+}
+
+// DeleteUserTx removes user, api keys, recovery codes and pending email change are removed by cascade.
+func DeleteUserTx(ctx context.Context, tx *sql.Tx, p *DeleteUserParams) error {
+	const query = "DELETE FROM users WHERE id = $1;"
+
+	res, err := tx.ExecContext(ctx, query, p.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.UserNotFoundError
+	}
+
+	return nil
+}
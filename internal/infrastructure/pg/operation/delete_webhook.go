@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type DeleteWebhook struct {
+	pgConn *sql.DB
+}
+
+type DeleteWebhookParams struct {
+	ID                 string
+	NewsletterPublicID string
+}
+
+func NewDeleteWebhook(pgConn *sql.DB) *DeleteWebhook {
+	return &DeleteWebhook{
+		pgConn: pgConn,
+	}
+}
+
+// Execute deletes webhook of newsletter together with its pending deliveries and log.
+func (o *DeleteWebhook) Execute(ctx context.Context, p *DeleteWebhookParams) error {
+	const query = `
+		DELETE FROM webhooks
+		WHERE id = $1 AND newsletter_id = (SELECT id FROM newsletters WHERE public_id = $2);
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.ID, p.NewsletterPublicID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.WebhookNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetActiveSubscriptionsParams struct {
+	NewsletterPublicID string
+}
+
+// GetActiveSubscriptionsTx returns subscribers of newsletter which did not unsubscribe.
+func GetActiveSubscriptionsTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	p *GetActiveSubscriptionsParams,
+) ([]*row.ActiveSubscription, error) {
+	const query = `
+		SELECT s.subscriber_email, s.token, s.locale
+		FROM subscriptions s
+		JOIN newsletters n ON n.id = s.newsletter_id
+		WHERE n.public_id = $1 AND s.disabled_at IS NULL;
+	`
+
+	rows, err := tx.QueryContext(ctx, query, p.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active subscriptions: %w", err)
+	}
+
+	subscriptions := make([]*row.ActiveSubscription, 0)
+	for rows.Next() {
+		var r row.ActiveSubscription
+		if err := rows.Scan(&r.Email, &r.Token, &r.Locale); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get active subscriptions: %w", err)
+		}
+
+		subscriptions = append(subscriptions, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return subscriptions, nil
+}
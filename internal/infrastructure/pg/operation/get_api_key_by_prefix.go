@@ -0,0 +1,54 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+	"github.com/lib/pq"
+)
+
+type GetAPIKeyByPrefix struct {
+	pgConn *sql.DB
+}
+
+type GetAPIKeyByPrefixParams struct {
+	Prefix string
+}
+
+func NewGetAPIKeyByPrefix(pgConn *sql.DB) *GetAPIKeyByPrefix {
+	return &GetAPIKeyByPrefix{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetAPIKeyByPrefix) Execute(ctx context.Context, p *GetAPIKeyByPrefixParams) (*row.APIKey, error) {
+	const query = `
+		SELECT id, user_id, name, prefix, key_hash, scopes, last_used_at, expires_at, created_at
+		FROM api_keys
+		WHERE prefix = $1 AND revoked_at IS NULL;
+	`
+	var r row.APIKey
+	if err := o.pgConn.QueryRowContext(ctx, query, p.Prefix).Scan(
+		&r.ID,
+		&r.UserID,
+		&r.Name,
+		&r.Prefix,
+		&r.KeyHash,
+		pq.Array(&r.Scopes),
+		&r.LastUsedAt,
+		&r.ExpiresAt,
+		&r.CreatedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.InvalidAPIKeyError
+		}
+
+		return nil, fmt.Errorf("failed to get api key by prefix: %w", err)
+	}
+
+	return &r, nil
+}
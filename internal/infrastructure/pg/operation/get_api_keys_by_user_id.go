@@ -0,0 +1,69 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+	"github.com/lib/pq"
+)
+
+type GetAPIKeysByUserID struct {
+	pgConn *sql.DB
+}
+
+type GetAPIKeysByUserIDParams struct {
+	UserID string
+}
+
+func NewGetAPIKeysByUserID(pgConn *sql.DB) *GetAPIKeysByUserID {
+	return &GetAPIKeysByUserID{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns keys which were not revoked, newest first.
+func (o *GetAPIKeysByUserID) Execute(ctx context.Context, p *GetAPIKeysByUserIDParams) ([]*row.APIKey, error) {
+	const query = `
+		SELECT id, user_id, name, prefix, key_hash, scopes, last_used_at, expires_at, created_at
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys by user id: %w", err)
+	}
+
+	keys := make([]*row.APIKey, 0)
+	for rows.Next() {
+		var r row.APIKey
+		if err := rows.Scan(
+			&r.ID,
+			&r.UserID,
+			&r.Name,
+			&r.Prefix,
+			&r.KeyHash,
+			pq.Array(&r.Scopes),
+			&r.LastUsedAt,
+			&r.ExpiresAt,
+			&r.CreatedAt,
+		); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get api keys by user id: %w", err)
+		}
+
+		keys = append(keys, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,36 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetEmailJobRecipientParams struct {
+	EmailJobID string
+}
+
+// GetEmailJobRecipientTx returns recipient of email, newsletter is missing for emails not sent on behalf of one.
+func GetEmailJobRecipientTx(ctx context.Context, tx *sql.Tx, p *GetEmailJobRecipientParams) (*row.EmailJobRecipient, error) {
+	const query = `
+		SELECT COALESCE(j.params->>'email', ''), n.public_id
+		FROM email_jobs j
+		LEFT JOIN newsletters n ON n.id = j.newsletter_id
+		WHERE j.id = $1;
+	`
+
+	var r row.EmailJobRecipient
+	if err := tx.QueryRowContext(ctx, query, p.EmailJobID).Scan(&r.Email, &r.NewsletterPublicID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.EmailJobNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get email job recipient: %w", err)
+	}
+
+	return &r, nil
+}
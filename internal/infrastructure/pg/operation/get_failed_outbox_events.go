@@ -0,0 +1,71 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetFailedOutboxEvents struct {
+	pgConn *sql.DB
+}
+
+type GetFailedOutboxEventsParams struct {
+	Limit int
+}
+
+func NewGetFailedOutboxEvents(pgConn *sql.DB) *GetFailedOutboxEvents {
+	return &GetFailedOutboxEvents{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns events given up by dispatcher, most recently failed first.
+func (o *GetFailedOutboxEvents) Execute(
+	ctx context.Context,
+	p *GetFailedOutboxEventsParams,
+) ([]*row.FailedOutboxEvent, error) {
+	const query = `
+		SELECT id, name, aggregate_key, newsletter_public_id, occurred_at, attempts, last_error, failed_at
+		FROM outbox
+		WHERE failed_at IS NOT NULL
+		ORDER BY failed_at DESC, id
+		LIMIT $1;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get failed outbox events: %w", err)
+	}
+
+	events := make([]*row.FailedOutboxEvent, 0)
+	for rows.Next() {
+		var r row.FailedOutboxEvent
+		if err := rows.Scan(
+			&r.ID,
+			&r.Name,
+			&r.AggregateKey,
+			&r.NewsletterPublicID,
+			&r.OccurredAt,
+			&r.Attempts,
+			&r.LastError,
+			&r.FailedAt,
+		); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get failed outbox events: %w", err)
+		}
+
+		events = append(events, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,53 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetInvitationByTokenHash struct {
+	pgConn *sql.DB
+}
+
+type GetInvitationByTokenHashParams struct {
+	TokenHash string
+}
+
+func NewGetInvitationByTokenHash(pgConn *sql.DB) *GetInvitationByTokenHash {
+	return &GetInvitationByTokenHash{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns pending invitation, accepted ones are treated as missing.
+func (o *GetInvitationByTokenHash) Execute(ctx context.Context, p *GetInvitationByTokenHashParams) (*row.Invitation, error) {
+	const query = `
+		SELECT i.id, n.public_id, n.name, i.email, i.role, i.invited_by, i.expires_at
+		FROM newsletter_invitations i
+		JOIN newsletters n ON n.id = i.newsletter_id
+		WHERE i.token_hash = $1 AND i.accepted_at IS NULL;
+	`
+	var r row.Invitation
+	if err := o.pgConn.QueryRowContext(ctx, query, p.TokenHash).Scan(
+		&r.ID,
+		&r.NewsletterPublicID,
+		&r.NewsletterName,
+		&r.Email,
+		&r.Role,
+		&r.InvitedBy,
+		&r.ExpiresAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.InvitationNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get invitation by token: %w", err)
+	}
+
+	return &r, nil
+}
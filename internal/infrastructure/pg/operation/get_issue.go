@@ -0,0 +1,57 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetIssue struct {
+	pgConn *sql.DB
+}
+
+type GetIssueParams struct {
+	ID                 string
+	NewsletterPublicID string
+}
+
+func NewGetIssue(pgConn *sql.DB) *GetIssue {
+	return &GetIssue{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetIssue) Execute(ctx context.Context, p *GetIssueParams) (*row.Issue, error) {
+	const query = `
+		SELECT i.id, n.public_id, i.slug, i.title, i.body_markdown, i.body_html, i.published_at, i.created_at, i.updated_at
+		FROM issues i
+		JOIN newsletters n ON n.id = i.newsletter_id
+		WHERE i.id = $1 AND n.public_id = $2;
+	`
+
+	var r row.Issue
+	err := o.pgConn.QueryRowContext(ctx, query, p.ID, p.NewsletterPublicID).Scan(
+		&r.ID,
+		&r.NewsletterPublicID,
+		&r.Slug,
+		&r.Title,
+		&r.Markdown,
+		&r.HTML,
+		&r.PublishedAt,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.IssueNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	return &r, nil
+}
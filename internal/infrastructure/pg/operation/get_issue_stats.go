@@ -0,0 +1,72 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetIssueStats struct {
+	pgConn *sql.DB
+}
+
+type GetIssueStatsParams struct {
+	NewsletterPublicID string
+	IssueID            string
+}
+
+func NewGetIssueStats(pgConn *sql.DB) *GetIssueStats {
+	return &GetIssueStats{
+		pgConn: pgConn,
+	}
+}
+
+// Execute counts sent emails of issue by their outcome, every number is count of emails, not of events. Unsubscribed
+// are recipients who left with this issue being the last one they received.
+func (o *GetIssueStats) Execute(ctx context.Context, p *GetIssueStatsParams) (*row.IssueStats, error) {
+	const query = `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM email_events e WHERE e.email_job_id = j.id AND e.event_type = 'DELIVERED'
+			)),
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM email_events e WHERE e.email_job_id = j.id AND e.event_type = 'BOUNCE'
+			)),
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM email_events e
+				WHERE e.email_job_id = j.id AND e.event_type = 'OPEN' AND e.read_at IS NOT NULL
+			)),
+			COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM email_clicks c WHERE c.email_job_id = j.id)),
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM subscriptions s
+				WHERE s.newsletter_id = j.newsletter_id AND s.token = j.params->>'subscription_token'
+					AND s.disabled_at >= j.updated_at
+					AND NOT EXISTS (
+						SELECT 1 FROM email_jobs l
+						WHERE l.newsletter_id = j.newsletter_id AND l.message_type = 'ISSUE' AND l.sent = true
+							AND l.params->>'subscription_token' = j.params->>'subscription_token'
+							AND l.updated_at > j.updated_at AND l.updated_at <= s.disabled_at
+					)
+			))
+		FROM email_jobs j
+		JOIN newsletters n ON n.id = j.newsletter_id
+		WHERE n.public_id = $1 AND j.message_type = 'ISSUE' AND j.sent = true AND j.params->>'issue_id' = $2;
+	`
+
+	var r row.IssueStats
+	if err := o.pgConn.QueryRowContext(ctx, query, p.NewsletterPublicID, p.IssueID).Scan(
+		&r.Sent,
+		&r.Delivered,
+		&r.Bounced,
+		&r.Opened,
+		&r.Clicked,
+		&r.Unsubscribed,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get issue stats: %w", err)
+	}
+
+	return &r, nil
+}
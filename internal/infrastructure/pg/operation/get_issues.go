@@ -0,0 +1,69 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetIssues struct {
+	pgConn *sql.DB
+}
+
+type GetIssuesParams struct {
+	NewsletterPublicID string
+}
+
+func NewGetIssues(pgConn *sql.DB) *GetIssues {
+	return &GetIssues{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns drafts and published issues of newsletter, newest first.
+func (o *GetIssues) Execute(ctx context.Context, p *GetIssuesParams) ([]*row.Issue, error) {
+	const query = `
+		SELECT i.id, n.public_id, i.slug, i.title, i.body_markdown, i.body_html, i.published_at, i.created_at, i.updated_at
+		FROM issues i
+		JOIN newsletters n ON n.id = i.newsletter_id
+		WHERE n.public_id = $1
+		ORDER BY i.created_at DESC;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+
+	issues := make([]*row.Issue, 0)
+	for rows.Next() {
+		var r row.Issue
+		if err := rows.Scan(
+			&r.ID,
+			&r.NewsletterPublicID,
+			&r.Slug,
+			&r.Title,
+			&r.Markdown,
+			&r.HTML,
+			&r.PublishedAt,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get issues: %w", err)
+		}
+
+		issues = append(issues, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return issues, nil
+}
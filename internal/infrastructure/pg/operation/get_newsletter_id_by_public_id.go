@@ -28,7 +28,7 @@ func NewGetNewsletterIDByPublicID(pgConn *sql.DB) *GetNewsletterIDByPublicID {
 }
 
 func (o *GetNewsletterIDByPublicID) Execute(ctx context.Context, p *GetNewsletterIDByPublicIDParams) (*NewsletterIDRow, error) {
-	const query = "SELECT id FROM newsletters WHERE public_id = $1;"
+	const query = "SELECT id FROM newsletters WHERE public_id = $1 AND archived_at IS NULL;"
 
 	var res NewsletterIDRow
 	if err := o.pgConn.QueryRowContext(ctx, query, p.PublicID).Scan(&res.ID); err != nil {
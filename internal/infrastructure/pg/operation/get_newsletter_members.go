@@ -0,0 +1,59 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetNewsletterMembers struct {
+	pgConn *sql.DB
+}
+
+type GetNewsletterMembersParams struct {
+	NewsletterPublicID string
+}
+
+func NewGetNewsletterMembers(pgConn *sql.DB) *GetNewsletterMembers {
+	return &GetNewsletterMembers{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetNewsletterMembers) Execute(ctx context.Context, p *GetNewsletterMembersParams) ([]*row.Member, error) {
+	const query = `
+		SELECT m.user_id, u.email, m.role, m.created_at
+		FROM newsletter_members m
+		JOIN newsletters n ON n.id = m.newsletter_id
+		JOIN users u ON u.id = m.user_id
+		WHERE n.public_id = $1
+		ORDER BY m.created_at;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get newsletter members: %w", err)
+	}
+
+	members := make([]*row.Member, 0)
+	for rows.Next() {
+		var r row.Member
+		if err := rows.Scan(&r.UserID, &r.Email, &r.Role, &r.CreatedAt); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get newsletter members: %w", err)
+		}
+
+		members = append(members, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return members, nil
+}
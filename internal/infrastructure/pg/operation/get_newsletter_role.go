@@ -0,0 +1,45 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type GetNewsletterRole struct {
+	pgConn *sql.DB
+}
+
+type GetNewsletterRoleParams struct {
+	NewsletterPublicID string
+	UserID             string
+}
+
+func NewGetNewsletterRole(pgConn *sql.DB) *GetNewsletterRole {
+	return &GetNewsletterRole{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns role of user in newsletter, non-members get same error as for missing newsletter.
+func (o *GetNewsletterRole) Execute(ctx context.Context, p *GetNewsletterRoleParams) (string, error) {
+	const query = `
+		SELECT m.role
+		FROM newsletter_members m
+		JOIN newsletters n ON n.id = m.newsletter_id
+		WHERE n.public_id = $1 AND m.user_id = $2;
+	`
+	var role string
+	if err := o.pgConn.QueryRowContext(ctx, query, p.NewsletterPublicID, p.UserID).Scan(&role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", application.NewsletterNotFoundError
+		}
+
+		return "", fmt.Errorf("failed to get newsletter role: %w", err)
+	}
+
+	return role, nil
+}
@@ -0,0 +1,54 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetNewsletterSender struct {
+	pgConn *sql.DB
+}
+
+type GetNewsletterSenderParams struct {
+	PublicID string
+}
+
+func NewGetNewsletterSender(pgConn *sql.DB) *GetNewsletterSender {
+	return &GetNewsletterSender{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns sender identity of newsletter, archived newsletters are included so their pending mail
+// keeps the identity.
+func (o *GetNewsletterSender) Execute(ctx context.Context, p *GetNewsletterSenderParams) (*row.NewsletterSender, error) {
+	return getNewsletterSender(o.pgConn.QueryRowContext(ctx, getNewsletterSenderQuery, p.PublicID))
+}
+
+func GetNewsletterSenderTx(ctx context.Context, tx *sql.Tx, p *GetNewsletterSenderParams) (*row.NewsletterSender, error) {
+	return getNewsletterSender(tx.QueryRowContext(ctx, getNewsletterSenderQuery, p.PublicID))
+}
+
+const getNewsletterSenderQuery = `
+	SELECT name, from_name, from_address, reply_to, postal_address
+	FROM newsletters
+	WHERE public_id = $1;
+`
+
+func getNewsletterSender(r *sql.Row) (*row.NewsletterSender, error) {
+	var res row.NewsletterSender
+	if err := r.Scan(&res.Name, &res.FromName, &res.FromAddress, &res.ReplyTo, &res.PostalAddress); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.NewsletterNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get newsletter sender: %w", err)
+	}
+
+	return &res, nil
+}
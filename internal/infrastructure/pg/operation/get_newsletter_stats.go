@@ -0,0 +1,90 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetNewsletterStats struct {
+	pgConn *sql.DB
+}
+
+type GetNewsletterStatsParams struct {
+	NewsletterPublicID string
+	Interval           string
+	From               time.Time
+	To                 time.Time
+}
+
+func NewGetNewsletterStats(pgConn *sql.DB) *GetNewsletterStats {
+	return &GetNewsletterStats{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns pre-aggregated statistics of period summed to buckets of interval, buckets without any event
+// are missing. Number of subscribers before start of period is returned as well.
+func (o *GetNewsletterStats) Execute(ctx context.Context, p *GetNewsletterStatsParams) ([]*row.StatsBucket, int, error) {
+	const (
+		baselineQuery = `
+			SELECT COALESCE(SUM(s.subscribed - s.unsubscribed), 0)
+			FROM newsletter_daily_stats s
+			JOIN newsletters n ON n.id = s.newsletter_id
+			WHERE n.public_id = $1 AND s.day < $2::date;
+		`
+		query = `
+			SELECT date_trunc($2, s.day::timestamp) AS bucket, SUM(s.subscribed), SUM(s.unsubscribed), SUM(s.sent),
+				SUM(s.delivered), SUM(s.bounced), SUM(s.opened), SUM(s.clicked)
+			FROM newsletter_daily_stats s
+			JOIN newsletters n ON n.id = s.newsletter_id
+			WHERE n.public_id = $1 AND s.day BETWEEN $3::date AND $4::date
+			GROUP BY bucket
+			ORDER BY bucket;
+		`
+	)
+
+	from, to := p.From.Format(time.DateOnly), p.To.Format(time.DateOnly)
+
+	var baseline int
+	if err := o.pgConn.QueryRowContext(ctx, baselineQuery, p.NewsletterPublicID, from).Scan(&baseline); err != nil {
+		return nil, 0, fmt.Errorf("failed to get subscribers before period: %w", err)
+	}
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.NewsletterPublicID, p.Interval, from, to)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get newsletter stats: %w", err)
+	}
+
+	buckets := make([]*row.StatsBucket, 0)
+	for rows.Next() {
+		var r row.StatsBucket
+		if err := rows.Scan(
+			&r.Start,
+			&r.Subscribed,
+			&r.Unsubscribed,
+			&r.Sent,
+			&r.Delivered,
+			&r.Bounced,
+			&r.Opened,
+			&r.Clicked,
+		); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, 0, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, 0, fmt.Errorf("failed to scan row on get newsletter stats: %w", err)
+		}
+
+		buckets = append(buckets, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, 0, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return buckets, baseline, nil
+}
@@ -0,0 +1,51 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetNewsletterTemplate struct {
+	pgConn *sql.DB
+}
+
+type GetNewsletterTemplateParams struct {
+	NewsletterPublicID string
+	Name               string
+}
+
+func NewGetNewsletterTemplate(pgConn *sql.DB) *GetNewsletterTemplate {
+	return &GetNewsletterTemplate{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetNewsletterTemplate) Execute(
+	ctx context.Context,
+	p *GetNewsletterTemplateParams,
+) (*row.NewsletterTemplate, error) {
+	const query = `
+		SELECT t.name, t.html, t.text, t.updated_at
+		FROM newsletter_templates t
+		JOIN newsletters n ON n.id = t.newsletter_id
+		WHERE n.public_id = $1 AND t.name = $2;
+	`
+
+	var res row.NewsletterTemplate
+	err := o.pgConn.QueryRowContext(ctx, query, p.NewsletterPublicID, p.Name).
+		Scan(&res.Name, &res.HTML, &res.Text, &res.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.TemplateNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get newsletter template: %w", err)
+	}
+
+	return &res, nil
+}
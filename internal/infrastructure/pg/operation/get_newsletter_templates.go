@@ -0,0 +1,61 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetNewsletterTemplates struct {
+	pgConn *sql.DB
+}
+
+type GetNewsletterTemplatesParams struct {
+	NewsletterPublicID string
+}
+
+func NewGetNewsletterTemplates(pgConn *sql.DB) *GetNewsletterTemplates {
+	return &GetNewsletterTemplates{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetNewsletterTemplates) Execute(
+	ctx context.Context,
+	p *GetNewsletterTemplatesParams,
+) ([]*row.NewsletterTemplate, error) {
+	const query = `
+		SELECT t.name, t.html, t.text, t.updated_at
+		FROM newsletter_templates t
+		JOIN newsletters n ON n.id = t.newsletter_id
+		WHERE n.public_id = $1
+		ORDER BY t.name;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get newsletter templates: %w", err)
+	}
+
+	templates := make([]*row.NewsletterTemplate, 0)
+	for rows.Next() {
+		var r row.NewsletterTemplate
+		if err := rows.Scan(&r.Name, &r.HTML, &r.Text, &r.UpdatedAt); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get newsletter templates: %w", err)
+		}
+
+		templates = append(templates, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return templates, nil
+}
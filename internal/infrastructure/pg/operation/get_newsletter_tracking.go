@@ -0,0 +1,40 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetNewsletterTracking struct {
+	pgConn *sql.DB
+}
+
+type GetNewsletterTrackingParams struct {
+	PublicID string
+}
+
+func NewGetNewsletterTracking(pgConn *sql.DB) *GetNewsletterTracking {
+	return &GetNewsletterTracking{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetNewsletterTracking) Execute(ctx context.Context, p *GetNewsletterTrackingParams) (*row.NewsletterTracking, error) {
+	const query = "SELECT track_opens, track_clicks FROM newsletters WHERE public_id = $1 AND archived_at IS NULL;"
+
+	var r row.NewsletterTracking
+	if err := o.pgConn.QueryRowContext(ctx, query, p.PublicID).Scan(&r.Opens, &r.Clicks); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.NewsletterNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get newsletter tracking: %w", err)
+	}
+
+	return &r, nil
+}
@@ -3,8 +3,10 @@ package operation
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/javor454/newsletter-assignment/internal/application"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
 )
 
@@ -28,7 +30,7 @@ func (o *GetNewslettersByPublicID) Execute(ctx context.Context, p *GetNewsletter
 	const query = `
 		SELECT id, public_id, name, description, created_at 
 		FROM newsletters
-		WHERE public_id = $1;
+		WHERE public_id = $1 AND archived_at IS NULL;
 	`
 
 	var newsRow row.Newsletter
@@ -39,6 +41,10 @@ func (o *GetNewslettersByPublicID) Execute(ctx context.Context, p *GetNewsletter
 		&newsRow.Description,
 		&newsRow.CreatedAt,
 	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.NewsletterNotFoundError
+		}
+
 		return nil, fmt.Errorf("failed to get newsletters by public id: %w", err)
 	}
 
@@ -0,0 +1,59 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+	"github.com/lib/pq"
+)
+
+type GetNewslettersByPublicIDs struct {
+	pgConn *sql.DB
+}
+
+type GetNewslettersByPublicIDsParams struct {
+	PublicIDs []string
+}
+
+func NewGetNewslettersByPublicIDs(pgConn *sql.DB) *GetNewslettersByPublicIDs {
+	return &GetNewslettersByPublicIDs{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns newsletters which are not archived ordered by public id, unknown ids are left out.
+func (o *GetNewslettersByPublicIDs) Execute(ctx context.Context, p *GetNewslettersByPublicIDsParams) ([]*row.Newsletter, error) {
+	const query = `
+		SELECT id, public_id, name, description, created_at
+		FROM newsletters
+		WHERE public_id = ANY($1::uuid[]) AND archived_at IS NULL
+		ORDER BY public_id;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, pq.Array(p.PublicIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get newsletters by public ids: %w", err)
+	}
+
+	newsletters := make([]*row.Newsletter, 0, len(p.PublicIDs))
+	for rows.Next() {
+		var r row.Newsletter
+		if err := rows.Scan(&r.ID, &r.PublicID, &r.Name, &r.Description, &r.CreatedAt); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get newsletters by public ids: %w", err)
+		}
+
+		newsletters = append(newsletters, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return newsletters, nil
+}
@@ -39,7 +39,7 @@ func (o *GetNewslettersBySubscriptionEmail) Execute(
 		SELECT n.id, n.public_id, n.name, n.description, n.created_at
 		FROM subscriptions s JOIN newsletters n ON n.id = s.newsletter_id
 		WHERE s.subscriber_email = $1 AND s.disabled_at IS NULL
-		ORDER BY n.id
+		ORDER BY n.public_id
 		LIMIT $2 OFFSET $3;
 	`
 
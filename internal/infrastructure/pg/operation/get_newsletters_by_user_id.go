@@ -31,14 +31,16 @@ func NewGetNewslettersByUserID(pgConn *sql.DB) *GetNewslettersByUserID {
 func (o *GetNewslettersByUserID) Execute(ctx context.Context, p *GetNewslettersByUserIDParams) ([]*row.Newsletter, *dto.Pagination, error) {
 	const countQuery = `
         SELECT COUNT(*) 
-        FROM newsletters
-        WHERE user_id = $1;
+        FROM newsletter_members m
+        JOIN newsletters n ON n.id = m.newsletter_id
+        WHERE m.user_id = $1 AND n.archived_at IS NULL;
     `
 	const query = `
-		SELECT id, public_id, name, description, created_at 
-		FROM newsletters
-		WHERE user_id = $1
-		ORDER BY id
+		SELECT n.id, n.public_id, n.name, n.description, n.created_at 
+		FROM newsletters n
+		JOIN newsletter_members m ON m.newsletter_id = n.id
+		WHERE m.user_id = $1 AND n.archived_at IS NULL
+		ORDER BY n.id
 		LIMIT $2 OFFSET $3;
 	`
 
@@ -0,0 +1,58 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetPublishedIssue struct {
+	pgConn *sql.DB
+}
+
+type GetPublishedIssueParams struct {
+	NewsletterPublicID string
+	Slug               string
+}
+
+func NewGetPublishedIssue(pgConn *sql.DB) *GetPublishedIssue {
+	return &GetPublishedIssue{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns published issue of active newsletter by slug, drafts are not found.
+func (o *GetPublishedIssue) Execute(ctx context.Context, p *GetPublishedIssueParams) (*row.Issue, error) {
+	const query = `
+		SELECT i.id, n.public_id, i.slug, i.title, i.body_markdown, i.body_html, i.published_at, i.created_at, i.updated_at
+		FROM issues i
+		JOIN newsletters n ON n.id = i.newsletter_id
+		WHERE n.public_id = $1 AND i.slug = $2 AND n.archived_at IS NULL AND i.published_at IS NOT NULL;
+	`
+
+	var r row.Issue
+	err := o.pgConn.QueryRowContext(ctx, query, p.NewsletterPublicID, p.Slug).Scan(
+		&r.ID,
+		&r.NewsletterPublicID,
+		&r.Slug,
+		&r.Title,
+		&r.Markdown,
+		&r.HTML,
+		&r.PublishedAt,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.IssueNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get published issue: %w", err)
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,95 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetPublishedIssues struct {
+	pgConn *sql.DB
+}
+
+type GetPublishedIssuesParams struct {
+	NewsletterPublicID string
+	PageSize           int
+	PageNumber         int
+}
+
+func NewGetPublishedIssues(pgConn *sql.DB) *GetPublishedIssues {
+	return &GetPublishedIssues{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns page of published issues of active newsletter, newest first, with time of latest change
+// of any published issue (nil when nothing is published), publication of new issue shifts every page.
+func (o *GetPublishedIssues) Execute(
+	ctx context.Context,
+	p *GetPublishedIssuesParams,
+) ([]*row.Issue, *dto.Pagination, *time.Time, error) {
+	const countQuery = `
+		SELECT COUNT(*), MAX(GREATEST(i.published_at, i.updated_at))
+		FROM issues i
+		JOIN newsletters n ON n.id = i.newsletter_id
+		WHERE n.public_id = $1 AND n.archived_at IS NULL AND i.published_at IS NOT NULL;
+	`
+	const query = `
+		SELECT i.id, n.public_id, i.slug, i.title, i.body_markdown, i.body_html, i.published_at, i.created_at, i.updated_at
+		FROM issues i
+		JOIN newsletters n ON n.id = i.newsletter_id
+		WHERE n.public_id = $1 AND n.archived_at IS NULL AND i.published_at IS NOT NULL
+		ORDER BY i.published_at DESC, i.id
+		LIMIT $2 OFFSET $3;
+	`
+
+	var totalItems int
+	var lastModified *time.Time
+	if err := o.pgConn.QueryRowContext(ctx, countQuery, p.NewsletterPublicID).Scan(&totalItems, &lastModified); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	totalPages := int(math.Ceil(float64(totalItems) / float64(p.PageSize)))
+
+	offset := (p.PageNumber - 1) * p.PageSize
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.NewsletterPublicID, p.PageSize, offset)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get published issues: %w", err)
+	}
+
+	issues := make([]*row.Issue, 0, p.PageSize)
+	for rows.Next() {
+		var r row.Issue
+		if err := rows.Scan(
+			&r.ID,
+			&r.NewsletterPublicID,
+			&r.Slug,
+			&r.Title,
+			&r.Markdown,
+			&r.HTML,
+			&r.PublishedAt,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, nil, nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, nil, nil, fmt.Errorf("failed to scan row on get published issues: %w", err)
+		}
+
+		issues = append(issues, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return issues, dto.NewPagination(p.PageNumber, p.PageSize, totalPages, totalItems), lastModified, nil
+}
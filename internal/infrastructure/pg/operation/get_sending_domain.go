@@ -0,0 +1,71 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetSendingDomain struct {
+	pgConn *sql.DB
+}
+
+type GetSendingDomainParams struct {
+	ID     string
+	UserID string
+}
+
+func NewGetSendingDomain(pgConn *sql.DB) *GetSendingDomain {
+	return &GetSendingDomain{
+		pgConn: pgConn,
+	}
+}
+
+const sendingDomainColumns = `
+	id, user_id, domain, verification_token, dkim_selector, dkim_private_key, dkim_public_key,
+	ownership_verified, spf_verified, dkim_verified, dmarc_verified, verified_at, checked_at, created_at
+`
+
+// Execute returns domain of user, domains of other users are reported as not found.
+func (o *GetSendingDomain) Execute(ctx context.Context, p *GetSendingDomainParams) (*row.SendingDomain, error) {
+	query := `SELECT ` + sendingDomainColumns + ` FROM sending_domains WHERE id = $1 AND user_id = $2;`
+
+	r, err := scanSendingDomain(o.pgConn.QueryRowContext(ctx, query, p.ID, p.UserID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.SendingDomainNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get sending domain: %w", err)
+	}
+
+	return r, nil
+}
+
+func scanSendingDomain(s interface{ Scan(dest ...any) error }) (*row.SendingDomain, error) {
+	var r row.SendingDomain
+	if err := s.Scan(
+		&r.ID,
+		&r.UserID,
+		&r.Domain,
+		&r.VerificationToken,
+		&r.DKIMSelector,
+		&r.DKIMPrivateKey,
+		&r.DKIMPublicKey,
+		&r.OwnershipVerified,
+		&r.SPFVerified,
+		&r.DKIMVerified,
+		&r.DMARCVerified,
+		&r.VerifiedAt,
+		&r.CheckedAt,
+		&r.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
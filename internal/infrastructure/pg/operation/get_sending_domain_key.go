@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type GetSendingDomainKey struct {
+	pgConn *sql.DB
+}
+
+type GetSendingDomainKeyParams struct {
+	Domain string
+}
+
+func NewGetSendingDomainKey(pgConn *sql.DB) *GetSendingDomainKey {
+	return &GetSendingDomainKey{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns DKIM selector and private key of verified domain, key confirmed by the latest check wins
+// when more users verified the same domain.
+func (o *GetSendingDomainKey) Execute(ctx context.Context, p *GetSendingDomainKeyParams) (string, string, error) {
+	const query = `
+		SELECT dkim_selector, dkim_private_key
+		FROM sending_domains
+		WHERE domain = $1 AND verified_at IS NOT NULL
+		ORDER BY checked_at DESC
+		LIMIT 1;
+	`
+
+	var selector, key string
+	if err := o.pgConn.QueryRowContext(ctx, query, p.Domain).Scan(&selector, &key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", application.SendingDomainNotFoundError
+		}
+
+		return "", "", fmt.Errorf("failed to get sending domain key: %w", err)
+	}
+
+	return selector, key, nil
+}
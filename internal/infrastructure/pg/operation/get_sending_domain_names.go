@@ -0,0 +1,41 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type GetSendingDomainNamesParams struct {
+	UserID string
+}
+
+// GetSendingDomainNamesTx returns names of all domains of user, verified or not.
+func GetSendingDomainNamesTx(ctx context.Context, tx *sql.Tx, p *GetSendingDomainNamesParams) ([]string, error) {
+	const query = "SELECT domain FROM sending_domains WHERE user_id = $1;"
+
+	rows, err := tx.QueryContext(ctx, query, p.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sending domain names: %w", err)
+	}
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get sending domain names: %w", err)
+		}
+
+		names = append(names, name)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return names, nil
+}
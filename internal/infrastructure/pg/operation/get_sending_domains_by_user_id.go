@@ -0,0 +1,55 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetSendingDomainsByUserID struct {
+	pgConn *sql.DB
+}
+
+type GetSendingDomainsByUserIDParams struct {
+	UserID string
+}
+
+func NewGetSendingDomainsByUserID(pgConn *sql.DB) *GetSendingDomainsByUserID {
+	return &GetSendingDomainsByUserID{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetSendingDomainsByUserID) Execute(
+	ctx context.Context,
+	p *GetSendingDomainsByUserIDParams,
+) ([]*row.SendingDomain, error) {
+	query := `SELECT ` + sendingDomainColumns + ` FROM sending_domains WHERE user_id = $1 ORDER BY domain;`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sending domains by user id: %w", err)
+	}
+
+	domains := make([]*row.SendingDomain, 0)
+	for rows.Next() {
+		r, err := scanSendingDomain(rows)
+		if err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get sending domains by user id: %w", err)
+		}
+
+		domains = append(domains, r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return domains, nil
+}
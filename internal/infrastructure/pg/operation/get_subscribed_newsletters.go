@@ -0,0 +1,54 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetSubscribedNewsletters struct {
+	pgConn *sql.DB
+}
+
+func NewGetSubscribedNewsletters(pgConn *sql.DB) *GetSubscribedNewsletters {
+	return &GetSubscribedNewsletters{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns every active subscription of all newsletters.
+func (o *GetSubscribedNewsletters) Execute(ctx context.Context) ([]*row.SubscribedNewsletter, error) {
+	const query = `
+		SELECT s.subscriber_email, n.public_id
+		FROM subscriptions s
+		JOIN newsletters n ON n.id = s.newsletter_id
+		WHERE s.disabled_at IS NULL;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscribed newsletters: %w", err)
+	}
+
+	subscriptions := make([]*row.SubscribedNewsletter, 0)
+	for rows.Next() {
+		var r row.SubscribedNewsletter
+		if err := rows.Scan(&r.Email, &r.NewsletterPublicID); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get subscribed newsletters: %w", err)
+		}
+
+		subscriptions = append(subscriptions, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return subscriptions, nil
+}
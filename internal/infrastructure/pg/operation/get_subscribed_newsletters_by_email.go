@@ -0,0 +1,59 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type GetSubscribedNewslettersByEmail struct {
+	pgConn *sql.DB
+}
+
+type GetSubscribedNewslettersByEmailParams struct {
+	Email string
+}
+
+func NewGetSubscribedNewslettersByEmail(pgConn *sql.DB) *GetSubscribedNewslettersByEmail {
+	return &GetSubscribedNewslettersByEmail{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns public ids of all newsletters subscriber is actively subscribed to.
+func (o *GetSubscribedNewslettersByEmail) Execute(
+	ctx context.Context,
+	p *GetSubscribedNewslettersByEmailParams,
+) ([]string, error) {
+	const query = `
+		SELECT n.public_id
+		FROM subscriptions s
+		JOIN newsletters n ON n.id = s.newsletter_id
+		WHERE s.subscriber_email = $1 AND s.disabled_at IS NULL;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscribed newsletters by email: %w", err)
+	}
+
+	publicIDs := make([]string, 0)
+	for rows.Next() {
+		var publicID string
+		if err := rows.Scan(&publicID); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get subscribed newsletters by email: %w", err)
+		}
+
+		publicIDs = append(publicIDs, publicID)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return publicIDs, nil
+}
@@ -0,0 +1,46 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type GetSubscriptionLocale struct {
+	pgConn *sql.DB
+}
+
+type GetSubscriptionLocaleParams struct {
+	NewsletterPublicID string
+	Email              string
+}
+
+func NewGetSubscriptionLocale(pgConn *sql.DB) *GetSubscriptionLocale {
+	return &GetSubscriptionLocale{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns preferred locale of active subscriber of newsletter.
+func (o *GetSubscriptionLocale) Execute(ctx context.Context, p *GetSubscriptionLocaleParams) (string, error) {
+	const query = `
+		SELECT s.locale
+		FROM subscriptions s
+		JOIN newsletters n ON n.id = s.newsletter_id
+		WHERE n.public_id = $1 AND s.subscriber_email = $2 AND s.disabled_at IS NULL;
+	`
+
+	var locale string
+	if err := o.pgConn.QueryRowContext(ctx, query, p.NewsletterPublicID, p.Email).Scan(&locale); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", application.SubscriptionNotFoundError
+		}
+
+		return "", fmt.Errorf("failed to get subscription locale: %w", err)
+	}
+
+	return locale, nil
+}
@@ -8,32 +8,32 @@ import (
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
 )
 
-// TODO (nice2have): make this generic for all message types
-
-type GetUnsentSubscribedEmailJobsOperation struct {
+type GetUnsentEmailJobs struct {
 	pgConn *sql.DB
 }
 
-func NewGetUnsentSubscribedEmailJobsOperation(pgConn *sql.DB) *GetUnsentSubscribedEmailJobsOperation {
-	return &GetUnsentSubscribedEmailJobsOperation{
+func NewGetUnsentEmailJobs(pgConn *sql.DB) *GetUnsentEmailJobs {
+	return &GetUnsentEmailJobs{
 		pgConn: pgConn,
 	}
 }
 
-func (o *GetUnsentSubscribedEmailJobsOperation) Execute(ctx context.Context, maxJobs int) ([]*row.EmailJob, error) {
-	rows, err := o.pgConn.QueryContext(
-		ctx,
-		fmt.Sprintf(
-			"SELECT id, message_type, params FROM email_jobs WHERE sent = FALSE AND message_type = '%s' LIMIT %d;",
-			row.SubscriptionType,
-			maxJobs,
-		),
-	)
+// Execute returns oldest unsent jobs of all message types.
+func (o *GetUnsentEmailJobs) Execute(ctx context.Context, maxJobs int) ([]*row.EmailJob, error) {
+	const query = `
+		SELECT id, message_type, params
+		FROM email_jobs
+		WHERE sent = FALSE
+		ORDER BY created_at
+		LIMIT $1;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, maxJobs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get unsent email jobs: %w", err)
 	}
 
-	jobs := make([]*row.EmailJob, 0, 100)
+	jobs := make([]*row.EmailJob, 0, maxJobs)
 
 	for rows.Next() {
 		var r row.EmailJob
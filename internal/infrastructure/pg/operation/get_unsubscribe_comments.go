@@ -0,0 +1,92 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetUnsubscribeComments struct {
+	pgConn *sql.DB
+}
+
+type GetUnsubscribeCommentsParams struct {
+	NewsletterPublicID string
+	From               time.Time
+	To                 time.Time
+	PageSize           int
+	PageNumber         int
+}
+
+func NewGetUnsubscribeComments(pgConn *sql.DB) *GetUnsubscribeComments {
+	return &GetUnsubscribeComments{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns page of free text reasons of subscriptions disabled between days in UTC, newest first. Email of
+// subscriber is not returned.
+func (o *GetUnsubscribeComments) Execute(
+	ctx context.Context,
+	p *GetUnsubscribeCommentsParams,
+) ([]*row.UnsubscribeComment, *dto.Pagination, error) {
+	const countQuery = `
+		SELECT COUNT(*)
+		FROM subscriptions s
+		JOIN newsletters n ON n.id = s.newsletter_id
+		WHERE n.public_id = $1 AND s.unsubscribe_comment IS NOT NULL
+			AND s.disabled_at >= $2::date AT TIME ZONE 'UTC'
+			AND s.disabled_at < ($3::date + 1) AT TIME ZONE 'UTC';
+	`
+	const query = `
+		SELECT s.unsubscribe_reason, s.unsubscribe_comment, s.disabled_at
+		FROM subscriptions s
+		JOIN newsletters n ON n.id = s.newsletter_id
+		WHERE n.public_id = $1 AND s.unsubscribe_comment IS NOT NULL
+			AND s.disabled_at >= $2::date AT TIME ZONE 'UTC'
+			AND s.disabled_at < ($3::date + 1) AT TIME ZONE 'UTC'
+		ORDER BY s.disabled_at DESC, s.id
+		LIMIT $4 OFFSET $5;
+	`
+
+	from, to := p.From.Format(time.DateOnly), p.To.Format(time.DateOnly)
+
+	var totalItems int
+	if err := o.pgConn.QueryRowContext(ctx, countQuery, p.NewsletterPublicID, from, to).Scan(&totalItems); err != nil {
+		return nil, nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	totalPages := int(math.Ceil(float64(totalItems) / float64(p.PageSize)))
+
+	offset := (p.PageNumber - 1) * p.PageSize
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.NewsletterPublicID, from, to, p.PageSize, offset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get unsubscribe comments: %w", err)
+	}
+
+	comments := make([]*row.UnsubscribeComment, 0, p.PageSize)
+	for rows.Next() {
+		var r row.UnsubscribeComment
+		if err := rows.Scan(&r.Reason, &r.Comment, &r.UnsubscribedAt); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, nil, fmt.Errorf("failed to scan row on get unsubscribe comments: %w", err)
+		}
+
+		comments = append(comments, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return comments, dto.NewPagination(p.PageNumber, p.PageSize, totalPages, totalItems), nil
+}
@@ -0,0 +1,69 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type GetUnsubscribeReasons struct {
+	pgConn *sql.DB
+}
+
+type GetUnsubscribeReasonsParams struct {
+	NewsletterPublicID string
+	From               time.Time
+	To                 time.Time
+}
+
+func NewGetUnsubscribeReasons(pgConn *sql.DB) *GetUnsubscribeReasons {
+	return &GetUnsubscribeReasons{
+		pgConn: pgConn,
+	}
+}
+
+// Execute counts reasons of subscriptions disabled between days in UTC, both are included. Codes nobody chose
+// are missing.
+func (o *GetUnsubscribeReasons) Execute(ctx context.Context, p *GetUnsubscribeReasonsParams) (map[string]int, error) {
+	const query = `
+		SELECT s.unsubscribe_reason, COUNT(*)
+		FROM subscriptions s
+		JOIN newsletters n ON n.id = s.newsletter_id
+		WHERE n.public_id = $1 AND s.unsubscribe_reason IS NOT NULL
+			AND s.disabled_at >= $2::date AT TIME ZONE 'UTC'
+			AND s.disabled_at < ($3::date + 1) AT TIME ZONE 'UTC'
+		GROUP BY s.unsubscribe_reason;
+	`
+
+	rows, err := o.pgConn.QueryContext(
+		ctx,
+		query,
+		p.NewsletterPublicID,
+		p.From.Format(time.DateOnly),
+		p.To.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unsubscribe reasons: %w", err)
+	}
+
+	reasons := make(map[string]int)
+	for rows.Next() {
+		var reason string
+		var count int
+		if err := rows.Scan(&reason, &count); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get unsubscribe reasons: %w", err)
+		}
+		reasons[reason] = count
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return reasons, nil
+}
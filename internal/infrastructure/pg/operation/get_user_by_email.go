@@ -19,7 +19,9 @@ type GetUserByEmailParams struct {
 
 type UserRow struct {
 	ID           string
+	Email        string
 	PasswordHash string
+	TOTPEnabled  bool
 }
 
 func NewGetUserByEmail(pgConn *sql.DB) *GetUserByEmail {
@@ -30,12 +32,17 @@ func NewGetUserByEmail(pgConn *sql.DB) *GetUserByEmail {
 
 func (o *GetUserByEmail) Execute(ctx context.Context, p *GetUserByEmailParams) (*UserRow, error) {
 	const query = `
-		SELECT id, password_hash
+		SELECT id, email, password_hash, totp_enabled_at IS NOT NULL
 		FROM users
 		WHERE email = $1;
 	`
 	var res UserRow
-	if err := o.pgConn.QueryRowContext(ctx, query, p.Email).Scan(&res.ID, &res.PasswordHash); err != nil {
+	if err := o.pgConn.QueryRowContext(ctx, query, p.Email).Scan(
+		&res.ID,
+		&res.Email,
+		&res.PasswordHash,
+		&res.TOTPEnabled,
+	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, application.UserNotFoundError
 		}
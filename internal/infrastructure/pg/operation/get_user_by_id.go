@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type GetUserByID struct {
+	pgConn *sql.DB
+}
+
+type GetUserByIDParams struct {
+	ID string
+}
+
+func NewGetUserByID(pgConn *sql.DB) *GetUserByID {
+	return &GetUserByID{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetUserByID) Execute(ctx context.Context, p *GetUserByIDParams) (*UserRow, error) {
+	const query = `
+		SELECT id, email, password_hash, totp_enabled_at IS NOT NULL
+		FROM users
+		WHERE id = $1;
+	`
+	var res UserRow
+	if err := o.pgConn.QueryRowContext(ctx, query, p.ID).Scan(
+		&res.ID,
+		&res.Email,
+		&res.PasswordHash,
+		&res.TOTPEnabled,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.UserNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get user by id: %w", err)
+	}
+
+	return &res, nil
+}
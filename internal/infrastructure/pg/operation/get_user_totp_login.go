@@ -0,0 +1,49 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type GetUserTOTPLogin struct {
+	pgConn *sql.DB
+}
+
+type GetUserTOTPLoginParams struct {
+	UserID string
+}
+
+type UserTOTPLoginRow struct {
+	ChallengeID *string
+	Locked      bool
+}
+
+func NewGetUserTOTPLogin(pgConn *sql.DB) *GetUserTOTPLogin {
+	return &GetUserTOTPLogin{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns id of pending challenge and whether totp login is locked after too many failed attempts.
+func (o *GetUserTOTPLogin) Execute(ctx context.Context, p *GetUserTOTPLoginParams) (*UserTOTPLoginRow, error) {
+	const query = `
+		SELECT totp_challenge_id, COALESCE(totp_locked_until > CURRENT_TIMESTAMP, false)
+		FROM users
+		WHERE id = $1;
+	`
+
+	var res UserTOTPLoginRow
+	if err := o.pgConn.QueryRowContext(ctx, query, p.UserID).Scan(&res.ChallengeID, &res.Locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.UserNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get totp login: %w", err)
+	}
+
+	return &res, nil
+}
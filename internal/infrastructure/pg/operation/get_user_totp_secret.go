@@ -0,0 +1,48 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type GetUserTOTPSecret struct {
+	pgConn *sql.DB
+}
+
+type GetUserTOTPSecretParams struct {
+	UserID string
+}
+
+type UserTOTPSecretRow struct {
+	Secret  *string
+	Enabled bool
+}
+
+func NewGetUserTOTPSecret(pgConn *sql.DB) *GetUserTOTPSecret {
+	return &GetUserTOTPSecret{
+		pgConn: pgConn,
+	}
+}
+
+func (o *GetUserTOTPSecret) Execute(ctx context.Context, p *GetUserTOTPSecretParams) (*UserTOTPSecretRow, error) {
+	const query = `
+		SELECT totp_secret, totp_enabled_at IS NOT NULL
+		FROM users
+		WHERE id = $1;
+	`
+
+	var res UserTOTPSecretRow
+	if err := o.pgConn.QueryRowContext(ctx, query, p.UserID).Scan(&res.Secret, &res.Enabled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.UserNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get totp secret: %w", err)
+	}
+
+	return &res, nil
+}
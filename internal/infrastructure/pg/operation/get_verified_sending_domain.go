@@ -0,0 +1,39 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type GetVerifiedSendingDomain struct {
+	pgConn *sql.DB
+}
+
+type GetVerifiedSendingDomainParams struct {
+	UserID string
+	Domain string
+}
+
+func NewGetVerifiedSendingDomain(pgConn *sql.DB) *GetVerifiedSendingDomain {
+	return &GetVerifiedSendingDomain{
+		pgConn: pgConn,
+	}
+}
+
+// Execute reports whether user has verified the domain.
+func (o *GetVerifiedSendingDomain) Execute(ctx context.Context, p *GetVerifiedSendingDomainParams) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1 FROM sending_domains
+			WHERE user_id = $1 AND domain = $2 AND verified_at IS NOT NULL
+		);
+	`
+
+	var exists bool
+	if err := o.pgConn.QueryRowContext(ctx, query, p.UserID, p.Domain).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to get verified sending domain: %w", err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetWebhook struct {
+	pgConn *sql.DB
+}
+
+type GetWebhookParams struct {
+	ID                 string
+	NewsletterPublicID string
+}
+
+func NewGetWebhook(pgConn *sql.DB) *GetWebhook {
+	return &GetWebhook{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns webhook of newsletter, webhooks of other newsletters are reported as not found.
+func (o *GetWebhook) Execute(ctx context.Context, p *GetWebhookParams) (*row.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks w
+		JOIN newsletters n ON n.id = w.newsletter_id
+		WHERE w.id = $1 AND n.public_id = $2;
+	`
+
+	r, err := scanWebhook(o.pgConn.QueryRowContext(ctx, query, p.ID, p.NewsletterPublicID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, application.WebhookNotFoundError
+		}
+
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,89 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type GetWebhookDeliveries struct {
+	pgConn *sql.DB
+}
+
+type GetWebhookDeliveriesParams struct {
+	WebhookID  string
+	PageSize   int
+	PageNumber int
+}
+
+func NewGetWebhookDeliveries(pgConn *sql.DB) *GetWebhookDeliveries {
+	return &GetWebhookDeliveries{
+		pgConn: pgConn,
+	}
+}
+
+// Execute returns page of delivery log of webhook, newest first.
+func (o *GetWebhookDeliveries) Execute(
+	ctx context.Context,
+	p *GetWebhookDeliveriesParams,
+) ([]*row.WebhookDelivery, *dto.Pagination, error) {
+	const countQuery = `SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = $1;`
+	const query = `
+		SELECT id, event_id, event_type, attempts, last_status_code, last_error, next_attempt_at, last_attempt_at,
+			delivered_at, failed_at, created_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3;
+	`
+
+	var totalItems int
+	if err := o.pgConn.QueryRowContext(ctx, countQuery, p.WebhookID).Scan(&totalItems); err != nil {
+		return nil, nil, fmt.Errorf("failed to get total count: %w", err)
+	}
+
+	totalPages := int(math.Ceil(float64(totalItems) / float64(p.PageSize)))
+
+	offset := (p.PageNumber - 1) * p.PageSize
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.WebhookID, p.PageSize, offset)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*row.WebhookDelivery, 0, p.PageSize)
+	for rows.Next() {
+		var r row.WebhookDelivery
+		if err := rows.Scan(
+			&r.ID,
+			&r.EventID,
+			&r.EventType,
+			&r.Attempts,
+			&r.LastStatusCode,
+			&r.LastError,
+			&r.NextAttemptAt,
+			&r.LastAttemptAt,
+			&r.DeliveredAt,
+			&r.FailedAt,
+			&r.CreatedAt,
+		); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, nil, fmt.Errorf("failed to scan row on get webhook deliveries: %w", err)
+		}
+
+		deliveries = append(deliveries, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return deliveries, dto.NewPagination(p.PageNumber, p.PageSize, totalPages, totalItems), nil
+}
@@ -0,0 +1,69 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+	"github.com/lib/pq"
+)
+
+type GetWebhooks struct {
+	pgConn *sql.DB
+}
+
+type GetWebhooksParams struct {
+	NewsletterPublicID string
+}
+
+func NewGetWebhooks(pgConn *sql.DB) *GetWebhooks {
+	return &GetWebhooks{
+		pgConn: pgConn,
+	}
+}
+
+const webhookColumns = `w.id, n.public_id, w.url, w.secret, w.event_types, w.created_at`
+
+func (o *GetWebhooks) Execute(ctx context.Context, p *GetWebhooksParams) ([]*row.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks w
+		JOIN newsletters n ON n.id = w.newsletter_id
+		WHERE n.public_id = $1
+		ORDER BY w.created_at;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	webhooks := make([]*row.Webhook, 0)
+	for rows.Next() {
+		r, err := scanWebhook(rows)
+		if err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on get webhooks: %w", err)
+		}
+		webhooks = append(webhooks, r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func scanWebhook(s interface{ Scan(dest ...any) error }) (*row.Webhook, error) {
+	var r row.Webhook
+	if err := s.Scan(&r.ID, &r.NewsletterPublicID, &r.URL, &r.Secret, pq.Array(&r.EventTypes), &r.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
@@ -0,0 +1,35 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateAcceptInvitationParams struct {
+	ID string
+}
+
+func UpdateAcceptInvitationTx(ctx context.Context, tx *sql.Tx, p *UpdateAcceptInvitationParams) error {
+	const query = `
+		UPDATE newsletter_invitations SET accepted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND accepted_at IS NULL;
+	`
+
+	res, err := tx.ExecContext(ctx, query, p.ID)
+	if err != nil {
+		return fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.InvitationNotFoundError
+	}
+
+	return nil
+}
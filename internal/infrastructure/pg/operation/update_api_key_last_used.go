@@ -0,0 +1,31 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type UpdateAPIKeyLastUsed struct {
+	pgConn *sql.DB
+}
+
+type UpdateAPIKeyLastUsedParams struct {
+	ID string
+}
+
+func NewUpdateAPIKeyLastUsed(pgConn *sql.DB) *UpdateAPIKeyLastUsed {
+	return &UpdateAPIKeyLastUsed{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateAPIKeyLastUsed) Execute(ctx context.Context, p *UpdateAPIKeyLastUsedParams) error {
+	const query = "UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1;"
+
+	if _, err := o.pgConn.ExecContext(ctx, query, p.ID); err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+
+	return nil
+}
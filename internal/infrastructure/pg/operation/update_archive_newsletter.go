@@ -0,0 +1,73 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateArchiveNewsletterParams struct {
+	PublicID string
+}
+
+// UpdateArchiveNewsletterTx archives newsletter, pending email jobs are cancelled and active subscriptions disabled.
+// Name of newsletter and emails of disabled subscriptions are returned.
+func UpdateArchiveNewsletterTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	p *UpdateArchiveNewsletterParams,
+) (string, []string, error) {
+	const (
+		archiveQuery = `
+			UPDATE newsletters SET archived_at = CURRENT_TIMESTAMP
+			WHERE public_id = $1 AND archived_at IS NULL
+			RETURNING id, name;
+		`
+		cancelQuery  = "DELETE FROM email_jobs WHERE newsletter_id = $1 AND sent = false;"
+		disableQuery = `
+			UPDATE subscriptions SET disabled_at = CURRENT_TIMESTAMP
+			WHERE newsletter_id = $1 AND disabled_at IS NULL
+			RETURNING subscriber_email;
+		`
+	)
+
+	var newsletterID, name string
+	if err := tx.QueryRowContext(ctx, archiveQuery, p.PublicID).Scan(&newsletterID, &name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, application.NewsletterNotFoundError
+		}
+
+		return "", nil, fmt.Errorf("failed to archive newsletter: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, cancelQuery, newsletterID); err != nil {
+		return "", nil, fmt.Errorf("failed to cancel pending email jobs: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, disableQuery, newsletterID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to disable subscriptions: %w", err)
+	}
+
+	emails := make([]string, 0)
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			if err := rows.Close(); err != nil {
+				return "", nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return "", nil, fmt.Errorf("failed to scan row on disable subscriptions: %w", err)
+		}
+		emails = append(emails, email)
+	}
+
+	if err := rows.Close(); err != nil {
+		return "", nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return name, emails, nil
+}
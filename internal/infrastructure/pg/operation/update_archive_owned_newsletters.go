@@ -0,0 +1,62 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type UpdateArchiveOwnedNewslettersParams struct {
+	UserID string
+}
+
+// UpdateArchiveOwnedNewslettersTx archives newsletters still owned by user, cancels their pending email jobs
+// and disables their subscriptions, returns subscriptions which were disabled.
+func UpdateArchiveOwnedNewslettersTx(
+	ctx context.Context,
+	tx *sql.Tx,
+	p *UpdateArchiveOwnedNewslettersParams,
+) ([]*row.Subscription, error) {
+	const query = `
+		WITH archived AS (
+			UPDATE newsletters SET user_id = NULL, archived_at = CURRENT_TIMESTAMP
+			WHERE user_id = $1
+			RETURNING id, public_id
+		), cancelled AS (
+			DELETE FROM email_jobs j
+			USING archived
+			WHERE j.newsletter_id = archived.id AND j.sent = false
+		)
+		UPDATE subscriptions s SET disabled_at = CURRENT_TIMESTAMP
+		FROM archived
+		WHERE s.newsletter_id = archived.id AND s.disabled_at IS NULL
+		RETURNING s.subscriber_email, archived.public_id;
+	`
+
+	rows, err := tx.QueryContext(ctx, query, p.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive owned newsletters: %w", err)
+	}
+
+	subscriptions := make([]*row.Subscription, 0)
+	for rows.Next() {
+		var r row.Subscription
+		if err := rows.Scan(&r.Email, &r.NewsletterPublicID); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on archive owned newsletters: %w", err)
+		}
+
+		subscriptions = append(subscriptions, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return subscriptions, nil
+}
@@ -0,0 +1,81 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type UpdateClaimOutboxEvents struct {
+	pgConn *sql.DB
+}
+
+type UpdateClaimOutboxEventsParams struct {
+	Limit int
+	// Lease postpones claimed events, they are dispatched again when process dies before recording result
+	Lease time.Duration
+}
+
+func NewUpdateClaimOutboxEvents(pgConn *sql.DB) *UpdateClaimOutboxEvents {
+	return &UpdateClaimOutboxEvents{
+		pgConn: pgConn,
+	}
+}
+
+// Execute claims due events in order they occurred, concurrent dispatchers skip rows claimed by each other. Event
+// waits while earlier event of its aggregate is not dispatched, so at most one event per aggregate is claimed and
+// failed event holds back later ones until it succeeds or is given up.
+func (o *UpdateClaimOutboxEvents) Execute(
+	ctx context.Context,
+	p *UpdateClaimOutboxEventsParams,
+) ([]*row.OutboxEvent, error) {
+	const query = `
+		WITH claimed AS (
+			UPDATE outbox
+			SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+			WHERE id IN (
+				SELECT o.id FROM outbox o
+				WHERE o.dispatched_at IS NULL AND o.failed_at IS NULL AND o.next_attempt_at <= CURRENT_TIMESTAMP
+					AND NOT EXISTS (
+						SELECT 1 FROM outbox e
+						WHERE e.aggregate_key = o.aggregate_key AND e.dispatched_at IS NULL AND e.failed_at IS NULL
+							AND (e.occurred_at, e.id) < (o.occurred_at, o.id)
+					)
+				ORDER BY o.occurred_at, o.id
+				LIMIT $1
+				FOR UPDATE OF o SKIP LOCKED
+			)
+			RETURNING id, name, aggregate_key, newsletter_public_id, occurred_at, data, attempts
+		)
+		SELECT id, name, aggregate_key, newsletter_public_id, occurred_at, data, attempts
+		FROM claimed
+		ORDER BY occurred_at, id;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.Limit, p.Lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	events := make([]*row.OutboxEvent, 0, p.Limit)
+	for rows.Next() {
+		var r row.OutboxEvent
+		if err := rows.Scan(&r.ID, &r.Name, &r.AggregateKey, &r.NewsletterPublicID, &r.OccurredAt, &r.Data, &r.Attempts); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on claim outbox events: %w", err)
+		}
+		events = append(events, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return events, nil
+}
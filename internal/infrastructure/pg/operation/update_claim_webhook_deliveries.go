@@ -0,0 +1,70 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type UpdateClaimWebhookDeliveries struct {
+	pgConn *sql.DB
+}
+
+type UpdateClaimWebhookDeliveriesParams struct {
+	Limit int
+	// Lease postpones claimed deliveries, they are attempted again when process dies before recording result
+	Lease time.Duration
+}
+
+func NewUpdateClaimWebhookDeliveries(pgConn *sql.DB) *UpdateClaimWebhookDeliveries {
+	return &UpdateClaimWebhookDeliveries{
+		pgConn: pgConn,
+	}
+}
+
+// Execute claims due deliveries, concurrent dispatchers skip rows claimed by each other.
+func (o *UpdateClaimWebhookDeliveries) Execute(
+	ctx context.Context,
+	p *UpdateClaimWebhookDeliveriesParams,
+) ([]*row.DueWebhookDelivery, error) {
+	const query = `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		FROM webhooks w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING d.id, d.event_type, d.payload, d.attempts, w.url, w.secret;
+	`
+
+	rows, err := o.pgConn.QueryContext(ctx, query, p.Limit, p.Lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*row.DueWebhookDelivery, 0, p.Limit)
+	for rows.Next() {
+		var r row.DueWebhookDelivery
+		if err := rows.Scan(&r.ID, &r.EventType, &r.Payload, &r.Attempts, &r.URL, &r.Secret); err != nil {
+			if err := rows.Close(); err != nil {
+				return nil, fmt.Errorf("failed to close rows: %w", err)
+			}
+
+			return nil, fmt.Errorf("failed to scan row on claim webhook deliveries: %w", err)
+		}
+		deliveries = append(deliveries, &r)
+	}
+
+	if err := rows.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close rows: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,60 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateConfirmEmailChange struct {
+	pgConn *sql.DB
+}
+
+type UpdateConfirmEmailChangeParams struct {
+	TokenHash string
+}
+
+func NewUpdateConfirmEmailChange(pgConn *sql.DB) *UpdateConfirmEmailChange {
+	return &UpdateConfirmEmailChange{
+		pgConn: pgConn,
+	}
+}
+
+// Execute consumes pending change and updates email of user in single statement.
+func (o *UpdateConfirmEmailChange) Execute(ctx context.Context, p *UpdateConfirmEmailChangeParams) error {
+	const (
+		emailTakenConstraint = "users_email_key"
+		query                = `
+			WITH change AS (
+				DELETE FROM user_email_changes
+				WHERE token_hash = $1 AND expires_at > CURRENT_TIMESTAMP
+				RETURNING user_id, new_email
+			)
+			UPDATE users u SET email = change.new_email
+			FROM change
+			WHERE u.id = change.user_id;
+		`
+	)
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.TokenHash)
+	if err != nil {
+		if strings.Contains(err.Error(), emailTakenConstraint) {
+			return application.EmailTakenError
+		}
+
+		return fmt.Errorf("failed to confirm email change: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.InvalidTokenError
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateEnableUserTOTPParams struct {
+	UserID string
+}
+
+func UpdateEnableUserTOTPTx(ctx context.Context, tx *sql.Tx, p *UpdateEnableUserTOTPParams) error {
+	const query = `
+		UPDATE users SET totp_enabled_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL;
+	`
+
+	res, err := tx.ExecContext(ctx, query, p.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to enable totp: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.TOTPAlreadyEnabledError
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type UpdateFailTOTPLogin struct {
+	pgConn *sql.DB
+}
+
+type UpdateFailTOTPLoginParams struct {
+	UserID      string
+	MaxAttempts int
+	Lockout     time.Duration
+}
+
+func NewUpdateFailTOTPLogin(pgConn *sql.DB) *UpdateFailTOTPLogin {
+	return &UpdateFailTOTPLogin{
+		pgConn: pgConn,
+	}
+}
+
+// Execute counts failed attempt, reaching max attempts locks totp login for lockout, drops pending challenge and starts
+// counting again.
+func (o *UpdateFailTOTPLogin) Execute(ctx context.Context, p *UpdateFailTOTPLoginParams) error {
+	const query = `
+		UPDATE users SET
+			totp_failed_attempts = CASE WHEN totp_failed_attempts + 1 >= $2 THEN 0 ELSE totp_failed_attempts + 1 END,
+			totp_locked_until = CASE
+				WHEN totp_failed_attempts + 1 >= $2 THEN CURRENT_TIMESTAMP + make_interval(secs => $3)
+				ELSE totp_locked_until
+			END,
+			totp_challenge_id = CASE WHEN totp_failed_attempts + 1 >= $2 THEN NULL ELSE totp_challenge_id END
+		WHERE id = $1;
+	`
+
+	if _, err := o.pgConn.ExecContext(ctx, query, p.UserID, p.MaxAttempts, p.Lockout.Seconds()); err != nil {
+		return fmt.Errorf("failed to record failed totp login: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateFinishTOTPLogin struct {
+	pgConn *sql.DB
+}
+
+type UpdateFinishTOTPLoginParams struct {
+	UserID      string
+	ChallengeID string
+}
+
+func NewUpdateFinishTOTPLogin(pgConn *sql.DB) *UpdateFinishTOTPLogin {
+	return &UpdateFinishTOTPLogin{
+		pgConn: pgConn,
+	}
+}
+
+// Execute consumes challenge and resets failed attempts, challenge consumed already is reported as invalid token.
+func (o *UpdateFinishTOTPLogin) Execute(ctx context.Context, p *UpdateFinishTOTPLoginParams) error {
+	const query = `
+		UPDATE users SET totp_failed_attempts = 0, totp_locked_until = NULL, totp_challenge_id = NULL
+		WHERE id = $1 AND totp_challenge_id = $2;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.UserID, p.ChallengeID)
+	if err != nil {
+		return fmt.Errorf("failed to finish totp login: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.InvalidTokenError
+	}
+
+	return nil
+}
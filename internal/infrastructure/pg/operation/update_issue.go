@@ -0,0 +1,52 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateIssue struct {
+	pgConn *sql.DB
+}
+
+type UpdateIssueParams struct {
+	ID                 string
+	NewsletterPublicID string
+	Title              string
+	Markdown           string
+	HTML               string
+	UpdatedAt          time.Time
+}
+
+func NewUpdateIssue(pgConn *sql.DB) *UpdateIssue {
+	return &UpdateIssue{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateIssue) Execute(ctx context.Context, p *UpdateIssueParams) error {
+	const query = `
+		UPDATE issues i SET title = $3, body_markdown = $4, body_html = $5, updated_at = $6
+		FROM newsletters n
+		WHERE i.newsletter_id = n.id AND i.id = $1 AND n.public_id = $2 AND n.archived_at IS NULL;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.ID, p.NewsletterPublicID, p.Title, p.Markdown, p.HTML, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.IssueNotFoundError
+	}
+
+	return nil
+}
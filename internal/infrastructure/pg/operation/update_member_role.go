@@ -0,0 +1,38 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateMemberRoleParams struct {
+	NewsletterPublicID string
+	UserID             string
+	Role               string
+}
+
+func UpdateMemberRoleTx(ctx context.Context, tx *sql.Tx, p *UpdateMemberRoleParams) error {
+	const query = `
+		UPDATE newsletter_members m SET role = $3
+		FROM newsletters n
+		WHERE n.id = m.newsletter_id AND n.public_id = $1 AND m.user_id = $2;
+	`
+
+	res, err := tx.ExecContext(ctx, query, p.NewsletterPublicID, p.UserID, p.Role)
+	if err != nil {
+		return fmt.Errorf("failed to update member role: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.MemberNotFoundError
+	}
+
+	return nil
+}
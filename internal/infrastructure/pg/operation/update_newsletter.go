@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateNewsletter struct {
+	pgConn *sql.DB
+}
+
+type UpdateNewsletterParams struct {
+	PublicID    string
+	Name        string
+	Description *string
+}
+
+func NewUpdateNewsletter(pgConn *sql.DB) *UpdateNewsletter {
+	return &UpdateNewsletter{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateNewsletter) Execute(ctx context.Context, p *UpdateNewsletterParams) error {
+	const query = `
+		UPDATE newsletters SET name = $2, description = $3
+		WHERE public_id = $1 AND archived_at IS NULL;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.PublicID, p.Name, p.Description)
+	if err != nil {
+		return fmt.Errorf("failed to update newsletter: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.NewsletterNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type UpdateNewsletterDailyStatsParams struct {
+	Since time.Time
+}
+
+// UpdateNewsletterDailyStatsTx recomputes daily statistics of all newsletters from Since, or from the last
+// aggregated day when it is earlier, so days without refresh are caught up. Empty table is computed from scratch.
+// Days are in UTC, events are counted in day they happened.
+func UpdateNewsletterDailyStatsTx(ctx context.Context, tx *sql.Tx, p *UpdateNewsletterDailyStatsParams) error {
+	const (
+		sinceQuery = `
+			SELECT LEAST(COALESCE(MAX(day), '1970-01-01'::date), $1::date)::timestamp AT TIME ZONE 'UTC'
+			FROM newsletter_daily_stats;
+		`
+		deleteQuery = "DELETE FROM newsletter_daily_stats WHERE day >= ($1::timestamptz AT TIME ZONE 'UTC')::date;"
+		insertQuery = `
+			INSERT INTO newsletter_daily_stats (
+				newsletter_id, day, subscribed, unsubscribed, sent, delivered, bounced, opened, clicked
+			)
+			SELECT newsletter_id, day, SUM(subscribed), SUM(unsubscribed), SUM(sent), SUM(delivered), SUM(bounced),
+				SUM(opened), SUM(clicked)
+			FROM (
+				SELECT newsletter_id, (created_at AT TIME ZONE 'UTC')::date AS day,
+					1 AS subscribed, 0 AS unsubscribed, 0 AS sent, 0 AS delivered, 0 AS bounced, 0 AS opened,
+					0 AS clicked
+				FROM subscriptions
+				WHERE created_at >= $1
+				UNION ALL
+				SELECT newsletter_id, (disabled_at AT TIME ZONE 'UTC')::date, 0, 1, 0, 0, 0, 0, 0
+				FROM subscriptions
+				WHERE disabled_at >= $1
+				UNION ALL
+				SELECT newsletter_id, (updated_at AT TIME ZONE 'UTC')::date, 0, 0, 1, 0, 0, 0, 0
+				FROM email_jobs
+				WHERE message_type = 'ISSUE' AND sent = true AND updated_at >= $1
+				UNION ALL
+				SELECT j.newsletter_id, (e.first_at AT TIME ZONE 'UTC')::date, 0, 0, 0,
+					(e.event_type = 'DELIVERED')::int, (e.event_type = 'BOUNCE')::int, 0, 0
+				FROM email_events e
+				JOIN email_jobs j ON j.id = e.email_job_id
+				WHERE e.event_type IN ('DELIVERED', 'BOUNCE') AND e.first_at >= $1
+				UNION ALL
+				SELECT j.newsletter_id, (e.read_at AT TIME ZONE 'UTC')::date, 0, 0, 0, 0, 0, 1, 0
+				FROM email_events e
+				JOIN email_jobs j ON j.id = e.email_job_id
+				WHERE e.event_type = 'OPEN' AND e.read_at >= $1
+				UNION ALL
+				-- only first click of email counts, clicked is number of emails with click
+				SELECT j.newsletter_id, (c.clicked_at AT TIME ZONE 'UTC')::date, 0, 0, 0, 0, 0, 0, 1
+				FROM email_clicks c
+				JOIN email_jobs j ON j.id = c.email_job_id
+				WHERE c.clicked_at >= $1 AND NOT EXISTS (
+					SELECT 1 FROM email_clicks p
+					WHERE p.email_job_id = c.email_job_id AND (p.clicked_at, p.id) < (c.clicked_at, c.id)
+				)
+			) events
+			WHERE newsletter_id IS NOT NULL
+			GROUP BY newsletter_id, day;
+		`
+	)
+
+	var since time.Time
+	if err := tx.QueryRowContext(ctx, sinceQuery, p.Since.UTC().Format(time.DateOnly)).Scan(&since); err != nil {
+		return fmt.Errorf("failed to get start of daily stats refresh: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, since); err != nil {
+		return fmt.Errorf("failed to delete daily stats: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, insertQuery, since); err != nil {
+		return fmt.Errorf("failed to insert daily stats: %w", err)
+	}
+
+	return nil
+}
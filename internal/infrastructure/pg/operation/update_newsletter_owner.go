@@ -0,0 +1,22 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type UpdateNewsletterOwnerParams struct {
+	NewsletterPublicID string
+	UserID             string
+}
+
+func UpdateNewsletterOwnerTx(ctx context.Context, tx *sql.Tx, p *UpdateNewsletterOwnerParams) error {
+	const query = "UPDATE newsletters SET user_id = $2 WHERE public_id = $1;"
+
+	if _, err := tx.ExecContext(ctx, query, p.NewsletterPublicID, p.UserID); err != nil {
+		return fmt.Errorf("failed to update newsletter owner: %w", err)
+	}
+
+	return nil
+}
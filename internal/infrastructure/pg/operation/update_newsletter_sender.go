@@ -0,0 +1,49 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateNewsletterSender struct {
+	pgConn *sql.DB
+}
+
+type UpdateNewsletterSenderParams struct {
+	PublicID      string
+	FromName      *string
+	FromAddress   *string
+	ReplyTo       *string
+	PostalAddress *string
+}
+
+func NewUpdateNewsletterSender(pgConn *sql.DB) *UpdateNewsletterSender {
+	return &UpdateNewsletterSender{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateNewsletterSender) Execute(ctx context.Context, p *UpdateNewsletterSenderParams) error {
+	const query = `
+		UPDATE newsletters SET from_name = $2, from_address = $3, reply_to = $4, postal_address = $5
+		WHERE public_id = $1 AND archived_at IS NULL;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.PublicID, p.FromName, p.FromAddress, p.ReplyTo, p.PostalAddress)
+	if err != nil {
+		return fmt.Errorf("failed to update newsletter sender: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.NewsletterNotFoundError
+	}
+
+	return nil
+}
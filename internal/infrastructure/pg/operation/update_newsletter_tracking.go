@@ -0,0 +1,44 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateNewsletterTracking struct {
+	pgConn *sql.DB
+}
+
+type UpdateNewsletterTrackingParams struct {
+	PublicID string
+	Opens    bool
+	Clicks   bool
+}
+
+func NewUpdateNewsletterTracking(pgConn *sql.DB) *UpdateNewsletterTracking {
+	return &UpdateNewsletterTracking{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateNewsletterTracking) Execute(ctx context.Context, p *UpdateNewsletterTrackingParams) error {
+	const query = "UPDATE newsletters SET track_opens = $2, track_clicks = $3 WHERE public_id = $1 AND archived_at IS NULL;"
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.PublicID, p.Opens, p.Clicks)
+	if err != nil {
+		return fmt.Errorf("failed to update newsletter tracking: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.NewsletterNotFoundError
+	}
+
+	return nil
+}
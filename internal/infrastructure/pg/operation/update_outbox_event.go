@@ -0,0 +1,44 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type UpdateOutboxEvent struct {
+	pgConn *sql.DB
+}
+
+// UpdateOutboxEventParams is result of dispatch, event is either dispatched, scheduled to next attempt or failed.
+type UpdateOutboxEventParams struct {
+	ID            string
+	Error         *string
+	AttemptAt     time.Time
+	NextAttemptAt *time.Time
+}
+
+func NewUpdateOutboxEvent(pgConn *sql.DB) *UpdateOutboxEvent {
+	return &UpdateOutboxEvent{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateOutboxEvent) Execute(ctx context.Context, p *UpdateOutboxEventParams) error {
+	const query = `
+		UPDATE outbox SET
+			attempts = attempts + 1,
+			last_error = $2,
+			dispatched_at = CASE WHEN $2::text IS NULL THEN $3::timestamptz END,
+			next_attempt_at = $4,
+			failed_at = CASE WHEN $2::text IS NOT NULL AND $4::timestamptz IS NULL THEN $3::timestamptz END
+		WHERE id = $1;
+	`
+
+	if _, err := o.pgConn.ExecContext(ctx, query, p.ID, p.Error, p.AttemptAt, p.NextAttemptAt); err != nil {
+		return fmt.Errorf("failed to update outbox event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdatePublishIssueParams struct {
+	ID                 string
+	NewsletterPublicID string
+	PublishedAt        time.Time
+}
+
+// UpdatePublishIssueTx marks issue as published, issue published concurrently fails with IssueAlreadyPublishedError.
+func UpdatePublishIssueTx(ctx context.Context, tx *sql.Tx, p *UpdatePublishIssueParams) error {
+	const query = `
+		UPDATE issues i SET published_at = $3
+		FROM newsletters n
+		WHERE i.newsletter_id = n.id AND i.id = $1 AND n.public_id = $2
+			AND n.archived_at IS NULL AND i.published_at IS NULL;
+	`
+
+	res, err := tx.ExecContext(ctx, query, p.ID, p.NewsletterPublicID, p.PublishedAt)
+	if err != nil {
+		return fmt.Errorf("failed to publish issue: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.IssueAlreadyPublishedError
+	}
+
+	return nil
+}
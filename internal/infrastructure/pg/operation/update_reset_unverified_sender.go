@@ -0,0 +1,40 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+type UpdateResetUnverifiedSenderParams struct {
+	// Domains are custom sending domains whose from addresses are checked, application domains are never passed here
+	Domains []string
+}
+
+// UpdateResetUnverifiedSenderTx resets from address on any of domains to default sender in every newsletter whose
+// current owner has no verified sending domain of the address. Used whenever owner or its verified domains change, so no
+// newsletter keeps sending from domain its owner cannot send from.
+func UpdateResetUnverifiedSenderTx(ctx context.Context, tx *sql.Tx, p *UpdateResetUnverifiedSenderParams) error {
+	const query = `
+		UPDATE newsletters n SET from_address = NULL
+		WHERE split_part(n.from_address, '@', 2) = ANY($1)
+			AND NOT EXISTS (
+				SELECT 1 FROM sending_domains sd
+				WHERE sd.user_id = n.user_id
+					AND sd.domain = split_part(n.from_address, '@', 2)
+					AND sd.verified_at IS NOT NULL
+			);
+	`
+
+	if len(p.Domains) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, query, pq.Array(p.Domains)); err != nil {
+		return fmt.Errorf("failed to reset unverified sender: %w", err)
+	}
+
+	return nil
+}
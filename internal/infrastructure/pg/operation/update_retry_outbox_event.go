@@ -0,0 +1,46 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateRetryOutboxEvent struct {
+	pgConn *sql.DB
+}
+
+type UpdateRetryOutboxEventParams struct {
+	ID string
+}
+
+func NewUpdateRetryOutboxEvent(pgConn *sql.DB) *UpdateRetryOutboxEvent {
+	return &UpdateRetryOutboxEvent{
+		pgConn: pgConn,
+	}
+}
+
+// Execute schedules failed event to be dispatched right away with fresh attempts, only failed events are accepted.
+func (o *UpdateRetryOutboxEvent) Execute(ctx context.Context, p *UpdateRetryOutboxEventParams) error {
+	const query = `
+		UPDATE outbox SET failed_at = NULL, attempts = 0, next_attempt_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND failed_at IS NOT NULL;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retry outbox event: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.FailedEventNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateRevokeAPIKey struct {
+	pgConn *sql.DB
+}
+
+type UpdateRevokeAPIKeyParams struct {
+	ID     string
+	UserID string
+}
+
+func NewUpdateRevokeAPIKey(pgConn *sql.DB) *UpdateRevokeAPIKey {
+	return &UpdateRevokeAPIKey{
+		pgConn: pgConn,
+	}
+}
+
+// Execute revokes key of user, keys of other users are reported as not found.
+func (o *UpdateRevokeAPIKey) Execute(ctx context.Context, p *UpdateRevokeAPIKeyParams) error {
+	const query = `
+		UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.ID, p.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.APIKeyNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateSendingDomainCheck struct {
+	pgConn *sql.DB
+}
+
+type UpdateSendingDomainCheckParams struct {
+	ID                string
+	OwnershipVerified bool
+	SPFVerified       bool
+	DKIMVerified      bool
+	DMARCVerified     bool
+	VerifiedAt        *time.Time
+	CheckedAt         *time.Time
+}
+
+func NewUpdateSendingDomainCheck(pgConn *sql.DB) *UpdateSendingDomainCheck {
+	return &UpdateSendingDomainCheck{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateSendingDomainCheck) Execute(ctx context.Context, p *UpdateSendingDomainCheckParams) error {
+	const query = `
+		UPDATE sending_domains
+		SET ownership_verified = $2, spf_verified = $3, dkim_verified = $4, dmarc_verified = $5,
+			verified_at = $6, checked_at = $7
+		WHERE id = $1;
+	`
+
+	res, err := o.pgConn.ExecContext(
+		ctx,
+		query,
+		p.ID,
+		p.OwnershipVerified,
+		p.SPFVerified,
+		p.DKIMVerified,
+		p.DMARCVerified,
+		p.VerifiedAt,
+		p.CheckedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update sending domain check: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.SendingDomainNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateSubscriptionReason struct {
+	pgConn *sql.DB
+}
+
+type UpdateSubscriptionReasonParams struct {
+	Email              string
+	NewsletterPublicID string
+	Reason             string
+	Comment            *string
+}
+
+func NewUpdateSubscriptionReason(pgConn *sql.DB) *UpdateSubscriptionReason {
+	return &UpdateSubscriptionReason{
+		pgConn: pgConn,
+	}
+}
+
+// Execute stores reason of unsubscribing, only disabled subscription has one.
+func (o *UpdateSubscriptionReason) Execute(ctx context.Context, p *UpdateSubscriptionReasonParams) error {
+	const query = `
+		UPDATE subscriptions SET unsubscribe_reason = $3, unsubscribe_comment = $4
+		WHERE subscriber_email = $1 AND disabled_at IS NOT NULL AND newsletter_id = (
+			SELECT id FROM newsletters WHERE public_id = $2
+		);
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.Email, p.NewsletterPublicID, p.Reason, p.Comment)
+	if err != nil {
+		return fmt.Errorf("failed to update subscription reason: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.SubscriptionNotFoundError
+	}
+
+	return nil
+}
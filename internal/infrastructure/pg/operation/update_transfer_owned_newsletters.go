@@ -0,0 +1,46 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type UpdateTransferOwnedNewslettersParams struct {
+	UserID string
+}
+
+// UpdateTransferOwnedNewslettersTx removes memberships of user and hands newsletters owned by user
+// over to their longest standing editor. Newsletters without editor stay owned by user.
+func UpdateTransferOwnedNewslettersTx(ctx context.Context, tx *sql.Tx, p *UpdateTransferOwnedNewslettersParams) error {
+	const (
+		deleteQuery   = "DELETE FROM newsletter_members WHERE user_id = $1;"
+		transferQuery = `
+			WITH successor AS (
+				SELECT DISTINCT ON (m.newsletter_id) m.newsletter_id, m.user_id
+				FROM newsletter_members m
+				JOIN newsletters n ON n.id = m.newsletter_id
+				WHERE n.user_id = $1 AND m.role = 'editor'
+				ORDER BY m.newsletter_id, m.created_at
+			), promoted AS (
+				UPDATE newsletter_members m SET role = 'owner'
+				FROM successor s
+				WHERE m.newsletter_id = s.newsletter_id AND m.user_id = s.user_id
+				RETURNING m.newsletter_id, m.user_id
+			)
+			UPDATE newsletters n SET user_id = promoted.user_id
+			FROM promoted
+			WHERE n.id = promoted.newsletter_id;
+		`
+	)
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, p.UserID); err != nil {
+		return fmt.Errorf("failed to delete memberships: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, transferQuery, p.UserID); err != nil {
+		return fmt.Errorf("failed to transfer owned newsletters: %w", err)
+	}
+
+	return nil
+}
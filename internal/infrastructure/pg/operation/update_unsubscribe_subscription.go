@@ -6,34 +6,31 @@ import (
 	"fmt"
 )
 
-type UpdateDisableSubscription struct {
-	pgConn *sql.DB
-}
-
 type UpdateDisableSubscriptionParams struct {
 	Email              string
 	NewsletterPublicID string
 }
 
-func NewUpdateDisableSubscription(pgConn *sql.DB) *UpdateDisableSubscription {
-	return &UpdateDisableSubscription{
-		pgConn: pgConn,
-	}
-}
-
-// Execute TODO: Tests this properly
-func (u *UpdateDisableSubscription) Execute(ctx context.Context, p *UpdateDisableSubscriptionParams) error {
+// UpdateDisableSubscriptionTx TODO: Tests this properly
+// Repeated unsubscribing keeps time of the first one and answer to survey. Returns true when active subscription
+// was disabled.
+func UpdateDisableSubscriptionTx(ctx context.Context, tx *sql.Tx, p *UpdateDisableSubscriptionParams) (bool, error) {
 	const query = `
 		UPDATE subscriptions SET disabled_at = CURRENT_TIMESTAMP
-	 	WHERE subscriber_email = $1 AND newsletter_id = (
+	 	WHERE subscriber_email = $1 AND disabled_at IS NULL AND newsletter_id = (
 	 	    SELECT id FROM newsletters WHERE public_id = $2
 	 	);
 	`
 
-	_, err := u.pgConn.ExecContext(ctx, query, p.Email, p.NewsletterPublicID)
+	res, err := tx.ExecContext(ctx, query, p.Email, p.NewsletterPublicID)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute update unsubscribe : %w", err)
+	}
+
+	affected, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to execute update unsubscribe : %w", err)
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
 	}
 
-	return nil
+	return affected > 0, nil
 }
@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateUseRecoveryCode struct {
+	pgConn *sql.DB
+}
+
+type UpdateUseRecoveryCodeParams struct {
+	UserID   string
+	CodeHash string
+}
+
+func NewUpdateUseRecoveryCode(pgConn *sql.DB) *UpdateUseRecoveryCode {
+	return &UpdateUseRecoveryCode{
+		pgConn: pgConn,
+	}
+}
+
+// Execute marks recovery code as used, each code can be used only once.
+func (o *UpdateUseRecoveryCode) Execute(ctx context.Context, p *UpdateUseRecoveryCodeParams) error {
+	const query = `
+		UPDATE user_recovery_codes SET used_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.UserID, p.CodeHash)
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.InvalidTOTPCodeError
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateUseTOTPStep struct {
+	pgConn *sql.DB
+}
+
+type UpdateUseTOTPStepParams struct {
+	UserID string
+	Step   int64
+}
+
+func NewUpdateUseTOTPStep(pgConn *sql.DB) *UpdateUseTOTPStep {
+	return &UpdateUseTOTPStep{
+		pgConn: pgConn,
+	}
+}
+
+// Execute records time step of accepted code, code of the same or earlier step is rejected so each code is used once.
+func (o *UpdateUseTOTPStep) Execute(ctx context.Context, p *UpdateUseTOTPStepParams) error {
+	const query = `
+		UPDATE users SET totp_last_step = $2
+		WHERE id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2);
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.UserID, p.Step)
+	if err != nil {
+		return fmt.Errorf("failed to use totp step: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.InvalidTOTPCodeError
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateUserPassword struct {
+	pgConn *sql.DB
+}
+
+type UpdateUserPasswordParams struct {
+	UserID       string
+	PasswordHash string
+}
+
+func NewUpdateUserPassword(pgConn *sql.DB) *UpdateUserPassword {
+	return &UpdateUserPassword{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateUserPassword) Execute(ctx context.Context, p *UpdateUserPasswordParams) error {
+	const query = "UPDATE users SET password_hash = $2 WHERE id = $1;"
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.UserID, p.PasswordHash)
+	if err != nil {
+		return fmt.Errorf("failed to update user password: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.UserNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateUserTOTPChallenge struct {
+	pgConn *sql.DB
+}
+
+type UpdateUserTOTPChallengeParams struct {
+	UserID      string
+	ChallengeID string
+}
+
+func NewUpdateUserTOTPChallenge(pgConn *sql.DB) *UpdateUserTOTPChallenge {
+	return &UpdateUserTOTPChallenge{
+		pgConn: pgConn,
+	}
+}
+
+// Execute stores id of issued challenge, challenges issued before are no longer accepted.
+func (o *UpdateUserTOTPChallenge) Execute(ctx context.Context, p *UpdateUserTOTPChallengeParams) error {
+	const query = `UPDATE users SET totp_challenge_id = $2 WHERE id = $1;`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.UserID, p.ChallengeID)
+	if err != nil {
+		return fmt.Errorf("failed to update totp challenge: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.UserNotFoundError
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+)
+
+type UpdateUserTOTPSecret struct {
+	pgConn *sql.DB
+}
+
+type UpdateUserTOTPSecretParams struct {
+	UserID string
+	Secret string
+}
+
+func NewUpdateUserTOTPSecret(pgConn *sql.DB) *UpdateUserTOTPSecret {
+	return &UpdateUserTOTPSecret{
+		pgConn: pgConn,
+	}
+}
+
+// Execute stores pending secret, secret of user with enabled totp is never overwritten.
+func (o *UpdateUserTOTPSecret) Execute(ctx context.Context, p *UpdateUserTOTPSecretParams) error {
+	const query = `
+		UPDATE users SET totp_secret = $2
+		WHERE id = $1 AND totp_enabled_at IS NULL;
+	`
+
+	res, err := o.pgConn.ExecContext(ctx, query, p.UserID, p.Secret)
+	if err != nil {
+		return fmt.Errorf("failed to update totp secret: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return application.TOTPAlreadyEnabledError
+	}
+
+	return nil
+}
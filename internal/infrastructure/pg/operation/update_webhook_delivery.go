@@ -0,0 +1,57 @@
+package operation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type UpdateWebhookDelivery struct {
+	pgConn *sql.DB
+}
+
+// UpdateWebhookDeliveryParams is result of attempt, delivery is either delivered, scheduled to next attempt or failed.
+type UpdateWebhookDeliveryParams struct {
+	ID            string
+	StatusCode    *int
+	Error         *string
+	AttemptAt     time.Time
+	Delivered     bool
+	NextAttemptAt *time.Time
+}
+
+func NewUpdateWebhookDelivery(pgConn *sql.DB) *UpdateWebhookDelivery {
+	return &UpdateWebhookDelivery{
+		pgConn: pgConn,
+	}
+}
+
+func (o *UpdateWebhookDelivery) Execute(ctx context.Context, p *UpdateWebhookDeliveryParams) error {
+	const query = `
+		UPDATE webhook_deliveries SET
+			attempts = attempts + 1,
+			last_attempt_at = $2,
+			last_status_code = $3,
+			last_error = $4,
+			delivered_at = CASE WHEN $5 THEN $2::timestamptz END,
+			next_attempt_at = $6,
+			failed_at = CASE WHEN NOT $5 AND $6::timestamptz IS NULL THEN $2::timestamptz END
+		WHERE id = $1;
+	`
+
+	if _, err := o.pgConn.ExecContext(
+		ctx,
+		query,
+		p.ID,
+		p.AttemptAt,
+		p.StatusCode,
+		p.Error,
+		p.Delivered,
+		p.NextAttemptAt,
+	); err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+type OutboxRepository struct {
+	getFailedOutboxEvents  *operation.GetFailedOutboxEvents
+	updateRetryOutboxEvent *operation.UpdateRetryOutboxEvent
+}
+
+func NewOutboxRepository(
+	gfoe *operation.GetFailedOutboxEvents,
+	uroe *operation.UpdateRetryOutboxEvent,
+) *OutboxRepository {
+	return &OutboxRepository{
+		getFailedOutboxEvents:  gfoe,
+		updateRetryOutboxEvent: uroe,
+	}
+}
+
+// GetFailedEvents returns at most limit events given up by dispatcher, most recently failed first.
+func (o *OutboxRepository) GetFailedEvents(ctx context.Context, limit int) ([]*dto.FailedEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, err := o.getFailedOutboxEvents.Execute(ctx, &operation.GetFailedOutboxEventsParams{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*dto.FailedEvent, 0, len(rows))
+	for _, r := range rows {
+		events = append(events, &dto.FailedEvent{
+			ID:                 r.ID,
+			Name:               r.Name,
+			AggregateKey:       r.AggregateKey,
+			NewsletterPublicID: r.NewsletterPublicID,
+			OccurredAt:         r.OccurredAt,
+			Attempts:           r.Attempts,
+			LastError:          r.LastError,
+			FailedAt:           r.FailedAt,
+		})
+	}
+
+	return events, nil
+}
+
+func (o *OutboxRepository) RetryFailedEvent(ctx context.Context, eventID *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return o.updateRetryOutboxEvent.Execute(ctx, &operation.UpdateRetryOutboxEventParams{ID: eventID.String()})
+}
@@ -8,6 +8,11 @@ type MailType string
 
 const (
 	SubscriptionType MailType = "SUBSCRIPTION"
+	InvitationType   MailType = "INVITATION"
+	EmailChangeType  MailType = "EMAIL_CHANGE"
+	// NewsletterRemovedType notifies subscriber about archived or deleted newsletter, job is not bound to newsletter
+	NewsletterRemovedType MailType = "NEWSLETTER_REMOVED"
+	IssueType             MailType = "ISSUE"
 )
 
 type Newsletter struct {
@@ -23,3 +28,188 @@ type EmailJob struct {
 	Type   MailType
 	Params []byte
 }
+
+type APIKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type Member struct {
+	UserID    string
+	Email     string
+	Role      string
+	CreatedAt time.Time
+}
+
+type Invitation struct {
+	ID                 string
+	NewsletterPublicID string
+	NewsletterName     string
+	Email              string
+	Role               string
+	InvitedBy          *string
+	ExpiresAt          time.Time
+}
+
+type Subscription struct {
+	Email              string
+	NewsletterPublicID string
+}
+
+type NewsletterSender struct {
+	Name          string
+	FromName      *string
+	FromAddress   *string
+	ReplyTo       *string
+	PostalAddress *string
+}
+
+type NewsletterTracking struct {
+	Opens  bool
+	Clicks bool
+}
+
+type SendingDomain struct {
+	ID                string
+	UserID            string
+	Domain            string
+	VerificationToken string
+	DKIMSelector      string
+	DKIMPrivateKey    string
+	DKIMPublicKey     string
+	OwnershipVerified bool
+	SPFVerified       bool
+	DKIMVerified      bool
+	DMARCVerified     bool
+	VerifiedAt        *time.Time
+	CheckedAt         *time.Time
+	CreatedAt         time.Time
+}
+
+type NewsletterTemplate struct {
+	Name      string
+	HTML      string
+	Text      *string
+	UpdatedAt time.Time
+}
+
+type Issue struct {
+	ID                 string
+	NewsletterPublicID string
+	Slug               string
+	Title              string
+	Markdown           string
+	HTML               string
+	PublishedAt        *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type ActiveSubscription struct {
+	Email  string
+	Token  string
+	Locale string
+}
+
+type StatsCounts struct {
+	Subscribed   int
+	Unsubscribed int
+	Sent         int
+	Delivered    int
+	Bounced      int
+	Opened       int
+	Clicked      int
+}
+
+type StatsBucket struct {
+	Start time.Time
+	StatsCounts
+}
+
+type IssueStats struct {
+	Sent         int
+	Delivered    int
+	Bounced      int
+	Opened       int
+	Clicked      int
+	Unsubscribed int
+}
+
+type UnsubscribeComment struct {
+	Reason         string
+	Comment        string
+	UnsubscribedAt time.Time
+}
+
+type Webhook struct {
+	ID                 string
+	NewsletterPublicID string
+	URL                string
+	Secret             string
+	EventTypes         []string
+	CreatedAt          time.Time
+}
+
+type WebhookDelivery struct {
+	ID             string
+	EventID        string
+	EventType      string
+	Attempts       int
+	LastStatusCode *int
+	LastError      *string
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+	FailedAt       *time.Time
+	CreatedAt      time.Time
+}
+
+// DueWebhookDelivery is claimed delivery with everything needed to send it.
+type DueWebhookDelivery struct {
+	ID        string
+	EventType string
+	Payload   []byte
+	Attempts  int
+	URL       string
+	Secret    string
+}
+
+type EmailJobRecipient struct {
+	Email              string
+	NewsletterPublicID *string
+}
+
+// OutboxEvent is claimed event waiting for dispatch, data is JSON object of string values.
+type OutboxEvent struct {
+	ID                 string
+	Name               string
+	AggregateKey       string
+	NewsletterPublicID string
+	OccurredAt         time.Time
+	Data               []byte
+	Attempts           int
+}
+
+// FailedOutboxEvent is event given up after its last attempt failed.
+type FailedOutboxEvent struct {
+	ID                 string
+	Name               string
+	AggregateKey       string
+	NewsletterPublicID string
+	OccurredAt         time.Time
+	Attempts           int
+	LastError          *string
+	FailedAt           time.Time
+}
+
+type SubscribedNewsletter struct {
+	Email              string
+	NewsletterPublicID string
+}
@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+type SenderIdentityRepository struct {
+	getNewsletterSender    *operation.GetNewsletterSender
+	updateNewsletterSender *operation.UpdateNewsletterSender
+}
+
+func NewSenderIdentityRepository(
+	gns *operation.GetNewsletterSender,
+	uns *operation.UpdateNewsletterSender,
+) *SenderIdentityRepository {
+	return &SenderIdentityRepository{
+		getNewsletterSender:    gns,
+		updateNewsletterSender: uns,
+	}
+}
+
+func (s *SenderIdentityRepository) GetSenderIdentity(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+) (*domain.SenderIdentity, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	res, err := s.getNewsletterSender.Execute(ctx, &operation.GetNewsletterSenderParams{
+		PublicID: newsletterPublicID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var fromAddress, replyTo *domain.Email
+	if res.FromAddress != nil {
+		if fromAddress, err = domain.NewEmail(*res.FromAddress); err != nil {
+			return nil, fmt.Errorf("invalid email format in db %w", err)
+		}
+	}
+	if res.ReplyTo != nil {
+		if replyTo, err = domain.NewEmail(*res.ReplyTo); err != nil {
+			return nil, fmt.Errorf("invalid email format in db %w", err)
+		}
+	}
+
+	return domain.NewSenderIdentity(valueOrEmpty(res.FromName), fromAddress, replyTo, valueOrEmpty(res.PostalAddress)), nil
+}
+
+// UpdateSenderIdentity replaces whole identity, empty values are stored as NULL so defaults apply.
+func (s *SenderIdentityRepository) UpdateSenderIdentity(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	identity *domain.SenderIdentity,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	p := &operation.UpdateNewsletterSenderParams{
+		PublicID:      newsletterPublicID.String(),
+		FromName:      nilIfEmpty(identity.FromName()),
+		PostalAddress: nilIfEmpty(identity.PostalAddress()),
+	}
+	if identity.FromAddress() != nil {
+		p.FromAddress = nilIfEmpty(identity.FromAddress().String())
+	}
+	if identity.ReplyTo() != nil {
+		p.ReplyTo = nilIfEmpty(identity.ReplyTo().String())
+	}
+
+	return s.updateNewsletterSender.Execute(ctx, p)
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
@@ -0,0 +1,207 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/dkim"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type SendingDomainRepository struct {
+	pgConn                    *sql.DB
+	createSendingDomain       *operation.CreateSendingDomain
+	getSendingDomain          *operation.GetSendingDomain
+	getSendingDomainsByUserID *operation.GetSendingDomainsByUserID
+	getVerifiedSendingDomain  *operation.GetVerifiedSendingDomain
+	updateSendingDomainCheck  *operation.UpdateSendingDomainCheck
+	getSendingDomainKey       *operation.GetSendingDomainKey
+}
+
+func NewSendingDomainRepository(
+	pgConn *sql.DB,
+	csd *operation.CreateSendingDomain,
+	gsd *operation.GetSendingDomain,
+	gsdbui *operation.GetSendingDomainsByUserID,
+	gvsd *operation.GetVerifiedSendingDomain,
+	usdc *operation.UpdateSendingDomainCheck,
+	gsdk *operation.GetSendingDomainKey,
+) *SendingDomainRepository {
+	return &SendingDomainRepository{
+		pgConn:                    pgConn,
+		createSendingDomain:       csd,
+		getSendingDomain:          gsd,
+		getSendingDomainsByUserID: gsdbui,
+		getVerifiedSendingDomain:  gvsd,
+		updateSendingDomainCheck:  usdc,
+		getSendingDomainKey:       gsdk,
+	}
+}
+
+func (s *SendingDomainRepository) Create(ctx context.Context, sd *domain.SendingDomain) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return s.createSendingDomain.Execute(ctx, &operation.CreateSendingDomainParams{
+		ID:                sd.ID().String(),
+		UserID:            sd.UserID().String(),
+		Domain:            sd.Name(),
+		VerificationToken: sd.VerificationToken(),
+		DKIMSelector:      sd.DKIMSelector(),
+		DKIMPrivateKey:    sd.DKIMPrivateKey(),
+		DKIMPublicKey:     sd.DKIMPublicKey(),
+		CreatedAt:         sd.CreatedAt(),
+	})
+}
+
+func (s *SendingDomainRepository) Get(ctx context.Context, userID, id *domain.ID) (*domain.SendingDomain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	r, err := s.getSendingDomain.Execute(ctx, &operation.GetSendingDomainParams{
+		ID:     id.String(),
+		UserID: userID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createSendingDomainFromRow(r)
+}
+
+func (s *SendingDomainRepository) GetByUserID(ctx context.Context, userID *domain.ID) ([]*domain.SendingDomain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, err := s.getSendingDomainsByUserID.Execute(ctx, &operation.GetSendingDomainsByUserIDParams{
+		UserID: userID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	domains := make([]*domain.SendingDomain, 0, len(rows))
+	for _, r := range rows {
+		sd, err := createSendingDomainFromRow(r)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, sd)
+	}
+
+	return domains, nil
+}
+
+func (s *SendingDomainRepository) UpdateCheck(ctx context.Context, sd *domain.SendingDomain) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return s.updateSendingDomainCheck.Execute(ctx, &operation.UpdateSendingDomainCheckParams{
+		ID:                sd.ID().String(),
+		OwnershipVerified: sd.OwnershipVerified(),
+		SPFVerified:       sd.SPFVerified(),
+		DKIMVerified:      sd.DKIMVerified(),
+		DMARCVerified:     sd.DMARCVerified(),
+		VerifiedAt:        sd.VerifiedAt(),
+		CheckedAt:         sd.CheckedAt(),
+	})
+}
+
+// Delete removes domain of user in single transaction, from address on the domain is reset to default sender in every
+// newsletter whose owner has no other verified record of the domain.
+func (s *SendingDomainRepository) Delete(ctx context.Context, userID, id *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	tx, err := s.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	name, err := operation.DeleteSendingDomainTx(ctx, tx, &operation.DeleteSendingDomainParams{
+		ID:     id.String(),
+		UserID: userID.String(),
+	})
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.UpdateResetUnverifiedSenderTx(ctx, tx, &operation.UpdateResetUnverifiedSenderParams{
+		Domains: []string{name},
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit delete sending domain tx: %w", err)
+	}
+
+	return nil
+}
+
+// IsVerifiedSenderAddress reports whether user verified domain of address, used when owner sets from address of
+// newsletter.
+func (s *SendingDomainRepository) IsVerifiedSenderAddress(
+	ctx context.Context,
+	userID *domain.ID,
+	address *domain.Email,
+) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return s.getVerifiedSendingDomain.Execute(ctx, &operation.GetVerifiedSendingDomainParams{
+		UserID: userID.String(),
+		Domain: address.Domain(),
+	})
+}
+
+// Signer returns DKIM signer of verified domain, nil when domain is not verified by any user.
+func (s *SendingDomainRepository) Signer(ctx context.Context, name string) (*dkim.Signer, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	selector, key, err := s.getSendingDomainKey.Execute(ctx, &operation.GetSendingDomainKeyParams{Domain: name})
+	if err != nil {
+		if errors.Is(err, application.SendingDomainNotFoundError) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return dkim.NewSigner(name, selector, key)
+}
+
+func createSendingDomainFromRow(r *row.SendingDomain) (*domain.SendingDomain, error) {
+	id, err := domain.CreateIDFromExisting(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uuid format in db %w", err)
+	}
+	userID, err := domain.CreateIDFromExisting(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uuid format in db %w", err)
+	}
+
+	return domain.CreateSendingDomainFromExisting(
+		id,
+		userID,
+		r.Domain,
+		r.VerificationToken,
+		r.DKIMSelector,
+		r.DKIMPrivateKey,
+		r.DKIMPublicKey,
+		r.OwnershipVerified,
+		r.SPFVerified,
+		r.DKIMVerified,
+		r.DMARCVerified,
+		r.VerifiedAt,
+		r.CheckedAt,
+		r.CreatedAt,
+	), nil
+}
@@ -0,0 +1,47 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+type SubscribedNewsletterRepository struct {
+	getSubscribedNewsletters        *operation.GetSubscribedNewsletters
+	getSubscribedNewslettersByEmail *operation.GetSubscribedNewslettersByEmail
+}
+
+func NewSubscribedNewsletterRepository(
+	gsn *operation.GetSubscribedNewsletters,
+	gsnbe *operation.GetSubscribedNewslettersByEmail,
+) *SubscribedNewsletterRepository {
+	return &SubscribedNewsletterRepository{getSubscribedNewsletters: gsn, getSubscribedNewslettersByEmail: gsnbe}
+}
+
+// GetSubscribedNewsletters returns public ids of newsletters by email of their active subscriber.
+func (s *SubscribedNewsletterRepository) GetSubscribedNewsletters(ctx context.Context) (map[string][]string, error) {
+	// all subscriptions are read at once, it takes longer than single row operations
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	rows, err := s.getSubscribedNewsletters.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribed := make(map[string][]string)
+	for _, r := range rows {
+		subscribed[r.Email] = append(subscribed[r.Email], r.NewsletterPublicID)
+	}
+
+	return subscribed, nil
+}
+
+// GetSubscribedNewslettersByEmail returns public ids of newsletters subscriber is actively subscribed to.
+func (s *SubscribedNewsletterRepository) GetSubscribedNewslettersByEmail(ctx context.Context, email string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return s.getSubscribedNewslettersByEmail.Execute(ctx, &operation.GetSubscribedNewslettersByEmailParams{Email: email})
+}
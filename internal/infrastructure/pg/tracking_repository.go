@@ -0,0 +1,86 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+type TrackingRepository struct {
+	getNewsletterTracking    *operation.GetNewsletterTracking
+	updateNewsletterTracking *operation.UpdateNewsletterTracking
+	createEmailOpen          *operation.CreateEmailOpen
+	createEmailClick         *operation.CreateEmailClick
+}
+
+func NewTrackingRepository(
+	gnt *operation.GetNewsletterTracking,
+	unt *operation.UpdateNewsletterTracking,
+	ceo *operation.CreateEmailOpen,
+	cec *operation.CreateEmailClick,
+) *TrackingRepository {
+	return &TrackingRepository{
+		getNewsletterTracking:    gnt,
+		updateNewsletterTracking: unt,
+		createEmailOpen:          ceo,
+		createEmailClick:         cec,
+	}
+}
+
+func (t *TrackingRepository) GetTrackingSettings(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+) (*domain.TrackingSettings, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	res, err := t.getNewsletterTracking.Execute(ctx, &operation.GetNewsletterTrackingParams{
+		PublicID: newsletterPublicID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewTrackingSettings(res.Opens, res.Clicks), nil
+}
+
+func (t *TrackingRepository) UpdateTrackingSettings(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	settings *domain.TrackingSettings,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return t.updateNewsletterTracking.Execute(ctx, &operation.UpdateNewsletterTrackingParams{
+		PublicID: newsletterPublicID.String(),
+		Opens:    settings.Opens(),
+		Clicks:   settings.Clicks(),
+	})
+}
+
+func (t *TrackingRepository) RecordEmailOpen(ctx context.Context, open *domain.EmailOpen) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return t.createEmailOpen.Execute(ctx, &operation.CreateEmailOpenParams{
+		EmailJobID:     open.EmailJobID().String(),
+		UserAgentClass: string(open.UserAgentClass()),
+		Prefetched:     open.Prefetched(),
+		OpenedAt:       open.OpenedAt(),
+	})
+}
+
+func (t *TrackingRepository) RecordEmailClick(ctx context.Context, click *domain.EmailClick) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return t.createEmailClick.Execute(ctx, &operation.CreateEmailClickParams{
+		ID:         click.ID().String(),
+		EmailJobID: click.EmailJobID().String(),
+		LinkIndex:  click.LinkIndex(),
+		ClickedAt:  click.ClickedAt(),
+	})
+}
@@ -0,0 +1,75 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+type UnsubscribeReasonRepository struct {
+	updateSubscriptionReason *operation.UpdateSubscriptionReason
+	getUnsubscribeComments   *operation.GetUnsubscribeComments
+}
+
+func NewUnsubscribeReasonRepository(
+	usr *operation.UpdateSubscriptionReason,
+	guc *operation.GetUnsubscribeComments,
+) *UnsubscribeReasonRepository {
+	return &UnsubscribeReasonRepository{
+		updateSubscriptionReason: usr,
+		getUnsubscribeComments:   guc,
+	}
+}
+
+// SaveUnsubscribeReason replaces previous answer, subscription has to be disabled.
+func (u *UnsubscribeReasonRepository) SaveUnsubscribeReason(
+	ctx context.Context,
+	email *domain.Email,
+	newsletterPublicID *domain.ID,
+	reason *domain.UnsubscribeReason,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateSubscriptionReason.Execute(ctx, &operation.UpdateSubscriptionReasonParams{
+		Email:              email.String(),
+		NewsletterPublicID: newsletterPublicID.String(),
+		Reason:             reason.Code(),
+		Comment:            nilIfEmpty(reason.Comment()),
+	})
+}
+
+func (u *UnsubscribeReasonRepository) GetUnsubscribeComments(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	period *domain.StatsPeriod,
+	pageSize, pageNumber int,
+) ([]*dto.UnsubscribeComment, *dto.Pagination, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, pagination, err := u.getUnsubscribeComments.Execute(ctx, &operation.GetUnsubscribeCommentsParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		From:               period.From(),
+		To:                 period.To(),
+		PageSize:           pageSize,
+		PageNumber:         pageNumber,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comments := make([]*dto.UnsubscribeComment, 0, len(rows))
+	for _, r := range rows {
+		comments = append(comments, &dto.UnsubscribeComment{
+			Reason:         r.Reason,
+			Comment:        r.Comment,
+			UnsubscribedAt: r.UnsubscribedAt,
+		})
+	}
+
+	return comments, pagination, nil
+}
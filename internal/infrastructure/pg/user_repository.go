@@ -2,24 +2,63 @@ package pg
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
 	"github.com/javor454/newsletter-assignment/internal/domain"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/bcrypt"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/totp"
 )
 
 type UserRepository struct {
-	createUser     *operation.CreateUser
-	getUserByEmail *operation.GetUserByEmail
+	pgConn               *sql.DB
+	createUser           *operation.CreateUser
+	getUserByEmail       *operation.GetUserByEmail
+	getUserByID          *operation.GetUserByID
+	updateUserTOTPSecret *operation.UpdateUserTOTPSecret
+	getUserTOTPSecret    *operation.GetUserTOTPSecret
+	updateUseRecovery    *operation.UpdateUseRecoveryCode
+	updateUseTOTPStep    *operation.UpdateUseTOTPStep
+	updateTOTPChallenge  *operation.UpdateUserTOTPChallenge
+	getTOTPLogin         *operation.GetUserTOTPLogin
+	updateFailTOTPLogin  *operation.UpdateFailTOTPLogin
+	updateFinishTOTP     *operation.UpdateFinishTOTPLogin
+	updateUserPassword   *operation.UpdateUserPassword
 }
 
-func NewUserRepository(createUser *operation.CreateUser, getUserByEmail *operation.GetUserByEmail) *UserRepository {
+func NewUserRepository(
+	pgConn *sql.DB,
+	createUser *operation.CreateUser,
+	getUserByEmail *operation.GetUserByEmail,
+	getUserByID *operation.GetUserByID,
+	updateUserTOTPSecret *operation.UpdateUserTOTPSecret,
+	getUserTOTPSecret *operation.GetUserTOTPSecret,
+	updateUseRecovery *operation.UpdateUseRecoveryCode,
+	updateUseTOTPStep *operation.UpdateUseTOTPStep,
+	updateTOTPChallenge *operation.UpdateUserTOTPChallenge,
+	getTOTPLogin *operation.GetUserTOTPLogin,
+	updateFailTOTPLogin *operation.UpdateFailTOTPLogin,
+	updateFinishTOTP *operation.UpdateFinishTOTPLogin,
+	updateUserPassword *operation.UpdateUserPassword,
+) *UserRepository {
 	return &UserRepository{
-		createUser:     createUser,
-		getUserByEmail: getUserByEmail,
+		pgConn:               pgConn,
+		createUser:           createUser,
+		getUserByEmail:       getUserByEmail,
+		getUserByID:          getUserByID,
+		updateUserTOTPSecret: updateUserTOTPSecret,
+		getUserTOTPSecret:    getUserTOTPSecret,
+		updateUseRecovery:    updateUseRecovery,
+		updateUseTOTPStep:    updateUseTOTPStep,
+		updateTOTPChallenge:  updateTOTPChallenge,
+		getTOTPLogin:         getTOTPLogin,
+		updateFailTOTPLogin:  updateFailTOTPLogin,
+		updateFinishTOTP:     updateFinishTOTP,
+		updateUserPassword:   updateUserPassword,
 	}
 }
 
@@ -67,5 +106,185 @@ func (u *UserRepository) GetByEmailAndPassword(
 		return nil, application.InvalidPasswordError
 	}
 
-	return domain.CreateUserFromExisting(id, email, pass), nil
+	return domain.CreateUserFromExisting(id, email, pass, res.TOTPEnabled), nil
+}
+
+// GetByID returns user without password, hash is never exposed outside of repository.
+func (u *UserRepository) GetByID(ctx context.Context, userID *domain.ID) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	res, err := u.getUserByID.Execute(ctx, &operation.GetUserByIDParams{ID: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := domain.NewEmail(res.Email)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email format in db %w", err)
+	}
+
+	return domain.CreateUserFromExisting(userID, email, nil, res.TOTPEnabled), nil
+}
+
+func (u *UserRepository) SetTOTPSecret(ctx context.Context, userID *domain.ID, secret string) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateUserTOTPSecret.Execute(ctx, &operation.UpdateUserTOTPSecretParams{
+		UserID: userID.String(),
+		Secret: secret,
+	})
+}
+
+// GetTOTPSecret returns secret of enrolled user, enabled reports if enrollment was confirmed.
+func (u *UserRepository) GetTOTPSecret(ctx context.Context, userID *domain.ID) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	res, err := u.getUserTOTPSecret.Execute(ctx, &operation.GetUserTOTPSecretParams{UserID: userID.String()})
+	if err != nil {
+		return "", false, err
+	}
+	if res.Secret == nil {
+		return "", false, application.TOTPNotEnrolledError
+	}
+
+	return *res.Secret, res.Enabled, nil
+}
+
+// EnableTOTP confirms enrollment and replaces recovery codes in single transaction.
+func (u *UserRepository) EnableTOTP(ctx context.Context, userID *domain.ID, recoveryCodes []string) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashes = append(hashes, totp.HashRecoveryCode(code))
+	}
+
+	tx, err := u.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	if err := operation.UpdateEnableUserTOTPTx(ctx, tx, &operation.UpdateEnableUserTOTPParams{
+		UserID: userID.String(),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.CreateRecoveryCodesTx(ctx, tx, &operation.CreateRecoveryCodesParams{
+		UserID:     userID.String(),
+		CodeHashes: hashes,
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit enable totp tx: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserRepository) UseRecoveryCode(ctx context.Context, userID *domain.ID, code string) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateUseRecovery.Execute(ctx, &operation.UpdateUseRecoveryCodeParams{
+		UserID:   userID.String(),
+		CodeHash: totp.HashRecoveryCode(code),
+	})
+}
+
+// UseTOTPStep records time step of accepted TOTP code, code of the same or earlier step was already used.
+func (u *UserRepository) UseTOTPStep(ctx context.Context, userID *domain.ID, step int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateUseTOTPStep.Execute(ctx, &operation.UpdateUseTOTPStepParams{
+		UserID: userID.String(),
+		Step:   step,
+	})
+}
+
+// StartTOTPChallenge stores id of issued challenge, challenges issued before are no longer accepted.
+func (u *UserRepository) StartTOTPChallenge(ctx context.Context, userID, challengeID *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateTOTPChallenge.Execute(ctx, &operation.UpdateUserTOTPChallengeParams{
+		UserID:      userID.String(),
+		ChallengeID: challengeID.String(),
+	})
+}
+
+func (u *UserRepository) GetTOTPLoginState(ctx context.Context, userID *domain.ID) (*dto.TOTPLoginState, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	res, err := u.getTOTPLogin.Execute(ctx, &operation.GetUserTOTPLoginParams{UserID: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	state := &dto.TOTPLoginState{Locked: res.Locked}
+	if res.ChallengeID != nil {
+		state.ChallengeID = *res.ChallengeID
+	}
+
+	return state, nil
+}
+
+// FailTOTPLogin counts failed attempt, login is locked for lockout once max attempts are reached.
+func (u *UserRepository) FailTOTPLogin(
+	ctx context.Context,
+	userID *domain.ID,
+	maxAttempts int,
+	lockout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateFailTOTPLogin.Execute(ctx, &operation.UpdateFailTOTPLoginParams{
+		UserID:      userID.String(),
+		MaxAttempts: maxAttempts,
+		Lockout:     lockout,
+	})
+}
+
+// FinishTOTPLogin consumes challenge and resets failed attempts, challenge can be finished only once.
+func (u *UserRepository) FinishTOTPLogin(ctx context.Context, userID, challengeID *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateFinishTOTP.Execute(ctx, &operation.UpdateFinishTOTPLoginParams{
+		UserID:      userID.String(),
+		ChallengeID: challengeID.String(),
+	})
+}
+
+func (u *UserRepository) UpdatePassword(ctx context.Context, userID *domain.ID, password *domain.Password) error {
+	bcryptHash, err := bcrypt.NewBcryptHashFromPassword(password)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return u.updateUserPassword.Execute(ctx, &operation.UpdateUserPasswordParams{
+		UserID:       userID.String(),
+		PasswordHash: bcryptHash.String(),
+	})
+}
+
+func rollback(tx *sql.Tx, err error) error {
+	txErr := tx.Rollback()
+	if txErr != nil {
+		return fmt.Errorf("failed to rollback transaction: %w", txErr)
+	}
+
+	return err
 }
@@ -0,0 +1,34 @@
+package secret
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Generate returns lowercase base32 encoded random value of size bytes.
+func Generate(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	return strings.ToLower(encoding.EncodeToString(b)), nil
+}
+
+// Hash returns hex encoded SHA-256 of value, secrets are stored only as hashes.
+func Hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateToken returns random token used in links sent by email.
+func GenerateToken() (string, error) {
+	return Generate(20)
+}
@@ -0,0 +1,38 @@
+package sender
+
+import (
+	"context"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type DomainVerifier interface {
+	IsVerifiedSenderAddress(ctx context.Context, userID *domain.ID, address *domain.Email) (bool, error)
+}
+
+// DomainVerifiers allows address verified by any of verifiers, application domains are usually checked first.
+type DomainVerifiers struct {
+	verifiers []DomainVerifier
+}
+
+func NewDomainVerifiers(verifiers ...DomainVerifier) *DomainVerifiers {
+	return &DomainVerifiers{verifiers: verifiers}
+}
+
+func (d *DomainVerifiers) IsVerifiedSenderAddress(
+	ctx context.Context,
+	userID *domain.ID,
+	address *domain.Email,
+) (bool, error) {
+	for _, v := range d.verifiers {
+		ok, err := v.IsVerifiedSenderAddress(ctx, userID, address)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,54 @@
+package sender
+
+import (
+	"context"
+	"strings"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+// StaticDomains is set of sending domains owned by application. Default sender is allowed only as exact address, its
+// domain may be shared mail provider where other mailboxes do not belong to application.
+type StaticDomains struct {
+	defaultAddress string
+	defaultDomain  string
+	domains        map[string]struct{}
+}
+
+func NewStaticDomains(defaultAddress string, domains []string) *StaticDomains {
+	s := &StaticDomains{
+		defaultAddress: strings.ToLower(defaultAddress),
+		domains:        make(map[string]struct{}, len(domains)),
+	}
+	if i := strings.LastIndex(s.defaultAddress, "@"); i >= 0 {
+		s.defaultDomain = s.defaultAddress[i+1:]
+	}
+	for _, d := range domains {
+		s.domains[strings.ToLower(d)] = struct{}{}
+	}
+
+	return s
+}
+
+// IsVerifiedSenderAddress allows default sender and addresses in application domains to every user.
+func (s *StaticDomains) IsVerifiedSenderAddress(_ context.Context, _ *domain.ID, address *domain.Email) (bool, error) {
+	if address.String() == s.defaultAddress {
+		return true, nil
+	}
+	_, ok := s.domains[address.Domain()]
+
+	return ok, nil
+}
+
+// Domains returns domains application sends from, domain of default sender included.
+func (s *StaticDomains) Domains() []string {
+	domains := make([]string, 0, len(s.domains)+1)
+	for d := range s.domains {
+		domains = append(domains, d)
+	}
+	if _, ok := s.domains[s.defaultDomain]; !ok && s.defaultDomain != "" {
+		domains = append(domains, s.defaultDomain)
+	}
+
+	return domains
+}
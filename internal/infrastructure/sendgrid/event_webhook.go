@@ -0,0 +1,106 @@
+package sendgrid
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+// emailJobIDArg is custom arg of message SendGrid copies to every event of it.
+const emailJobIDArg = "email_job_id"
+
+// deliveryEvents maps SendGrid events to delivery events, dropped messages are never delivered so they count
+// as bounced. Opens and clicks are tracked by application itself.
+var deliveryEvents = map[string]domain.DeliveryEventType{
+	"delivered": domain.DeliveryEventDelivered,
+	"bounce":    domain.DeliveryEventBounced,
+	"dropped":   domain.DeliveryEventBounced,
+}
+
+type event struct {
+	Event      string `json:"event"`
+	Timestamp  int64  `json:"timestamp"`
+	EmailJobID string `json:"email_job_id"`
+}
+
+// EventWebhook verifies and parses requests of SendGrid Signed Event Webhook.
+type EventWebhook struct {
+	key *ecdsa.PublicKey
+}
+
+// NewEventWebhook parses base64 encoded ECDSA verification key of webhook, without key every request is rejected.
+func NewEventWebhook(verificationKey string) (*EventWebhook, error) {
+	if verificationKey == "" {
+		return &EventWebhook{}, nil
+	}
+
+	der, err := base64.StdEncoding.DecodeString(verificationKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode webhook verification key: %w", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook verification key: %w", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("webhook verification key is not ECDSA key")
+	}
+
+	return &EventWebhook{key: ecdsaKey}, nil
+}
+
+// ParseDeliveryEvents verifies signature of payload and returns its delivery events of email jobs, events of other
+// messages and other types are skipped.
+func (w *EventWebhook) ParseDeliveryEvents(signature, timestamp string, payload []byte) ([]*domain.DeliveryEvent, error) {
+	if err := w.verify(signature, timestamp, payload); err != nil {
+		return nil, err
+	}
+
+	var events []*event
+	if err := json.Unmarshal(payload, &events); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal webhook events: %w", err)
+	}
+
+	deliveries := make([]*domain.DeliveryEvent, 0, len(events))
+	for _, e := range events {
+		eventType, ok := deliveryEvents[e.Event]
+		if !ok || e.EmailJobID == "" {
+			continue
+		}
+		jobID, err := domain.CreateIDFromExisting(e.EmailJobID)
+		if err != nil {
+			continue
+		}
+
+		deliveries = append(deliveries, domain.NewDeliveryEvent(jobID, eventType, time.Unix(e.Timestamp, 0).UTC()))
+	}
+
+	return deliveries, nil
+}
+
+// verify checks ECDSA signature of timestamp followed by payload.
+func (w *EventWebhook) verify(signature, timestamp string, payload []byte) error {
+	if w.key == nil || signature == "" || timestamp == "" {
+		return application.InvalidWebhookSignatureError
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return application.InvalidWebhookSignatureError
+	}
+	digest := sha256.Sum256(append([]byte(timestamp), payload...))
+	if !ecdsa.VerifyASN1(w.key, digest[:], sig) {
+		return application.InvalidWebhookSignatureError
+	}
+
+	return nil
+}
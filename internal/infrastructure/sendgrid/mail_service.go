@@ -1,66 +1,352 @@
 package sendgrid
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"html/template"
-	"os"
-	"path/filepath"
+	netmail "net/mail"
 	"strings"
+	"time"
 
 	"github.com/javor454/newsletter-assignment/app/config"
 	"github.com/javor454/newsletter-assignment/app/logger"
 	"github.com/javor454/newsletter-assignment/app/sendgrid"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/mailtemplate"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/smtp"
 	"github.com/sendgrid/sendgrid-go/helpers/mail"
 )
 
 const (
-	SubscribedTemplateName = "subscribed"
+	SubscribedTemplateName  = "subscribed"
+	InvitationTemplateName  = "invitation"
+	EmailChangeTemplateName = "email_change"
+	RemovedTemplateName     = "newsletter_removed"
+	IssueTemplateName       = "issue"
 )
 
+// Sender is resolved identity message is sent from.
+type Sender struct {
+	Name          string
+	Address       string
+	ReplyTo       string
+	PostalAddress string
+}
+
+// Issue is issue as it is delivered, content is html rendered from markdown which is already sanitized, with links
+// routed through click tracking. Tracking pixel is embedded only when its URL is given.
+type Issue struct {
+	Title         string
+	Content       string
+	TrackingPixel string
+}
+
+// Rendered is composed message ready to be delivered.
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// templateVariables lists data every template is rendered with, templates are validated against it on load.
+var templateVariables = map[string]*mailtemplate.Variables{
+	SubscribedTemplateName: {
+		Required:    []string{"Link", "PostalAddress"},
+		Optional:    []string{"Recipient", "NewsletterName", "SenderName", "Locale"},
+		Overridable: true,
+	},
+	InvitationTemplateName: {
+		Required:    []string{"Link", "Token", "PostalAddress"},
+		Optional:    []string{"Recipient", "NewsletterName", "Role", "SenderName", "Locale"},
+		Overridable: true,
+	},
+	EmailChangeTemplateName: {
+		Required: []string{"Link", "PostalAddress"},
+		Optional: []string{"Recipient", "SenderName", "Locale"},
+	},
+	RemovedTemplateName: {
+		Required: []string{"PostalAddress"},
+		Optional: []string{"Recipient", "NewsletterName", "SenderName", "Locale"},
+	},
+	IssueTemplateName: {
+		Required: []string{"Title", "Content", "Link", "PostalAddress"},
+		Optional: []string{"Recipient", "NewsletterName", "SenderName", "Locale", "TrackingPixel"},
+	},
+}
+
 type MailService struct {
 	lg        logger.Logger
 	conf      *config.AppConfig
 	client    *sendgrid.Client
-	templates map[string]*template.Template
+	relay     *smtp.Relay
+	templates *mailtemplate.Registry
 }
 
-func NewMailService(lg logger.Logger, conf *config.AppConfig, client *sendgrid.Client) *MailService {
-	m := &MailService{
+// NewMailService sends messages through SendGrid, or through own SMTP relay when it is given.
+func NewMailService(lg logger.Logger, conf *config.AppConfig, client *sendgrid.Client, relay *smtp.Relay) *MailService {
+	templates, err := mailtemplate.NewRegistry(
+		lg,
+		conf.SendGridTemplateDir,
+		domain.DefaultLocale().String(),
+		templateVariables,
+	)
+	if err != nil {
+		panic("failed to load templates: " + err.Error())
+	}
+	lg.Info("[EMAIL] Service initialized, templates loaded")
+
+	return &MailService{
 		lg:        lg,
 		conf:      conf,
 		client:    client,
-		templates: make(map[string]*template.Template),
+		relay:     relay,
+		templates: templates,
 	}
+}
 
-	if err := m.loadTemplates(conf.SendGridTemplateDir); err != nil {
-		panic("failed to load templates: " + err.Error())
+// WatchTemplates reloads templates whenever template directory changes, meant for development.
+func (m *MailService) WatchTemplates(ctx context.Context, interval time.Duration) {
+	m.templates.Watch(ctx, interval)
+	m.lg.Infof("[EMAIL] Watching templates every %s", interval)
+}
+
+// ValidateTemplate checks that override of template can be rendered with data of its message.
+func (m *MailService) ValidateTemplate(name, html, text string) error {
+	if err := m.templates.Validate(name, &mailtemplate.Override{HTML: html, Text: text}); err != nil {
+		return fmt.Errorf("%w: %s", application.InvalidTemplateError, err.Error())
 	}
-	lg.Info("[EMAIL] Service initialized, templates loaded")
 
-	return m
+	return nil
 }
 
-func (m *MailService) SendSubscribed(recipient, newsletterPublicID, token string) error {
-	tmpl, ok := m.templates[SubscribedTemplateName]
-	if !ok {
-		return fmt.Errorf("template \"%s\" not loaded", SubscribedTemplateName)
+// DefaultSender is used for messages not related to any newsletter and for values newsletter does not override.
+func (m *MailService) DefaultSender() *Sender {
+	return &Sender{
+		Name:          m.conf.SenderName,
+		Address:       m.conf.SenderAddress,
+		PostalAddress: m.conf.SenderPostalAddress,
 	}
+}
 
+// SendSubscribed confirms subscription in preferred locale of subscriber.
+func (m *MailService) SendSubscribed(
+	sender *Sender,
+	override *mailtemplate.Override,
+	locale, recipient, newsletterName, newsletterPublicID, token string,
+) error {
 	link := m.createUnsubscribeLink(newsletterPublicID, token)
 	m.lg.Debugf("[EMAIL] Unsubscribe link: %s", link)
 
-	var body bytes.Buffer
-	if err := tmpl.Execute(&body, map[string]any{
+	return m.send(sender, override, locale, recipient, SubscribedTemplateName, map[string]any{
+		"Recipient":      recipient,
+		"NewsletterName": newsletterName,
+		"Link":           link,
+	})
+}
+
+func (m *MailService) SendInvitation(
+	sender *Sender,
+	override *mailtemplate.Override,
+	recipient, newsletterName, role, token string,
+) error {
+	return m.send(sender, override, "", recipient, InvitationTemplateName, map[string]any{
+		"Recipient":      recipient,
+		"NewsletterName": newsletterName,
+		"Role":           role,
+		"Token":          token,
+		"Link":           fmt.Sprintf("%s:%d/api/v1/invitations/accept", m.conf.Host, m.conf.HttpPort),
+	})
+}
+
+func (m *MailService) SendEmailChange(recipient, token string) error {
+	return m.send(m.DefaultSender(), nil, "", recipient, EmailChangeTemplateName, map[string]any{
 		"Recipient": recipient,
-		"Link":      link,
-	}); err != nil {
-		return fmt.Errorf("template \"%s\" execute error: %w", SubscribedTemplateName, err)
+		"Link":      fmt.Sprintf("%s:%d/api/v1/users/email/verify?token=%s", m.conf.Host, m.conf.HttpPort, token),
+	})
+}
+
+// SendNewsletterRemoved notifies subscriber in their preferred locale.
+func (m *MailService) SendNewsletterRemoved(sender *Sender, locale, recipient, newsletterName string) error {
+	return m.send(sender, nil, locale, recipient, RemovedTemplateName, map[string]any{
+		"Recipient":      recipient,
+		"NewsletterName": newsletterName,
+	})
+}
+
+// SendIssue delivers published issue. Email job is attached to message so delivery events reported by SendGrid can be
+// paired with it.
+func (m *MailService) SendIssue(
+	sender *Sender,
+	issue *Issue,
+	emailJobID, locale, recipient, newsletterName, newsletterPublicID, token string,
+) error {
+	data := m.issueData(issue, recipient, newsletterName, newsletterPublicID, token)
+
+	rendered, err := m.render(sender, nil, locale, IssueTemplateName, data)
+	if err != nil {
+		return err
+	}
+
+	return m.deliver(sender, recipient, rendered.Subject, rendered.Text, rendered.HTML, map[string]string{
+		emailJobIDArg: emailJobID,
+	})
+}
+
+// Preview renders newsletter template with sample data as recipient would receive it, nothing is sent. Issue template
+// is rendered with given issue.
+func (m *MailService) Preview(
+	sender *Sender,
+	override *mailtemplate.Override,
+	issue *Issue,
+	locale, templateName, recipient, newsletterName, newsletterPublicID string,
+) (*Rendered, error) {
+	data, err := m.sampleData(templateName, issue, recipient, newsletterName, newsletterPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.render(sender, override, locale, templateName, data)
+}
+
+// SendTest delivers preview of newsletter template to recipient through the normal transport, subject is marked
+// as test so it is not mistaken for real message.
+func (m *MailService) SendTest(
+	sender *Sender,
+	override *mailtemplate.Override,
+	issue *Issue,
+	locale, templateName, recipient, newsletterName, newsletterPublicID string,
+) error {
+	rendered, err := m.Preview(
+		sender,
+		override,
+		issue,
+		locale,
+		templateName,
+		recipient,
+		newsletterName,
+		newsletterPublicID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return m.deliver(sender, recipient, "[TEST] "+rendered.Subject, rendered.Text, rendered.HTML, nil)
+}
+
+// sampleData returns data of newsletter template with placeholder tokens, links lead nowhere.
+func (m *MailService) sampleData(
+	templateName string,
+	issue *Issue,
+	recipient, newsletterName, newsletterPublicID string,
+) (map[string]any, error) {
+	const previewToken = "preview"
+
+	switch templateName {
+	case SubscribedTemplateName:
+		return map[string]any{
+			"Recipient":      recipient,
+			"NewsletterName": newsletterName,
+			"Link":           m.createUnsubscribeLink(newsletterPublicID, previewToken),
+		}, nil
+	case InvitationTemplateName:
+		return map[string]any{
+			"Recipient":      recipient,
+			"NewsletterName": newsletterName,
+			"Role":           string(domain.RoleEditor),
+			"Token":          previewToken,
+			"Link":           fmt.Sprintf("%s:%d/api/v1/invitations/accept", m.conf.Host, m.conf.HttpPort),
+		}, nil
+	case RemovedTemplateName:
+		return map[string]any{
+			"Recipient":      recipient,
+			"NewsletterName": newsletterName,
+		}, nil
+	case IssueTemplateName:
+		if issue == nil {
+			return nil, application.IssueRequiredError
+		}
+
+		return m.issueData(issue, recipient, newsletterName, newsletterPublicID, previewToken), nil
+	default:
+		return nil, application.TemplateNotFoundError
+	}
+}
+
+func (m *MailService) issueData(issue *Issue, recipient, newsletterName, newsletterPublicID, token string) map[string]any {
+	data := map[string]any{
+		"Recipient":      recipient,
+		"NewsletterName": newsletterName,
+		"Title":          issue.Title,
+		"Content":        template.HTML(issue.Content),
+		"Link":           m.createUnsubscribeLink(newsletterPublicID, token),
+	}
+	if issue.TrackingPixel != "" {
+		data["TrackingPixel"] = issue.TrackingPixel
+	}
+
+	return data
+}
+
+// send renders template and delivers it to recipient.
+func (m *MailService) send(
+	sender *Sender,
+	override *mailtemplate.Override,
+	locale, recipient, templateName string,
+	data map[string]any,
+) error {
+	rendered, err := m.render(sender, override, locale, templateName, data)
+	if err != nil {
+		return err
+	}
+
+	return m.deliver(sender, recipient, rendered.Subject, rendered.Text, rendered.HTML, nil)
+}
+
+// render renders template in locale, or its newsletter override when given, with sender details available
+// as SenderName and PostalAddress. Subject is translated by key <template>.subject, empty locale means default one.
+func (m *MailService) render(
+	sender *Sender,
+	override *mailtemplate.Override,
+	locale, templateName string,
+	data map[string]any,
+) (*Rendered, error) {
+	if locale == "" {
+		locale = domain.DefaultLocale().String()
+	}
+	data["SenderName"] = sender.Name
+	data["PostalAddress"] = sender.PostalAddress
+	data["Locale"] = locale
+
+	subject, err := m.templates.Translate(locale, templateName+".subject", data)
+	if err != nil {
+		return nil, err
+	}
+
+	text, html, err := m.templates.Render(templateName, locale, override, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rendered{Subject: subject, Text: text, HTML: html}, nil
+}
+
+// deliver sends message through own relay when configured, otherwise through SendGrid. Custom args are returned
+// by SendGrid in events of message, relay ignores them.
+func (m *MailService) deliver(sender *Sender, recipient, subject, text, html string, customArgs map[string]string) error {
+	if m.relay != nil {
+		return m.sendThroughRelay(sender, recipient, subject, text, html)
 	}
 
-	from := mail.NewEmail("Jiri", "javornicky.jiri@gmail.com")
+	from := mail.NewEmail(sender.Name, sender.Address)
 	to := mail.NewEmail("Recipient", recipient)
-	message := mail.NewSingleEmail(from, "Subscribed to newsletter", to, body.String(), body.String())
+	message := mail.NewSingleEmail(from, subject, to, text, html)
+	if sender.ReplyTo != "" {
+		message.SetReplyTo(mail.NewEmail(sender.Name, sender.ReplyTo))
+	}
+	for key, value := range customArgs {
+		message.SetCustomArg(key, value)
+	}
 
 	response, err := m.client.Send(message)
 	if err != nil {
@@ -72,6 +358,34 @@ func (m *MailService) SendSubscribed(recipient, newsletterPublicID, token string
 	return nil
 }
 
+func (m *MailService) sendThroughRelay(sender *Sender, recipient, subject, text, html string) error {
+	messageID, err := smtp.NewMessageID(sender.Address[strings.LastIndex(sender.Address, "@")+1:])
+	if err != nil {
+		return err
+	}
+
+	message := &smtp.Message{
+		From:      &netmail.Address{Name: sender.Name, Address: sender.Address},
+		To:        &netmail.Address{Address: recipient},
+		Subject:   subject,
+		Text:      text,
+		HTML:      html,
+		Date:      time.Now(),
+		MessageID: messageID,
+	}
+	if sender.ReplyTo != "" {
+		message.ReplyTo = &netmail.Address{Name: sender.Name, Address: sender.ReplyTo}
+	}
+
+	if err := m.relay.Send(message); err != nil {
+		return err
+	}
+
+	m.lg.Debugf("[EMAIL] Send success through relay. Message ID: %s", messageID)
+
+	return nil
+}
+
 func (m *MailService) createUnsubscribeLink(newsletterPublicID string, token string) string {
 	return fmt.Sprintf(
 		"%s:%d/api/v1/unsubscribe?newsletter_public_id=%s&token=%s",
@@ -81,32 +395,3 @@ func (m *MailService) createUnsubscribeLink(newsletterPublicID string, token str
 		token,
 	)
 }
-
-func (m *MailService) loadTemplates(dir string) error {
-	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return fmt.Errorf("failed to load templates from %s: %w", path, err)
-		}
-		if info.IsDir() {
-			return nil
-		}
-		if filepath.Ext(path) != ".html" {
-			return nil
-		}
-
-		b, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read template file %s: %w", path, err)
-		}
-
-		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
-		tmpl, err := template.New(name).Parse(string(b))
-		if err != nil {
-			return fmt.Errorf("failed to parse template %s: %w", name, err)
-		}
-
-		m.templates[name] = tmpl
-
-		return nil
-	})
-}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/secret"
+)
+
+type EmailChangeParams struct {
+	Email string `json:"email"`
+	Token string `json:"token"`
+}
+
+type AccountRepository struct {
+	pgConn                   *sql.DB
+	updateConfirmEmailChange *operation.UpdateConfirmEmailChange
+}
+
+func NewAccountRepository(pgConn *sql.DB, ucec *operation.UpdateConfirmEmailChange) *AccountRepository {
+	return &AccountRepository{
+		pgConn:                   pgConn,
+		updateConfirmEmailChange: ucec,
+	}
+}
+
+// RequestEmailChange stores pending change together with verification email job, only hash of token is persisted.
+func (a *AccountRepository) RequestEmailChange(ctx context.Context, change *domain.EmailChange, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	paramsJson, err := json.Marshal(EmailChangeParams{
+		Email: change.Email().String(),
+		Token: token,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal email change params: %w", err)
+	}
+
+	tx, err := a.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	if err := operation.CreateEmailChangeTx(ctx, tx, &operation.CreateEmailChangeParams{
+		UserID:    change.UserID().String(),
+		NewEmail:  change.Email().String(),
+		TokenHash: secret.Hash(token),
+		ExpiresAt: change.ExpiresAt(),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.CreateEmailJobTx(ctx, tx, &operation.CreateEmailJobParams{
+		ID:     uuid.New().String(),
+		Type:   row.EmailChangeType,
+		Params: paramsJson,
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit email change tx: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AccountRepository) ConfirmEmailChange(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return a.updateConfirmEmailChange.Execute(ctx, &operation.UpdateConfirmEmailChangeParams{
+		TokenHash: secret.Hash(token),
+	})
+}
+
+// Delete removes user in single transaction. Owned newsletters are transferred to their longest standing editor,
+// the rest is archived and their subscriptions are disabled, each raising EventSubscriptionDisabled. Sending domains of
+// user are removed by cascade, so from address on them is reset unless new owner verified the domain too.
+func (a *AccountRepository) Delete(ctx context.Context, userID *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	tx, err := a.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	domains, err := operation.GetSendingDomainNamesTx(ctx, tx, &operation.GetSendingDomainNamesParams{
+		UserID: userID.String(),
+	})
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.UpdateTransferOwnedNewslettersTx(ctx, tx, &operation.UpdateTransferOwnedNewslettersParams{
+		UserID: userID.String(),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	rows, err := operation.UpdateArchiveOwnedNewslettersTx(ctx, tx, &operation.UpdateArchiveOwnedNewslettersParams{
+		UserID: userID.String(),
+	})
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	events := make([]*domain.Event, 0, len(rows))
+	for _, r := range rows {
+		email, err := domain.NewEmail(r.Email)
+		if err != nil {
+			return rollback(tx, fmt.Errorf("invalid email format in db %w", err))
+		}
+		pubID, err := domain.CreateIDFromExisting(r.NewsletterPublicID)
+		if err != nil {
+			return rollback(tx, fmt.Errorf("invalid uuid format in db %w", err))
+		}
+		events = append(events, domain.NewUnsubscription(pubID, email).PullEvents()...)
+	}
+	if err := storeEventsTx(ctx, tx, events); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.DeleteUserTx(ctx, tx, &operation.DeleteUserParams{UserID: userID.String()}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.UpdateResetUnverifiedSenderTx(ctx, tx, &operation.UpdateResetUnverifiedSenderParams{
+		Domains: domains,
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit delete account tx: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+type DeliveryEventRepository struct {
+	pgConn *sql.DB
+}
+
+func NewDeliveryEventRepository(pgConn *sql.DB) *DeliveryEventRepository {
+	return &DeliveryEventRepository{pgConn: pgConn}
+}
+
+// RecordDeliveryEvent records event of email, the first bounce of email sent on behalf of newsletter raises
+// domain event.
+func (d *DeliveryEventRepository) RecordDeliveryEvent(ctx context.Context, event *domain.DeliveryEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	tx, err := d.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	first, err := operation.CreateDeliveryEventTx(ctx, tx, &operation.CreateDeliveryEventParams{
+		EmailJobID: event.EmailJobID().String(),
+		Type:       string(event.Type()),
+		OccurredAt: event.OccurredAt(),
+	})
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	if first && event.Type() == domain.DeliveryEventBounced {
+		recipient, err := operation.GetEmailJobRecipientTx(ctx, tx, &operation.GetEmailJobRecipientParams{
+			EmailJobID: event.EmailJobID().String(),
+		})
+		if err != nil {
+			return rollback(tx, err)
+		}
+
+		if recipient.NewsletterPublicID != nil {
+			pubID, err := domain.CreateIDFromExisting(*recipient.NewsletterPublicID)
+			if err != nil {
+				return rollback(tx, fmt.Errorf("invalid newsletter public id in db %w", err))
+			}
+			event.AttributeToNewsletter(pubID, recipient.Email)
+		}
+	}
+	if err := storeEventsTx(ctx, tx, event.PullEvents()); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit delivery event tx: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+const (
+	// outboxEventLease is longer than handling of whole batch, claimed events are not dispatched twice meanwhile.
+	outboxEventLease = time.Minute
+	// maxOutboxRetryDelay caps growing delay of failed dispatch.
+	maxOutboxRetryDelay = time.Hour
+	// maxOutboxAttempts gives up event after about 9 hours of failed attempts, so it stops holding back its aggregate.
+	maxOutboxAttempts = 20
+)
+
+// EventHandler reacts to dispatched event. Dispatch is at least once and failure of any handler repeats it for every
+// handler of the event, so handlers have to be idempotent.
+type EventHandler interface {
+	HandleEvent(ctx context.Context, event *domain.Event) error
+}
+
+type EventDispatcher struct {
+	lg                      logger.Logger
+	updateClaimOutboxEvents *operation.UpdateClaimOutboxEvents
+	updateOutboxEvent       *operation.UpdateOutboxEvent
+	handlers                map[domain.EventName][]EventHandler
+	now                     func() time.Time
+}
+
+func NewEventDispatcher(
+	lg logger.Logger,
+	ucoe *operation.UpdateClaimOutboxEvents,
+	uoe *operation.UpdateOutboxEvent,
+	now func() time.Time,
+) *EventDispatcher {
+	return &EventDispatcher{
+		lg:                      lg,
+		updateClaimOutboxEvents: ucoe,
+		updateOutboxEvent:       uoe,
+		handlers:                make(map[domain.EventName][]EventHandler),
+		now:                     now,
+	}
+}
+
+// Register adds handler of events with given names, it has to be called before dispatching starts.
+func (d *EventDispatcher) Register(h EventHandler, names ...domain.EventName) {
+	for _, name := range names {
+		d.handlers[name] = append(d.handlers[name], h)
+	}
+}
+
+// DispatchEvents passes batch of due events from outbox to their handlers in order events occurred, failed ones are
+// scheduled with growing delay. Later events of aggregate with failed event are not claimed until it is dispatched,
+// event failing maxOutboxAttempts times is marked failed and left to operators.
+func (d *EventDispatcher) DispatchEvents(ctx context.Context) error {
+	const maxEvents = 100
+
+	claimCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	events, err := d.updateClaimOutboxEvents.Execute(claimCtx, &operation.UpdateClaimOutboxEventsParams{
+		Limit: maxEvents,
+		Lease: outboxEventLease,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+	d.lg.Debugf("[EVENT] Dispatching %d events...", len(events))
+
+	for _, e := range events {
+		if err := d.dispatchEvent(ctx, e); err != nil {
+			d.lg.WithField("event_id", e.ID).WithError(err).Error("failed to record event dispatch")
+		}
+	}
+
+	return nil
+}
+
+func (d *EventDispatcher) dispatchEvent(ctx context.Context, r *row.OutboxEvent) error {
+	handleErr := d.handle(ctx, r)
+	now := d.now()
+
+	p := &operation.UpdateOutboxEventParams{ID: r.ID, AttemptAt: now}
+	if handleErr != nil {
+		msg := handleErr.Error()
+		p.Error = &msg
+		lg := d.lg.WithFields(map[string]interface{}{
+			"event_id":      r.ID,
+			"event_name":    r.Name,
+			"aggregate_key": r.AggregateKey,
+			"attempts":      r.Attempts + 1,
+		}).WithError(handleErr)
+		if delay, ok := outboxRetryDelay(r.Attempts + 1); ok {
+			next := now.Add(delay)
+			p.NextAttemptAt = &next
+			lg.Warn("[EVENT] Dispatch failed")
+		} else {
+			lg.Error("[EVENT] Dispatch failed permanently, event is given up")
+		}
+	}
+
+	updateCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return d.updateOutboxEvent.Execute(updateCtx, p)
+}
+
+func (d *EventDispatcher) handle(ctx context.Context, r *row.OutboxEvent) error {
+	event, err := createEventFromRow(r)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, h := range d.handlers[event.Name()] {
+		if err := h.HandleEvent(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// outboxRetryDelay doubles wait from second up to an hour with every failed attempt, false when event is given up.
+func outboxRetryDelay(attempts int) (time.Duration, bool) {
+	if attempts >= maxOutboxAttempts {
+		return 0, false
+	}
+	if attempts > 12 {
+		return maxOutboxRetryDelay, true
+	}
+
+	return min(time.Second<<attempts, maxOutboxRetryDelay), true
+}
+
+func createEventFromRow(r *row.OutboxEvent) (*domain.Event, error) {
+	id, err := domain.CreateIDFromExisting(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event id in db %w", err)
+	}
+	pubID, err := domain.CreateIDFromExisting(r.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid newsletter public id in db %w", err)
+	}
+	var data map[string]string
+	if err := json.Unmarshal(r.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
+	}
+
+	return domain.CreateEventFromExisting(id, domain.EventName(r.Name), r.AggregateKey, pubID, r.OccurredAt, data), nil
+}
+
+// storeEventsTx writes events raised by aggregate to outbox in transaction of the change which raised them.
+func storeEventsTx(ctx context.Context, tx *sql.Tx, events []*domain.Event) error {
+	params := make([]*operation.CreateOutboxEventParams, 0, len(events))
+	for _, e := range events {
+		data := e.Data()
+		if data == nil {
+			data = map[string]string{}
+		}
+		dataJson, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event data: %w", err)
+		}
+
+		params = append(params, &operation.CreateOutboxEventParams{
+			ID:                 e.ID().String(),
+			Name:               string(e.Name()),
+			AggregateKey:       e.AggregateKey(),
+			NewsletterPublicID: e.NewsletterPublicID().String(),
+			OccurredAt:         e.OccurredAt(),
+			Data:               dataJson,
+		})
+	}
+
+	return operation.CreateOutboxEventsTx(ctx, tx, params)
+}
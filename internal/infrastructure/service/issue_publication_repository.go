@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+)
+
+type IssueParams struct {
+	Email              string `json:"email"`
+	NewsletterPublicID string `json:"newsletter_id"`
+	IssueID            string `json:"issue_id"`
+	SubscriptionToken  string `json:"subscription_token"`
+	Locale             string `json:"locale,omitempty"`
+}
+
+type IssuePublicationRepository struct {
+	pgConn *sql.DB
+}
+
+func NewIssuePublicationRepository(pgConn *sql.DB) *IssuePublicationRepository {
+	return &IssuePublicationRepository{pgConn: pgConn}
+}
+
+// Publish marks issue as published and enqueues its email for every active subscriber in single transaction,
+// jobs reference the issue so the body is not copied per recipient.
+func (i *IssuePublicationRepository) Publish(ctx context.Context, issue *domain.Issue) (int, error) {
+	// one job is written per subscriber, large newsletters need more time than single row operations
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := i.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return 0, fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	newsletterPublicID := issue.NewsletterPublicID().String()
+	if err := operation.UpdatePublishIssueTx(ctx, tx, &operation.UpdatePublishIssueParams{
+		ID:                 issue.ID().String(),
+		NewsletterPublicID: newsletterPublicID,
+		PublishedAt:        *issue.PublishedAt(),
+	}); err != nil {
+		return 0, rollback(tx, err)
+	}
+
+	subscriptions, err := operation.GetActiveSubscriptionsTx(ctx, tx, &operation.GetActiveSubscriptionsParams{
+		NewsletterPublicID: newsletterPublicID,
+	})
+	if err != nil {
+		return 0, rollback(tx, err)
+	}
+
+	for _, s := range subscriptions {
+		paramsJson, err := json.Marshal(IssueParams{
+			Email:              s.Email,
+			NewsletterPublicID: newsletterPublicID,
+			IssueID:            issue.ID().String(),
+			SubscriptionToken:  s.Token,
+			Locale:             s.Locale,
+		})
+		if err != nil {
+			return 0, rollback(tx, fmt.Errorf("failed to marshal issue params: %w", err))
+		}
+		if err := operation.CreateEmailJobTx(ctx, tx, &operation.CreateEmailJobParams{
+			ID:                 uuid.New().String(),
+			Type:               row.IssueType,
+			Params:             paramsJson,
+			NewsletterPublicID: &newsletterPublicID,
+		}); err != nil {
+			return 0, rollback(tx, err)
+		}
+	}
+
+	if err := storeEventsTx(ctx, tx, issue.PullEvents()); err != nil {
+		return 0, rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit issue publication tx: %w", err)
+	}
+
+	return len(subscriptions), nil
+}
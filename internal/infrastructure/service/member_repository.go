@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/secret"
+)
+
+type MemberRepository struct {
+	pgConn                   *sql.DB
+	getNewsletterRole        *operation.GetNewsletterRole
+	getNewsletterMembers     *operation.GetNewsletterMembers
+	getInvitationByTokenHash *operation.GetInvitationByTokenHash
+	deleteMember             *operation.DeleteMember
+}
+
+func NewMemberRepository(
+	pgConn *sql.DB,
+	gnr *operation.GetNewsletterRole,
+	gnm *operation.GetNewsletterMembers,
+	gibth *operation.GetInvitationByTokenHash,
+	dm *operation.DeleteMember,
+) *MemberRepository {
+	return &MemberRepository{
+		pgConn:                   pgConn,
+		getNewsletterRole:        gnr,
+		getNewsletterMembers:     gnm,
+		getInvitationByTokenHash: gibth,
+		deleteMember:             dm,
+	}
+}
+
+func (m *MemberRepository) GetRole(ctx context.Context, newsletterPublicID, userID *domain.ID) (domain.Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	role, err := m.getNewsletterRole.Execute(ctx, &operation.GetNewsletterRoleParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		UserID:             userID.String(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	r, err := domain.NewRole(role)
+	if err != nil {
+		return "", fmt.Errorf("invalid role %q in db %w", role, err)
+	}
+
+	return r, nil
+}
+
+func (m *MemberRepository) GetMembers(ctx context.Context, newsletterPublicID *domain.ID) ([]*domain.Member, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, err := m.getNewsletterMembers.Execute(ctx, &operation.GetNewsletterMembersParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*domain.Member, 0, len(rows))
+	for _, r := range rows {
+		userID, err := domain.CreateIDFromExisting(r.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid format in db %w", err)
+		}
+		email, err := domain.NewEmail(r.Email)
+		if err != nil {
+			return nil, fmt.Errorf("invalid email format in db %w", err)
+		}
+		role, err := domain.NewRole(r.Role)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role %q in db %w", r.Role, err)
+		}
+		members = append(members, domain.CreateMemberFromExisting(userID, email, role, r.CreatedAt))
+	}
+
+	return members, nil
+}
+
+// Invite stores invitation together with email job, only hash of token is persisted.
+func (m *MemberRepository) Invite(ctx context.Context, invitation *domain.Invitation, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	paramsJson, err := json.Marshal(InvitationParams{
+		Email:              invitation.Email().String(),
+		NewsletterPublicID: invitation.NewsletterPublicID().String(),
+		NewsletterName:     invitation.NewsletterName(),
+		Role:               string(invitation.Role()),
+		Token:              token,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal invitation params: %w", err)
+	}
+
+	tx, err := m.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	if err := operation.CreateInvitationTx(ctx, tx, &operation.CreateInvitationParams{
+		ID:                 invitation.ID().String(),
+		NewsletterPublicID: invitation.NewsletterPublicID().String(),
+		Email:              invitation.Email().String(),
+		Role:               string(invitation.Role()),
+		TokenHash:          secret.Hash(token),
+		InvitedBy:          invitation.InvitedBy().String(),
+		ExpiresAt:          invitation.ExpiresAt(),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	newsletterPublicID := invitation.NewsletterPublicID().String()
+	if err := operation.CreateEmailJobTx(ctx, tx, &operation.CreateEmailJobParams{
+		ID:                 uuid.New().String(),
+		Type:               row.InvitationType,
+		Params:             paramsJson,
+		NewsletterPublicID: &newsletterPublicID,
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit invitation tx: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemberRepository) GetInvitationByToken(ctx context.Context, token string) (*domain.Invitation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	r, err := m.getInvitationByTokenHash.Execute(ctx, &operation.GetInvitationByTokenHashParams{
+		TokenHash: secret.Hash(token),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := domain.CreateIDFromExisting(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uuid format in db %w", err)
+	}
+	publicID, err := domain.CreateIDFromExisting(r.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid uuid format in db %w", err)
+	}
+	email, err := domain.NewEmail(r.Email)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email format in db %w", err)
+	}
+	role, err := domain.NewRole(r.Role)
+	if err != nil {
+		return nil, fmt.Errorf("invalid role %q in db %w", r.Role, err)
+	}
+	var invitedBy *domain.ID
+	if r.InvitedBy != nil {
+		invitedBy, err = domain.CreateIDFromExisting(*r.InvitedBy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid format in db %w", err)
+		}
+	}
+
+	return domain.CreateInvitationFromExisting(id, publicID, r.NewsletterName, email, role, invitedBy, r.ExpiresAt), nil
+}
+
+// AcceptInvitation creates membership and marks invitation as accepted in single transaction.
+func (m *MemberRepository) AcceptInvitation(ctx context.Context, invitation *domain.Invitation, userID *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	tx, err := m.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	if err := operation.UpdateAcceptInvitationTx(ctx, tx, &operation.UpdateAcceptInvitationParams{
+		ID: invitation.ID().String(),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.CreateMemberTx(ctx, tx, &operation.CreateMemberParams{
+		ID:                 uuid.New().String(),
+		NewsletterPublicID: invitation.NewsletterPublicID().String(),
+		UserID:             userID.String(),
+		Role:               string(invitation.Role()),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit accept invitation tx: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MemberRepository) RemoveMember(ctx context.Context, newsletterPublicID, userID *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return m.deleteMember.Execute(ctx, &operation.DeleteMemberParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		UserID:             userID.String(),
+	})
+}
+
+// TransferOwnership demotes owner to editor and promotes existing member to owner in single transaction. From address on
+// domain of previous owner is reset to default sender unless new owner verified the domain too.
+func (m *MemberRepository) TransferOwnership(ctx context.Context, newsletterPublicID, ownerID, newOwnerID *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	tx, err := m.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	// owner is demoted first, unique index allows single owner per newsletter
+	if err := operation.UpdateMemberRoleTx(ctx, tx, &operation.UpdateMemberRoleParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		UserID:             ownerID.String(),
+		Role:               string(domain.RoleEditor),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.UpdateMemberRoleTx(ctx, tx, &operation.UpdateMemberRoleParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		UserID:             newOwnerID.String(),
+		Role:               string(domain.RoleOwner),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := operation.UpdateNewsletterOwnerTx(ctx, tx, &operation.UpdateNewsletterOwnerParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		UserID:             newOwnerID.String(),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	domains, err := operation.GetSendingDomainNamesTx(ctx, tx, &operation.GetSendingDomainNamesParams{
+		UserID: ownerID.String(),
+	})
+	if err != nil {
+		return rollback(tx, err)
+	}
+	if err := operation.UpdateResetUnverifiedSenderTx(ctx, tx, &operation.UpdateResetUnverifiedSenderParams{
+		Domains: domains,
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transfer ownership tx: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+type NewsletterRemovedParams struct {
+	Email          string        `json:"email"`
+	NewsletterName string        `json:"newsletter_name"`
+	Sender         *SenderParams `json:"sender,omitempty"`
+	Locale         string        `json:"locale,omitempty"`
+}
+
+type NewsletterRemovalRepository struct {
+	pgConn  *sql.DB
+	timeout time.Duration
+}
+
+// NewNewsletterRemovalRepository removes newsletters within timeout, removal transaction touches every subscription
+// and email job of newsletter so it can not share short timeout of single row queries.
+func NewNewsletterRemovalRepository(pgConn *sql.DB, timeout time.Duration) *NewsletterRemovalRepository {
+	return &NewsletterRemovalRepository{pgConn: pgConn, timeout: timeout}
+}
+
+// Archive hides newsletter, cancels its pending email jobs and disables subscriptions in single transaction.
+func (n *NewsletterRemovalRepository) Archive(ctx context.Context, newsletterPublicID *domain.ID, notify bool) error {
+	return n.remove(ctx, newsletterPublicID, notify, func(ctx context.Context, tx *sql.Tx) (string, []string, error) {
+		return operation.UpdateArchiveNewsletterTx(ctx, tx, &operation.UpdateArchiveNewsletterParams{
+			PublicID: newsletterPublicID.String(),
+		})
+	})
+}
+
+// Delete removes newsletter together with its subscriptions and email jobs in single transaction.
+func (n *NewsletterRemovalRepository) Delete(ctx context.Context, newsletterPublicID *domain.ID, notify bool) error {
+	return n.remove(ctx, newsletterPublicID, notify, func(ctx context.Context, tx *sql.Tx) (string, []string, error) {
+		return operation.DeleteNewsletterTx(ctx, tx, &operation.DeleteNewsletterParams{
+			PublicID: newsletterPublicID.String(),
+		})
+	})
+}
+
+// remove runs removal operation returning newsletter name and active subscriber emails, every active subscription
+// raises EventSubscriptionDisabled. Notification jobs are enqueued for active subscribers before removal.
+func (n *NewsletterRemovalRepository) remove(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	notify bool,
+	removeTx func(ctx context.Context, tx *sql.Tx) (string, []string, error),
+) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	tx, err := n.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	// sender identity is read before removal, hard deleted newsletter would lose it
+	sender, err := operation.GetNewsletterSenderTx(ctx, tx, &operation.GetNewsletterSenderParams{
+		PublicID: newsletterPublicID.String(),
+	})
+	if err != nil {
+		return rollback(tx, err)
+	}
+	if notify {
+		senderJson, err := json.Marshal(createSenderParams(sender))
+		if err != nil {
+			return rollback(tx, fmt.Errorf("failed to marshal sender params: %w", err))
+		}
+		if err := operation.CreateNewsletterRemovedEmailJobsTx(ctx, tx, &operation.CreateNewsletterRemovedEmailJobsParams{
+			NewsletterPublicID: newsletterPublicID.String(),
+			Sender:             senderJson,
+		}); err != nil {
+			return rollback(tx, err)
+		}
+	}
+
+	_, emails, err := removeTx(ctx, tx)
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	events := make([]*domain.Event, 0, len(emails))
+	for _, e := range emails {
+		email, err := domain.NewEmail(e)
+		if err != nil {
+			return rollback(tx, fmt.Errorf("invalid email format in db %w", err))
+		}
+		events = append(events, domain.NewUnsubscription(newsletterPublicID, email).PullEvents()...)
+	}
+
+	if err := storeEventsTx(ctx, tx, events); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit newsletter removal tx: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+)
+
+// deliveryEventDelay is how long mail provider retries reporting of delivery events, days it covers are refreshed.
+const deliveryEventDelay = 72 * time.Hour
+
+type StatsRepository struct {
+	pgConn                *sql.DB
+	getNewsletterStats    *operation.GetNewsletterStats
+	getIssueStats         *operation.GetIssueStats
+	getUnsubscribeReasons *operation.GetUnsubscribeReasons
+}
+
+func NewStatsRepository(
+	pgConn *sql.DB,
+	gns *operation.GetNewsletterStats,
+	gis *operation.GetIssueStats,
+	gur *operation.GetUnsubscribeReasons,
+) *StatsRepository {
+	return &StatsRepository{pgConn: pgConn, getNewsletterStats: gns, getIssueStats: gis, getUnsubscribeReasons: gur}
+}
+
+func (s *StatsRepository) GetNewsletterStats(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	period *domain.StatsPeriod,
+) (*dto.NewsletterStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	buckets, baseline, err := s.getNewsletterStats.Execute(ctx, &operation.GetNewsletterStatsParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		Interval:           string(period.Interval()),
+		From:               period.From(),
+		To:                 period.To(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[time.Time]*dto.StatsCounts, len(buckets))
+	for _, b := range buckets {
+		counts[period.Interval().Start(b.Start)] = &dto.StatsCounts{
+			Subscribed:   b.Subscribed,
+			Unsubscribed: b.Unsubscribed,
+			Sent:         b.Sent,
+			Delivered:    b.Delivered,
+			Bounced:      b.Bounced,
+			Opened:       b.Opened,
+			Clicked:      b.Clicked,
+		}
+	}
+
+	reasons, err := s.getUnsubscribeReasons.Execute(ctx, &operation.GetUnsubscribeReasonsParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		From:               period.From(),
+		To:                 period.To(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := dto.NewNewsletterStats(period, baseline, counts)
+	stats.UnsubscribeReasons = make(map[string]int, len(reasons))
+	for _, code := range domain.UnsubscribeReasonCodes() {
+		stats.UnsubscribeReasons[code] = reasons[code]
+	}
+
+	return stats, nil
+}
+
+func (s *StatsRepository) GetIssueStats(ctx context.Context, newsletterPublicID, issueID *domain.ID) (*dto.IssueStats, error) {
+	// issue stats are computed from events of all its emails, large newsletters need more time
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := s.getIssueStats.Execute(ctx, &operation.GetIssueStatsParams{
+		NewsletterPublicID: newsletterPublicID.String(),
+		IssueID:            issueID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.IssueStats{
+		IssueID:      issueID,
+		Sent:         res.Sent,
+		Delivered:    res.Delivered,
+		Bounced:      res.Bounced,
+		Opened:       res.Opened,
+		Clicked:      res.Clicked,
+		Unsubscribed: res.Unsubscribed,
+	}, nil
+}
+
+// RefreshStats recomputes pre-aggregated daily statistics of days which can still receive events.
+func (s *StatsRepository) RefreshStats(ctx context.Context, now time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	tx, err := s.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	if err := operation.UpdateNewsletterDailyStatsTx(ctx, tx, &operation.UpdateNewsletterDailyStatsParams{
+		Since: now.Add(-deliveryEventDelay),
+	}); err != nil {
+		return rollback(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit stats refresh tx: %w", err)
+	}
+
+	return nil
+}
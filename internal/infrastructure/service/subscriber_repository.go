@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -11,56 +12,85 @@ import (
 	"github.com/google/uuid"
 	"github.com/javor454/newsletter-assignment/app/config"
 	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
 	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/mailtemplate"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/sendgrid"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/tracking"
 )
 
 type SubscriptionParams struct {
 	Email              string `json:"email"`
 	NewsletterPublicID string `json:"newsletter_id"`
 	SubscriptionToken  string `json:"subscription_token"`
+	Locale             string `json:"locale,omitempty"`
 }
 
-type SubscriptionCache interface {
-	AddSubscribedNewsletter(ctx context.Context, email, newsletterPublicID string) error
+type InvitationParams struct {
+	Email              string `json:"email"`
+	NewsletterPublicID string `json:"newsletter_id"`
+	NewsletterName     string `json:"newsletter_name"`
+	Role               string `json:"role"`
+	Token              string `json:"token"`
+}
+
+// SenderParams is snapshot of newsletter sender identity for jobs which can outlive the newsletter.
+type SenderParams struct {
+	FromName      *string `json:"from_name,omitempty"`
+	FromAddress   *string `json:"from_address,omitempty"`
+	ReplyTo       *string `json:"reply_to,omitempty"`
+	PostalAddress *string `json:"postal_address,omitempty"`
 }
 
 type SubscriberRepository struct {
-	lg                        logger.Logger
-	pgConn                    *sql.DB
-	getNewsletterByPublicID   *operation.GetNewsletterIDByPublicID
-	getUnsendEmailJobs        *operation.GetUnsentSubscribedEmailJobsOperation
-	mailService               *sendgrid.MailService
-	updateUnsentEmailJobs     *operation.UpdateUnsentEmailJobs
-	appConfig                 *config.AppConfig
-	updateDisableSubscription *operation.UpdateDisableSubscription
-	subscriptionCache         SubscriptionCache
+	lg                      logger.Logger
+	pgConn                  *sql.DB
+	getNewsletterByPublicID *operation.GetNewsletterIDByPublicID
+	getUnsendEmailJobs      *operation.GetUnsentEmailJobs
+	mailService             *sendgrid.MailService
+	updateUnsentEmailJobs   *operation.UpdateUnsentEmailJobs
+	appConfig               *config.AppConfig
+	getNewsletterSender     *operation.GetNewsletterSender
+	getNewsletterTemplate   *operation.GetNewsletterTemplate
+	getSubscriptionLocale   *operation.GetSubscriptionLocale
+	getIssue                *operation.GetIssue
+	getNewsletterTracking   *operation.GetNewsletterTracking
+	tracker                 *tracking.Tracker
 }
 
 func NewSubscriberRepository(
 	lg logger.Logger,
 	pgConn *sql.DB,
 	gn *operation.GetNewsletterIDByPublicID,
-	gu *operation.GetUnsentSubscribedEmailJobsOperation,
+	gu *operation.GetUnsentEmailJobs,
 	ms *sendgrid.MailService,
 	uo *operation.UpdateUnsentEmailJobs,
 	conf *config.AppConfig,
-	uds *operation.UpdateDisableSubscription,
-	sc SubscriptionCache,
+	gns *operation.GetNewsletterSender,
+	gnt *operation.GetNewsletterTemplate,
+	gsl *operation.GetSubscriptionLocale,
+	gi *operation.GetIssue,
+	gntr *operation.GetNewsletterTracking,
+	tr *tracking.Tracker,
 ) *SubscriberRepository {
 	lg.Infof("[EMAIL] Sending email: %v", conf.SendMail)
 	return &SubscriberRepository{
-		lg:                        lg,
-		pgConn:                    pgConn,
-		getNewsletterByPublicID:   gn,
-		getUnsendEmailJobs:        gu,
-		mailService:               ms,
-		updateUnsentEmailJobs:     uo,
-		appConfig:                 conf,
-		updateDisableSubscription: uds,
-		subscriptionCache:         sc,
+		lg:                      lg,
+		pgConn:                  pgConn,
+		getNewsletterByPublicID: gn,
+		getUnsendEmailJobs:      gu,
+		mailService:             ms,
+		updateUnsentEmailJobs:   uo,
+		appConfig:               conf,
+		getNewsletterSender:     gns,
+		getNewsletterTemplate:   gnt,
+		getSubscriptionLocale:   gsl,
+		getIssue:                gi,
+		getNewsletterTracking:   gntr,
+		tracker:                 tr,
 	}
 }
 
@@ -84,27 +114,38 @@ func (s *SubscriberRepository) Subscribe(ctx context.Context, subscription *doma
 		return fmt.Errorf("error beginning transaction: %w", err)
 	}
 
-	if err := operation.CreateOrUpdateSubscriptionTx(ctx, tx, &operation.CreateSubscriptionParams{
+	started, err := operation.CreateOrUpdateSubscriptionTx(ctx, tx, &operation.CreateSubscriptionParams{
 		ID:                subscription.ID().String(),
 		SubscriberEmail:   subscription.Email().String(),
 		NewsletterID:      idRow.ID,
 		SubscriptionToken: subscription.Token(),
-	}); err != nil {
+		Locale:            subscription.Locale().String(),
+	})
+	if err != nil {
 		return rollback(tx, err)
 	}
+	// subscriber who is already subscribed does not subscribe again
+	if events := subscription.PullEvents(); started {
+		if err := storeEventsTx(ctx, tx, events); err != nil {
+			return rollback(tx, err)
+		}
+	}
 	paramsJson, err := json.Marshal(SubscriptionParams{
 		Email:              subscription.Email().String(),
 		NewsletterPublicID: subscription.NewsletterPublicID().String(),
 		SubscriptionToken:  subscription.Token(),
+		Locale:             subscription.Locale().String(),
 	})
 	if err != nil {
 		return rollback(tx, err)
 	}
 
+	newsletterPublicID := subscription.NewsletterPublicID().String()
 	if err := operation.CreateEmailJobTx(ctx, tx, &operation.CreateEmailJobParams{
-		ID:     uuid.New().String(),
-		Type:   row.SubscriptionType,
-		Params: paramsJson,
+		ID:                 uuid.New().String(),
+		Type:               row.SubscriptionType,
+		Params:             paramsJson,
+		NewsletterPublicID: &newsletterPublicID,
 	}); err != nil {
 		return rollback(tx, err)
 	}
@@ -116,7 +157,8 @@ func (s *SubscriberRepository) Subscribe(ctx context.Context, subscription *doma
 	return nil
 }
 
-func (s *SubscriberRepository) ProcessSubscribeEmailJobs(ctx context.Context) error {
+// ProcessEmailJobs sends batch of unsent emails of all message types.
+func (s *SubscriberRepository) ProcessEmailJobs(ctx context.Context) error {
 	const maxJobs = 100
 
 	getUnsendCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
@@ -142,39 +184,14 @@ func (s *SubscriberRepository) ProcessSubscribeEmailJobs(ctx context.Context) er
 		go func(emailJob *row.EmailJob) {
 			defer wg.Done()
 
-			// TODO (nice2have): make ProcessSubscribeEmailJobs generic for all message types
-			if job.Type != row.SubscriptionType {
-				s.lg.WithField("job_id", job.ID).Errorf("invalid job type on job processing: %s", job.Type)
+			if err := s.processEmailJob(ctx, emailJob); err != nil {
+				s.lg.WithField("job_id", emailJob.ID).WithError(err).Error("failed to process email job")
 				return
 			}
 
-			var subscribeParams SubscriptionParams
-			if err := json.Unmarshal(job.Params, &subscribeParams); err != nil {
-				s.lg.WithField("job_id", job.ID).WithError(err).Error("failed to unmarshal subscription job params")
-				return
-			}
-
-			if s.appConfig.SendMail || true {
-				if err := s.mailService.SendSubscribed(
-					subscribeParams.Email,
-					subscribeParams.NewsletterPublicID,
-					subscribeParams.SubscriptionToken,
-				); err != nil {
-					s.lg.WithField("job_id", job.ID).WithError(err).Error("failed to send subscribed email")
-					return
-				}
-			}
-
 			mu.Lock()
 			processedIDs = append(processedIDs, emailJob.ID)
 			mu.Unlock()
-
-			// TODO: if cache fails, processing is still successful to prevent infinite mails
-			//  - maybe periodically check data? or introduce integrity hash and invalidate it at the begin of processing
-			if err := s.subscriptionCache.AddSubscribedNewsletter(ctx, subscribeParams.Email, subscribeParams.NewsletterPublicID); err != nil {
-				s.lg.WithField("job_id", job.ID).WithError(err).Error("failed to cache subscription")
-				return
-			}
 		}(job)
 	}
 
@@ -195,16 +212,407 @@ func (s *SubscriberRepository) ProcessSubscribeEmailJobs(ctx context.Context) er
 	return nil
 }
 
-func (s *SubscriberRepository) Unsubscribe(ctx context.Context, email *domain.Email, newsletterPublicID *domain.ID) error {
+func (s *SubscriberRepository) processEmailJob(ctx context.Context, emailJob *row.EmailJob) error {
+	switch emailJob.Type {
+	case row.SubscriptionType:
+		var subscribeParams SubscriptionParams
+		if err := json.Unmarshal(emailJob.Params, &subscribeParams); err != nil {
+			return fmt.Errorf("failed to unmarshal subscription job params: %w", err)
+		}
+
+		sender, newsletterName, err := s.newsletterSender(ctx, subscribeParams.NewsletterPublicID)
+		if err != nil {
+			return err
+		}
+		override, err := s.templateOverride(ctx, subscribeParams.NewsletterPublicID, sendgrid.SubscribedTemplateName)
+		if err != nil {
+			return err
+		}
+
+		if s.appConfig.SendMail || true {
+			if err := s.mailService.SendSubscribed(
+				sender,
+				override,
+				subscribeParams.Locale,
+				subscribeParams.Email,
+				newsletterName,
+				subscribeParams.NewsletterPublicID,
+				subscribeParams.SubscriptionToken,
+			); err != nil {
+				return fmt.Errorf("failed to send subscribed email: %w", err)
+			}
+		}
+
+		return nil
+	case row.InvitationType:
+		var invitationParams InvitationParams
+		if err := json.Unmarshal(emailJob.Params, &invitationParams); err != nil {
+			return fmt.Errorf("failed to unmarshal invitation job params: %w", err)
+		}
+
+		sender := s.mailService.DefaultSender()
+		var override *mailtemplate.Override
+		// invitations created before sender identity was introduced do not reference newsletter
+		if invitationParams.NewsletterPublicID != "" {
+			var err error
+			if sender, _, err = s.newsletterSender(ctx, invitationParams.NewsletterPublicID); err != nil {
+				return err
+			}
+			override, err = s.templateOverride(ctx, invitationParams.NewsletterPublicID, sendgrid.InvitationTemplateName)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := s.mailService.SendInvitation(
+			sender,
+			override,
+			invitationParams.Email,
+			invitationParams.NewsletterName,
+			invitationParams.Role,
+			invitationParams.Token,
+		); err != nil {
+			return fmt.Errorf("failed to send invitation email: %w", err)
+		}
+
+		return nil
+	case row.EmailChangeType:
+		var emailChangeParams EmailChangeParams
+		if err := json.Unmarshal(emailJob.Params, &emailChangeParams); err != nil {
+			return fmt.Errorf("failed to unmarshal email change job params: %w", err)
+		}
+
+		if err := s.mailService.SendEmailChange(emailChangeParams.Email, emailChangeParams.Token); err != nil {
+			return fmt.Errorf("failed to send email change email: %w", err)
+		}
+
+		return nil
+	case row.NewsletterRemovedType:
+		var removedParams NewsletterRemovedParams
+		if err := json.Unmarshal(emailJob.Params, &removedParams); err != nil {
+			return fmt.Errorf("failed to unmarshal newsletter removed job params: %w", err)
+		}
+
+		sender := s.mailService.DefaultSender()
+		if removedParams.Sender != nil {
+			sender = mergeSender(sender, removedParams.Sender)
+		}
+
+		if err := s.mailService.SendNewsletterRemoved(
+			sender,
+			removedParams.Locale,
+			removedParams.Email,
+			removedParams.NewsletterName,
+		); err != nil {
+			return fmt.Errorf("failed to send newsletter removed email: %w", err)
+		}
+
+		return nil
+	case row.IssueType:
+		var issueParams IssueParams
+		if err := json.Unmarshal(emailJob.Params, &issueParams); err != nil {
+			return fmt.Errorf("failed to unmarshal issue job params: %w", err)
+		}
+
+		sender, newsletterName, err := s.newsletterSender(ctx, issueParams.NewsletterPublicID)
+		if err != nil {
+			return err
+		}
+		issue, err := s.issue(ctx, issueParams.NewsletterPublicID, issueParams.IssueID, emailJob.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.mailService.SendIssue(
+			sender,
+			issue,
+			emailJob.ID,
+			issueParams.Locale,
+			issueParams.Email,
+			newsletterName,
+			issueParams.NewsletterPublicID,
+			issueParams.SubscriptionToken,
+		); err != nil {
+			return fmt.Errorf("failed to send issue email: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("invalid job type on job processing: %s", emailJob.Type)
+	}
+}
+
+// newsletterSender returns sender identity of newsletter with default values for unset fields and newsletter name.
+func (s *SubscriberRepository) newsletterSender(ctx context.Context, newsletterPublicID string) (*sendgrid.Sender, string, error) {
 	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
 	defer cancel()
 
-	if err := s.updateDisableSubscription.Execute(ctx, &operation.UpdateDisableSubscriptionParams{
-		Email:              email.String(),
+	res, err := s.getNewsletterSender.Execute(ctx, &operation.GetNewsletterSenderParams{PublicID: newsletterPublicID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return mergeSender(s.mailService.DefaultSender(), createSenderParams(res)), res.Name, nil
+}
+
+// templateOverride returns override of template stored for newsletter, nil when newsletter uses default one.
+func (s *SubscriberRepository) templateOverride(
+	ctx context.Context,
+	newsletterPublicID, name string,
+) (*mailtemplate.Override, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	res, err := s.getNewsletterTemplate.Execute(ctx, &operation.GetNewsletterTemplateParams{
+		NewsletterPublicID: newsletterPublicID,
+		Name:               name,
+	})
+	if err != nil {
+		if errors.Is(err, application.TemplateNotFoundError) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	override := &mailtemplate.Override{HTML: res.HTML}
+	if res.Text != nil {
+		override.Text = *res.Text
+	}
+
+	return override, nil
+}
+
+// issue returns issue of newsletter as delivered in email job, draft issues are returned too so they can be previewed.
+func (s *SubscriberRepository) issue(ctx context.Context, newsletterPublicID, issueID, emailJobID string) (*sendgrid.Issue, error) {
+	getCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	res, err := s.getIssue.Execute(getCtx, &operation.GetIssueParams{ID: issueID, NewsletterPublicID: newsletterPublicID})
+	if err != nil {
+		return nil, err
+	}
+
+	content, trackingPixel, err := s.trackIssue(ctx, newsletterPublicID, emailJobID, res.HTML)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sendgrid.Issue{Title: res.Title, Content: content, TrackingPixel: trackingPixel}, nil
+}
+
+// previewIssue returns issue rendered for template preview, nil for other templates. Links are tracked under random
+// email job, so they redirect as in delivered email without recording anything.
+func (s *SubscriberRepository) previewIssue(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	name string,
+	issueID *domain.ID,
+) (*sendgrid.Issue, error) {
+	if name != sendgrid.IssueTemplateName {
+		return nil, nil
+	}
+	if issueID == nil {
+		return nil, application.IssueRequiredError
+	}
+
+	return s.issue(ctx, newsletterPublicID.String(), issueID.String(), domain.NewID().String())
+}
+
+// trackIssue returns content of issue with links routed through click tracking and URL of tracking pixel of email
+// job, each of them is left out when owner disabled its tracking.
+func (s *SubscriberRepository) trackIssue(
+	ctx context.Context,
+	newsletterPublicID, emailJobID, content string,
+) (string, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	res, err := s.getNewsletterTracking.Execute(ctx, &operation.GetNewsletterTrackingParams{PublicID: newsletterPublicID})
+	if err != nil {
+		return "", "", err
+	}
+
+	if res.Clicks {
+		if content, err = s.tracker.RewriteLinks(content, emailJobID); err != nil {
+			return "", "", fmt.Errorf("failed to rewrite links of issue: %w", err)
+		}
+	}
+	var trackingPixel string
+	if res.Opens {
+		trackingPixel = s.tracker.OpenPixelURL(emailJobID)
+	}
+
+	return content, trackingPixel, nil
+}
+
+// GetSubscriptionLocale returns preferred locale of active subscriber of newsletter.
+func (s *SubscriberRepository) GetSubscriptionLocale(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	email *domain.Email,
+) (*domain.Locale, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	locale, err := s.getSubscriptionLocale.Execute(ctx, &operation.GetSubscriptionLocaleParams{
 		NewsletterPublicID: newsletterPublicID.String(),
-	}); err != nil {
+		Email:              email.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewLocale(locale)
+}
+
+// PreviewEmail renders template of newsletter for recipient, draft takes precedence over stored override. Issue
+// template is rendered with issue of given ID, draft or published.
+func (s *SubscriberRepository) PreviewEmail(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	name string,
+	draft *domain.TemplateOverride,
+	issueID *domain.ID,
+	recipient *domain.Email,
+	locale *domain.Locale,
+) (*dto.EmailPreview, error) {
+	sender, newsletterName, override, err := s.previewSource(ctx, newsletterPublicID.String(), name, draft)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := s.previewIssue(ctx, newsletterPublicID, name, issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := s.mailService.Preview(
+		sender,
+		override,
+		issue,
+		locale.String(),
+		name,
+		recipient.String(),
+		newsletterName,
+		newsletterPublicID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.EmailPreview{Subject: rendered.Subject, HTML: rendered.HTML, Text: rendered.Text}, nil
+}
+
+// SendTestEmail delivers preview of template of newsletter to recipient, draft takes precedence over stored override.
+// Issue template is rendered with issue of given ID, draft or published.
+func (s *SubscriberRepository) SendTestEmail(
+	ctx context.Context,
+	newsletterPublicID *domain.ID,
+	name string,
+	draft *domain.TemplateOverride,
+	issueID *domain.ID,
+	recipient *domain.Email,
+	locale *domain.Locale,
+) error {
+	sender, newsletterName, override, err := s.previewSource(ctx, newsletterPublicID.String(), name, draft)
+	if err != nil {
 		return err
 	}
+	issue, err := s.previewIssue(ctx, newsletterPublicID, name, issueID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.mailService.SendTest(
+		sender,
+		override,
+		issue,
+		locale.String(),
+		name,
+		recipient.String(),
+		newsletterName,
+		newsletterPublicID.String(),
+	); err != nil {
+		return fmt.Errorf("failed to send test email: %w", err)
+	}
+
+	return nil
+}
+
+// previewSource resolves sender of newsletter and template override used for preview.
+func (s *SubscriberRepository) previewSource(
+	ctx context.Context,
+	newsletterPublicID, name string,
+	draft *domain.TemplateOverride,
+) (*sendgrid.Sender, string, *mailtemplate.Override, error) {
+	sender, newsletterName, err := s.newsletterSender(ctx, newsletterPublicID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if draft != nil {
+		return sender, newsletterName, &mailtemplate.Override{HTML: draft.HTML(), Text: draft.Text()}, nil
+	}
+
+	override, err := s.templateOverride(ctx, newsletterPublicID, name)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return sender, newsletterName, override, nil
+}
+
+func createSenderParams(r *row.NewsletterSender) *SenderParams {
+	return &SenderParams{
+		FromName:      r.FromName,
+		FromAddress:   r.FromAddress,
+		ReplyTo:       r.ReplyTo,
+		PostalAddress: r.PostalAddress,
+	}
+}
+
+func mergeSender(sender *sendgrid.Sender, p *SenderParams) *sendgrid.Sender {
+	if p.FromName != nil {
+		sender.Name = *p.FromName
+	}
+	if p.FromAddress != nil {
+		sender.Address = *p.FromAddress
+	}
+	if p.ReplyTo != nil {
+		sender.ReplyTo = *p.ReplyTo
+	}
+	if p.PostalAddress != nil {
+		sender.PostalAddress = *p.PostalAddress
+	}
+
+	return sender
+}
+
+func (s *SubscriberRepository) Unsubscribe(ctx context.Context, unsubscription *domain.Unsubscription) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	tx, err := s.pgConn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	disabled, err := operation.UpdateDisableSubscriptionTx(ctx, tx, &operation.UpdateDisableSubscriptionParams{
+		Email:              unsubscription.Email().String(),
+		NewsletterPublicID: unsubscription.NewsletterPublicID().String(),
+	})
+	if err != nil {
+		return rollback(tx, err)
+	}
+	if events := unsubscription.PullEvents(); disabled {
+		if err := storeEventsTx(ctx, tx, events); err != nil {
+			return rollback(tx, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit unsubscribe tx: %w", err)
+	}
 
 	return nil
 }
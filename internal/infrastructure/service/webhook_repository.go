@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/row"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/webhook"
+)
+
+// webhookDeliveryLease is longer than timeout of single request, claimed deliveries are not sent twice meanwhile.
+const webhookDeliveryLease = 2 * time.Minute
+
+type WebhookRepository struct {
+	lg                           logger.Logger
+	createWebhook                *operation.CreateWebhook
+	getWebhooks                  *operation.GetWebhooks
+	getWebhook                   *operation.GetWebhook
+	deleteWebhook                *operation.DeleteWebhook
+	getWebhookDeliveries         *operation.GetWebhookDeliveries
+	createWebhookDelivery        *operation.CreateWebhookDelivery
+	createWebhookDeliveries      *operation.CreateWebhookDeliveries
+	updateClaimWebhookDeliveries *operation.UpdateClaimWebhookDeliveries
+	updateWebhookDelivery        *operation.UpdateWebhookDelivery
+	sender                       *webhook.Sender
+	now                          func() time.Time
+}
+
+func NewWebhookRepository(
+	lg logger.Logger,
+	cw *operation.CreateWebhook,
+	gws *operation.GetWebhooks,
+	gw *operation.GetWebhook,
+	dw *operation.DeleteWebhook,
+	gwd *operation.GetWebhookDeliveries,
+	cwd *operation.CreateWebhookDelivery,
+	cwds *operation.CreateWebhookDeliveries,
+	ucwd *operation.UpdateClaimWebhookDeliveries,
+	uwd *operation.UpdateWebhookDelivery,
+	ws *webhook.Sender,
+	now func() time.Time,
+) *WebhookRepository {
+	return &WebhookRepository{
+		lg:                           lg,
+		createWebhook:                cw,
+		getWebhooks:                  gws,
+		getWebhook:                   gw,
+		deleteWebhook:                dw,
+		getWebhookDeliveries:         gwd,
+		createWebhookDelivery:        cwd,
+		createWebhookDeliveries:      cwds,
+		updateClaimWebhookDeliveries: ucwd,
+		updateWebhookDelivery:        uwd,
+		sender:                       ws,
+		now:                          now,
+	}
+}
+
+func (w *WebhookRepository) CreateWebhook(ctx context.Context, wh *domain.Webhook) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	eventTypes := make([]string, 0, len(wh.EventTypes()))
+	for _, t := range wh.EventTypes() {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	return w.createWebhook.Execute(ctx, &operation.CreateWebhookParams{
+		ID:                 wh.ID().String(),
+		NewsletterPublicID: wh.NewsletterPublicID().String(),
+		URL:                wh.URL(),
+		Secret:             wh.Secret(),
+		EventTypes:         eventTypes,
+		CreatedAt:          wh.CreatedAt(),
+	})
+}
+
+func (w *WebhookRepository) GetWebhooks(ctx context.Context, newsletterPublicID *domain.ID) ([]*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, err := w.getWebhooks.Execute(ctx, &operation.GetWebhooksParams{NewsletterPublicID: newsletterPublicID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]*domain.Webhook, 0, len(rows))
+	for _, r := range rows {
+		wh, err := createWebhookFromRow(r)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, wh)
+	}
+
+	return webhooks, nil
+}
+
+func (w *WebhookRepository) GetWebhook(ctx context.Context, newsletterPublicID, webhookID *domain.ID) (*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	r, err := w.getWebhook.Execute(ctx, &operation.GetWebhookParams{
+		ID:                 webhookID.String(),
+		NewsletterPublicID: newsletterPublicID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createWebhookFromRow(r)
+}
+
+func (w *WebhookRepository) DeleteWebhook(ctx context.Context, newsletterPublicID, webhookID *domain.ID) error {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return w.deleteWebhook.Execute(ctx, &operation.DeleteWebhookParams{
+		ID:                 webhookID.String(),
+		NewsletterPublicID: newsletterPublicID.String(),
+	})
+}
+
+func (w *WebhookRepository) GetWebhookDeliveries(
+	ctx context.Context,
+	webhookID *domain.ID,
+	pageSize, pageNumber int,
+) ([]*dto.WebhookDelivery, *dto.Pagination, error) {
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	rows, pagination, err := w.getWebhookDeliveries.Execute(ctx, &operation.GetWebhookDeliveriesParams{
+		WebhookID:  webhookID.String(),
+		PageSize:   pageSize,
+		PageNumber: pageNumber,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deliveries := make([]*dto.WebhookDelivery, 0, len(rows))
+	for _, r := range rows {
+		d := &dto.WebhookDelivery{
+			ID:             r.ID,
+			EventID:        r.EventID,
+			EventType:      r.EventType,
+			Status:         dto.WebhookDeliveryPending,
+			Attempts:       r.Attempts,
+			LastStatusCode: r.LastStatusCode,
+			LastError:      r.LastError,
+			LastAttemptAt:  r.LastAttemptAt,
+			NextAttemptAt:  r.NextAttemptAt,
+			CreatedAt:      r.CreatedAt,
+		}
+		switch {
+		case r.DeliveredAt != nil:
+			d.Status = dto.WebhookDeliveryDelivered
+			d.NextAttemptAt = nil
+		case r.FailedAt != nil:
+			d.Status = dto.WebhookDeliveryFailed
+			d.NextAttemptAt = nil
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, pagination, nil
+}
+
+// SendTestEvent sends test event right away without retries, the attempt is written to delivery log.
+func (w *WebhookRepository) SendTestEvent(ctx context.Context, wh *domain.Webhook) (*dto.WebhookDelivery, error) {
+	now := w.now()
+	event := domain.NewWebhookEvent(domain.NewID(), domain.WebhookEventTest, wh.NewsletterPublicID(), now, map[string]string{
+		"webhook_id": wh.ID().String(),
+	})
+	payload, err := webhook.Payload(event)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryID := domain.NewID().String()
+	statusCode, sendErr := w.sender.Send(ctx, wh.URL(), wh.Secret(), deliveryID, string(event.Type()), payload)
+
+	delivery := &dto.WebhookDelivery{
+		ID:            deliveryID,
+		EventID:       event.ID().String(),
+		EventType:     string(event.Type()),
+		Status:        dto.WebhookDeliveryDelivered,
+		Attempts:      1,
+		LastAttemptAt: &now,
+		CreatedAt:     now,
+	}
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+	if sendErr != nil {
+		msg := sendErr.Error()
+		delivery.Status = dto.WebhookDeliveryFailed
+		delivery.LastError = &msg
+	}
+
+	logCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	if err := w.createWebhookDelivery.Execute(logCtx, &operation.CreateWebhookDeliveryParams{
+		ID:         delivery.ID,
+		WebhookID:  wh.ID().String(),
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Payload:    payload,
+		StatusCode: delivery.LastStatusCode,
+		Error:      delivery.LastError,
+		AttemptAt:  now,
+		Delivered:  sendErr == nil,
+	}); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// ProcessWebhookDeliveries sends batch of due deliveries, failed ones are scheduled with growing delay until they
+// give up. Delivery is at least once, receivers deduplicate by event id in payload.
+func (w *WebhookRepository) ProcessWebhookDeliveries(ctx context.Context) error {
+	const maxDeliveries = 100
+
+	claimCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	deliveries, err := w.updateClaimWebhookDeliveries.Execute(claimCtx, &operation.UpdateClaimWebhookDeliveriesParams{
+		Limit: maxDeliveries,
+		Lease: webhookDeliveryLease,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+	w.lg.Debugf("[WEBHOOK] Processing %d deliveries...", len(deliveries))
+
+	var wg sync.WaitGroup
+	for _, d := range deliveries {
+		wg.Add(1)
+
+		go func(delivery *row.DueWebhookDelivery) {
+			defer wg.Done()
+
+			if err := w.processWebhookDelivery(ctx, delivery); err != nil {
+				w.lg.WithField("delivery_id", delivery.ID).WithError(err).Error("failed to record webhook delivery")
+			}
+		}(d)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (w *WebhookRepository) processWebhookDelivery(ctx context.Context, d *row.DueWebhookDelivery) error {
+	statusCode, sendErr := w.sender.Send(ctx, d.URL, d.Secret, d.ID, d.EventType, d.Payload)
+	now := w.now()
+
+	p := &operation.UpdateWebhookDeliveryParams{
+		ID:        d.ID,
+		AttemptAt: now,
+		Delivered: sendErr == nil,
+	}
+	if statusCode != 0 {
+		p.StatusCode = &statusCode
+	}
+	if sendErr != nil {
+		msg := sendErr.Error()
+		p.Error = &msg
+		if delay, ok := domain.WebhookRetryDelay(d.Attempts + 1); ok {
+			next := now.Add(delay)
+			p.NextAttemptAt = &next
+		}
+		w.lg.WithField("delivery_id", d.ID).WithError(sendErr).Warn("[WEBHOOK] Delivery attempt failed")
+	}
+
+	updateCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return w.updateWebhookDelivery.Execute(updateCtx, p)
+}
+
+func createWebhookFromRow(r *row.Webhook) (*domain.Webhook, error) {
+	id, err := domain.CreateIDFromExisting(r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook id in db %w", err)
+	}
+	pubID, err := domain.CreateIDFromExisting(r.NewsletterPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid newsletter public id in db %w", err)
+	}
+	eventTypes := make([]domain.WebhookEventType, 0, len(r.EventTypes))
+	for _, t := range r.EventTypes {
+		eventTypes = append(eventTypes, domain.WebhookEventType(t))
+	}
+
+	return domain.CreateWebhookFromExisting(id, pubID, r.URL, r.Secret, eventTypes, r.CreatedAt), nil
+}
+
+// HandleEvent enqueues event for webhooks of newsletter subscribed to it, event id keeps repeated dispatch from
+// enqueueing it twice.
+func (w *WebhookRepository) HandleEvent(ctx context.Context, event *domain.Event) error {
+	eventType, err := domain.NewWebhookEventType(string(event.Name()))
+	if err != nil {
+		return nil
+	}
+
+	payload, err := webhook.Payload(domain.NewWebhookEvent(
+		event.ID(),
+		eventType,
+		event.NewsletterPublicID(),
+		event.OccurredAt(),
+		event.Data(),
+	))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer cancel()
+
+	return w.createWebhookDeliveries.Execute(ctx, &operation.CreateWebhookDeliveriesParams{
+		NewsletterPublicID: event.NewsletterPublicID().String(),
+		EventID:            event.ID().String(),
+		EventType:          string(eventType),
+		Payload:            payload,
+	})
+}
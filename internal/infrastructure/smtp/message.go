@@ -0,0 +1,86 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Message is email rendered for single recipient.
+type Message struct {
+	From      *mail.Address
+	To        *mail.Address
+	ReplyTo   *mail.Address
+	Subject   string
+	Text      string
+	HTML      string
+	Date      time.Time
+	MessageID string
+}
+
+// NewMessageID returns random message ID in domain of sender.
+func NewMessageID(domain string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate message id: %w", err)
+	}
+
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">", nil
+}
+
+// Bytes returns RFC 5322 message with multipart/alternative body, text part goes first as the least preferred.
+// Boundary is derived from message ID so the same message is always encoded the same way.
+func (m *Message) Bytes() ([]byte, error) {
+	sum := sha256.Sum256([]byte(m.MessageID))
+	// "=_" can not appear in quoted-printable content
+	boundary := "=_" + hex.EncodeToString(sum[:14])
+
+	var b bytes.Buffer
+	writeHeader(&b, "From", m.From.String())
+	writeHeader(&b, "To", m.To.String())
+	if m.ReplyTo != nil {
+		writeHeader(&b, "Reply-To", m.ReplyTo.String())
+	}
+	writeHeader(&b, "Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader(&b, "Date", m.Date.Format(time.RFC1123Z))
+	writeHeader(&b, "Message-ID", m.MessageID)
+	writeHeader(&b, "MIME-Version", "1.0")
+	writeHeader(&b, "Content-Type", `multipart/alternative; boundary="`+boundary+`"`)
+	b.WriteString("\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=utf-8", content: m.Text},
+		{contentType: "text/html; charset=utf-8", content: m.HTML},
+	} {
+		b.WriteString("--" + boundary + "\r\n")
+		writeHeader(&b, "Content-Type", part.contentType)
+		writeHeader(&b, "Content-Transfer-Encoding", "quoted-printable")
+		b.WriteString("\r\n")
+
+		qp := quotedprintable.NewWriter(&b)
+		if _, err := qp.Write([]byte(strings.ReplaceAll(part.content, "\r\n", "\n"))); err != nil {
+			return nil, fmt.Errorf("failed to encode message part: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode message part: %w", err)
+		}
+		b.WriteString("\r\n")
+	}
+	b.WriteString("--" + boundary + "--\r\n")
+
+	return b.Bytes(), nil
+}
+
+func writeHeader(b *bytes.Buffer, name, value string) {
+	b.WriteString(name + ": " + value + "\r\n")
+}
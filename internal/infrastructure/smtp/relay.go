@@ -0,0 +1,89 @@
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	netsmtp "net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/dkim"
+)
+
+// SigningKeys returns signer of domain, nil signer means domain has no key.
+type SigningKeys interface {
+	Signer(ctx context.Context, domain string) (*dkim.Signer, error)
+}
+
+// Relay delivers messages through own SMTP server, messages are DKIM signed by key of from domain.
+type Relay struct {
+	lg   logger.Logger
+	addr string
+	auth netsmtp.Auth
+	keys []SigningKeys
+	now  func() time.Time
+}
+
+func NewRelay(
+	lg logger.Logger,
+	host string,
+	port int,
+	username, password string,
+	now func() time.Time,
+	keys ...SigningKeys,
+) *Relay {
+	r := &Relay{
+		lg:   lg,
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		keys: keys,
+		now:  now,
+	}
+	if username != "" {
+		r.auth = netsmtp.PlainAuth("", username, password, host)
+	}
+
+	return r
+}
+
+// Sign returns encoded message signed by first key found for from domain, message of domain without key is
+// returned unsigned.
+func (r *Relay) Sign(ctx context.Context, m *Message) ([]byte, error) {
+	data, err := m.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	domain := strings.ToLower(m.From.Address[strings.LastIndex(m.From.Address, "@")+1:])
+	for _, k := range r.keys {
+		signer, err := k.Signer(ctx, domain)
+		if err != nil {
+			return nil, err
+		}
+		if signer != nil {
+			return signer.Sign(data, r.now())
+		}
+	}
+
+	r.lg.WithField("domain", domain).Warn("[SMTP] No DKIM key for domain, sending unsigned message")
+
+	return data, nil
+}
+
+func (r *Relay) Send(m *Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	data, err := r.Sign(ctx, m)
+	if err != nil {
+		return err
+	}
+
+	if err := netsmtp.SendMail(r.addr, r.auth, m.From.Address, []string{m.To.Address}, data); err != nil {
+		return fmt.Errorf("failed to send message through smtp relay: %w", err)
+	}
+
+	return nil
+}
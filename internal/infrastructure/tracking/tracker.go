@@ -0,0 +1,151 @@
+package tracking
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Tracker builds signed tracking links of sent emails and verifies them when they are requested.
+type Tracker struct {
+	baseURL string
+	key     []byte
+	now     func() time.Time
+}
+
+func NewTracker(baseURL, secret string, now func() time.Time) *Tracker {
+	return &Tracker{baseURL: baseURL, key: []byte(secret), now: now}
+}
+
+// OpenPixelURL returns address of tracking pixel of email job, time of sending is part of signed token.
+func (t *Tracker) OpenPixelURL(emailJobID string) string {
+	sentAt := strconv.FormatInt(t.now().Unix(), 10)
+
+	return fmt.Sprintf(
+		"%s/api/v1/track/open/%s.%s.%s",
+		t.baseURL,
+		emailJobID,
+		sentAt,
+		t.sign("open", emailJobID, sentAt),
+	)
+}
+
+// ParseOpenToken returns email job and time of sending from token of tracking pixel.
+func (t *Tracker) ParseOpenToken(token string) (string, time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", time.Time{}, application.InvalidTrackingTokenError
+	}
+	emailJobID, sentAt, signature := parts[0], parts[1], parts[2]
+
+	if !hmac.Equal([]byte(signature), []byte(t.sign("open", emailJobID, sentAt))) {
+		return "", time.Time{}, application.InvalidTrackingTokenError
+	}
+	unix, err := strconv.ParseInt(sentAt, 10, 64)
+	if err != nil {
+		return "", time.Time{}, application.InvalidTrackingTokenError
+	}
+
+	return emailJobID, time.Unix(unix, 0), nil
+}
+
+// ClickURL returns address redirecting to target through click tracking, target is part of signature so the link
+// can not be reused to redirect elsewhere.
+func (t *Tracker) ClickURL(emailJobID string, linkIndex int, target string) string {
+	index := strconv.Itoa(linkIndex)
+
+	return fmt.Sprintf(
+		"%s/api/v1/track/click/%s.%s.%s?url=%s",
+		t.baseURL,
+		emailJobID,
+		index,
+		t.sign("click", emailJobID, index, target),
+		url.QueryEscape(target),
+	)
+}
+
+// ParseClickToken returns email job and index of link from token of tracked link, token is valid only with target
+// it was signed with.
+func (t *Tracker) ParseClickToken(token, target string) (string, int, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", 0, application.InvalidTrackingTokenError
+	}
+	emailJobID, index, signature := parts[0], parts[1], parts[2]
+
+	if !hmac.Equal([]byte(signature), []byte(t.sign("click", emailJobID, index, target))) {
+		return "", 0, application.InvalidTrackingTokenError
+	}
+	linkIndex, err := strconv.Atoi(index)
+	if err != nil {
+		return "", 0, application.InvalidTrackingTokenError
+	}
+
+	return emailJobID, linkIndex, nil
+}
+
+// RewriteLinks routes http links of HTML fragment through click tracking. Index of link is its position among all
+// links of fragment, mailto and unsubscribe links are kept untouched.
+func (t *Tracker) RewriteLinks(fragment, emailJobID string) (string, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse html: %w", err)
+	}
+
+	index := 0
+	var rewrite func(n *html.Node)
+	rewrite = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.A {
+			for i, attr := range n.Attr {
+				if attr.Key != "href" {
+					continue
+				}
+				if t.isTrackable(attr.Val) {
+					n.Attr[i].Val = t.ClickURL(emailJobID, index, attr.Val)
+				}
+				index++
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			rewrite(c)
+		}
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		rewrite(n)
+		if err := html.Render(&b, n); err != nil {
+			return "", fmt.Errorf("failed to render html: %w", err)
+		}
+	}
+
+	return b.String(), nil
+}
+
+func (t *Tracker) isTrackable(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+
+	// unsubscribing has to work without detour, also for recipients blocking tracking domains
+	return !strings.HasPrefix(href, t.baseURL+"/api/v1/unsubscribe")
+}
+
+// sign returns truncated HMAC of parts, purpose is first part so signature of one link is not valid for another.
+func (t *Tracker) sign(parts ...string) string {
+	mac := hmac.New(sha256.New, t.key)
+	mac.Write([]byte(strings.Join(parts, "\x00")))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
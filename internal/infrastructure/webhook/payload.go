@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+const (
+	EventHeader     = "X-Newsletter-Event"
+	DeliveryHeader  = "X-Newsletter-Delivery"
+	TimestampHeader = "X-Newsletter-Timestamp"
+	// SignatureHeader carries "sha256=" followed by hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed by secret
+	// of webhook, timestamp lets receivers reject replayed requests.
+	SignatureHeader = "X-Newsletter-Signature"
+)
+
+type payload struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	NewsletterID string            `json:"newsletter_id"`
+	OccurredAt   string            `json:"occurred_at"`
+	Data         map[string]string `json:"data"`
+}
+
+// Payload returns JSON body of event, it is stored once and every attempt sends the same bytes.
+func Payload(event *domain.WebhookEvent) ([]byte, error) {
+	data := event.Data()
+	if data == nil {
+		data = map[string]string{}
+	}
+
+	b, err := json.Marshal(payload{
+		ID:           event.ID().String(),
+		Type:         string(event.Type()),
+		NewsletterID: event.NewsletterPublicID().String(),
+		OccurredAt:   event.OccurredAt().UTC().Format(time.RFC3339Nano),
+		Data:         data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	return b, nil
+}
+
+// Sign returns value of signature header for body sent at timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
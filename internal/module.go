@@ -3,6 +3,8 @@ package internal
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"net"
 	"time"
 
 	"github.com/javor454/newsletter-assignment/app/config"
@@ -12,12 +14,23 @@ import (
 	"github.com/javor454/newsletter-assignment/app/logger"
 	"github.com/javor454/newsletter-assignment/app/sendgrid"
 	"github.com/javor454/newsletter-assignment/internal/application/handler"
-	firebaseinfra "github.com/javor454/newsletter-assignment/internal/infrastructure/firebase"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/apikey"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/cache"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/dkim"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/dns"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/jwt"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/markdown"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/secret"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/sender"
 	sendgridinfra "github.com/javor454/newsletter-assignment/internal/infrastructure/sendgrid"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/service"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/smtp"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/totp"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/tracking"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/webhook"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/controller"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
 )
@@ -33,49 +46,271 @@ func RegisterDependencies(
 ) {
 	cuo := operation.NewCreateUser(pgConn)
 	gube := operation.NewGetUserByEmail(pgConn)
+	gubi := operation.NewGetUserByID(pgConn)
+	uuts := operation.NewUpdateUserTOTPSecret(pgConn)
+	guts := operation.NewGetUserTOTPSecret(pgConn)
+	uurc := operation.NewUpdateUseRecoveryCode(pgConn)
+	uutst := operation.NewUpdateUseTOTPStep(pgConn)
+	uutc := operation.NewUpdateUserTOTPChallenge(pgConn)
+	gutl := operation.NewGetUserTOTPLogin(pgConn)
+	uftl := operation.NewUpdateFailTOTPLogin(pgConn)
+	ufitl := operation.NewUpdateFinishTOTPLogin(pgConn)
 	cno := operation.NewCreateNewsletter(pgConn)
 	gnbui := operation.NewGetNewslettersByUserID(pgConn)
 	gnibpi := operation.NewGetNewsletterIDByPublicID(pgConn)
 	gnbse := operation.NewGetNewslettersBySubscriptionEmail(pgConn)
-	guej := operation.NewGetUnsentSubscribedEmailJobsOperation(pgConn)
+	guej := operation.NewGetUnsentEmailJobs(pgConn)
 	uuej := operation.NewUpdateUnsentEmailJobs(pgConn)
-	uds := operation.NewUpdateDisableSubscription(pgConn)
 	gnbpi := operation.NewGetNewslettersByPublicID(pgConn)
+	cak := operation.NewCreateAPIKey(pgConn)
+	gakbui := operation.NewGetAPIKeysByUserID(pgConn)
+	gakbp := operation.NewGetAPIKeyByPrefix(pgConn)
+	uaklu := operation.NewUpdateAPIKeyLastUsed(pgConn)
+	urak := operation.NewUpdateRevokeAPIKey(pgConn)
+	gnr := operation.NewGetNewsletterRole(pgConn)
+	gnm := operation.NewGetNewsletterMembers(pgConn)
+	gibth := operation.NewGetInvitationByTokenHash(pgConn)
+	dmo := operation.NewDeleteMember(pgConn)
+	uup := operation.NewUpdateUserPassword(pgConn)
+	ucec := operation.NewUpdateConfirmEmailChange(pgConn)
+	uno := operation.NewUpdateNewsletter(pgConn)
+	gnso := operation.NewGetNewsletterSender(pgConn)
+	unso := operation.NewUpdateNewsletterSender(pgConn)
+	csd := operation.NewCreateSendingDomain(pgConn)
+	gsd := operation.NewGetSendingDomain(pgConn)
+	gsdbui := operation.NewGetSendingDomainsByUserID(pgConn)
+	gvsd := operation.NewGetVerifiedSendingDomain(pgConn)
+	usdc := operation.NewUpdateSendingDomainCheck(pgConn)
+	gsdk := operation.NewGetSendingDomainKey(pgConn)
+	cunt := operation.NewCreateOrUpdateNewsletterTemplate(pgConn)
+	gnt := operation.NewGetNewsletterTemplate(pgConn)
+	gnts := operation.NewGetNewsletterTemplates(pgConn)
+	dnt := operation.NewDeleteNewsletterTemplate(pgConn)
+	gsl := operation.NewGetSubscriptionLocale(pgConn)
+	cio := operation.NewCreateIssue(pgConn)
+	uio := operation.NewUpdateIssue(pgConn)
+	gio := operation.NewGetIssue(pgConn)
+	giso := operation.NewGetIssues(pgConn)
+	gpiso := operation.NewGetPublishedIssues(pgConn)
+	gpio := operation.NewGetPublishedIssue(pgConn)
+	gntro := operation.NewGetNewsletterTracking(pgConn)
+	untro := operation.NewUpdateNewsletterTracking(pgConn)
+	ceoo := operation.NewCreateEmailOpen(pgConn)
+	ceco := operation.NewCreateEmailClick(pgConn)
+	gnsto := operation.NewGetNewsletterStats(pgConn)
+	gisto := operation.NewGetIssueStats(pgConn)
+	usro := operation.NewUpdateSubscriptionReason(pgConn)
+	guro := operation.NewGetUnsubscribeReasons(pgConn)
+	gucmo := operation.NewGetUnsubscribeComments(pgConn)
+	cwo := operation.NewCreateWebhook(pgConn)
+	gwso := operation.NewGetWebhooks(pgConn)
+	gwo := operation.NewGetWebhook(pgConn)
+	dwo := operation.NewDeleteWebhook(pgConn)
+	gwdo := operation.NewGetWebhookDeliveries(pgConn)
+	cwdo := operation.NewCreateWebhookDelivery(pgConn)
+	ucwdo := operation.NewUpdateClaimWebhookDeliveries(pgConn)
+	uwdo := operation.NewUpdateWebhookDelivery(pgConn)
+	cwdso := operation.NewCreateWebhookDeliveries(pgConn)
+	ucoeo := operation.NewUpdateClaimOutboxEvents(pgConn)
+	uoeo := operation.NewUpdateOutboxEvent(pgConn)
+	gfoeo := operation.NewGetFailedOutboxEvents(pgConn)
+	uroeo := operation.NewUpdateRetryOutboxEvent(pgConn)
+	gsno := operation.NewGetSubscribedNewsletters(pgConn)
+	gsnbeo := operation.NewGetSubscribedNewslettersByEmail(pgConn)
+	gnbpiso := operation.NewGetNewslettersByPublicIDs(pgConn)
 
-	ms := sendgridinfra.NewMailService(lg, appConfig, mailClient)
+	ur := pg.NewUserRepository(pgConn, cuo, gube, gubi, uuts, guts, uurc, uutst, uutc, gutl, uftl, ufitl, uup)
+	nr := pg.NewNewsletterRepository(cno, gnbui, gnbse, gnbpi, uno, gnbpiso)
+	akr := pg.NewAPIKeyRepository(cak, gakbui, gakbp, uaklu, urak)
+	acr := service.NewAccountRepository(pgConn, ucec)
+	nrr := service.NewNewsletterRemovalRepository(pgConn, appConfig.RemovalTimeout)
+	sir := pg.NewSenderIdentityRepository(gnso, unso)
+	sdr := pg.NewSendingDomainRepository(pgConn, csd, gsd, gsdbui, gvsd, usdc, gsdk)
+	ntr := pg.NewNewsletterTemplateRepository(cunt, gnts, dnt)
+	ir := pg.NewIssueRepository(cio, uio, gio, giso, gpiso, gpio)
+	ipr := service.NewIssuePublicationRepository(pgConn)
+	mdr := markdown.NewRenderer()
+	trr := pg.NewTrackingRepository(gntro, untro, ceoo, ceco)
+	der := service.NewDeliveryEventRepository(pgConn)
+	str := service.NewStatsRepository(pgConn, gnsto, gisto, guro)
+	urr := pg.NewUnsubscribeReasonRepository(usro, gucmo)
+	ws := webhook.NewSender(webhook.NewHTTPClient(appConfig.WebhookAllowPrivate), time.Now)
+	whr := service.NewWebhookRepository(lg, cwo, gwso, gwo, dwo, gwdo, cwdo, cwdso, ucwdo, uwdo, ws, time.Now)
+	snr := pg.NewSubscribedNewsletterRepository(gsno, gsnbeo)
+	sc := cache.NewSubscriptionCache(appConfig, fbClient, snr, time.Now)
+	ed := service.NewEventDispatcher(lg, ucoeo, uoeo, time.Now)
+	obr := pg.NewOutboxRepository(gfoeo, uroeo)
+	baseURL := fmt.Sprintf("%s:%d", appConfig.Host, appConfig.HttpPort)
+	tt := tracking.NewTracker(baseURL, appConfig.TrackingSecret, time.Now)
+	mr := service.NewMemberRepository(pgConn, gnr, gnm, gibth, dmo)
+	ssd := sender.NewStaticDomains(appConfig.SenderAddress, appConfig.SenderDomains)
+	sdv := sender.NewDomainVerifiers(ssd, sdr)
+	sdc := dns.NewChecker(net.DefaultResolver, appConfig.SpfInclude)
 
-	sc := firebaseinfra.NewSubscriptionCacheManager(fbClient)
+	var relay *smtp.Relay
+	if appConfig.SmtpHost != "" {
+		keys := []smtp.SigningKeys{sdr}
+		if appConfig.DkimKeyFile != "" {
+			sk, err := dkim.NewStaticKeys(ssd.Domains(), appConfig.DkimSelector, appConfig.DkimKeyFile)
+			if err != nil {
+				panic("[DKIM] failed to load key: " + err.Error())
+			}
+			keys = append([]smtp.SigningKeys{sk}, keys...)
+		}
+		relay = smtp.NewRelay(
+			lg,
+			appConfig.SmtpHost,
+			appConfig.SmtpPort,
+			appConfig.SmtpUsername,
+			appConfig.SmtpPassword,
+			time.Now,
+			keys...,
+		)
+	}
+	ms := sendgridinfra.NewMailService(lg, appConfig, mailClient, relay)
+	if appConfig.TemplateWatch > 0 {
+		ms.WatchTemplates(ctx, appConfig.TemplateWatch)
+	}
+	ew, err := sendgridinfra.NewEventWebhook(appConfig.SendGridWebhookKey)
+	if err != nil {
+		panic("[SENDGRID] failed to load webhook key: " + err.Error())
+	}
+	sr := service.NewSubscriberRepository(lg, pgConn, gnibpi, guej, ms, uuej, appConfig, gnso, gnt, gsl, gio, gntro, tt)
 
-	ur := pg.NewUserRepository(cuo, gube)
-	nr := pg.NewNewsletterRepository(cno, gnbui, gnbse, gnbpi)
-	sr := service.NewSubscriberRepository(lg, pgConn, gnibpi, guej, ms, uuej, appConfig, uds, sc)
-
-	tm := jwt.NewTokenManager(appConfig.JwtSecret, appConfig.Host)
+	ks, err := jwt.NewKeySet(appConfig.JwtSecret, appConfig.JwtKeysDir, appConfig.JwtActiveKeyID)
+	if err != nil {
+		panic("[JWT] failed to load keys: " + err.Error())
+	}
+	tm := jwt.NewTokenManager(ks, appConfig.Host, appConfig.JwtAudience)
+	tg := totp.NewGenerator(appConfig.AppName, time.Now)
 
 	hm := healthcheck.NewHealthMonitor(
 		healthcheck.NewPgIndicator(pgConn, 5*time.Second),
 	)
 
 	ruh := handler.NewRegisterUserHandler(ur, tm)
-	luh := handler.NewLoginUserHandler(ur, tm)
+	luh := handler.NewLoginUserHandler(ur, tm, tm, ur)
+	vtlh := handler.NewVerifyTOTPLoginHandler(tm, ur, tg, tm)
+	eth := handler.NewEnrollTOTPHandler(ur, ur, tg)
+	cth := handler.NewConfirmTOTPHandler(ur, tg, totp.GenerateRecoveryCodes)
+	cph := handler.NewChangePasswordHandler(ur, ur, ur)
+	rech := handler.NewRequestEmailChangeHandler(ur, ur, acr, secret.GenerateToken)
+	cech := handler.NewConfirmEmailChangeHandler(acr)
+	dah := handler.NewDeleteAccountHandler(ur, ur, acr)
 	dth := handler.NewDecodeTokenHandler(tm)
 	cnh := handler.NewCreateNewsletterHandler(nr)
 	gnbuih := handler.NewGetNewslettersByUserIDHandler(nr)
 	stnh := handler.NewSubscribeToNewsletterHandler(tm, sr)
-	gnbseh := handler.NewGetNewslettersBySubscriptionEmailHandler(nr)
-	uh := handler.NewUnsubscribeNewsletterHandler(sr, tm, sc)
+	gnbseh := handler.NewGetNewslettersBySubscriptionEmailHandler(lg, nr, sc, nr)
+	uh := handler.NewUnsubscribeNewsletterHandler(sr, tm)
+	surh := handler.NewSaveUnsubscribeReasonHandler(tm, urr)
 	pejh := handler.NewProcessEmailJobsHandler(lg, sr)
 	pejh.Handle(ctx)
 	gnbpih := handler.NewGetNewsletterByPublicIDHandler(nr)
+	unh := handler.NewUpdateNewsletterHandler(mr, nr, nr)
+	rnh := handler.NewRemoveNewsletterHandler(mr, nrr)
+	gsih := handler.NewGetSenderIdentityHandler(mr, sir)
+	usih := handler.NewUpdateSenderIdentityHandler(mr, sir, sdv)
+	rsdh := handler.NewRegisterSendingDomainHandler(sdr, sdc, dkim.GenerateKey, secret.GenerateToken)
+	gsdh := handler.NewGetSendingDomainsHandler(sdr, sdc)
+	vsdh := handler.NewVerifySendingDomainHandler(sdr, sdr, sdc, time.Now)
+	dsdh := handler.NewDeleteSendingDomainHandler(sdr)
+	gtoh := handler.NewGetTemplateOverridesHandler(mr, ntr)
+	utoh := handler.NewUpdateTemplateOverrideHandler(mr, ntr, ms)
+	dtoh := handler.NewDeleteTemplateOverrideHandler(mr, ntr)
+	peh := handler.NewPreviewEmailHandler(mr, ms, sr, sr)
+	steh := handler.NewSendTestEmailHandler(mr, ms, ur, sr)
+	cih := handler.NewCreateIssueHandler(mr, mdr, ir)
+	uih := handler.NewUpdateIssueHandler(mr, mdr, ir, ir)
+	gish := handler.NewGetIssuesHandler(mr, ir)
+	gih := handler.NewGetIssueHandler(mr, ir)
+	pih := handler.NewPublishIssueHandler(mr, ir, ipr)
+	gah := handler.NewGetArchiveHandler(nr, ir)
+	gaih := handler.NewGetArchivedIssueHandler(nr, ir)
+	gtsh := handler.NewGetTrackingSettingsHandler(mr, trr)
+	utsh := handler.NewUpdateTrackingSettingsHandler(mr, trr)
+	reoh := handler.NewRecordEmailOpenHandler(tt, trr, time.Now)
+	recch := handler.NewRecordEmailClickHandler(tt, trr, time.Now)
+	gnsh := handler.NewGetNewsletterStatsHandler(mr, str, time.Now)
+	gissh := handler.NewGetIssueStatsHandler(mr, ir, str)
+	guch := handler.NewGetUnsubscribeCommentsHandler(mr, urr, time.Now)
+	rdeh := handler.NewRecordDeliveryEventsHandler(ew, der)
+	rsh := handler.NewRefreshStatsHandler(lg, str, time.Now)
+	rsh.Handle(ctx)
+	cwh := handler.NewCreateWebhookHandler(mr, whr, secret.GenerateToken)
+	gwh := handler.NewGetWebhooksHandler(mr, whr)
+	dwh := handler.NewDeleteWebhookHandler(mr, whr)
+	gwdh := handler.NewGetWebhookDeliveriesHandler(mr, whr, whr)
+	stweh := handler.NewSendTestWebhookEventHandler(mr, whr, whr)
+	pwdh := handler.NewProcessWebhookDeliveriesHandler(lg, whr)
+	pwdh.Handle(ctx)
+	csh := handler.NewCacheSubscriptionHandler(sc)
+	ed.Register(csh, domain.EventSubscriptionCreated, domain.EventSubscriptionDisabled)
+	ed.Register(
+		whr,
+		domain.EventSubscriptionCreated,
+		domain.EventSubscriptionDisabled,
+		domain.EventIssuePublished,
+		domain.EventEmailBounced,
+	)
+	deh := handler.NewDispatchEventsHandler(lg, ed)
+	gfeh := handler.NewGetFailedEventsHandler(obr)
+	rfeh := handler.NewRetryFailedEventHandler(obr)
+	deh.Handle(ctx)
+	rsch := handler.NewReconcileSubscriptionCacheHandler(lg, snr, sc, time.Now)
+	// memory cache is repaired by expiration of its entries, only shared firebase one needs reconciliation
+	if appConfig.CacheBackend == config.CacheBackendFirebase {
+		scrh := handler.NewScheduleCacheReconciliationHandler(lg, rsch, appConfig.CacheReconcile)
+		scrh.Handle(ctx)
+	}
+	gpkh := handler.NewGetPublicKeysHandler(ks)
+	cakh := handler.NewCreateAPIKeyHandler(akr, apikey.Generate)
+	gakbuih := handler.NewGetAPIKeysByUserIDHandler(akr)
+	rakh := handler.NewRevokeAPIKeyHandler(akr)
+	aakh := handler.NewAuthenticateAPIKeyHandler(akr, time.Now)
+	imh := handler.NewInviteMemberHandler(mr, nr, mr, secret.GenerateToken)
+	aih := handler.NewAcceptInvitationHandler(ur, mr, time.Now)
+	gnmh := handler.NewGetNewsletterMembersHandler(mr, mr)
+	rmh := handler.NewRemoveMemberHandler(mr, mr)
+	toh := handler.NewTransferOwnershipHandler(mr, mr)
 
-	am := middleware.NewAuthMiddleware(dth, lg)
+	am := middleware.NewAuthMiddleware(dth, aakh, lg)
 
 	hc := controller.NewHealthController(lg, hm)
 	hc.RegisterHealhController(httpServer)
-	uc := controller.NewUserController(lg, ruh, luh)
-	uc.RegisterUserController(httpServer)
-	nc := controller.NewNewsletterController(lg, cnh, gnbuih, gnbpih)
+	kc := controller.NewKeyController(lg, gpkh)
+	kc.RegisterKeyController(httpServer)
+	uc := controller.NewUserController(lg, ruh, luh, vtlh, eth, cth, cph, rech, cech, dah)
+	uc.RegisterUserController(am, httpServer)
+	nc := controller.NewNewsletterController(lg, cnh, gnbuih, gnbpih, unh, rnh)
 	nc.RegisterNewsletterController(am, httpServer)
-	sco := controller.NewSubscriptionController(lg, gnbseh, stnh, uh)
+	mc := controller.NewMemberController(lg, imh, aih, gnmh, rmh, toh)
+	mc.RegisterMemberController(am, httpServer)
+	snc := controller.NewSenderController(lg, gsih, usih)
+	snc.RegisterSenderController(am, httpServer)
+	tc := controller.NewTemplateController(lg, gtoh, utoh, dtoh, peh, steh)
+	tc.RegisterTemplateController(am, httpServer)
+	ic := controller.NewIssueController(lg, cih, uih, gish, gih, pih)
+	ic.RegisterIssueController(am, httpServer)
+	arc := controller.NewArchiveController(lg, baseURL, gah, gaih)
+	arc.RegisterArchiveController(httpServer)
+	trc := controller.NewTrackingController(lg, gtsh, utsh, reoh, recch)
+	trc.RegisterTrackingController(am, httpServer)
+	stc := controller.NewStatsController(lg, gnsh, gissh, guch, rdeh)
+	stc.RegisterStatsController(am, httpServer)
+	whc := controller.NewWebhookController(lg, cwh, gwh, dwh, gwdh, stweh)
+	whc.RegisterWebhookController(am, httpServer)
+	sdco := controller.NewSendingDomainController(lg, rsdh, gsdh, vsdh, dsdh)
+	sdco.RegisterSendingDomainController(am, httpServer)
+	akc := controller.NewAPIKeyController(lg, cakh, gakbuih, rakh)
+	akc.RegisterAPIKeyController(am, httpServer)
+	sco := controller.NewSubscriptionController(lg, gnbseh, stnh, uh, surh)
 	sco.RegisterSubscriptionController(httpServer)
+	// reconciliation is exposed only for firebase backend, nil handler leaves its endpoint out
+	var mrsch controller.ReconcileSubscriptionCacheHandler
+	if appConfig.CacheBackend == config.CacheBackendFirebase {
+		mrsch = rsch
+	}
+	mtc := controller.NewMaintenanceController(lg, appConfig.MaintenanceToken, mrsch, gfeh, rfeh)
+	mtc.RegisterMaintenanceController(httpServer)
 }
@@ -0,0 +1,251 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type CreateAPIKeyHandler interface {
+	Handle(ctx context.Context, userID, name string, scopes []string, expiresAt *time.Time) (*dto.CreatedAPIKey, error)
+}
+
+type GetAPIKeysByUserIDHandler interface {
+	Handle(ctx context.Context, userID string) ([]*domain.APIKey, error)
+}
+
+type RevokeAPIKeyHandler interface {
+	Handle(ctx context.Context, userID, apiKeyID string) error
+}
+
+type APIKeyController struct {
+	lg                 logger.Logger
+	createAPIKey       CreateAPIKeyHandler
+	getAPIKeysByUserID GetAPIKeysByUserIDHandler
+	revokeAPIKey       RevokeAPIKeyHandler
+}
+
+func NewAPIKeyController(
+	lg logger.Logger,
+	cakh CreateAPIKeyHandler,
+	gakbuih GetAPIKeysByUserIDHandler,
+	rakh RevokeAPIKeyHandler,
+) *APIKeyController {
+	return &APIKeyController{
+		lg:                 lg,
+		createAPIKey:       cakh,
+		getAPIKeysByUserID: gakbuih,
+		revokeAPIKey:       rakh,
+	}
+}
+
+// RegisterAPIKeyController registers key management, which is reachable only by user token.
+func (a *APIKeyController) RegisterAPIKeyController(
+	authMiddleware *middleware.AuthMiddleware,
+	httpServer *http_server.Server,
+) {
+	httpServer.GetEngine().POST("api/v1/api-keys", authMiddleware.Handle, a.Create)
+	httpServer.GetEngine().GET("api/v1/api-keys", authMiddleware.Handle, a.GetAPIKeys)
+	httpServer.GetEngine().DELETE("api/v1/api-keys/:id", authMiddleware.Handle, a.Revoke)
+}
+
+// Create
+//
+//	@Summary	Create API key, the key is returned only once
+//	@Router		/api/v1/api-keys [post]
+//	@Tags		api key
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string						true	"Bearer <token>"	default(Bearer )
+//	@Param		APIKey			body		request.CreateAPIKeyRequest	true	"API key name, scopes and optional expiration"
+//
+//	@Success	201				{object}	response.CreatedAPIKey		"API key was successfully created"
+//	@Failure	400				{object}	response.Error				"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	500				"Unexpected exception"
+func (a *APIKeyController) Create(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		a.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		a.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		a.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		a.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	created, err := a.createAPIKey.Handle(ctx, userID.(string), req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		code, body := func(err error) (int, gin.H) {
+			if errors.Is(err, application.InvalidUUIDError) ||
+				errors.Is(err, application.InvalidScopeError) ||
+				errors.Is(err, application.InvalidExpirationError) {
+				return http.StatusBadRequest, gin.H{"error": err.Error()}
+			}
+			if errors.Is(err, application.UnknownUserError) {
+				return http.StatusUnauthorized, gin.H{}
+			}
+
+			return http.StatusInternalServerError, gin.H{}
+		}(err)
+		a.lg.WithError(err).Error("Failed to create api key")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.CreateCreatedAPIKeyResponseFromDto(created))
+}
+
+// GetAPIKeys
+//
+//	@Summary	List active API keys of user
+//	@Router		/api/v1/api-keys [get]
+//	@Tags		api key
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string			true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string			true	"Bearer <token>"	default(Bearer )
+//
+//	@Success	200				{array}		response.APIKey	"Successfully retrieved api keys"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	500				"Unexpected exception"
+func (a *APIKeyController) GetAPIKeys(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		a.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		a.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		a.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	keys, err := a.getAPIKeysByUserID.Handle(ctx, userID.(string))
+	if err != nil {
+		code, body := func(err error) (int, gin.H) {
+			if errors.Is(err, application.InvalidUUIDError) {
+				return http.StatusBadRequest, gin.H{"error": err.Error()}
+			}
+
+			return http.StatusInternalServerError, gin.H{}
+		}(err)
+		a.lg.WithError(err).Error("Failed to get api keys")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	mapped := make([]*response.APIKey, 0, len(keys))
+	for _, k := range keys {
+		mapped = append(mapped, response.CreateAPIKeyResponseFromEntity(k))
+	}
+
+	ctx.JSON(http.StatusOK, mapped)
+}
+
+// Revoke
+//
+//	@Summary	Revoke API key, it stops working immediately
+//	@Router		/api/v1/api-keys/{id} [delete]
+//	@Tags		api key
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header	string	true	"application/json"	default(application/json)
+//	@Param		Authorization	header	string	true	"Bearer <token>"	default(Bearer )
+//	@Param		id				path	string	true	"API key ID"
+//
+//	@Success	204				"API key was successfully revoked"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"API key not found"
+//	@Failure	500				"Unexpected exception"
+func (a *APIKeyController) Revoke(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		a.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		a.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		a.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := a.revokeAPIKey.Handle(ctx, userID.(string), ctx.Param("id")); err != nil {
+		code, body := func(err error) (int, gin.H) {
+			if errors.Is(err, application.InvalidUUIDError) {
+				return http.StatusBadRequest, gin.H{"error": err.Error()}
+			}
+			if errors.Is(err, application.APIKeyNotFoundError) {
+				return http.StatusNotFound, gin.H{}
+			}
+
+			return http.StatusInternalServerError, gin.H{}
+		}(err)
+		a.lg.WithError(err).Error("Failed to revoke api key")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
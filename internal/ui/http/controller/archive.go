@@ -0,0 +1,447 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/feed"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/page"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+// archivePageSize is number of issues on one server rendered archive page and in feeds.
+const archivePageSize = 20
+
+const (
+	rssFeedName  = "rss.xml"
+	atomFeedName = "atom.xml"
+)
+
+type GetArchiveHandler interface {
+	Handle(ctx context.Context, newsletterPublicID string, pageSize, pageNumber int) (*dto.Archive, error)
+}
+
+type GetArchivedIssueHandler interface {
+	Handle(ctx context.Context, newsletterPublicID, slug string) (*dto.ArchivedIssue, error)
+}
+
+// ArchiveController serves published issues to anonymous readers as JSON and as HTML pages meant for sharing.
+type ArchiveController struct {
+	lg               logger.Logger
+	baseURL          string
+	getArchive       GetArchiveHandler
+	getArchivedIssue GetArchivedIssueHandler
+}
+
+func NewArchiveController(
+	lg logger.Logger,
+	baseURL string,
+	gah GetArchiveHandler,
+	gaih GetArchivedIssueHandler,
+) *ArchiveController {
+	return &ArchiveController{
+		lg:               lg,
+		baseURL:          baseURL,
+		getArchive:       gah,
+		getArchivedIssue: gaih,
+	}
+}
+
+func (a *ArchiveController) RegisterArchiveController(httpServer *http_server.Server) {
+	httpServer.GetEngine().GET("api/v1/newsletters/:public_id/archive", a.GetArchive)
+	httpServer.GetEngine().GET("api/v1/newsletters/:public_id/archive/:slug", a.GetArchivedIssue)
+
+	httpServer.GetEngine().GET("newsletters/:public_id", a.ArchivePage)
+	httpServer.GetEngine().GET("newsletters/:public_id/issues/:slug", a.IssuePage)
+	httpServer.GetEngine().GET("newsletters/:public_id/"+rssFeedName, a.RSSFeed)
+	httpServer.GetEngine().GET("newsletters/:public_id/"+atomFeedName, a.AtomFeed)
+}
+
+// GetArchive
+//
+//	@Summary	List published issues of newsletter, newest first
+//	@Router		/api/v1/newsletters/{public_id}/archive [get]
+//	@Tags		public newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type		header		string														true	"application/json"	default(application/json)
+//	@Param		If-None-Match		header		string														false	"ETag of cached response"
+//	@Param		If-Modified-Since	header		string														false	"Last-Modified of cached response"
+//	@Param		public_id			path		string														true	"Newsletter public ID"
+//	@Param		page_size			query		int															true	"Number of items on page"	default(10)	minimum(1)
+//	@Param		page_number			query		int															true	"Page number"				default(1)	minimum(1)
+//
+//	@Success	200					{object}	response.PaginatedResponse[[]response.ArchivedIssueSummary]	"Published issues"
+//	@Success	304					"Cached response is current"
+//	@Failure	400					{object}	response.Error	"Invalid request with detail"
+//	@Failure	404					{object}	response.Error	"Newsletter not found or archived"
+//	@Failure	500					"Unexpected exception"
+func (a *ArchiveController) GetArchive(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		a.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		a.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	pageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 {
+		a.lg.WithError(err).Error("Failed to parse page size")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid pageSize"})
+
+		return
+	}
+
+	pageNumber, err := strconv.Atoi(ctx.DefaultQuery("page_number", "1"))
+	if err != nil || pageNumber < 1 {
+		a.lg.WithError(err).Error("Failed to parse page number")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid page number"})
+
+		return
+	}
+
+	archive, err := a.getArchive.Handle(ctx, ctx.Param("public_id"), pageSize, pageNumber)
+	if err != nil {
+		code, body := archiveErrorResponse(err)
+		a.lg.WithError(err).Error("Failed to get archive")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	publicID := archive.Newsletter.PublicID().String()
+	mapped := make([]*response.ArchivedIssueSummary, 0, len(archive.Issues))
+	for _, i := range archive.Issues {
+		mapped = append(
+			mapped,
+			response.CreateArchivedIssueSummaryResponseFromEntity(i, a.issueURL(publicID, i.Slug().String())),
+		)
+	}
+
+	a.writeJSON(ctx, archive.LastModified, response.PaginatedResponse[[]*response.ArchivedIssueSummary]{
+		Data: mapped,
+		Pagination: response.Pagination{
+			CurrentPage: archive.Pagination.CurrentPage,
+			PageSize:    archive.Pagination.PageSize,
+			TotalPages:  archive.Pagination.TotalPages,
+			TotalItems:  archive.Pagination.TotalItems,
+			HasPrevious: archive.Pagination.HasPrevious,
+			HasNext:     archive.Pagination.HasNext,
+		},
+	})
+}
+
+// GetArchivedIssue
+//
+//	@Summary	Retrieve published issue by its slug
+//	@Router		/api/v1/newsletters/{public_id}/archive/{slug} [get]
+//	@Tags		public newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type		header		string					true	"application/json"	default(application/json)
+//	@Param		If-None-Match		header		string					false	"ETag of cached response"
+//	@Param		If-Modified-Since	header		string					false	"Last-Modified of cached response"
+//	@Param		public_id			path		string					true	"Newsletter public ID"
+//	@Param		slug				path		string					true	"Issue slug"
+//
+//	@Success	200					{object}	response.ArchivedIssue	"Published issue"
+//	@Success	304					"Cached response is current"
+//	@Failure	400					{object}	response.Error	"Invalid request with detail"
+//	@Failure	404					{object}	response.Error	"Newsletter or issue not found"
+//	@Failure	500					"Unexpected exception"
+func (a *ArchiveController) GetArchivedIssue(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		a.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		a.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	archived, err := a.getArchivedIssue.Handle(ctx, ctx.Param("public_id"), ctx.Param("slug"))
+	if err != nil {
+		code, body := archiveErrorResponse(err)
+		a.lg.WithError(err).Error("Failed to get archived issue")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	url := a.issueURL(archived.Newsletter.PublicID().String(), archived.Issue.Slug().String())
+	a.writeJSON(ctx, archived.LastModified, response.CreateArchivedIssueResponseFromEntity(archived.Issue, url))
+}
+
+// ArchivePage
+//
+//	@Summary	HTML page listing published issues of newsletter
+//	@Router		/newsletters/{public_id} [get]
+//	@Tags		public newsletter
+//	@Produce	html
+//
+//	@Param		public_id	path	string	true	"Newsletter public ID"
+//	@Param		page		query	int		false	"Page number"	default(1)	minimum(1)
+//
+//	@Success	200			"Rendered page"
+//	@Success	304			"Cached page is current"
+//	@Failure	400			"Invalid request"
+//	@Failure	404			"Newsletter not found or archived"
+//	@Failure	500			"Unexpected exception"
+func (a *ArchiveController) ArchivePage(ctx *gin.Context) {
+	pageNumber, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil || pageNumber < 1 {
+		a.lg.WithError(err).Error("Failed to parse page number")
+		ctx.String(http.StatusBadRequest, http.StatusText(http.StatusBadRequest))
+
+		return
+	}
+
+	archive, err := a.getArchive.Handle(ctx, ctx.Param("public_id"), archivePageSize, pageNumber)
+	if err != nil {
+		code, _ := archiveErrorResponse(err)
+		a.lg.WithError(err).Error("Failed to get archive page")
+		ctx.String(code, http.StatusText(code))
+
+		return
+	}
+
+	publicID := archive.Newsletter.PublicID().String()
+	p := &page.Archive{
+		Newsletter: a.pageNewsletter(archive.Newsletter),
+		Issues:     make([]*page.IssueSummary, 0, len(archive.Issues)),
+	}
+	for _, i := range archive.Issues {
+		p.Issues = append(p.Issues, &page.IssueSummary{
+			Title:       i.Title(),
+			URL:         a.issueURL(publicID, i.Slug().String()),
+			PublishedAt: *i.PublishedAt(),
+		})
+	}
+	if archive.Pagination.HasPrevious {
+		p.NewerURL = fmt.Sprintf("%s?page=%d", a.archiveURL(publicID), pageNumber-1)
+	}
+	if archive.Pagination.HasNext {
+		p.OlderURL = fmt.Sprintf("%s?page=%d", a.archiveURL(publicID), pageNumber+1)
+	}
+
+	body, err := page.RenderArchive(p)
+	if err != nil {
+		a.lg.WithError(err).Error("Failed to render archive page")
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+
+		return
+	}
+
+	writeCacheable(ctx, "text/html; charset=utf-8", archive.LastModified, body)
+}
+
+// IssuePage
+//
+//	@Summary	HTML page with published issue, link meant for sharing
+//	@Router		/newsletters/{public_id}/issues/{slug} [get]
+//	@Tags		public newsletter
+//	@Produce	html
+//
+//	@Param		public_id	path	string	true	"Newsletter public ID"
+//	@Param		slug		path	string	true	"Issue slug"
+//
+//	@Success	200			"Rendered page"
+//	@Success	304			"Cached page is current"
+//	@Failure	400			"Invalid request"
+//	@Failure	404			"Newsletter or issue not found"
+//	@Failure	500			"Unexpected exception"
+func (a *ArchiveController) IssuePage(ctx *gin.Context) {
+	archived, err := a.getArchivedIssue.Handle(ctx, ctx.Param("public_id"), ctx.Param("slug"))
+	if err != nil {
+		code, _ := archiveErrorResponse(err)
+		a.lg.WithError(err).Error("Failed to get issue page")
+		ctx.String(code, http.StatusText(code))
+
+		return
+	}
+
+	body, err := page.RenderIssue(&page.Issue{
+		Newsletter:  a.pageNewsletter(archived.Newsletter),
+		Title:       archived.Issue.Title(),
+		PublishedAt: *archived.Issue.PublishedAt(),
+		// html is sanitized by markdown renderer when issue is saved
+		Content: template.HTML(archived.Issue.HTML()),
+	})
+	if err != nil {
+		a.lg.WithError(err).Error("Failed to render issue page")
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+
+		return
+	}
+
+	writeCacheable(ctx, "text/html; charset=utf-8", archived.LastModified, body)
+}
+
+// RSSFeed
+//
+//	@Summary	RSS 2.0 feed of latest published issues of newsletter
+//	@Router		/newsletters/{public_id}/rss.xml [get]
+//	@Tags		public newsletter
+//	@Produce	xml
+//
+//	@Param		If-None-Match		header	string	false	"ETag of cached feed"
+//	@Param		If-Modified-Since	header	string	false	"Last-Modified of cached feed"
+//	@Param		public_id			path	string	true	"Newsletter public ID"
+//
+//	@Success	200					"RSS feed"
+//	@Success	304					"Cached feed is current"
+//	@Failure	400					"Invalid request"
+//	@Failure	404					"Newsletter not found or archived"
+//	@Failure	500					"Unexpected exception"
+func (a *ArchiveController) RSSFeed(ctx *gin.Context) {
+	a.writeFeed(ctx, rssFeedName, "application/rss+xml; charset=utf-8", feed.RSS)
+}
+
+// AtomFeed
+//
+//	@Summary	Atom 1.0 feed of latest published issues of newsletter
+//	@Router		/newsletters/{public_id}/atom.xml [get]
+//	@Tags		public newsletter
+//	@Produce	xml
+//
+//	@Param		If-None-Match		header	string	false	"ETag of cached feed"
+//	@Param		If-Modified-Since	header	string	false	"Last-Modified of cached feed"
+//	@Param		public_id			path	string	true	"Newsletter public ID"
+//
+//	@Success	200					"Atom feed"
+//	@Success	304					"Cached feed is current"
+//	@Failure	400					"Invalid request"
+//	@Failure	404					"Newsletter not found or archived"
+//	@Failure	500					"Unexpected exception"
+func (a *ArchiveController) AtomFeed(ctx *gin.Context) {
+	a.writeFeed(ctx, atomFeedName, "application/atom+xml; charset=utf-8", feed.Atom)
+}
+
+func (a *ArchiveController) writeFeed(
+	ctx *gin.Context,
+	name, contentType string,
+	encode func(*feed.Feed) ([]byte, error),
+) {
+	archive, err := a.getArchive.Handle(ctx, ctx.Param("public_id"), archivePageSize, 1)
+	if err != nil {
+		code, _ := archiveErrorResponse(err)
+		a.lg.WithError(err).Error("Failed to get feed")
+		ctx.String(code, http.StatusText(code))
+
+		return
+	}
+
+	publicID := archive.Newsletter.PublicID().String()
+	f := &feed.Feed{
+		// urn ids stay the same when host of application changes
+		ID:       "urn:uuid:" + publicID,
+		Title:    archive.Newsletter.Name(),
+		Author:   archive.Newsletter.Name(),
+		Link:     a.archiveURL(publicID),
+		SelfLink: a.feedURL(publicID, name),
+		Updated:  archive.LastModified,
+		Entries:  make([]*feed.Entry, 0, len(archive.Issues)),
+	}
+	if archive.Newsletter.Description() != nil {
+		f.Description = *archive.Newsletter.Description()
+	}
+	for _, i := range archive.Issues {
+		f.Entries = append(f.Entries, &feed.Entry{
+			ID:        "urn:uuid:" + i.ID().String(),
+			Title:     i.Title(),
+			Link:      a.issueURL(publicID, i.Slug().String()),
+			Content:   i.HTML(),
+			Published: *i.PublishedAt(),
+			Updated:   i.ModifiedAt(),
+		})
+	}
+
+	body, err := encode(f)
+	if err != nil {
+		a.lg.WithError(err).Error("Failed to encode feed")
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+
+		return
+	}
+
+	writeCacheable(ctx, contentType, archive.LastModified, body)
+}
+
+func (a *ArchiveController) writeJSON(ctx *gin.Context, lastModified time.Time, body any) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		a.lg.WithError(err).Error("Failed to encode response")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	writeCacheable(ctx, "application/json; charset=utf-8", lastModified, encoded)
+}
+
+func (a *ArchiveController) pageNewsletter(n *domain.Newsletter) *page.Newsletter {
+	p := &page.Newsletter{
+		Name:    n.Name(),
+		URL:     a.archiveURL(n.PublicID().String()),
+		RSSURL:  a.feedURL(n.PublicID().String(), rssFeedName),
+		AtomURL: a.feedURL(n.PublicID().String(), atomFeedName),
+	}
+	if n.Description() != nil {
+		p.Description = *n.Description()
+	}
+
+	return p
+}
+
+func (a *ArchiveController) archiveURL(newsletterPublicID string) string {
+	return fmt.Sprintf("%s/newsletters/%s", a.baseURL, newsletterPublicID)
+}
+
+func (a *ArchiveController) feedURL(newsletterPublicID, name string) string {
+	return fmt.Sprintf("%s/newsletters/%s/%s", a.baseURL, newsletterPublicID, name)
+}
+
+func (a *ArchiveController) issueURL(newsletterPublicID, slug string) string {
+	return fmt.Sprintf("%s/newsletters/%s/issues/%s", a.baseURL, newsletterPublicID, slug)
+}
+
+func archiveErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, application.InvalidUUIDError),
+		errors.Is(err, application.InvalidSlugError):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, application.NewsletterNotFoundError):
+		return http.StatusNotFound, gin.H{"error": "Newsletter not found"}
+	case errors.Is(err, application.IssueNotFoundError):
+		return http.StatusNotFound, gin.H{"error": err.Error()}
+	default:
+		return http.StatusInternalServerError, gin.H{}
+	}
+}
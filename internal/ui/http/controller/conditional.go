@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeCacheable writes body with validators for conditional requests and answers 304 when client copy is current.
+// ETag is derived from body, so any visible change (including template change) invalidates cached copies.
+func writeCacheable(ctx *gin.Context, contentType string, lastModified time.Time, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	ctx.Header("ETag", etag)
+	ctx.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	ctx.Header("Cache-Control", "public, max-age=300")
+
+	if notModified(ctx.Request, etag, lastModified) {
+		ctx.Status(http.StatusNotModified)
+
+		return
+	}
+
+	ctx.Data(http.StatusOK, contentType, body)
+}
+
+// notModified evaluates If-None-Match first, If-Modified-Since is ignored when it is present (RFC 9110 13.2.2).
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	// header has second precision
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,405 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type CreateIssueHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, title, slug, markdown string) (*domain.Issue, error)
+}
+
+type UpdateIssueHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, issueID, title, markdown string) (*domain.Issue, error)
+}
+
+type GetIssuesHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string) ([]*domain.Issue, error)
+}
+
+type GetIssueHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, issueID string) (*domain.Issue, error)
+}
+
+type PublishIssueHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, issueID string) (*dto.PublishedIssue, error)
+}
+
+type IssueController struct {
+	lg           logger.Logger
+	createIssue  CreateIssueHandler
+	updateIssue  UpdateIssueHandler
+	getIssues    GetIssuesHandler
+	getIssue     GetIssueHandler
+	publishIssue PublishIssueHandler
+}
+
+func NewIssueController(
+	lg logger.Logger,
+	cih CreateIssueHandler,
+	uih UpdateIssueHandler,
+	gish GetIssuesHandler,
+	gih GetIssueHandler,
+	pih PublishIssueHandler,
+) *IssueController {
+	return &IssueController{
+		lg:           lg,
+		createIssue:  cih,
+		updateIssue:  uih,
+		getIssues:    gish,
+		getIssue:     gih,
+		publishIssue: pih,
+	}
+}
+
+func (i *IssueController) RegisterIssueController(
+	authMiddleware *middleware.AuthMiddleware,
+	httpServer *http_server.Server,
+) {
+	httpServer.GetEngine().POST(
+		"api/v1/newsletters/:public_id/issues",
+		authMiddleware.HandleScoped(domain.ScopeIssuesWrite),
+		i.CreateIssue,
+	)
+	httpServer.GetEngine().GET(
+		"api/v1/newsletters/:public_id/issues",
+		authMiddleware.HandleScoped(domain.ScopeIssuesRead),
+		i.GetIssues,
+	)
+	httpServer.GetEngine().GET(
+		"api/v1/newsletters/:public_id/issues/:issue_id",
+		authMiddleware.HandleScoped(domain.ScopeIssuesRead),
+		i.GetIssue,
+	)
+	httpServer.GetEngine().PUT(
+		"api/v1/newsletters/:public_id/issues/:issue_id",
+		authMiddleware.HandleScoped(domain.ScopeIssuesWrite),
+		i.UpdateIssue,
+	)
+	httpServer.GetEngine().POST(
+		"api/v1/newsletters/:public_id/issues/:issue_id/publish",
+		authMiddleware.HandleScoped(domain.ScopeIssuesWrite),
+		i.PublishIssue,
+	)
+}
+
+// CreateIssue
+//
+//	@Summary	Create draft issue written in markdown, editor role is required
+//	@Router		/api/v1/newsletters/{public_id}/issues [post]
+//	@Tags		issue
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string						true	"Bearer <token> or ApiKey <key>"	default(Bearer )
+//	@Param		public_id		path		string						true	"Newsletter public ID"
+//	@Param		Issue			body		request.CreateIssueRequest	true	"Slug is derived from title when empty"
+//
+//	@Success	201				{object}	response.Issue				"Issue was created"
+//	@Failure	400				{object}	response.Error				"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	409				{object}	response.Error	"Slug already used in newsletter"
+//	@Failure	500				"Unexpected exception"
+func (i *IssueController) CreateIssue(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		i.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		i.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.CreateIssueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		i.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		i.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	issue, err := i.createIssue.Handle(ctx, userID.(string), ctx.Param("public_id"), req.Title, req.Slug, req.Markdown)
+	if err != nil {
+		code, body := issueErrorResponse(err)
+		i.lg.WithError(err).Error("Failed to create issue")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.CreateIssueResponseFromEntity(issue))
+}
+
+// GetIssues
+//
+//	@Summary	List drafts and published issues of newsletter, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/issues [get]
+//	@Tags		issue
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string			true	"application/json"					default(application/json)
+//	@Param		Authorization	header		string			true	"Bearer <token> or ApiKey <key>"	default(Bearer )
+//	@Param		public_id		path		string			true	"Newsletter public ID"
+//
+//	@Success	200				{array}		response.Issue	"Successfully retrieved issues"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (i *IssueController) GetIssues(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		i.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		i.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		i.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	issues, err := i.getIssues.Handle(ctx, userID.(string), ctx.Param("public_id"))
+	if err != nil {
+		code, body := issueErrorResponse(err)
+		i.lg.WithError(err).Error("Failed to get issues")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	mapped := make([]*response.Issue, 0, len(issues))
+	for _, issue := range issues {
+		mapped = append(mapped, response.CreateIssueResponseFromEntity(issue))
+	}
+
+	ctx.JSON(http.StatusOK, mapped)
+}
+
+// GetIssue
+//
+//	@Summary	Get issue of newsletter, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/issues/{issue_id} [get]
+//	@Tags		issue
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string			true	"application/json"					default(application/json)
+//	@Param		Authorization	header		string			true	"Bearer <token> or ApiKey <key>"	default(Bearer )
+//	@Param		public_id		path		string			true	"Newsletter public ID"
+//	@Param		issue_id		path		string			true	"Issue ID"
+//
+//	@Success	200				{object}	response.Issue	"Successfully retrieved issue"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"Newsletter or issue not found"
+//	@Failure	500				"Unexpected exception"
+func (i *IssueController) GetIssue(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		i.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		i.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		i.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	issue, err := i.getIssue.Handle(ctx, userID.(string), ctx.Param("public_id"), ctx.Param("issue_id"))
+	if err != nil {
+		code, body := issueErrorResponse(err)
+		i.lg.WithError(err).Error("Failed to get issue")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateIssueResponseFromEntity(issue))
+}
+
+// UpdateIssue
+//
+//	@Summary	Replace title and markdown body of issue, editor role is required
+//	@Router		/api/v1/newsletters/{public_id}/issues/{issue_id} [put]
+//	@Tags		issue
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string						true	"Bearer <token> or ApiKey <key>"	default(Bearer )
+//	@Param		public_id		path		string						true	"Newsletter public ID"
+//	@Param		issue_id		path		string						true	"Issue ID"
+//	@Param		Issue			body		request.UpdateIssueRequest	true	"Issue content"
+//
+//	@Success	200				{object}	response.Issue				"Issue was updated"
+//	@Failure	400				{object}	response.Error				"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter or issue not found"
+//	@Failure	500				"Unexpected exception"
+func (i *IssueController) UpdateIssue(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		i.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		i.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.UpdateIssueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		i.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		i.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	issue, err := i.updateIssue.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		ctx.Param("issue_id"),
+		req.Title,
+		req.Markdown,
+	)
+	if err != nil {
+		code, body := issueErrorResponse(err)
+		i.lg.WithError(err).Error("Failed to update issue")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateIssueResponseFromEntity(issue))
+}
+
+// PublishIssue
+//
+//	@Summary	Publish issue and send it to active subscribers, editor role is required
+//	@Router		/api/v1/newsletters/{public_id}/issues/{issue_id}/publish [post]
+//	@Tags		issue
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string					true	"application/json"					default(application/json)
+//	@Param		Authorization	header		string					true	"Bearer <token> or ApiKey <key>"	default(Bearer )
+//	@Param		public_id		path		string					true	"Newsletter public ID"
+//	@Param		issue_id		path		string					true	"Issue ID"
+//
+//	@Success	200				{object}	response.PublishedIssue	"Issue was published"
+//	@Failure	400				{object}	response.Error			"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter or issue not found"
+//	@Failure	409				{object}	response.Error	"Issue already published"
+//	@Failure	500				"Unexpected exception"
+func (i *IssueController) PublishIssue(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		i.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		i.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		i.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	published, err := i.publishIssue.Handle(ctx, userID.(string), ctx.Param("public_id"), ctx.Param("issue_id"))
+	if err != nil {
+		code, body := issueErrorResponse(err)
+		i.lg.WithError(err).Error("Failed to publish issue")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreatePublishedIssueResponseFromDto(published))
+}
+
+func issueErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, application.InvalidSlugError):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, application.IssueNotFoundError):
+		return http.StatusNotFound, gin.H{"error": err.Error()}
+	case errors.Is(err, application.IssueSlugTakenError),
+		errors.Is(err, application.IssueAlreadyPublishedError):
+		return http.StatusConflict, gin.H{"error": err.Error()}
+	default:
+		return memberErrorResponse(err)
+	}
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type GetPublicKeysHandler interface {
+	Handle() []*dto.PublicKey
+}
+
+type KeyController struct {
+	lg            logger.Logger
+	getPublicKeys GetPublicKeysHandler
+}
+
+func NewKeyController(lg logger.Logger, gpkh GetPublicKeysHandler) *KeyController {
+	controller := &KeyController{lg: lg, getPublicKeys: gpkh}
+
+	return controller
+}
+
+func (k *KeyController) RegisterKeyController(httpServer *http_server.Server) {
+	httpServer.GetEngine().GET(".well-known/jwks.json", k.JWKS)
+}
+
+// JWKS
+//
+//	@Summary	Public keys for verification of issued tokens by other services
+//	@Router		/.well-known/jwks.json [get]
+//	@Tags		public key
+//	@Produce	json
+//
+//	@Success	200	{object}	response.JWKS
+func (k *KeyController) JWKS(ctx *gin.Context) {
+	// verifiers refetch on unknown kid, short cache is enough to survive rotation
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, response.CreateJWKSResponseFromDto(k.getPublicKeys.Handle()))
+}
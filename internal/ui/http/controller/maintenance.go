@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+const maintenanceTokenHeader = "X-Maintenance-Token"
+
+type ReconcileSubscriptionCacheHandler interface {
+	Handle(ctx context.Context) (*dto.CacheReconciliation, error)
+}
+
+type GetFailedEventsHandler interface {
+	Handle(ctx context.Context) ([]*dto.FailedEvent, error)
+}
+
+type RetryFailedEventHandler interface {
+	Handle(ctx context.Context, eventID string) error
+}
+
+// MaintenanceController exposes operational tasks to operators holding maintenance token.
+type MaintenanceController struct {
+	lg                         logger.Logger
+	maintenanceToken           string
+	reconcileSubscriptionCache ReconcileSubscriptionCacheHandler
+	getFailedEvents            GetFailedEventsHandler
+	retryFailedEvent           RetryFailedEventHandler
+}
+
+// NewMaintenanceController takes nil reconcile handler when cache backend needs no reconciliation.
+func NewMaintenanceController(
+	lg logger.Logger,
+	maintenanceToken string,
+	rsch ReconcileSubscriptionCacheHandler,
+	gfeh GetFailedEventsHandler,
+	rfeh RetryFailedEventHandler,
+) *MaintenanceController {
+	return &MaintenanceController{
+		lg:                         lg,
+		maintenanceToken:           maintenanceToken,
+		reconcileSubscriptionCache: rsch,
+		getFailedEvents:            gfeh,
+		retryFailedEvent:           rfeh,
+	}
+}
+
+// RegisterMaintenanceController registers nothing when maintenance token is not configured.
+func (m *MaintenanceController) RegisterMaintenanceController(httpServer *http_server.Server) {
+	if m.maintenanceToken == "" {
+		return
+	}
+
+	engine := httpServer.GetEngine()
+	if m.reconcileSubscriptionCache != nil {
+		engine.POST("api/maintenance/subscription-cache/reconcile", m.authorize, m.ReconcileSubscriptionCache)
+	}
+	engine.GET("api/maintenance/events/failed", m.authorize, m.GetFailedEvents)
+	engine.POST("api/maintenance/events/failed/:event_id/retry", m.authorize, m.RetryFailedEvent)
+}
+
+func (m *MaintenanceController) authorize(ctx *gin.Context) {
+	token := ctx.GetHeader(maintenanceTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(m.maintenanceToken)) != 1 {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid maintenance token"})
+
+		return
+	}
+
+	ctx.Next()
+}
+
+// ReconcileSubscriptionCache
+//
+//	@Summary	Compares subscription cache with active subscriptions and repairs drift right away
+//	@Router		/api/maintenance/subscription-cache/reconcile [post]
+//	@Tags		maintenance
+//	@Produce	json
+//
+//	@Param		X-Maintenance-Token	header		string	true	"Maintenance token"
+//
+//	@Success	200					{object}	response.CacheReconciliation
+//	@Failure	401					"Invalid maintenance token"
+//	@Failure	500					"Unexpected exception"
+func (m *MaintenanceController) ReconcileSubscriptionCache(ctx *gin.Context) {
+	report, err := m.reconcileSubscriptionCache.Handle(ctx)
+	if err != nil {
+		m.lg.WithError(err).Error("Failed to reconcile subscription cache")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CacheReconciliation{
+		StartedAt:     report.StartedAt,
+		DurationMs:    report.Duration.Milliseconds(),
+		Subscribers:   report.Subscribers,
+		Subscriptions: report.Subscriptions,
+		Cached:        report.Cached,
+		Missing:       report.Missing,
+		Stale:         report.Stale,
+		Repaired:      report.Repaired,
+		Added:         report.Added,
+		Removed:       report.Removed,
+		Failed:        report.Failed,
+	})
+}
+
+// GetFailedEvents
+//
+//	@Summary	Lists domain events given up after their last dispatch attempt failed, most recently failed first
+//	@Router		/api/maintenance/events/failed [get]
+//	@Tags		maintenance
+//	@Produce	json
+//
+//	@Param		X-Maintenance-Token	header	string	true	"Maintenance token"
+//
+//	@Success	200					{array}	response.FailedEvent
+//	@Failure	401					"Invalid maintenance token"
+//	@Failure	500					"Unexpected exception"
+func (m *MaintenanceController) GetFailedEvents(ctx *gin.Context) {
+	events, err := m.getFailedEvents.Handle(ctx)
+	if err != nil {
+		m.lg.WithError(err).Error("Failed to get failed events")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateFailedEventsResponse(events))
+}
+
+// RetryFailedEvent
+//
+//	@Summary	Schedules failed domain event to be dispatched again with fresh attempts
+//	@Router		/api/maintenance/events/failed/{event_id}/retry [post]
+//	@Tags		maintenance
+//
+//	@Param		X-Maintenance-Token	header	string	true	"Maintenance token"
+//	@Param		event_id			path	string	true	"Event ID"
+//
+//	@Success	204					"Event was scheduled"
+//	@Failure	400					{object}	response.Error	"Invalid event ID"
+//	@Failure	401					"Invalid maintenance token"
+//	@Failure	404					"Failed event not found"
+//	@Failure	500					"Unexpected exception"
+func (m *MaintenanceController) RetryFailedEvent(ctx *gin.Context) {
+	if err := m.retryFailedEvent.Handle(ctx, ctx.Param("event_id")); err != nil {
+		m.lg.WithError(err).Error("Failed to retry failed event")
+		switch {
+		case errors.Is(err, application.InvalidUUIDError):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, application.FailedEventNotFoundError):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{})
+		}
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
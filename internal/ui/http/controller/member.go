@@ -0,0 +1,396 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type InviteMemberHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, email, role string) error
+}
+
+type AcceptInvitationHandler interface {
+	Handle(ctx context.Context, userID, token string) (*domain.Invitation, error)
+}
+
+type GetNewsletterMembersHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string) ([]*domain.Member, error)
+}
+
+type RemoveMemberHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, memberUserID string) error
+}
+
+type TransferOwnershipHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, newOwnerUserID string) error
+}
+
+type MemberController struct {
+	lg                   logger.Logger
+	inviteMember         InviteMemberHandler
+	acceptInvitation     AcceptInvitationHandler
+	getNewsletterMembers GetNewsletterMembersHandler
+	removeMember         RemoveMemberHandler
+	transferOwnership    TransferOwnershipHandler
+}
+
+func NewMemberController(
+	lg logger.Logger,
+	imh InviteMemberHandler,
+	aih AcceptInvitationHandler,
+	gnmh GetNewsletterMembersHandler,
+	rmh RemoveMemberHandler,
+	toh TransferOwnershipHandler,
+) *MemberController {
+	return &MemberController{
+		lg:                   lg,
+		inviteMember:         imh,
+		acceptInvitation:     aih,
+		getNewsletterMembers: gnmh,
+		removeMember:         rmh,
+		transferOwnership:    toh,
+	}
+}
+
+func (m *MemberController) RegisterMemberController(
+	authMiddleware *middleware.AuthMiddleware,
+	httpServer *http_server.Server,
+) {
+	httpServer.GetEngine().POST("api/v1/newsletters/:newsletter_public_id/invitations", authMiddleware.Handle, m.Invite)
+	httpServer.GetEngine().POST("api/v1/newsletters/:newsletter_public_id/owner", authMiddleware.Handle, m.TransferOwnership)
+	httpServer.GetEngine().POST("api/v1/invitations/accept", authMiddleware.Handle, m.AcceptInvitation)
+	httpServer.GetEngine().GET("api/v1/newsletters/:public_id/members", authMiddleware.Handle, m.GetMembers)
+	httpServer.GetEngine().DELETE("api/v1/newsletters/:public_id/members/:user_id", authMiddleware.Handle, m.RemoveMember)
+}
+
+// Invite
+//
+//	@Summary	Invite collaborator to newsletter by email, only owner can invite
+//	@Router		/api/v1/newsletters/{newsletter_public_id}/invitations [post]
+//	@Tags		member
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization			header	string						true	"Bearer <token>"	default(Bearer )
+//	@Param		newsletter_public_id	path	string						true	"Newsletter public ID"
+//	@Param		Invitation				body	request.InviteMemberRequest	true	"Email and role (editor or viewer)"
+//
+//	@Success	201						"Invitation was successfully sent"
+//	@Failure	400						{object}	response.Error	"Invalid request with detail"
+//	@Failure	401						"Unauthorized"
+//	@Failure	403						"Insufficient role"
+//	@Failure	404						"Newsletter not found"
+//	@Failure	409						"Already member"
+//	@Failure	500						"Unexpected exception"
+func (m *MemberController) Invite(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		m.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		m.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.InviteMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		m.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		m.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := m.inviteMember.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("newsletter_public_id"),
+		req.Email,
+		req.Role,
+	); err != nil {
+		code, body := memberErrorResponse(err)
+		m.lg.WithError(err).Error("Failed to invite member")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{})
+}
+
+// AcceptInvitation
+//
+//	@Summary	Accept invitation to newsletter, user has to be signed in with invited email
+//	@Router		/api/v1/invitations/accept [post]
+//	@Tags		member
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string							true	"Bearer <token>"	default(Bearer )
+//	@Param		Invitation		body		request.AcceptInvitationRequest	true	"Token from invitation email"
+//
+//	@Success	200				{object}	response.AcceptedInvitation		"Invitation was accepted"
+//	@Failure	400				{object}	response.Error					"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Invitation was sent to different email"
+//	@Failure	404				"Invitation not found or expired"
+//	@Failure	409				"Already member"
+//	@Failure	500				"Unexpected exception"
+func (m *MemberController) AcceptInvitation(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		m.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		m.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.AcceptInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		m.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		m.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	invitation, err := m.acceptInvitation.Handle(ctx, userID.(string), req.Token)
+	if err != nil {
+		code, body := memberErrorResponse(err)
+		m.lg.WithError(err).Error("Failed to accept invitation")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateAcceptedInvitationResponseFromEntity(invitation))
+}
+
+// GetMembers
+//
+//	@Summary	List members of newsletter, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/members [get]
+//	@Tags		member
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string			true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string			true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string			true	"Newsletter public ID"
+//
+//	@Success	200				{array}		response.Member	"Successfully retrieved members"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (m *MemberController) GetMembers(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		m.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		m.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		m.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	members, err := m.getNewsletterMembers.Handle(ctx, userID.(string), ctx.Param("public_id"))
+	if err != nil {
+		code, body := memberErrorResponse(err)
+		m.lg.WithError(err).Error("Failed to get newsletter members")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	mapped := make([]*response.Member, 0, len(members))
+	for _, member := range members {
+		mapped = append(mapped, response.CreateMemberResponseFromEntity(member))
+	}
+
+	ctx.JSON(http.StatusOK, mapped)
+}
+
+// RemoveMember
+//
+//	@Summary	Remove member from newsletter, owner can remove others and members can leave
+//	@Router		/api/v1/newsletters/{public_id}/members/{user_id} [delete]
+//	@Tags		member
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header	string	true	"application/json"	default(application/json)
+//	@Param		Authorization	header	string	true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path	string	true	"Newsletter public ID"
+//	@Param		user_id			path	string	true	"User ID of member"
+//
+//	@Success	204				"Member was removed"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter or member not found"
+//	@Failure	409				"Owner can not be removed"
+//	@Failure	500				"Unexpected exception"
+func (m *MemberController) RemoveMember(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		m.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		m.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		m.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := m.removeMember.Handle(ctx, userID.(string), ctx.Param("public_id"), ctx.Param("user_id")); err != nil {
+		code, body := memberErrorResponse(err)
+		m.lg.WithError(err).Error("Failed to remove member")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// TransferOwnership
+//
+//	@Summary	Transfer ownership to existing member, previous owner becomes editor
+//	@Router		/api/v1/newsletters/{newsletter_public_id}/owner [post]
+//	@Tags		member
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization			header	string								true	"Bearer <token>"	default(Bearer )
+//	@Param		newsletter_public_id	path	string								true	"Newsletter public ID"
+//	@Param		Owner					body	request.TransferOwnershipRequest	true	"User ID of new owner"
+//
+//	@Success	204						"Ownership was transferred"
+//	@Failure	400						{object}	response.Error	"Invalid request with detail"
+//	@Failure	401						"Unauthorized"
+//	@Failure	403						"Insufficient role"
+//	@Failure	404						"Newsletter or member not found"
+//	@Failure	500						"Unexpected exception"
+func (m *MemberController) TransferOwnership(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		m.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		m.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.TransferOwnershipRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		m.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		m.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := m.transferOwnership.Handle(ctx, userID.(string), ctx.Param("newsletter_public_id"), req.UserID); err != nil {
+		code, body := memberErrorResponse(err)
+		m.lg.WithError(err).Error("Failed to transfer ownership")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func memberErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, application.InvalidUUIDError),
+		errors.Is(err, application.InvalidEmailError),
+		errors.Is(err, application.InvalidRoleError):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, application.InsufficientRoleError),
+		errors.Is(err, application.InvitationEmailMismatchError):
+		return http.StatusForbidden, gin.H{"error": err.Error()}
+	case errors.Is(err, application.NewsletterNotFoundError),
+		errors.Is(err, application.MemberNotFoundError),
+		errors.Is(err, application.InvitationNotFoundError):
+		return http.StatusNotFound, gin.H{"error": err.Error()}
+	case errors.Is(err, application.AlreadyMemberError),
+		errors.Is(err, application.OwnerRemovalError):
+		return http.StatusConflict, gin.H{"error": err.Error()}
+	case errors.Is(err, application.UserNotFoundError):
+		return http.StatusUnauthorized, gin.H{}
+	default:
+		return http.StatusInternalServerError, gin.H{}
+	}
+}
@@ -29,11 +29,21 @@ type GetNewsletterByPublicIDHandler interface {
 	Handle(ctx context.Context, publicID string) (*domain.Newsletter, error)
 }
 
+type UpdateNewsletterHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string, name, description *string) (*domain.Newsletter, error)
+}
+
+type RemoveNewsletterHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string, hardDelete, notify bool) error
+}
+
 type NewsletterController struct {
 	lg                      logger.Logger
 	createNewsletter        CreateNewsletterHandler
 	getNewslettersByUserID  GetNewslettersByUserIDHandler
 	getNewsletterByPublicID GetNewsletterByPublicIDHandler
+	updateNewsletter        UpdateNewsletterHandler
+	removeNewsletter        RemoveNewsletterHandler
 }
 
 func NewNewsletterController(
@@ -41,12 +51,16 @@ func NewNewsletterController(
 	cnh CreateNewsletterHandler,
 	gnbui GetNewslettersByUserIDHandler,
 	gnbpih GetNewsletterByPublicIDHandler,
+	unh UpdateNewsletterHandler,
+	rnh RemoveNewsletterHandler,
 ) *NewsletterController {
 	controller := &NewsletterController{
 		createNewsletter:        cnh,
 		getNewslettersByUserID:  gnbui,
 		lg:                      lg,
 		getNewsletterByPublicID: gnbpih,
+		updateNewsletter:        unh,
+		removeNewsletter:        rnh,
 	}
 
 	return controller
@@ -56,8 +70,10 @@ func (u *NewsletterController) RegisterNewsletterController(
 	authMiddleware *middleware.AuthMiddleware,
 	httpServer *http_server.Server,
 ) {
-	httpServer.GetEngine().POST("api/v1/newsletters", authMiddleware.Handle, u.Create)
-	httpServer.GetEngine().GET("api/v1/newsletters", authMiddleware.Handle, u.GetNewslettersByUserID)
+	httpServer.GetEngine().POST("api/v1/newsletters", authMiddleware.HandleScoped(domain.ScopeNewslettersWrite), u.Create)
+	httpServer.GetEngine().GET("api/v1/newsletters", authMiddleware.HandleScoped(domain.ScopeNewslettersRead), u.GetNewslettersByUserID)
+	httpServer.GetEngine().PATCH("api/v1/newsletters/:public_id", authMiddleware.HandleScoped(domain.ScopeNewslettersWrite), u.Update)
+	httpServer.GetEngine().DELETE("api/v1/newsletters/:public_id", authMiddleware.HandleScoped(domain.ScopeNewslettersWrite), u.Remove)
 
 	httpServer.GetEngine().GET("api/v1/newsletters/:public_id", u.GetNewsletterByPublicID)
 }
@@ -70,7 +86,7 @@ func (u *NewsletterController) RegisterNewsletterController(
 //	@Accepts	json
 //	@Produce	json
 //
-//	@Param		Authorization	header	string							true	"Bearer <token>"	default(Bearer )
+//	@Param		Authorization	header	string							true	"Bearer <token> or ApiKey <key>"	default(Bearer )
 //	@Param		Newsletter		body	request.CreateNewsletterRequest	true	"Newsletter data to create"
 //
 //	@Success	201				"Newsletter was successfully created"
@@ -136,10 +152,10 @@ func (u *NewsletterController) Create(ctx *gin.Context) {
 //	@Accepts	json
 //	@Produce	json
 //
-//	@Param		Content-Type	header		string						true	"application/json"			default(application/json)
-//	@Param		Authorization	header		string						true	"Bearer <token>"			default(Bearer )
-//	@Param		page_size		query		int							true	"Number of items on page"	default(10)	minimum(1)
-//	@Param		page_number		query		int							true	"Page number"				default(1)	minimum(1)
+//	@Param		Content-Type	header		string						true	"application/json"					default(application/json)
+//	@Param		Authorization	header		string						true	"Bearer <token> or ApiKey <key>"	default(Bearer )
+//	@Param		page_size		query		int							true	"Number of items on page"			default(10)	minimum(1)
+//	@Param		page_number		query		int							true	"Page number"						default(1)	minimum(1)
 //
 //	@Success	200				{object}	response.InternalNewsletter	"Successfully retrieved newsletters by user ID"
 //	@Failure	400				{object}	response.Error				"Invalid request with detail"
@@ -241,6 +257,7 @@ func (u *NewsletterController) GetNewslettersByUserID(ctx *gin.Context) {
 //
 //	@Success	200				{object}	response.PublicNewsletter	"Successfully retrieved newsletter by public ID"
 //	@Failure	400				{object}	response.Error				"Invalid request with detail"
+//	@Failure	404				{object}	response.Error				"Newsletter not found or archived"
 //	@Failure	500				"Unexpected exception"
 func (u *NewsletterController) GetNewsletterByPublicID(ctx *gin.Context) {
 	var h *request.ContentTypeHeader
@@ -271,6 +288,9 @@ func (u *NewsletterController) GetNewsletterByPublicID(ctx *gin.Context) {
 			if errors.Is(err, application.InvalidUUIDError) {
 				return http.StatusBadRequest, gin.H{"error": err.Error()}
 			}
+			if errors.Is(err, application.NewsletterNotFoundError) {
+				return http.StatusNotFound, gin.H{"error": "Newsletter not found"}
+			}
 
 			return http.StatusInternalServerError, gin.H{}
 		}(err)
@@ -282,3 +302,139 @@ func (u *NewsletterController) GetNewsletterByPublicID(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response.CreatePublicNewsletterResponseFromEntity(newsletter))
 }
+
+// Update
+//
+//	@Summary	Change name or description of newsletter, only owner can do it
+//	@Router		/api/v1/newsletters/{public_id} [patch]
+//	@Tags		newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string							true	"Bearer <token> or ApiKey <key>"	default(Bearer )
+//	@Param		public_id		path		string							true	"Newsletter public ID"
+//	@Param		Newsletter		body		request.UpdateNewsletterRequest	true	"Changed newsletter data"
+//
+//	@Success	200				{object}	response.InternalNewsletter		"Newsletter was successfully updated"
+//	@Failure	400				{object}	response.Error					"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				{object}	response.Error	"Insufficient role"
+//	@Failure	404				{object}	response.Error	"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (u *NewsletterController) Update(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		u.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		u.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.UpdateNewsletterRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		u.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if req.Name == nil && req.Description == nil {
+		u.lg.Error("Nothing to update")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "name or description is required"})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		u.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	newsletter, err := u.updateNewsletter.Handle(ctx, userID.(string), ctx.Param("public_id"), req.Name, req.Description)
+	if err != nil {
+		code, body := newsletterManagementErrorResponse(err)
+		u.lg.WithError(err).Error("Failed to update newsletter")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateInternalNewsletterResponseFromEntity(newsletter))
+}
+
+// Remove
+//
+//	@Summary		Archive or permanently delete newsletter, only owner can do it
+//	@Description	Archived newsletter is hidden, accepts no new subscriptions, its pending emails are cancelled
+//	@Description	and subscriptions disabled. Hard delete removes newsletter with subscriptions and email jobs.
+//	@Router			/api/v1/newsletters/{public_id} [delete]
+//	@Tags			newsletter
+//	@Produce		json
+//
+//	@Param			Authorization	header	string	true	"Bearer <token> or ApiKey <key>"	default(Bearer )
+//	@Param			public_id		path	string	true	"Newsletter public ID"
+//	@Param			hard_delete		query	bool	false	"Delete permanently instead of archiving"	default(false)
+//	@Param			notify			query	bool	false	"Notify active subscribers by email"		default(false)
+//
+//	@Success		204				"Newsletter was removed"
+//	@Failure		400				{object}	response.Error	"Invalid request with detail"
+//	@Failure		401				"Unauthorized"
+//	@Failure		403				{object}	response.Error	"Insufficient role"
+//	@Failure		404				{object}	response.Error	"Newsletter not found"
+//	@Failure		500				"Unexpected exception"
+func (u *NewsletterController) Remove(ctx *gin.Context) {
+	hardDelete, err := strconv.ParseBool(ctx.DefaultQuery("hard_delete", "false"))
+	if err != nil {
+		u.lg.WithError(err).Error("Failed to parse hard_delete")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid hard_delete"})
+
+		return
+	}
+	notify, err := strconv.ParseBool(ctx.DefaultQuery("notify", "false"))
+	if err != nil {
+		u.lg.WithError(err).Error("Failed to parse notify")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid notify"})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		u.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := u.removeNewsletter.Handle(ctx, userID.(string), ctx.Param("public_id"), hardDelete, notify); err != nil {
+		code, body := newsletterManagementErrorResponse(err)
+		u.lg.WithError(err).Error("Failed to remove newsletter")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func newsletterManagementErrorResponse(err error) (int, gin.H) {
+	if errors.Is(err, application.InvalidUUIDError) {
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	}
+	if errors.Is(err, application.InsufficientRoleError) {
+		return http.StatusForbidden, gin.H{"error": "Insufficient role"}
+	}
+	if errors.Is(err, application.NewsletterNotFoundError) {
+		return http.StatusNotFound, gin.H{"error": "Newsletter not found"}
+	}
+
+	return http.StatusInternalServerError, gin.H{}
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type GetSenderIdentityHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string) (*domain.SenderIdentity, error)
+}
+
+type UpdateSenderIdentityHandler interface {
+	Handle(
+		ctx context.Context,
+		userID, newsletterPublicID, fromName, fromAddress, replyTo, postalAddress string,
+	) (*domain.SenderIdentity, error)
+}
+
+type SenderController struct {
+	lg                   logger.Logger
+	getSenderIdentity    GetSenderIdentityHandler
+	updateSenderIdentity UpdateSenderIdentityHandler
+}
+
+func NewSenderController(
+	lg logger.Logger,
+	gsih GetSenderIdentityHandler,
+	usih UpdateSenderIdentityHandler,
+) *SenderController {
+	return &SenderController{
+		lg:                   lg,
+		getSenderIdentity:    gsih,
+		updateSenderIdentity: usih,
+	}
+}
+
+func (s *SenderController) RegisterSenderController(
+	authMiddleware *middleware.AuthMiddleware,
+	httpServer *http_server.Server,
+) {
+	httpServer.GetEngine().GET("api/v1/newsletters/:public_id/sender", authMiddleware.Handle, s.GetSenderIdentity)
+	httpServer.GetEngine().PUT("api/v1/newsletters/:public_id/sender", authMiddleware.Handle, s.UpdateSenderIdentity)
+}
+
+// GetSenderIdentity
+//
+//	@Summary	Get sender identity of newsletter, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/sender [get]
+//	@Tags		newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string					true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string					true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string					true	"Newsletter public ID"
+//
+//	@Success	200				{object}	response.SenderIdentity	"Successfully retrieved sender identity"
+//	@Failure	400				{object}	response.Error			"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (s *SenderController) GetSenderIdentity(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	identity, err := s.getSenderIdentity.Handle(ctx, userID.(string), ctx.Param("public_id"))
+	if err != nil {
+		code, body := senderErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to get sender identity")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateSenderIdentityResponseFromEntity(identity))
+}
+
+// UpdateSenderIdentity
+//
+//	@Summary	Replace sender identity of newsletter, only owner can change it
+//	@Router		/api/v1/newsletters/{public_id}/sender [put]
+//	@Tags		newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string								true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string								true	"Newsletter public ID"
+//	@Param		Sender			body		request.UpdateSenderIdentityRequest	true	"Empty values fall back to defaults"
+//
+//	@Success	200				{object}	response.SenderIdentity				"Sender identity was updated"
+//	@Failure	400				{object}	response.Error						"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	422				{object}	response.Error	"Sender domain is not verified"
+//	@Failure	500				"Unexpected exception"
+func (s *SenderController) UpdateSenderIdentity(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.UpdateSenderIdentityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		s.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	identity, err := s.updateSenderIdentity.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		req.FromName,
+		req.FromAddress,
+		req.ReplyTo,
+		req.PostalAddress,
+	)
+	if err != nil {
+		code, body := senderErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to update sender identity")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateSenderIdentityResponseFromEntity(identity))
+}
+
+func senderErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, application.UnverifiedSenderDomainError):
+		return http.StatusUnprocessableEntity, gin.H{"error": err.Error()}
+	default:
+		return memberErrorResponse(err)
+	}
+}
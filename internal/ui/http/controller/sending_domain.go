@@ -0,0 +1,299 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type RegisterSendingDomainHandler interface {
+	Handle(ctx context.Context, userID, name string) (*dto.SendingDomain, error)
+}
+
+type GetSendingDomainsHandler interface {
+	Handle(ctx context.Context, userID string) ([]*dto.SendingDomain, error)
+}
+
+type VerifySendingDomainHandler interface {
+	Handle(ctx context.Context, userID, sendingDomainID string) (*dto.SendingDomain, error)
+}
+
+type DeleteSendingDomainHandler interface {
+	Handle(ctx context.Context, userID, sendingDomainID string) error
+}
+
+type SendingDomainController struct {
+	lg                    logger.Logger
+	registerSendingDomain RegisterSendingDomainHandler
+	getSendingDomains     GetSendingDomainsHandler
+	verifySendingDomain   VerifySendingDomainHandler
+	deleteSendingDomain   DeleteSendingDomainHandler
+}
+
+func NewSendingDomainController(
+	lg logger.Logger,
+	rsdh RegisterSendingDomainHandler,
+	gsdh GetSendingDomainsHandler,
+	vsdh VerifySendingDomainHandler,
+	dsdh DeleteSendingDomainHandler,
+) *SendingDomainController {
+	return &SendingDomainController{
+		lg:                    lg,
+		registerSendingDomain: rsdh,
+		getSendingDomains:     gsdh,
+		verifySendingDomain:   vsdh,
+		deleteSendingDomain:   dsdh,
+	}
+}
+
+func (s *SendingDomainController) RegisterSendingDomainController(
+	authMiddleware *middleware.AuthMiddleware,
+	httpServer *http_server.Server,
+) {
+	httpServer.GetEngine().POST("api/v1/sending-domains", authMiddleware.Handle, s.Register)
+	httpServer.GetEngine().GET("api/v1/sending-domains", authMiddleware.Handle, s.GetSendingDomains)
+	httpServer.GetEngine().POST("api/v1/sending-domains/:id/verify", authMiddleware.Handle, s.Verify)
+	httpServer.GetEngine().DELETE("api/v1/sending-domains/:id", authMiddleware.Handle, s.Delete)
+}
+
+// Register
+//
+//	@Summary	Register sending domain, response contains DNS records which have to be published before verification
+//	@Router		/api/v1/sending-domains [post]
+//	@Tags		sending domain
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string									true	"Bearer <token>"	default(Bearer )
+//	@Param		SendingDomain	body		request.RegisterSendingDomainRequest	true	"Domain name"
+//
+//	@Success	201				{object}	response.SendingDomain					"Sending domain was registered"
+//	@Failure	400				{object}	response.Error							"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	409				"Domain already registered"
+//	@Failure	500				"Unexpected exception"
+func (s *SendingDomainController) Register(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.RegisterSendingDomainRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		s.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	sd, err := s.registerSendingDomain.Handle(ctx, userID.(string), req.Domain)
+	if err != nil {
+		code, body := sendingDomainErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to register sending domain")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.CreateSendingDomainResponseFromDto(sd))
+}
+
+// GetSendingDomains
+//
+//	@Summary	List sending domains of user with their DNS records
+//	@Router		/api/v1/sending-domains [get]
+//	@Tags		sending domain
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string					true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string					true	"Bearer <token>"	default(Bearer )
+//
+//	@Success	200				{array}		response.SendingDomain	"Successfully retrieved sending domains"
+//	@Failure	400				{object}	response.Error			"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	500				"Unexpected exception"
+func (s *SendingDomainController) GetSendingDomains(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	domains, err := s.getSendingDomains.Handle(ctx, userID.(string))
+	if err != nil {
+		code, body := sendingDomainErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to get sending domains")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	mapped := make([]*response.SendingDomain, 0, len(domains))
+	for _, sd := range domains {
+		mapped = append(mapped, response.CreateSendingDomainResponseFromDto(sd))
+	}
+
+	ctx.JSON(http.StatusOK, mapped)
+}
+
+// Verify
+//
+//	@Summary	Check DNS records of sending domain, domain can be used as from address once all records are verified
+//	@Router		/api/v1/sending-domains/{id}/verify [post]
+//	@Tags		sending domain
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string					true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string					true	"Bearer <token>"	default(Bearer )
+//	@Param		id				path		string					true	"Sending domain ID"
+//
+//	@Success	200				{object}	response.SendingDomain	"Result of the check"
+//	@Failure	400				{object}	response.Error			"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"Sending domain not found"
+//	@Failure	500				"Unexpected exception"
+func (s *SendingDomainController) Verify(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	sd, err := s.verifySendingDomain.Handle(ctx, userID.(string), ctx.Param("id"))
+	if err != nil {
+		code, body := sendingDomainErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to verify sending domain")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateSendingDomainResponseFromDto(sd))
+}
+
+// Delete
+//
+//	@Summary	Delete sending domain
+//	@Router		/api/v1/sending-domains/{id} [delete]
+//	@Tags		sending domain
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header	string	true	"application/json"	default(application/json)
+//	@Param		Authorization	header	string	true	"Bearer <token>"	default(Bearer )
+//	@Param		id				path	string	true	"Sending domain ID"
+//
+//	@Success	204				"Sending domain was deleted"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"Sending domain not found"
+//	@Failure	500				"Unexpected exception"
+func (s *SendingDomainController) Delete(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := s.deleteSendingDomain.Handle(ctx, userID.(string), ctx.Param("id")); err != nil {
+		code, body := sendingDomainErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to delete sending domain")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func sendingDomainErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, application.InvalidUUIDError),
+		errors.Is(err, application.InvalidDomainError):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, application.SendingDomainNotFoundError):
+		return http.StatusNotFound, gin.H{"error": err.Error()}
+	case errors.Is(err, application.SendingDomainTakenError):
+		return http.StatusConflict, gin.H{"error": err.Error()}
+	case errors.Is(err, application.UnknownUserError):
+		return http.StatusUnauthorized, gin.H{}
+	default:
+		return http.StatusInternalServerError, gin.H{}
+	}
+}
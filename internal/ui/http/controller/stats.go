@@ -0,0 +1,347 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type GetNewsletterStatsHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, interval, from, to string) (*dto.NewsletterStats, error)
+}
+
+type GetIssueStatsHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, issueID string) (*dto.IssueStats, error)
+}
+
+type GetUnsubscribeCommentsHandler interface {
+	Handle(
+		ctx context.Context,
+		userID, newsletterPublicID, from, to string,
+		pageSize, pageNumber int,
+	) ([]*dto.UnsubscribeComment, *dto.Pagination, error)
+}
+
+type RecordDeliveryEventsHandler interface {
+	Handle(ctx context.Context, signature, timestamp string, payload []byte) error
+}
+
+type StatsController struct {
+	lg                     logger.Logger
+	getNewsletterStats     GetNewsletterStatsHandler
+	getIssueStats          GetIssueStatsHandler
+	getUnsubscribeComments GetUnsubscribeCommentsHandler
+	recordDeliveryEvents   RecordDeliveryEventsHandler
+}
+
+func NewStatsController(
+	lg logger.Logger,
+	gnsh GetNewsletterStatsHandler,
+	gish GetIssueStatsHandler,
+	guch GetUnsubscribeCommentsHandler,
+	rdeh RecordDeliveryEventsHandler,
+) *StatsController {
+	return &StatsController{
+		lg:                     lg,
+		getNewsletterStats:     gnsh,
+		getIssueStats:          gish,
+		getUnsubscribeComments: guch,
+		recordDeliveryEvents:   rdeh,
+	}
+}
+
+func (s *StatsController) RegisterStatsController(authMiddleware *middleware.AuthMiddleware, httpServer *http_server.Server) {
+	httpServer.GetEngine().GET("api/v1/newsletters/:public_id/stats", authMiddleware.Handle, s.GetNewsletterStats)
+	httpServer.GetEngine().GET(
+		"api/v1/newsletters/:public_id/stats/issues/:issue_id",
+		authMiddleware.Handle,
+		s.GetIssueStats,
+	)
+	httpServer.GetEngine().GET(
+		"api/v1/newsletters/:public_id/stats/unsubscribe-comments",
+		authMiddleware.Handle,
+		s.GetUnsubscribeComments,
+	)
+
+	httpServer.GetEngine().POST("api/v1/webhooks/sendgrid/events", s.RecordDeliveryEvents)
+}
+
+// GetNewsletterStats
+//
+//	@Summary	Get statistics of newsletter in time buckets, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/stats [get]
+//	@Tags		stats
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string						true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string						true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string						true	"Newsletter public ID"
+//	@Param		interval		query		string						false	"Bucket size"	Enums(day, week, month)	default(day)
+//	@Param		from			query		string						false	"First day of period (YYYY-MM-DD), 30 buckets before end by default"
+//	@Param		to				query		string						false	"Last day of period (YYYY-MM-DD), today by default"
+//
+//	@Success	200				{object}	response.NewsletterStats	"Successfully retrieved statistics"
+//	@Failure	400				{object}	response.Error				"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (s *StatsController) GetNewsletterStats(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	stats, err := s.getNewsletterStats.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		ctx.Query("interval"),
+		ctx.Query("from"),
+		ctx.Query("to"),
+	)
+	if err != nil {
+		code, body := statsErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to get newsletter stats")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateNewsletterStatsResponseFromDto(stats))
+}
+
+// GetIssueStats
+//
+//	@Summary	Get delivery and engagement statistics of issue, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/stats/issues/{issue_id} [get]
+//	@Tags		stats
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string				true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string				true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string				true	"Newsletter public ID"
+//	@Param		issue_id		path		string				true	"Issue ID"
+//
+//	@Success	200				{object}	response.IssueStats	"Successfully retrieved statistics"
+//	@Failure	400				{object}	response.Error		"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter or issue not found"
+//	@Failure	500				"Unexpected exception"
+func (s *StatsController) GetIssueStats(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	stats, err := s.getIssueStats.Handle(ctx, userID.(string), ctx.Param("public_id"), ctx.Param("issue_id"))
+	if err != nil {
+		code, body := statsErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to get issue stats")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateIssueStatsResponseFromDto(stats))
+}
+
+// GetUnsubscribeComments
+//
+//	@Summary	Get comments of subscribers who unsubscribed in period, newest first, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/stats/unsubscribe-comments [get]
+//	@Tags		stats
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string														true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string														true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string														true	"Newsletter public ID"
+//	@Param		from			query		string														false	"First day of period (YYYY-MM-DD), 30 days before end by default"
+//	@Param		to				query		string														false	"Last day of period (YYYY-MM-DD), today by default"
+//	@Param		page_size		query		int															false	"Number of items on page"	default(10)	minimum(1)
+//	@Param		page_number		query		int															false	"Page number"				default(1)	minimum(1)
+//
+//	@Success	200				{object}	response.PaginatedResponse[[]response.UnsubscribeComment]	"Successfully retrieved comments"
+//	@Failure	400				{object}	response.Error												"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (s *StatsController) GetUnsubscribeComments(ctx *gin.Context) {
+	pageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 {
+		s.lg.WithError(err).Error("Failed to parse page size")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid pageSize"})
+
+		return
+	}
+
+	pageNumber, err := strconv.Atoi(ctx.DefaultQuery("page_number", "1"))
+	if err != nil || pageNumber < 1 {
+		s.lg.WithError(err).Error("Failed to parse page number")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid page number"})
+
+		return
+	}
+
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		s.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		s.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	comments, pagination, err := s.getUnsubscribeComments.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		ctx.Query("from"),
+		ctx.Query("to"),
+		pageSize,
+		pageNumber,
+	)
+	if err != nil {
+		code, body := statsErrorResponse(err)
+		s.lg.WithError(err).Error("Failed to get unsubscribe comments")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	mapped := make([]*response.UnsubscribeComment, 0, len(comments))
+	for _, c := range comments {
+		mapped = append(mapped, response.CreateUnsubscribeCommentResponseFromDto(c))
+	}
+
+	ctx.JSON(http.StatusOK, response.PaginatedResponse[[]*response.UnsubscribeComment]{
+		Data: mapped,
+		Pagination: response.Pagination{
+			CurrentPage: pagination.CurrentPage,
+			PageSize:    pagination.PageSize,
+			TotalPages:  pagination.TotalPages,
+			TotalItems:  pagination.TotalItems,
+			HasPrevious: pagination.HasPrevious,
+			HasNext:     pagination.HasNext,
+		},
+	})
+}
+
+// RecordDeliveryEvents
+//
+//	@Summary	Signed Event Webhook of SendGrid, records delivered and bounced emails
+//	@Router		/api/v1/webhooks/sendgrid/events [post]
+//	@Tags		stats
+//	@Accepts	json
+//
+//	@Param		X-Twilio-Email-Event-Webhook-Signature	header	string	true	"ECDSA signature of payload"
+//	@Param		X-Twilio-Email-Event-Webhook-Timestamp	header	string	true	"Timestamp of signature"
+//
+//	@Success	204										"Events were recorded"
+//	@Failure	401										"Invalid signature"
+//	@Failure	500										"Unexpected exception, SendGrid retries request"
+func (s *StatsController) RecordDeliveryEvents(ctx *gin.Context) {
+	var h request.EventWebhookHeaders
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		s.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	payload, err := ctx.GetRawData()
+	if err != nil {
+		s.lg.WithError(err).Error("Failed to read webhook payload")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := s.recordDeliveryEvents.Handle(ctx, h.Signature, h.Timestamp, payload); err != nil {
+		if errors.Is(err, application.InvalidWebhookSignatureError) {
+			s.lg.WithError(err).Warn("Failed to verify delivery events")
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		s.lg.WithError(err).Error("Failed to record delivery events")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func statsErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, application.InvalidStatsIntervalError),
+		errors.Is(err, application.InvalidStatsPeriodError):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	default:
+		return issueErrorResponse(err)
+	}
+}
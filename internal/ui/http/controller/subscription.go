@@ -21,18 +21,23 @@ type GetNewslettersBySubscriptionEmailHandler interface {
 }
 
 type SubscribeToNewsletterHandler interface {
-	Handle(ctx context.Context, newsletterPublicID, email string) error
+	Handle(ctx context.Context, newsletterPublicID, email, locale string) error
 }
 
 type UnsubscribeNewsletterHandler interface {
 	Handle(ctx context.Context, newsletterPublicID, token string) error
 }
 
+type SaveUnsubscribeReasonHandler interface {
+	Handle(ctx context.Context, newsletterPublicID, token, code, comment string) error
+}
+
 type SubscriptionController struct {
 	lg                                       logger.Logger
 	getNewslettersBySubscriptionEmailHandler GetNewslettersBySubscriptionEmailHandler
 	subscribeToNewsletter                    SubscribeToNewsletterHandler
 	unsubscribeNewsletterHandler             UnsubscribeNewsletterHandler
+	saveUnsubscribeReason                    SaveUnsubscribeReasonHandler
 }
 
 func NewSubscriptionController(
@@ -40,12 +45,14 @@ func NewSubscriptionController(
 	gsnbeh GetNewslettersBySubscriptionEmailHandler,
 	stnh SubscribeToNewsletterHandler,
 	unh UnsubscribeNewsletterHandler,
+	surh SaveUnsubscribeReasonHandler,
 ) *SubscriptionController {
 	controller := &SubscriptionController{
 		getNewslettersBySubscriptionEmailHandler: gsnbeh,
 		lg:                                       lg,
 		unsubscribeNewsletterHandler:             unh,
 		subscribeToNewsletter:                    stnh,
+		saveUnsubscribeReason:                    surh,
 	}
 
 	return controller
@@ -63,6 +70,7 @@ func (u *SubscriptionController) RegisterSubscriptionController(httpServer *http
 		"api/v1/unsubscribe",
 		u.UnsubscribeNewsletter,
 	)
+	httpServer.GetEngine().POST("api/v1/unsubscribe/reason", u.SaveUnsubscribeReason)
 }
 
 // GetNewslettersBySubscriptionEmail
@@ -170,8 +178,9 @@ func (u *SubscriptionController) GetNewslettersBySubscriptionEmail(ctx *gin.Cont
 //	@Accept		json
 //	@Produce	json
 //
+//	@Param		Accept-Language			header	string							false	"Used when locale is not in body"	default(en)
 //	@Param		newsletter_public_id	path	string							true	"Public newsletter identifier"
-//	@Param		email					body	request.SubscribeToNewsletter	true	"Subscriber email address"
+//	@Param		email					body	request.SubscribeToNewsletter	true	"Subscriber email address and optional locale (en, cs)"
 //
 //	@Success	201						"Successfully subscribed to newsletter"
 //	@Failure	400						{object}	response.Error	"Invalid request with detail"
@@ -209,9 +218,22 @@ func (u *SubscriptionController) SubscribeToNewsletter(ctx *gin.Context) {
 		return
 	}
 
-	if err := u.subscribeToNewsletter.Handle(ctx, newsletterID, req.Email); err != nil {
+	// explicit choice wins over browser preference
+	locale := req.Locale
+	if locale == "" {
+		var alh request.AcceptLanguageHeader
+		if err := ctx.ShouldBindHeader(&alh); err != nil {
+			u.lg.WithError(err).Error("Failed to bind headers")
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+		locale = alh.Locale(domain.SupportedLocales())
+	}
+
+	if err := u.subscribeToNewsletter.Handle(ctx, newsletterID, req.Email, locale); err != nil {
 		code, body := func(err error) (int, gin.H) {
-			if errors.Is(err, application.InvalidUUIDError) {
+			if errors.Is(err, application.InvalidUUIDError) || errors.Is(err, application.InvalidLocaleError) {
 				return http.StatusBadRequest, gin.H{"error": err.Error()}
 			}
 			if errors.Is(err, application.AlreadySubscibedToNewsletterError) {
@@ -286,3 +308,65 @@ func (u *SubscriptionController) UnsubscribeNewsletter(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{})
 }
+
+// SaveUnsubscribeReason
+//
+//	@Summary	Optional survey after unsubscribing, stores why subscriber left
+//	@Router		/api/v1/unsubscribe/reason [post]
+//	@Tags		public subscription
+//	@Accept		json
+//	@Produce	json
+//
+//	@Param		Content-Type	header	string						true	"application/json"	default(application/json)
+//	@Param		reason			body	request.UnsubscribeReason	true	"Token from unsubscribe link, reason code and optional comment"
+//
+//	@Success	204				"Reason was saved"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Invalid token"
+//	@Failure	404				{object}	response.Error	"Unsubscribed subscription not found"
+//	@Failure	500				"Unexpected exception"
+func (u *SubscriptionController) SaveUnsubscribeReason(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		u.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		u.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.UnsubscribeReason
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		u.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	if err := u.saveUnsubscribeReason.Handle(ctx, req.NewsletterPublicID, req.Token, req.Reason, req.Comment); err != nil {
+		code, body := func(err error) (int, gin.H) {
+			if errors.Is(err, application.InvalidUUIDError) || errors.Is(err, application.InvalidUnsubscribeReasonError) {
+				return http.StatusBadRequest, gin.H{"error": err.Error()}
+			}
+			if errors.Is(err, application.InvalidTokenError) {
+				return http.StatusUnauthorized, gin.H{}
+			}
+			if errors.Is(err, application.SubscriptionNotFoundError) {
+				return http.StatusNotFound, gin.H{"error": "Unsubscribed subscription not found"}
+			}
+
+			return http.StatusInternalServerError, gin.H{}
+		}(err)
+		u.lg.WithError(err).Error("Failed to save unsubscribe reason")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,441 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type GetTemplateOverridesHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string) ([]*domain.TemplateOverride, error)
+}
+
+type UpdateTemplateOverrideHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, name, html, text string) (*domain.TemplateOverride, error)
+}
+
+type DeleteTemplateOverrideHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, name string) error
+}
+
+type PreviewEmailHandler interface {
+	Handle(
+		ctx context.Context,
+		userID, newsletterPublicID, name, html, text, issueID, subscriberEmail, locale string,
+	) (*dto.EmailPreview, error)
+}
+
+type SendTestEmailHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, name, html, text, issueID, locale string) error
+}
+
+type TemplateController struct {
+	lg                     logger.Logger
+	getTemplateOverrides   GetTemplateOverridesHandler
+	updateTemplateOverride UpdateTemplateOverrideHandler
+	deleteTemplateOverride DeleteTemplateOverrideHandler
+	previewEmail           PreviewEmailHandler
+	sendTestEmail          SendTestEmailHandler
+}
+
+func NewTemplateController(
+	lg logger.Logger,
+	gtoh GetTemplateOverridesHandler,
+	utoh UpdateTemplateOverrideHandler,
+	dtoh DeleteTemplateOverrideHandler,
+	peh PreviewEmailHandler,
+	steh SendTestEmailHandler,
+) *TemplateController {
+	return &TemplateController{
+		lg:                     lg,
+		getTemplateOverrides:   gtoh,
+		updateTemplateOverride: utoh,
+		deleteTemplateOverride: dtoh,
+		previewEmail:           peh,
+		sendTestEmail:          steh,
+	}
+}
+
+func (t *TemplateController) RegisterTemplateController(
+	authMiddleware *middleware.AuthMiddleware,
+	httpServer *http_server.Server,
+) {
+	httpServer.GetEngine().GET("api/v1/newsletters/:public_id/templates", authMiddleware.Handle, t.GetTemplateOverrides)
+	httpServer.GetEngine().PUT(
+		"api/v1/newsletters/:public_id/templates/:name",
+		authMiddleware.Handle,
+		t.UpdateTemplateOverride,
+	)
+	httpServer.GetEngine().DELETE(
+		"api/v1/newsletters/:public_id/templates/:name",
+		authMiddleware.Handle,
+		t.DeleteTemplateOverride,
+	)
+	httpServer.GetEngine().POST(
+		"api/v1/newsletters/:public_id/templates/:name/preview",
+		authMiddleware.Handle,
+		t.PreviewEmail,
+	)
+	httpServer.GetEngine().POST(
+		"api/v1/newsletters/:public_id/templates/:name/test",
+		authMiddleware.Handle,
+		t.SendTestEmail,
+	)
+}
+
+// GetTemplateOverrides
+//
+//	@Summary	List email templates overridden by newsletter, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/templates [get]
+//	@Tags		newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string						true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string						true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string						true	"Newsletter public ID"
+//
+//	@Success	200				{array}		response.TemplateOverride	"Successfully retrieved template overrides"
+//	@Failure	400				{object}	response.Error				"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (t *TemplateController) GetTemplateOverrides(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		t.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		t.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		t.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	overrides, err := t.getTemplateOverrides.Handle(ctx, userID.(string), ctx.Param("public_id"))
+	if err != nil {
+		code, body := templateErrorResponse(err)
+		t.lg.WithError(err).Error("Failed to get template overrides")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	mapped := make([]*response.TemplateOverride, 0, len(overrides))
+	for _, o := range overrides {
+		mapped = append(mapped, response.CreateTemplateOverrideResponseFromEntity(o))
+	}
+
+	ctx.JSON(http.StatusOK, mapped)
+}
+
+// UpdateTemplateOverride
+//
+//	@Summary	Replace content of email template for newsletter, editor role is required
+//	@Router		/api/v1/newsletters/{public_id}/templates/{name} [put]
+//	@Tags		newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string									true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string									true	"Newsletter public ID"
+//	@Param		name			path		string									true	"Template name"	Enums(subscribed, invitation)
+//	@Param		Template		body		request.UpdateTemplateOverrideRequest	true	"Content block of layout, text is converted from html when empty"
+//
+//	@Success	200				{object}	response.TemplateOverride				"Template override was saved"
+//	@Failure	400				{object}	response.Error							"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	422				{object}	response.Error	"Template is invalid or misses required variables"
+//	@Failure	500				"Unexpected exception"
+func (t *TemplateController) UpdateTemplateOverride(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		t.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		t.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.UpdateTemplateOverrideRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		t.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		t.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	override, err := t.updateTemplateOverride.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		ctx.Param("name"),
+		req.HTML,
+		req.Text,
+	)
+	if err != nil {
+		code, body := templateErrorResponse(err)
+		t.lg.WithError(err).Error("Failed to update template override")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateTemplateOverrideResponseFromEntity(override))
+}
+
+// DeleteTemplateOverride
+//
+//	@Summary	Remove override so newsletter uses default template again, editor role is required
+//	@Router		/api/v1/newsletters/{public_id}/templates/{name} [delete]
+//	@Tags		newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header	string	true	"application/json"	default(application/json)
+//	@Param		Authorization	header	string	true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path	string	true	"Newsletter public ID"
+//	@Param		name			path	string	true	"Template name"
+//
+//	@Success	204				"Template override was removed"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter or template override not found"
+//	@Failure	500				"Unexpected exception"
+func (t *TemplateController) DeleteTemplateOverride(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		t.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		t.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		t.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := t.deleteTemplateOverride.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		ctx.Param("name"),
+	); err != nil {
+		code, body := templateErrorResponse(err)
+		t.lg.WithError(err).Error("Failed to delete template override")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// PreviewEmail
+//
+//	@Summary		Render email template of newsletter with sample or subscriber data, editor role is required
+//	@Description	Issue template is rendered with issue given by issue_id, draft or published, as it would be delivered
+//	@Router			/api/v1/newsletters/{public_id}/templates/{name}/preview [post]
+//	@Tags			newsletter
+//	@Accepts		json
+//	@Produce		json
+//
+//	@Param			Authorization	header		string						true	"Bearer <token>"	default(Bearer )
+//	@Param			public_id		path		string						true	"Newsletter public ID"
+//	@Param			name			path		string						true	"Template name"	Enums(subscribed, invitation, newsletter_removed, issue)
+//	@Param			Preview			body		request.PreviewEmailRequest	false	"Draft content replacing stored template, locale and subscriber to render for"
+//
+//	@Success		200				{object}	response.EmailPreview		"Rendered email"
+//	@Failure		400				{object}	response.Error				"Invalid request with detail"
+//	@Failure		401				"Unauthorized"
+//	@Failure		403				"Insufficient role"
+//	@Failure		404				"Newsletter, template, issue or subscription not found"
+//	@Failure		422				{object}	response.Error	"Draft template is invalid or misses required variables"
+//	@Failure		500				"Unexpected exception"
+func (t *TemplateController) PreviewEmail(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		t.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		t.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.PreviewEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		t.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		t.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	preview, err := t.previewEmail.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		ctx.Param("name"),
+		req.HTML,
+		req.Text,
+		req.IssueID,
+		req.SubscriberEmail,
+		req.Locale,
+	)
+	if err != nil {
+		code, body := templateErrorResponse(err)
+		t.lg.WithError(err).Error("Failed to preview email")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateEmailPreviewResponse(preview))
+}
+
+// SendTestEmail
+//
+//	@Summary		Send email template of newsletter rendered with sample data to own email, editor role is required
+//	@Description	Issue template is sent with issue given by issue_id, draft or published, as it would be delivered
+//	@Router			/api/v1/newsletters/{public_id}/templates/{name}/test [post]
+//	@Tags			newsletter
+//	@Accepts		json
+//	@Produce		json
+//
+//	@Param			Authorization	header	string							true	"Bearer <token>"	default(Bearer )
+//	@Param			public_id		path	string							true	"Newsletter public ID"
+//	@Param			name			path	string							true	"Template name"	Enums(subscribed, invitation, newsletter_removed, issue)
+//	@Param			Test			body	request.SendTestEmailRequest	false	"Draft content replacing stored template and locale"
+//
+//	@Success		204				"Test email was sent"
+//	@Failure		400				{object}	response.Error	"Invalid request with detail"
+//	@Failure		401				"Unauthorized"
+//	@Failure		403				"Insufficient role"
+//	@Failure		404				"Newsletter, template or issue not found"
+//	@Failure		422				{object}	response.Error	"Draft template is invalid or misses required variables"
+//	@Failure		500				"Unexpected exception"
+func (t *TemplateController) SendTestEmail(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		t.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		t.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.SendTestEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		t.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		t.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := t.sendTestEmail.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		ctx.Param("name"),
+		req.HTML,
+		req.Text,
+		req.IssueID,
+		req.Locale,
+	); err != nil {
+		code, body := templateErrorResponse(err)
+		t.lg.WithError(err).Error("Failed to send test email")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func templateErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, application.InvalidTemplateError):
+		return http.StatusUnprocessableEntity, gin.H{"error": err.Error()}
+	case errors.Is(err, application.TemplateNotFoundError),
+		errors.Is(err, application.SubscriptionNotFoundError),
+		errors.Is(err, application.IssueNotFoundError):
+		return http.StatusNotFound, gin.H{"error": err.Error()}
+	case errors.Is(err, application.InvalidLocaleError),
+		errors.Is(err, application.IssueRequiredError):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	default:
+		return memberErrorResponse(err)
+	}
+}
@@ -0,0 +1,248 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+// pixel is transparent 1x1 GIF.
+var pixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+type GetTrackingSettingsHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string) (*domain.TrackingSettings, error)
+}
+
+type UpdateTrackingSettingsHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string, opens, clicks bool) (*domain.TrackingSettings, error)
+}
+
+type RecordEmailOpenHandler interface {
+	Handle(ctx context.Context, token, userAgent string) error
+}
+
+type RecordEmailClickHandler interface {
+	Handle(ctx context.Context, token, target string) error
+}
+
+type TrackingController struct {
+	lg                     logger.Logger
+	getTrackingSettings    GetTrackingSettingsHandler
+	updateTrackingSettings UpdateTrackingSettingsHandler
+	recordEmailOpen        RecordEmailOpenHandler
+	recordEmailClick       RecordEmailClickHandler
+}
+
+func NewTrackingController(
+	lg logger.Logger,
+	gtsh GetTrackingSettingsHandler,
+	utsh UpdateTrackingSettingsHandler,
+	reoh RecordEmailOpenHandler,
+	rech RecordEmailClickHandler,
+) *TrackingController {
+	return &TrackingController{
+		lg:                     lg,
+		getTrackingSettings:    gtsh,
+		updateTrackingSettings: utsh,
+		recordEmailOpen:        reoh,
+		recordEmailClick:       rech,
+	}
+}
+
+func (t *TrackingController) RegisterTrackingController(
+	authMiddleware *middleware.AuthMiddleware,
+	httpServer *http_server.Server,
+) {
+	httpServer.GetEngine().GET("api/v1/newsletters/:public_id/tracking", authMiddleware.Handle, t.GetTrackingSettings)
+	httpServer.GetEngine().PUT("api/v1/newsletters/:public_id/tracking", authMiddleware.Handle, t.UpdateTrackingSettings)
+
+	httpServer.GetEngine().GET("api/v1/track/open/:token", t.TrackOpen)
+	httpServer.GetEngine().GET("api/v1/track/click/:token", t.TrackClick)
+}
+
+// GetTrackingSettings
+//
+//	@Summary	Get tracking settings of newsletter, available to every member
+//	@Router		/api/v1/newsletters/{public_id}/tracking [get]
+//	@Tags		newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string						true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string						true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string						true	"Newsletter public ID"
+//
+//	@Success	200				{object}	response.TrackingSettings	"Successfully retrieved tracking settings"
+//	@Failure	400				{object}	response.Error				"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (t *TrackingController) GetTrackingSettings(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		t.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		t.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		t.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	settings, err := t.getTrackingSettings.Handle(ctx, userID.(string), ctx.Param("public_id"))
+	if err != nil {
+		code, body := memberErrorResponse(err)
+		t.lg.WithError(err).Error("Failed to get tracking settings")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateTrackingSettingsResponseFromEntity(settings))
+}
+
+// UpdateTrackingSettings
+//
+//	@Summary	Enable or disable tracking of newsletter, only owner can change it
+//	@Router		/api/v1/newsletters/{public_id}/tracking [put]
+//	@Tags		newsletter
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string									true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string									true	"Newsletter public ID"
+//	@Param		Tracking		body		request.UpdateTrackingSettingsRequest	true	"Tracking settings"
+//
+//	@Success	200				{object}	response.TrackingSettings				"Tracking settings were updated"
+//	@Failure	400				{object}	response.Error							"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (t *TrackingController) UpdateTrackingSettings(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		t.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		t.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.UpdateTrackingSettingsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		t.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		t.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	settings, err := t.updateTrackingSettings.Handle(ctx, userID.(string), ctx.Param("public_id"), *req.Opens, *req.Clicks)
+	if err != nil {
+		code, body := memberErrorResponse(err)
+		t.lg.WithError(err).Error("Failed to update tracking settings")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateTrackingSettingsResponseFromEntity(settings))
+}
+
+// TrackOpen
+//
+//	@Summary	Tracking pixel embedded in issue emails, records open of email
+//	@Router		/api/v1/track/open/{token} [get]
+//	@Tags		tracking
+//	@Produce	image/gif
+//
+//	@Param		token	path	string	true	"Signed token of email"
+//
+//	@Success	200		"Transparent pixel, returned even when token is invalid"
+func (t *TrackingController) TrackOpen(ctx *gin.Context) {
+	if err := t.recordEmailOpen.Handle(ctx, ctx.Param("token"), ctx.Request.UserAgent()); err != nil {
+		lg := t.lg.WithError(err)
+		if errors.Is(err, application.InvalidTrackingTokenError) || errors.Is(err, application.EmailJobNotFoundError) {
+			lg.Warn("Failed to record email open")
+		} else {
+			lg.Error("Failed to record email open")
+		}
+	}
+
+	// every fetch has to reach server, otherwise repeated opens are not counted
+	ctx.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
+	ctx.Data(http.StatusOK, "image/gif", pixel)
+}
+
+// TrackClick
+//
+//	@Summary	Redirect of tracked link in issue emails, records click and redirects to original address
+//	@Router		/api/v1/track/click/{token} [get]
+//	@Tags		tracking
+//	@Produce	json
+//
+//	@Param		token	path	string	true	"Signed token of link"
+//	@Param		url		query	string	true	"Original address of link"
+//
+//	@Success	302		"Redirect to original address"
+//	@Failure	400		{object}	response.Error	"Link was not issued by server"
+func (t *TrackingController) TrackClick(ctx *gin.Context) {
+	target := ctx.Query("url")
+	if err := t.recordEmailClick.Handle(ctx, ctx.Param("token"), target); err != nil {
+		// unsigned target would make open redirect out of endpoint
+		if errors.Is(err, application.InvalidTrackingTokenError) {
+			t.lg.WithError(err).Warn("Failed to verify tracked link")
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+			return
+		}
+
+		// link is genuine, reader is redirected even when click was not recorded
+		lg := t.lg.WithError(err)
+		if errors.Is(err, application.EmailJobNotFoundError) {
+			lg.Warn("Failed to record email click")
+		} else {
+			lg.Error("Failed to record email click")
+		}
+	}
+
+	ctx.Header("Cache-Control", "no-store, no-cache, must-revalidate, private")
+	ctx.Redirect(http.StatusFound, target)
+}
@@ -10,7 +10,10 @@ import (
 	"github.com/javor454/newsletter-assignment/app/http_server"
 	"github.com/javor454/newsletter-assignment/app/logger"
 	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
 )
 
 type RegisterUserHandler interface {
@@ -18,32 +21,93 @@ type RegisterUserHandler interface {
 }
 
 type LoginUserHandler interface {
-	Handle(ctx context.Context, email string, password string) (string, error)
+	Handle(ctx context.Context, email string, password string) (*dto.LoginResult, error)
+}
+
+type VerifyTOTPLoginHandler interface {
+	Handle(ctx context.Context, challengeToken, code, recoveryCode string) (string, error)
+}
+
+type EnrollTOTPHandler interface {
+	Handle(ctx context.Context, userID string) (*dto.TOTPEnrollment, error)
+}
+
+type ConfirmTOTPHandler interface {
+	Handle(ctx context.Context, userID, code string) ([]string, error)
+}
+
+type ChangePasswordHandler interface {
+	Handle(ctx context.Context, userID, currentPassword, newPassword string) error
+}
+
+type RequestEmailChangeHandler interface {
+	Handle(ctx context.Context, userID, email, password string) error
+}
+
+type ConfirmEmailChangeHandler interface {
+	Handle(ctx context.Context, token string) error
+}
+
+type DeleteAccountHandler interface {
+	Handle(ctx context.Context, userID, password string) error
 }
 
 type UserController struct {
-	lg  logger.Logger
-	ruh RegisterUserHandler
-	luh LoginUserHandler
+	lg   logger.Logger
+	ruh  RegisterUserHandler
+	luh  LoginUserHandler
+	vtlh VerifyTOTPLoginHandler
+	eth  EnrollTOTPHandler
+	cth  ConfirmTOTPHandler
+	cph  ChangePasswordHandler
+	rech RequestEmailChangeHandler
+	cech ConfirmEmailChangeHandler
+	dah  DeleteAccountHandler
 }
 
 func NewUserController(
 	lg logger.Logger,
 	ruh RegisterUserHandler,
 	luh LoginUserHandler,
+	vtlh VerifyTOTPLoginHandler,
+	eth EnrollTOTPHandler,
+	cth ConfirmTOTPHandler,
+	cph ChangePasswordHandler,
+	rech RequestEmailChangeHandler,
+	cech ConfirmEmailChangeHandler,
+	dah DeleteAccountHandler,
 ) *UserController {
 	controller := &UserController{
-		ruh: ruh,
-		luh: luh,
-		lg:  lg,
+		ruh:  ruh,
+		luh:  luh,
+		vtlh: vtlh,
+		eth:  eth,
+		cth:  cth,
+		cph:  cph,
+		rech: rech,
+		cech: cech,
+		dah:  dah,
+		lg:   lg,
 	}
 
 	return controller
 }
 
-func (u *UserController) RegisterUserController(httpServer *http_server.Server) {
+func (u *UserController) RegisterUserController(
+	authMiddleware *middleware.AuthMiddleware,
+	httpServer *http_server.Server,
+) {
 	httpServer.GetEngine().POST("api/v1/users/register", u.Register)
 	httpServer.GetEngine().POST("api/v1/users/login", u.Login)
+	httpServer.GetEngine().POST("api/v1/users/login/totp", u.LoginTOTP)
+	// GET to verify new email via link
+	httpServer.GetEngine().GET("api/v1/users/email/verify", u.VerifyEmailChange)
+
+	httpServer.GetEngine().POST("api/v1/users/totp", authMiddleware.Handle, u.EnrollTOTP)
+	httpServer.GetEngine().POST("api/v1/users/totp/confirm", authMiddleware.Handle, u.ConfirmTOTP)
+	httpServer.GetEngine().PUT("api/v1/users/password", authMiddleware.Handle, u.ChangePassword)
+	httpServer.GetEngine().POST("api/v1/users/email", authMiddleware.Handle, u.ChangeEmail)
+	httpServer.GetEngine().DELETE("api/v1/users/me", authMiddleware.Handle, u.DeleteAccount)
 }
 
 // Register
@@ -113,8 +177,9 @@ func (u *UserController) Register(ctx *gin.Context) {
 //	@Param		data	body	request.UserRequest	true	"Data for user login"
 //
 //	@Success	201		"User successfully logged in"
-//	@Failure	400		{object}	response.Error	"Invalid request with detail"
-//	@Failure	401		{object}	response.Error	"Invalid credentials"
+//	@Success	202		{object}	response.LoginChallenge	"Password verified, TOTP code required"
+//	@Failure	400		{object}	response.Error			"Invalid request with detail"
+//	@Failure	401		{object}	response.Error			"Invalid credentials"
 //	@Failure	500		"Unexpected exception"
 func (u *UserController) Login(ctx *gin.Context) {
 	var h *request.ContentTypeHeader
@@ -139,7 +204,7 @@ func (u *UserController) Login(ctx *gin.Context) {
 		return
 	}
 
-	token, err := u.luh.Handle(ctx, req.Email, req.Password)
+	result, err := u.luh.Handle(ctx, req.Email, req.Password)
 	if err != nil {
 		code, body := func(err error) (int, gin.H) {
 			if errors.Is(err, application.UserNotFoundError) || errors.Is(err, application.InvalidPasswordError) {
@@ -154,6 +219,424 @@ func (u *UserController) Login(ctx *gin.Context) {
 		return
 	}
 
+	if result.ChallengeRequired {
+		ctx.JSON(http.StatusAccepted, response.LoginChallenge{ChallengeToken: result.Token})
+
+		return
+	}
+
+	ctx.Header("Authorization", fmt.Sprintf("Bearer %s", result.Token))
+	ctx.JSON(http.StatusCreated, gin.H{})
+}
+
+// LoginTOTP
+//
+//	@Summary	Finish login of user with TOTP enabled, returning token for authorization
+//	@Router		/api/v1/users/login/totp [post]
+//	@Tags		public user
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		data	body	request.TOTPLoginRequest	true	"Challenge token from login with TOTP or recovery code"
+//
+//	@Success	201		"User successfully logged in"
+//	@Failure	400		{object}	response.Error	"Invalid request with detail"
+//	@Failure	401		{object}	response.Error	"Invalid challenge token or code"
+//	@Failure	429		{object}	response.Error	"Too many failed codes, login is locked for a while"
+//	@Failure	500		"Unexpected exception"
+func (u *UserController) LoginTOTP(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		u.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		u.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.TOTPLoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		u.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if req.Code == "" && req.RecoveryCode == "" {
+		u.lg.Error("Missing TOTP code")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "code or recovery_code is required"})
+
+		return
+	}
+
+	token, err := u.vtlh.Handle(ctx, req.ChallengeToken, req.Code, req.RecoveryCode)
+	if err != nil {
+		code, body := func(err error) (int, gin.H) {
+			if errors.Is(err, application.InvalidTokenError) || errors.Is(err, application.InvalidUUIDError) {
+				return http.StatusUnauthorized, gin.H{"error": "Invalid challenge token"}
+			}
+			if errors.Is(err, application.InvalidTOTPCodeError) {
+				return http.StatusUnauthorized, gin.H{"error": "Invalid code"}
+			}
+			if errors.Is(err, application.TOTPLockedError) {
+				return http.StatusTooManyRequests, gin.H{"error": "Too many failed codes, try again later"}
+			}
+			if errors.Is(err, application.UserNotFoundError) {
+				return http.StatusUnauthorized, gin.H{"error": "Invalid challenge token"}
+			}
+
+			return http.StatusInternalServerError, gin.H{}
+		}(err)
+		u.lg.WithError(err).Error("Failed to handle TOTP login")
+		ctx.JSON(code, body)
+
+		return
+	}
+
 	ctx.Header("Authorization", fmt.Sprintf("Bearer %s", token))
 	ctx.JSON(http.StatusCreated, gin.H{})
 }
+
+// EnrollTOTP
+//
+//	@Summary	Start TOTP enrollment, returning secret for authenticator app
+//	@Router		/api/v1/users/totp [post]
+//	@Tags		user
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string					true	"Bearer <token>"	default(Bearer )
+//
+//	@Success	201				{object}	response.TOTPEnrollment	"Secret generated, has to be confirmed by code"
+//	@Failure	401				"Unauthorized"
+//	@Failure	409				{object}	response.Error	"TOTP already enabled"
+//	@Failure	500				"Unexpected exception"
+func (u *UserController) EnrollTOTP(ctx *gin.Context) {
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		u.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	enrollment, err := u.eth.Handle(ctx, userID.(string))
+	if err != nil {
+		code, body := func(err error) (int, gin.H) {
+			if errors.Is(err, application.InvalidUUIDError) || errors.Is(err, application.UserNotFoundError) {
+				return http.StatusUnauthorized, gin.H{}
+			}
+			if errors.Is(err, application.TOTPAlreadyEnabledError) {
+				return http.StatusConflict, gin.H{"error": "TOTP already enabled"}
+			}
+
+			return http.StatusInternalServerError, gin.H{}
+		}(err)
+		u.lg.WithError(err).Error("Failed to enroll TOTP")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.CreateTOTPEnrollmentResponseFromDto(enrollment))
+}
+
+// ConfirmTOTP
+//
+//	@Summary	Confirm TOTP enrollment by code, returning single use recovery codes
+//	@Router		/api/v1/users/totp/confirm [post]
+//	@Tags		user
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header		string					true	"Bearer <token>"	default(Bearer )
+//	@Param		data			body		request.TOTPCodeRequest	true	"Code from authenticator app"
+//
+//	@Success	200				{object}	response.RecoveryCodes	"TOTP enabled"
+//	@Failure	400				{object}	response.Error			"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	409				{object}	response.Error	"TOTP already enabled"
+//	@Failure	500				"Unexpected exception"
+func (u *UserController) ConfirmTOTP(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		u.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		u.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.TOTPCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		u.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		u.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	codes, err := u.cth.Handle(ctx, userID.(string), req.Code)
+	if err != nil {
+		code, body := func(err error) (int, gin.H) {
+			if errors.Is(err, application.InvalidUUIDError) || errors.Is(err, application.UserNotFoundError) {
+				return http.StatusUnauthorized, gin.H{}
+			}
+			if errors.Is(err, application.TOTPNotEnrolledError) {
+				return http.StatusBadRequest, gin.H{"error": "TOTP enrollment not started"}
+			}
+			if errors.Is(err, application.InvalidTOTPCodeError) {
+				return http.StatusBadRequest, gin.H{"error": "Invalid code"}
+			}
+			if errors.Is(err, application.TOTPAlreadyEnabledError) {
+				return http.StatusConflict, gin.H{"error": "TOTP already enabled"}
+			}
+
+			return http.StatusInternalServerError, gin.H{}
+		}(err)
+		u.lg.WithError(err).Error("Failed to confirm TOTP")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.RecoveryCodes{RecoveryCodes: codes})
+}
+
+// ChangePassword
+//
+//	@Summary	Change password of signed in user, current password is required
+//	@Router		/api/v1/users/password [put]
+//	@Tags		user
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header	string							true	"Bearer <token>"	default(Bearer )
+//	@Param		data			body	request.ChangePasswordRequest	true	"Current and new password"
+//
+//	@Success	204				"Password changed"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				{object}	response.Error	"Invalid current password"
+//	@Failure	500				"Unexpected exception"
+func (u *UserController) ChangePassword(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		u.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		u.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		u.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		u.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := u.cph.Handle(ctx, userID.(string), req.CurrentPassword, req.NewPassword); err != nil {
+		code, body := accountErrorResponse(err)
+		u.lg.WithError(err).Error("Failed to change password")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ChangeEmail
+//
+//	@Summary	Request email change, verification link is sent to new email
+//	@Router		/api/v1/users/email [post]
+//	@Tags		user
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Authorization	header	string						true	"Bearer <token>"	default(Bearer )
+//	@Param		data			body	request.ChangeEmailRequest	true	"New email and current password"
+//
+//	@Success	202				"Verification email queued"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				{object}	response.Error	"Invalid password"
+//	@Failure	409				{object}	response.Error	"Email taken"
+//	@Failure	500				"Unexpected exception"
+func (u *UserController) ChangeEmail(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		u.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		u.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.ChangeEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		u.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		u.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := u.rech.Handle(ctx, userID.(string), req.Email, req.Password); err != nil {
+		code, body := accountErrorResponse(err)
+		u.lg.WithError(err).Error("Failed to request email change")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+// VerifyEmailChange
+//
+//	@Summary	Verify new email by token from verification link, new email replaces the current one
+//	@Router		/api/v1/users/email/verify [get]
+//	@Tags		public user
+//	@Produce	json
+//
+//	@Param		token	query	string	true	"Token from verification link"
+//
+//	@Success	204		"Email changed"
+//	@Failure	400		{object}	response.Error	"Invalid or expired token"
+//	@Failure	409		{object}	response.Error	"Email taken"
+//	@Failure	500		"Unexpected exception"
+func (u *UserController) VerifyEmailChange(ctx *gin.Context) {
+	if err := u.cech.Handle(ctx, ctx.Query("token")); err != nil {
+		code, body := func(err error) (int, gin.H) {
+			if errors.Is(err, application.InvalidTokenError) {
+				return http.StatusBadRequest, gin.H{"error": "Invalid or expired token"}
+			}
+
+			return accountErrorResponse(err)
+		}(err)
+		u.lg.WithError(err).Error("Failed to verify email change")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteAccount
+//
+//	@Summary		Delete account of signed in user
+//	@Description	Owned newsletters are transferred to their longest standing editor,
+//	@Description	newsletters without editor are archived and their subscriptions disabled.
+//	@Router			/api/v1/users/me [delete]
+//	@Tags			user
+//	@Accepts		json
+//	@Produce		json
+//
+//	@Param			Authorization	header	string							true	"Bearer <token>"	default(Bearer )
+//	@Param			data			body	request.DeleteAccountRequest	true	"Current password"
+//
+//	@Success		204				"Account deleted"
+//	@Failure		400				{object}	response.Error	"Invalid request with detail"
+//	@Failure		401				{object}	response.Error	"Invalid password"
+//	@Failure		500				"Unexpected exception"
+func (u *UserController) DeleteAccount(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		u.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		u.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.DeleteAccountRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		u.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		u.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := u.dah.Handle(ctx, userID.(string), req.Password); err != nil {
+		code, body := accountErrorResponse(err)
+		u.lg.WithError(err).Error("Failed to delete account")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func accountErrorResponse(err error) (int, gin.H) {
+	if errors.Is(err, application.InvalidPasswordError) {
+		return http.StatusUnauthorized, gin.H{"error": "Invalid password"}
+	}
+	if errors.Is(err, application.InvalidUUIDError) || errors.Is(err, application.UserNotFoundError) {
+		return http.StatusUnauthorized, gin.H{}
+	}
+	if errors.Is(err, application.InvalidEmailError) {
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	}
+	if errors.Is(err, application.EmailTakenError) {
+		return http.StatusConflict, gin.H{"error": "Email taken"}
+	}
+
+	return http.StatusInternalServerError, gin.H{}
+}
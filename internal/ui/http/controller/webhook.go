@@ -0,0 +1,421 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/http_server"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+)
+
+type CreateWebhookHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, url string, eventTypes []string) (*domain.Webhook, error)
+}
+
+type GetWebhooksHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID string) ([]*domain.Webhook, error)
+}
+
+type DeleteWebhookHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, webhookID string) error
+}
+
+type GetWebhookDeliveriesHandler interface {
+	Handle(
+		ctx context.Context,
+		userID, newsletterPublicID, webhookID string,
+		pageSize, pageNumber int,
+	) ([]*dto.WebhookDelivery, *dto.Pagination, error)
+}
+
+type SendTestWebhookEventHandler interface {
+	Handle(ctx context.Context, userID, newsletterPublicID, webhookID string) (*dto.WebhookDelivery, error)
+}
+
+type WebhookController struct {
+	lg                   logger.Logger
+	createWebhook        CreateWebhookHandler
+	getWebhooks          GetWebhooksHandler
+	deleteWebhook        DeleteWebhookHandler
+	getWebhookDeliveries GetWebhookDeliveriesHandler
+	sendTestEvent        SendTestWebhookEventHandler
+}
+
+func NewWebhookController(
+	lg logger.Logger,
+	cwh CreateWebhookHandler,
+	gwh GetWebhooksHandler,
+	dwh DeleteWebhookHandler,
+	gwdh GetWebhookDeliveriesHandler,
+	stweh SendTestWebhookEventHandler,
+) *WebhookController {
+	return &WebhookController{
+		lg:                   lg,
+		createWebhook:        cwh,
+		getWebhooks:          gwh,
+		deleteWebhook:        dwh,
+		getWebhookDeliveries: gwdh,
+		sendTestEvent:        stweh,
+	}
+}
+
+func (w *WebhookController) RegisterWebhookController(authMiddleware *middleware.AuthMiddleware, httpServer *http_server.Server) {
+	httpServer.GetEngine().POST("api/v1/newsletters/:public_id/webhooks", authMiddleware.Handle, w.Create)
+	httpServer.GetEngine().GET("api/v1/newsletters/:public_id/webhooks", authMiddleware.Handle, w.GetWebhooks)
+	httpServer.GetEngine().DELETE(
+		"api/v1/newsletters/:public_id/webhooks/:webhook_id",
+		authMiddleware.Handle,
+		w.Delete,
+	)
+	httpServer.GetEngine().GET(
+		"api/v1/newsletters/:public_id/webhooks/:webhook_id/deliveries",
+		authMiddleware.Handle,
+		w.GetDeliveries,
+	)
+	httpServer.GetEngine().POST(
+		"api/v1/newsletters/:public_id/webhooks/:webhook_id/test",
+		authMiddleware.Handle,
+		w.SendTestEvent,
+	)
+}
+
+// Create
+//
+//	@Summary	Register webhook notified about selected events of newsletter, secret is returned only once, owner only
+//	@Router		/api/v1/newsletters/{public_id}/webhooks [post]
+//	@Tags		webhook
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string							true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string							true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string							true	"Newsletter public ID"
+//	@Param		Webhook			body		request.CreateWebhookRequest	true	"Endpoint URL and event types (subscription.created, subscription.disabled, issue.published, email.bounced)"
+//
+//	@Success	201				{object}	response.CreatedWebhook			"Webhook was registered"
+//	@Failure	400				{object}	response.Error					"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (w *WebhookController) Create(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		w.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		w.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	var req *request.CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		w.lg.WithError(err).Error("Failed to bind request")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		w.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	webhook, err := w.createWebhook.Handle(ctx, userID.(string), ctx.Param("public_id"), req.URL, req.EventTypes)
+	if err != nil {
+		code, body := webhookErrorResponse(err)
+		w.lg.WithError(err).Error("Failed to create webhook")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.CreateCreatedWebhookResponseFromEntity(webhook))
+}
+
+// GetWebhooks
+//
+//	@Summary	List webhooks of newsletter, owner only
+//	@Router		/api/v1/newsletters/{public_id}/webhooks [get]
+//	@Tags		webhook
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string				true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string				true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string				true	"Newsletter public ID"
+//
+//	@Success	200				{array}		response.Webhook	"Webhooks of newsletter"
+//	@Failure	400				{object}	response.Error		"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter not found"
+//	@Failure	500				"Unexpected exception"
+func (w *WebhookController) GetWebhooks(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		w.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		w.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		w.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	webhooks, err := w.getWebhooks.Handle(ctx, userID.(string), ctx.Param("public_id"))
+	if err != nil {
+		code, body := webhookErrorResponse(err)
+		w.lg.WithError(err).Error("Failed to get webhooks")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	mapped := make([]*response.Webhook, 0, len(webhooks))
+	for _, wh := range webhooks {
+		mapped = append(mapped, response.CreateWebhookResponseFromEntity(wh))
+	}
+
+	ctx.JSON(http.StatusOK, mapped)
+}
+
+// Delete
+//
+//	@Summary	Remove webhook, its pending deliveries are dropped, owner only
+//	@Router		/api/v1/newsletters/{public_id}/webhooks/{webhook_id} [delete]
+//	@Tags		webhook
+//	@Accepts	json
+//
+//	@Param		Content-Type	header	string	true	"application/json"	default(application/json)
+//	@Param		Authorization	header	string	true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path	string	true	"Newsletter public ID"
+//	@Param		webhook_id		path	string	true	"Webhook ID"
+//
+//	@Success	204				"Webhook was removed"
+//	@Failure	400				{object}	response.Error	"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter or webhook not found"
+//	@Failure	500				"Unexpected exception"
+func (w *WebhookController) Delete(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		w.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		w.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		w.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	if err := w.deleteWebhook.Handle(ctx, userID.(string), ctx.Param("public_id"), ctx.Param("webhook_id")); err != nil {
+		code, body := webhookErrorResponse(err)
+		w.lg.WithError(err).Error("Failed to delete webhook")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetDeliveries
+//
+//	@Summary	Delivery log of webhook, newest first, owner only
+//	@Router		/api/v1/newsletters/{public_id}/webhooks/{webhook_id}/deliveries [get]
+//	@Tags		webhook
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string													true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string													true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string													true	"Newsletter public ID"
+//	@Param		webhook_id		path		string													true	"Webhook ID"
+//	@Param		page_size		query		int														false	"Number of items on page"	default(10)	minimum(1)
+//	@Param		page_number		query		int														false	"Page number"				default(1)	minimum(1)
+//
+//	@Success	200				{object}	response.PaginatedResponse[[]response.WebhookDelivery]	"Delivery log"
+//	@Failure	400				{object}	response.Error											"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter or webhook not found"
+//	@Failure	500				"Unexpected exception"
+func (w *WebhookController) GetDeliveries(ctx *gin.Context) {
+	pageSize, err := strconv.Atoi(ctx.DefaultQuery("page_size", "10"))
+	if err != nil || pageSize < 1 {
+		w.lg.WithError(err).Error("Failed to parse page size")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid pageSize"})
+
+		return
+	}
+
+	pageNumber, err := strconv.Atoi(ctx.DefaultQuery("page_number", "1"))
+	if err != nil || pageNumber < 1 {
+		w.lg.WithError(err).Error("Failed to parse page number")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid page number"})
+
+		return
+	}
+
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		w.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		w.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		w.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	deliveries, pagination, err := w.getWebhookDeliveries.Handle(
+		ctx,
+		userID.(string),
+		ctx.Param("public_id"),
+		ctx.Param("webhook_id"),
+		pageSize,
+		pageNumber,
+	)
+	if err != nil {
+		code, body := webhookErrorResponse(err)
+		w.lg.WithError(err).Error("Failed to get webhook deliveries")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	mapped := make([]*response.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		mapped = append(mapped, response.CreateWebhookDeliveryResponseFromDto(d))
+	}
+
+	ctx.JSON(http.StatusOK, response.PaginatedResponse[[]*response.WebhookDelivery]{
+		Data: mapped,
+		Pagination: response.Pagination{
+			CurrentPage: pagination.CurrentPage,
+			PageSize:    pagination.PageSize,
+			TotalPages:  pagination.TotalPages,
+			TotalItems:  pagination.TotalItems,
+			HasPrevious: pagination.HasPrevious,
+			HasNext:     pagination.HasNext,
+		},
+	})
+}
+
+// SendTestEvent
+//
+//	@Summary	Send webhook.test event to webhook right away, result of the attempt is returned and logged, owner only
+//	@Router		/api/v1/newsletters/{public_id}/webhooks/{webhook_id}/test [post]
+//	@Tags		webhook
+//	@Accepts	json
+//	@Produce	json
+//
+//	@Param		Content-Type	header		string						true	"application/json"	default(application/json)
+//	@Param		Authorization	header		string						true	"Bearer <token>"	default(Bearer )
+//	@Param		public_id		path		string						true	"Newsletter public ID"
+//	@Param		webhook_id		path		string						true	"Webhook ID"
+//
+//	@Success	200				{object}	response.WebhookDelivery	"Test event was attempted, status tells the result"
+//	@Failure	400				{object}	response.Error				"Invalid request with detail"
+//	@Failure	401				"Unauthorized"
+//	@Failure	403				"Insufficient role"
+//	@Failure	404				"Newsletter or webhook not found"
+//	@Failure	500				"Unexpected exception"
+func (w *WebhookController) SendTestEvent(ctx *gin.Context) {
+	var h *request.ContentTypeHeader
+	if err := ctx.ShouldBindHeader(&h); err != nil {
+		w.lg.WithError(err).Error("Failed to bind headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+	if err := h.Validate(); err != nil {
+		w.lg.WithError(err).Error("Failed to validate headers")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		return
+	}
+
+	userID, ok := ctx.Get(middleware.UserIDKey)
+	if !ok {
+		w.lg.Error("User ID missing in gin context")
+		ctx.JSON(http.StatusInternalServerError, gin.H{})
+
+		return
+	}
+
+	delivery, err := w.sendTestEvent.Handle(ctx, userID.(string), ctx.Param("public_id"), ctx.Param("webhook_id"))
+	if err != nil {
+		code, body := webhookErrorResponse(err)
+		w.lg.WithError(err).Error("Failed to send test webhook event")
+		ctx.JSON(code, body)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.CreateWebhookDeliveryResponseFromDto(delivery))
+}
+
+func webhookErrorResponse(err error) (int, gin.H) {
+	switch {
+	case errors.Is(err, application.InvalidWebhookURLError),
+		errors.Is(err, application.InvalidWebhookEventTypeError):
+		return http.StatusBadRequest, gin.H{"error": err.Error()}
+	case errors.Is(err, application.WebhookNotFoundError):
+		return http.StatusNotFound, gin.H{"error": err.Error()}
+	default:
+		return memberErrorResponse(err)
+	}
+}
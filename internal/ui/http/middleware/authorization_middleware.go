@@ -1,29 +1,53 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/domain"
 )
 
 const UserIDKey = "user_id"
 
+const (
+	bearerScheme = "Bearer"
+	apiKeyScheme = "ApiKey"
+)
+
 type DecodeToken interface {
 	Handle(string) (string, error)
 }
 
+type AuthenticateAPIKey interface {
+	Handle(ctx context.Context, key string) (*domain.APIKey, error)
+}
+
 type AuthMiddleware struct {
-	decodeToken DecodeToken
-	lg          logger.Logger
+	decodeToken        DecodeToken
+	authenticateAPIKey AuthenticateAPIKey
+	lg                 logger.Logger
 }
 
-func NewAuthMiddleware(dt DecodeToken, lg logger.Logger) *AuthMiddleware {
-	return &AuthMiddleware{decodeToken: dt, lg: lg}
+func NewAuthMiddleware(dt DecodeToken, aak AuthenticateAPIKey, lg logger.Logger) *AuthMiddleware {
+	return &AuthMiddleware{decodeToken: dt, authenticateAPIKey: aak, lg: lg}
 }
 
+// Handle accepts user tokens only.
 func (a *AuthMiddleware) Handle(c *gin.Context) {
+	a.authenticate(c, "")
+}
+
+// HandleScoped accepts user tokens and api keys granted given scope.
+func (a *AuthMiddleware) HandleScoped(scope domain.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		a.authenticate(c, scope)
+	}
+}
+
+func (a *AuthMiddleware) authenticate(c *gin.Context, scope domain.Scope) {
 	authHeader := c.Request.Header.Get("Authorization")
 	if authHeader == "" {
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is missing"})
@@ -31,21 +55,50 @@ func (a *AuthMiddleware) Handle(c *gin.Context) {
 		return
 	}
 
-	bearerToken := strings.Split(authHeader, " ")
-	if len(bearerToken) != 2 || bearerToken[0] != "Bearer" {
+	credentials := strings.Split(authHeader, " ")
+	if len(credentials) != 2 {
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header"})
 
 		return
 	}
 
-	userID, err := a.decodeToken.Handle(bearerToken[1])
-	if err != nil {
-		a.lg.WithError(err).Error("Error decoding token")
+	switch credentials[0] {
+	case bearerScheme:
+		userID, err := a.decodeToken.Handle(credentials[1])
+		if err != nil {
+			a.lg.WithError(err).Error("Error decoding token")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header"})
+
+			return
+		}
+
+		c.Set(UserIDKey, userID)
+	case apiKeyScheme:
+		if scope == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "API key not allowed"})
+
+			return
+		}
+
+		apiKey, err := a.authenticateAPIKey.Handle(c, credentials[1])
+		if err != nil {
+			a.lg.WithError(err).Error("Error authenticating api key")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header"})
+
+			return
+		}
+		if !apiKey.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Missing scope " + string(scope)})
+
+			return
+		}
+
+		c.Set(UserIDKey, apiKey.UserID().String())
+	default:
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header"})
 
 		return
 	}
 
-	c.Set(UserIDKey, userID)
 	c.Next()
 }
@@ -0,0 +1,75 @@
+package page
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"time"
+)
+
+//go:embed template/*.html
+var files embed.FS
+
+var pages = template.Must(template.ParseFS(files, "template/*.html"))
+
+type Newsletter struct {
+	Name        string
+	Description string
+	URL         string
+	RSSURL      string
+	AtomURL     string
+}
+
+// Head is data of shared page header, feeds of newsletter are advertised for autodiscovery.
+type Head struct {
+	Title      string
+	Newsletter *Newsletter
+}
+
+type IssueSummary struct {
+	Title       string
+	URL         string
+	PublishedAt time.Time
+}
+
+// Archive is page listing published issues, newer and older links are empty on first and last page.
+type Archive struct {
+	Newsletter *Newsletter
+	Issues     []*IssueSummary
+	NewerURL   string
+	OlderURL   string
+}
+
+// Issue is page with single published issue, content is already sanitized by markdown renderer.
+type Issue struct {
+	Newsletter  *Newsletter
+	Title       string
+	PublishedAt time.Time
+	Content     template.HTML
+}
+
+func (a *Archive) Head() *Head {
+	return &Head{Title: a.Newsletter.Name, Newsletter: a.Newsletter}
+}
+
+func (i *Issue) Head() *Head {
+	return &Head{Title: i.Title, Newsletter: i.Newsletter}
+}
+
+func RenderArchive(a *Archive) ([]byte, error) {
+	return render("archive.html", a)
+}
+
+func RenderIssue(i *Issue) ([]byte, error) {
+	return render("issue.html", i)
+}
+
+func render(name string, data any) ([]byte, error) {
+	var b bytes.Buffer
+	if err := pages.ExecuteTemplate(&b, name, data); err != nil {
+		return nil, fmt.Errorf("failed to render page %s: %w", name, err)
+	}
+
+	return b.Bytes(), nil
+}
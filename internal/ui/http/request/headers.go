@@ -1,6 +1,11 @@
 package request
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+
+	"golang.org/x/text/language"
+)
 
 type ContentTypeHeader struct {
 	Value string `header:"Content-Type" example:"application/json" binding:"required"`
@@ -13,3 +18,30 @@ func (bh *ContentTypeHeader) Validate() error {
 
 	return nil
 }
+
+type AcceptLanguageHeader struct {
+	Value string `header:"Accept-Language" example:"cs-CZ,cs;q=0.9,en;q=0.8"`
+}
+
+// Locale returns supported locale client prefers most, empty when header is missing or nothing matches.
+func (bh *AcceptLanguageHeader) Locale(supported []string) string {
+	tags, _, err := language.ParseAcceptLanguage(bh.Value)
+	if err != nil {
+		return ""
+	}
+
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		if slices.Contains(supported, base.String()) {
+			return base.String()
+		}
+	}
+
+	return ""
+}
+
+// EventWebhookHeaders carry signature of SendGrid Signed Event Webhook.
+type EventWebhookHeaders struct {
+	Signature string `header:"X-Twilio-Email-Event-Webhook-Signature"`
+	Timestamp string `header:"X-Twilio-Email-Event-Webhook-Timestamp"`
+}
@@ -1,15 +1,125 @@
 package request
 
+import "time"
+
 type CreateNewsletterRequest struct {
 	Name        string  `json:"name" binding:"required" example:"Tiktok News 420"`
 	Description *string `json:"description,omitempty" example:"Amazing news from the TikTok world. You would not believe number 4."`
 }
 
 type SubscribeToNewsletter struct {
-	Email string `json:"email" binding:"required" example:"test@test.com"`
+	Email  string `json:"email" binding:"required" example:"test@test.com"`
+	Locale string `json:"locale" example:"cs"`
+}
+
+type UnsubscribeReason struct {
+	NewsletterPublicID string `json:"newsletter_public_id" binding:"required" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+	Token              string `json:"token" binding:"required"`
+	Reason             string `json:"reason" binding:"required" example:"too_frequent" enums:"too_frequent,not_relevant,too_long,never_subscribed,other"`
+	Comment            string `json:"comment" example:"Too many emails in one week"`
 }
 
 type UserRequest struct {
 	Email    string `json:"email" binding:"required" example:"test@test.com"`
 	Password string `json:"password" binding:"required" example:"Pa$$W0rD"`
 }
+
+type TOTPLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Code           string `json:"code,omitempty" example:"123456"`
+	RecoveryCode   string `json:"recovery_code,omitempty" example:"abcde-fghij"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required" example:"CI publisher"`
+	Scopes    []string   `json:"scopes" binding:"required" example:"newsletters:read,newsletters:write"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2025-09-20T00:00:00Z"`
+}
+
+type RegisterSendingDomainRequest struct {
+	Domain string `json:"domain" binding:"required" example:"news.example.com"`
+}
+
+type InviteMemberRequest struct {
+	Email string `json:"email" binding:"required" example:"editor@test.com"`
+	Role  string `json:"role" binding:"required" example:"editor"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required" example:"mzxw6ytboi4dambqgiztkmzxw6ytboi"`
+}
+
+type TransferOwnershipRequest struct {
+	UserID string `json:"user_id" binding:"required" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"Pa$$W0rD"`
+	NewPassword     string `json:"new_password" binding:"required" example:"N3w-Pa$$W0rD"`
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email" binding:"required" example:"new@test.com"`
+	Password string `json:"password" binding:"required" example:"Pa$$W0rD"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required" example:"Pa$$W0rD"`
+}
+
+type UpdateNewsletterRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,min=1" example:"Tiktok News 421"`
+	Description *string `json:"description,omitempty" example:"Even more amazing news, empty value removes description."`
+}
+
+type UpdateSenderIdentityRequest struct {
+	FromName      string `json:"from_name" example:"Tiktok News"`
+	FromAddress   string `json:"from_address" binding:"omitempty,email" example:"news@example.com"`
+	ReplyTo       string `json:"reply_to" binding:"omitempty,email" example:"editor@example.com"`
+	PostalAddress string `json:"postal_address" example:"Vodickova 1, 110 00 Prague, Czech Republic"`
+}
+
+type UpdateTrackingSettingsRequest struct {
+	Opens  *bool `json:"opens" binding:"required" example:"false"`
+	Clicks *bool `json:"clicks" binding:"required" example:"false"`
+}
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required" example:"https://crm.example.com/hooks/newsletter"`
+	EventTypes []string `json:"event_types" binding:"required" example:"subscription.created,subscription.disabled"`
+}
+
+type UpdateTemplateOverrideRequest struct {
+	HTML string `json:"html" binding:"required" example:"<h1>Welcome, {{.Recipient}}!</h1><a href=\"{{.Link}}\">Unsubscribe</a>"`
+	Text string `json:"text" example:"Welcome, {{.Recipient}}! Unsubscribe: {{.Link}}"`
+}
+
+type PreviewEmailRequest struct {
+	HTML            string `json:"html" example:"<h1>Welcome, {{.Recipient}}!</h1><a href=\"{{.Link}}\">Unsubscribe</a>"`
+	Text            string `json:"text" example:"Welcome, {{.Recipient}}! Unsubscribe: {{.Link}}"`
+	Locale          string `json:"locale" example:"cs"`
+	SubscriberEmail string `json:"subscriber_email" binding:"omitempty,email" example:"john@example.com"`
+	IssueID         string `json:"issue_id" example:"0b9c1a52-63f5-4c1e-9e34-5f2d3c2f4e7a"`
+}
+
+type SendTestEmailRequest struct {
+	HTML    string `json:"html" example:"<h1>Welcome, {{.Recipient}}!</h1><a href=\"{{.Link}}\">Unsubscribe</a>"`
+	Text    string `json:"text" example:"Welcome, {{.Recipient}}! Unsubscribe: {{.Link}}"`
+	Locale  string `json:"locale" example:"cs"`
+	IssueID string `json:"issue_id" example:"0b9c1a52-63f5-4c1e-9e34-5f2d3c2f4e7a"`
+}
+
+type CreateIssueRequest struct {
+	Title    string `json:"title" binding:"required,max=255" example:"Weekly digest #42"`
+	Slug     string `json:"slug" binding:"max=100" example:"weekly-digest-42"`
+	Markdown string `json:"markdown" binding:"required" example:"## Top stories\n\nRead [the full story](https://example.com/story)."`
+}
+
+type UpdateIssueRequest struct {
+	Title    string `json:"title" binding:"required,max=255" example:"Weekly digest #42"`
+	Markdown string `json:"markdown" binding:"required" example:"## Top stories\n\nRead [the full story](https://example.com/story)."`
+}
@@ -0,0 +1,56 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type APIKey struct {
+	ID         string   `json:"id" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+	Name       string   `json:"name" example:"CI publisher"`
+	Prefix     string   `json:"prefix" example:"k3p2x7ab"`
+	Scopes     []string `json:"scopes" example:"newsletters:read"`
+	LastUsedAt *string  `json:"last_used_at,omitempty" example:"2024-09-20T23:16:32Z"`
+	ExpiresAt  *string  `json:"expires_at,omitempty" example:"2025-09-20T00:00:00Z"`
+	CreatedAt  string   `json:"created_at" example:"2024-09-20T23:16:32Z"`
+}
+
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key" example:"nl_k3p2x7ab_mzxw6ytboi4dambqgiztkmzxw6ytboi"`
+}
+
+func CreateAPIKeyResponseFromEntity(k *domain.APIKey) *APIKey {
+	scopes := make([]string, 0, len(k.Scopes()))
+	for _, s := range k.Scopes() {
+		scopes = append(scopes, string(s))
+	}
+
+	return &APIKey{
+		ID:         k.ID().String(),
+		Name:       k.Name(),
+		Prefix:     k.Prefix(),
+		Scopes:     scopes,
+		LastUsedAt: formatOptionalTime(k.LastUsedAt()),
+		ExpiresAt:  formatOptionalTime(k.ExpiresAt()),
+		CreatedAt:  k.CreatedAt().Format(time.RFC3339Nano),
+	}
+}
+
+func CreateCreatedAPIKeyResponseFromDto(k *dto.CreatedAPIKey) *CreatedAPIKey {
+	return &CreatedAPIKey{
+		APIKey: *CreateAPIKeyResponseFromEntity(k.APIKey),
+		Key:    k.Key,
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339Nano)
+
+	return &formatted
+}
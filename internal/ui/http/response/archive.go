@@ -0,0 +1,43 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type ArchivedIssueSummary struct {
+	Slug        string `json:"slug" example:"weekly-digest-42"`
+	Title       string `json:"title" example:"Weekly digest #42"`
+	URL         string `json:"url" example:"http://localhost:8080/newsletters/90c0a606-4429-44cc-9531-6f9cd038620a/issues/weekly-digest-42"`
+	PublishedAt string `json:"published_at" example:"2024-09-20T23:16:32Z"`
+}
+
+type ArchivedIssue struct {
+	Slug        string `json:"slug" example:"weekly-digest-42"`
+	Title       string `json:"title" example:"Weekly digest #42"`
+	URL         string `json:"url" example:"http://localhost:8080/newsletters/90c0a606-4429-44cc-9531-6f9cd038620a/issues/weekly-digest-42"`
+	HTML        string `json:"html" example:"<h2 style=\"font-size:22px;\">Top stories</h2>"`
+	PublishedAt string `json:"published_at" example:"2024-09-20T23:16:32Z"`
+	UpdatedAt   string `json:"updated_at" example:"2024-09-20T23:16:32Z"`
+}
+
+func CreateArchivedIssueSummaryResponseFromEntity(i *domain.Issue, url string) *ArchivedIssueSummary {
+	return &ArchivedIssueSummary{
+		Slug:        i.Slug().String(),
+		Title:       i.Title(),
+		URL:         url,
+		PublishedAt: i.PublishedAt().Format(time.RFC3339Nano),
+	}
+}
+
+func CreateArchivedIssueResponseFromEntity(i *domain.Issue, url string) *ArchivedIssue {
+	return &ArchivedIssue{
+		Slug:        i.Slug().String(),
+		Title:       i.Title(),
+		URL:         url,
+		HTML:        i.HTML(),
+		PublishedAt: i.PublishedAt().Format(time.RFC3339Nano),
+		UpdatedAt:   i.UpdatedAt().Format(time.RFC3339Nano),
+	}
+}
@@ -0,0 +1,17 @@
+package response
+
+import "github.com/javor454/newsletter-assignment/internal/application/dto"
+
+type EmailPreview struct {
+	Subject string `json:"subject" example:"You are subscribed to Tech News"`
+	HTML    string `json:"html" example:"<h1>Welcome, subscriber@example.com!</h1>"`
+	Text    string `json:"text" example:"Welcome, subscriber@example.com!"`
+}
+
+func CreateEmailPreviewResponse(p *dto.EmailPreview) *EmailPreview {
+	return &EmailPreview{
+		Subject: p.Subject,
+		HTML:    p.HTML,
+		Text:    p.Text,
+	}
+}
@@ -0,0 +1,44 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type Issue struct {
+	ID          string  `json:"id" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+	Slug        string  `json:"slug" example:"weekly-digest-42"`
+	Title       string  `json:"title" example:"Weekly digest #42"`
+	Markdown    string  `json:"markdown" example:"## Top stories"`
+	HTML        string  `json:"html" example:"<h2 style=\"font-size:22px;\">Top stories</h2>"`
+	PublishedAt *string `json:"published_at,omitempty" example:"2024-09-20T23:16:32Z"`
+	CreatedAt   string  `json:"created_at" example:"2024-09-20T23:16:32Z"`
+	UpdatedAt   string  `json:"updated_at" example:"2024-09-20T23:16:32Z"`
+}
+
+type PublishedIssue struct {
+	Issue      *Issue `json:"issue"`
+	Recipients int    `json:"recipients" example:"120"`
+}
+
+func CreateIssueResponseFromEntity(i *domain.Issue) *Issue {
+	return &Issue{
+		ID:          i.ID().String(),
+		Slug:        i.Slug().String(),
+		Title:       i.Title(),
+		Markdown:    i.Markdown(),
+		HTML:        i.HTML(),
+		PublishedAt: formatOptionalTime(i.PublishedAt()),
+		CreatedAt:   i.CreatedAt().Format(time.RFC3339Nano),
+		UpdatedAt:   i.UpdatedAt().Format(time.RFC3339Nano),
+	}
+}
+
+func CreatePublishedIssueResponseFromDto(p *dto.PublishedIssue) *PublishedIssue {
+	return &PublishedIssue{
+		Issue:      CreateIssueResponseFromEntity(p.Issue),
+		Recipients: p.Recipients,
+	}
+}
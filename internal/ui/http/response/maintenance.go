@@ -0,0 +1,50 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+)
+
+type CacheReconciliation struct {
+	StartedAt     time.Time `json:"started_at" example:"2024-10-01T12:00:00Z"`
+	DurationMs    int64     `json:"duration_ms" example:"120"`
+	Subscribers   int       `json:"subscribers" example:"100"`
+	Subscriptions int       `json:"subscriptions" example:"150"`
+	Cached        int       `json:"cached" example:"149"`
+	Missing       int       `json:"missing" example:"2"`
+	Stale         int       `json:"stale" example:"1"`
+	Repaired      int       `json:"repaired" example:"3"`
+	Added         int       `json:"added" example:"2"`
+	Removed       int       `json:"removed" example:"1"`
+	Failed        int       `json:"failed" example:"0"`
+}
+
+type FailedEvent struct {
+	ID                 string    `json:"id" example:"6f1c8e0e-7c3d-4b0a-9a58-0d4f8d1e2b3c"`
+	Name               string    `json:"name" example:"subscription.created"`
+	AggregateKey       string    `json:"aggregate_key" example:"subscription:6f1c8e0e-7c3d-4b0a-9a58-0d4f8d1e2b3c:john@example.com"`
+	NewsletterPublicID string    `json:"newsletter_public_id" example:"6f1c8e0e-7c3d-4b0a-9a58-0d4f8d1e2b3c"`
+	OccurredAt         time.Time `json:"occurred_at" example:"2024-10-01T12:00:00Z"`
+	Attempts           int       `json:"attempts" example:"20"`
+	LastError          *string   `json:"last_error" example:"failed to set subscription cache: connection refused"`
+	FailedAt           time.Time `json:"failed_at" example:"2024-10-01T21:00:00Z"`
+}
+
+func CreateFailedEventsResponse(events []*dto.FailedEvent) []*FailedEvent {
+	res := make([]*FailedEvent, 0, len(events))
+	for _, e := range events {
+		res = append(res, &FailedEvent{
+			ID:                 e.ID,
+			Name:               e.Name,
+			AggregateKey:       e.AggregateKey,
+			NewsletterPublicID: e.NewsletterPublicID,
+			OccurredAt:         e.OccurredAt,
+			Attempts:           e.Attempts,
+			LastError:          e.LastError,
+			FailedAt:           e.FailedAt,
+		})
+	}
+
+	return res
+}
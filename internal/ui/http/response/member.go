@@ -0,0 +1,35 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type Member struct {
+	UserID    string `json:"user_id" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+	Email     string `json:"email" example:"editor@test.com"`
+	Role      string `json:"role" example:"editor"`
+	CreatedAt string `json:"created_at" example:"2024-09-20T23:16:32Z"`
+}
+
+type AcceptedInvitation struct {
+	NewsletterPublicID string `json:"newsletter_public_id" example:"90c0a606-4429-44cc-9531-6f9cd038620a"`
+	Role               string `json:"role" example:"editor"`
+}
+
+func CreateMemberResponseFromEntity(m *domain.Member) *Member {
+	return &Member{
+		UserID:    m.UserID().String(),
+		Email:     m.Email().String(),
+		Role:      string(m.Role()),
+		CreatedAt: m.CreatedAt().Format(time.RFC3339Nano),
+	}
+}
+
+func CreateAcceptedInvitationResponseFromEntity(i *domain.Invitation) *AcceptedInvitation {
+	return &AcceptedInvitation{
+		NewsletterPublicID: i.NewsletterPublicID().String(),
+		Role:               string(i.Role()),
+	}
+}
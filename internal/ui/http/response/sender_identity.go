@@ -0,0 +1,26 @@
+package response
+
+import "github.com/javor454/newsletter-assignment/internal/domain"
+
+// SenderIdentity contains only values set by owner, empty values fall back to service defaults.
+type SenderIdentity struct {
+	FromName      string `json:"from_name" example:"Tiktok News"`
+	FromAddress   string `json:"from_address" example:"news@example.com"`
+	ReplyTo       string `json:"reply_to" example:"editor@example.com"`
+	PostalAddress string `json:"postal_address" example:"Vodickova 1, 110 00 Prague, Czech Republic"`
+}
+
+func CreateSenderIdentityResponseFromEntity(s *domain.SenderIdentity) *SenderIdentity {
+	res := &SenderIdentity{
+		FromName:      s.FromName(),
+		PostalAddress: s.PostalAddress(),
+	}
+	if s.FromAddress() != nil {
+		res.FromAddress = s.FromAddress().String()
+	}
+	if s.ReplyTo() != nil {
+		res.ReplyTo = s.ReplyTo().String()
+	}
+
+	return res
+}
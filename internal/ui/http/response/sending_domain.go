@@ -0,0 +1,48 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+)
+
+type DNSRecord struct {
+	Purpose  string `json:"purpose" example:"dkim"`
+	Type     string `json:"type" example:"TXT"`
+	Name     string `json:"name" example:"nl202409._domainkey.news.example.com"`
+	Value    string `json:"value" example:"v=DKIM1; k=rsa; p=MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA..."`
+	Verified bool   `json:"verified" example:"true"`
+}
+
+type SendingDomain struct {
+	ID         string       `json:"id" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+	Domain     string       `json:"domain" example:"news.example.com"`
+	Verified   bool         `json:"verified" example:"false"`
+	VerifiedAt *string      `json:"verified_at,omitempty" example:"2024-09-20T23:16:32Z"`
+	CheckedAt  *string      `json:"checked_at,omitempty" example:"2024-09-20T23:16:32Z"`
+	CreatedAt  string       `json:"created_at" example:"2024-09-20T23:16:32Z"`
+	Records    []*DNSRecord `json:"records"`
+}
+
+func CreateSendingDomainResponseFromDto(d *dto.SendingDomain) *SendingDomain {
+	records := make([]*DNSRecord, 0, len(d.Records))
+	for _, r := range d.Records {
+		records = append(records, &DNSRecord{
+			Purpose:  r.Purpose,
+			Type:     r.Type,
+			Name:     r.Name,
+			Value:    r.Value,
+			Verified: r.Verified,
+		})
+	}
+
+	return &SendingDomain{
+		ID:         d.Domain.ID().String(),
+		Domain:     d.Domain.Name(),
+		Verified:   d.Domain.IsVerified(),
+		VerifiedAt: formatOptionalTime(d.Domain.VerifiedAt()),
+		CheckedAt:  formatOptionalTime(d.Domain.CheckedAt()),
+		CreatedAt:  d.Domain.CreatedAt().Format(time.RFC3339Nano),
+		Records:    records,
+	}
+}
@@ -0,0 +1,102 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+)
+
+type StatsCounts struct {
+	Subscribed   int `json:"subscribed" example:"42"`
+	Unsubscribed int `json:"unsubscribed" example:"3"`
+	Sent         int `json:"sent" example:"1200"`
+	Delivered    int `json:"delivered" example:"1180"`
+	Bounced      int `json:"bounced" example:"20"`
+	Opened       int `json:"opened" example:"640"`
+	Clicked      int `json:"clicked" example:"210"`
+}
+
+type StatsBucket struct {
+	Start       string `json:"start" example:"2024-09-16"`
+	Subscribers int    `json:"subscribers" example:"1239"`
+	StatsCounts
+}
+
+type NewsletterStats struct {
+	Interval           string         `json:"interval" example:"week"`
+	From               string         `json:"from" example:"2024-07-01"`
+	To                 string         `json:"to" example:"2024-09-20"`
+	Subscribers        int            `json:"subscribers" example:"1239"`
+	Totals             StatsCounts    `json:"totals"`
+	Buckets            []*StatsBucket `json:"buckets"`
+	UnsubscribeReasons map[string]int `json:"unsubscribe_reasons" example:"too_frequent:2,other:1"`
+}
+
+type UnsubscribeComment struct {
+	Reason         string `json:"reason" example:"other"`
+	Comment        string `json:"comment" example:"Moved to competitor"`
+	UnsubscribedAt string `json:"unsubscribed_at" example:"2024-09-20T08:15:00Z"`
+}
+
+type IssueStats struct {
+	IssueID      string `json:"issue_id" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+	Sent         int    `json:"sent" example:"1200"`
+	Delivered    int    `json:"delivered" example:"1180"`
+	Bounced      int    `json:"bounced" example:"20"`
+	Opened       int    `json:"opened" example:"640"`
+	Clicked      int    `json:"clicked" example:"210"`
+	Unsubscribed int    `json:"unsubscribed" example:"3"`
+}
+
+func CreateNewsletterStatsResponseFromDto(s *dto.NewsletterStats) *NewsletterStats {
+	buckets := make([]*StatsBucket, 0, len(s.Buckets))
+	for _, b := range s.Buckets {
+		buckets = append(buckets, &StatsBucket{
+			Start:       b.Start.Format(time.DateOnly),
+			Subscribers: b.Subscribers,
+			StatsCounts: createStatsCounts(&b.StatsCounts),
+		})
+	}
+
+	return &NewsletterStats{
+		Interval:           string(s.Period.Interval()),
+		From:               s.Period.From().Format(time.DateOnly),
+		To:                 s.Period.To().Format(time.DateOnly),
+		Subscribers:        s.Subscribers,
+		Totals:             createStatsCounts(&s.Totals),
+		Buckets:            buckets,
+		UnsubscribeReasons: s.UnsubscribeReasons,
+	}
+}
+
+func CreateUnsubscribeCommentResponseFromDto(c *dto.UnsubscribeComment) *UnsubscribeComment {
+	return &UnsubscribeComment{
+		Reason:         c.Reason,
+		Comment:        c.Comment,
+		UnsubscribedAt: c.UnsubscribedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func CreateIssueStatsResponseFromDto(s *dto.IssueStats) *IssueStats {
+	return &IssueStats{
+		IssueID:      s.IssueID.String(),
+		Sent:         s.Sent,
+		Delivered:    s.Delivered,
+		Bounced:      s.Bounced,
+		Opened:       s.Opened,
+		Clicked:      s.Clicked,
+		Unsubscribed: s.Unsubscribed,
+	}
+}
+
+func createStatsCounts(c *dto.StatsCounts) StatsCounts {
+	return StatsCounts{
+		Subscribed:   c.Subscribed,
+		Unsubscribed: c.Unsubscribed,
+		Sent:         c.Sent,
+		Delivered:    c.Delivered,
+		Bounced:      c.Bounced,
+		Opened:       c.Opened,
+		Clicked:      c.Clicked,
+	}
+}
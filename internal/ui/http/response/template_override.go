@@ -0,0 +1,27 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type TemplateOverride struct {
+	Name      string `json:"name" example:"subscribed"`
+	HTML      string `json:"html" example:"<h1>Welcome, {{.Recipient}}!</h1><a href=\"{{.Link}}\">Unsubscribe</a>"`
+	Text      string `json:"text" example:"Welcome, {{.Recipient}}! Unsubscribe: {{.Link}}"`
+	UpdatedAt string `json:"updated_at,omitempty" example:"2024-09-20T23:16:32Z"`
+}
+
+func CreateTemplateOverrideResponseFromEntity(t *domain.TemplateOverride) *TemplateOverride {
+	res := &TemplateOverride{
+		Name: t.Name(),
+		HTML: t.HTML(),
+		Text: t.Text(),
+	}
+	if !t.UpdatedAt().IsZero() {
+		res.UpdatedAt = t.UpdatedAt().Format(time.RFC3339Nano)
+	}
+
+	return res
+}
@@ -0,0 +1,23 @@
+package response
+
+import "github.com/javor454/newsletter-assignment/internal/application/dto"
+
+type LoginChallenge struct {
+	ChallengeToken string `json:"challenge_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+type TOTPEnrollment struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/newsletter-assignment:test@test.com?secret=JBSWY3DPEHPK3PXP"`
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"abcde-fghij"`
+}
+
+func CreateTOTPEnrollmentResponseFromDto(e *dto.TOTPEnrollment) *TOTPEnrollment {
+	return &TOTPEnrollment{
+		Secret:          e.Secret,
+		ProvisioningURI: e.ProvisioningURI,
+	}
+}
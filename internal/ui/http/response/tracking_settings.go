@@ -0,0 +1,12 @@
+package response
+
+import "github.com/javor454/newsletter-assignment/internal/domain"
+
+type TrackingSettings struct {
+	Opens  bool `json:"opens" example:"true"`
+	Clicks bool `json:"clicks" example:"true"`
+}
+
+func CreateTrackingSettingsResponseFromEntity(t *domain.TrackingSettings) *TrackingSettings {
+	return &TrackingSettings{Opens: t.Opens(), Clicks: t.Clicks()}
+}
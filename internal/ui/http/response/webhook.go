@@ -0,0 +1,69 @@
+package response
+
+import (
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+)
+
+type Webhook struct {
+	ID         string   `json:"id" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+	URL        string   `json:"url" example:"https://crm.example.com/hooks/newsletter"`
+	EventTypes []string `json:"event_types" example:"subscription.created,subscription.disabled"`
+	CreatedAt  string   `json:"created_at" example:"2024-09-20T23:16:32Z"`
+}
+
+type CreatedWebhook struct {
+	Webhook
+	Secret string `json:"secret" example:"mzxw6ytboi4dambqgiztkmzxw6ytboi4dambq"`
+}
+
+type WebhookDelivery struct {
+	ID             string  `json:"id" example:"1541c9c1-e43e-4527-850a-77f4e5be9599"`
+	EventID        string  `json:"event_id" example:"9a3f1c2e-7d4b-4c8a-9e6f-2b1d0c3a4e5f"`
+	EventType      string  `json:"event_type" example:"subscription.created"`
+	Status         string  `json:"status" example:"pending" enums:"pending,delivered,failed"`
+	Attempts       int     `json:"attempts" example:"2"`
+	LastStatusCode *int    `json:"last_status_code,omitempty" example:"503"`
+	LastError      *string `json:"last_error,omitempty" example:"webhook responded with 503: "`
+	LastAttemptAt  *string `json:"last_attempt_at,omitempty" example:"2024-09-20T23:16:32Z"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty" example:"2024-09-20T23:21:32Z"`
+	CreatedAt      string  `json:"created_at" example:"2024-09-20T23:15:32Z"`
+}
+
+func CreateWebhookResponseFromEntity(w *domain.Webhook) *Webhook {
+	eventTypes := make([]string, 0, len(w.EventTypes()))
+	for _, t := range w.EventTypes() {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	return &Webhook{
+		ID:         w.ID().String(),
+		URL:        w.URL(),
+		EventTypes: eventTypes,
+		CreatedAt:  w.CreatedAt().Format(time.RFC3339Nano),
+	}
+}
+
+func CreateCreatedWebhookResponseFromEntity(w *domain.Webhook) *CreatedWebhook {
+	return &CreatedWebhook{
+		Webhook: *CreateWebhookResponseFromEntity(w),
+		Secret:  w.Secret(),
+	}
+}
+
+func CreateWebhookDeliveryResponseFromDto(d *dto.WebhookDelivery) *WebhookDelivery {
+	return &WebhookDelivery{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		LastAttemptAt:  formatOptionalTime(d.LastAttemptAt),
+		NextAttemptAt:  formatOptionalTime(d.NextAttemptAt),
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
@@ -32,11 +32,6 @@ func main() {
 	if err != nil {
 		panic("[CONFIG] failed to load: " + err.Error())
 	}
-	fbConfig, err := config.NewFirebaseConfig()
-	if err != nil {
-		panic("[CONFIG] failed to load: " + err.Error())
-	}
-
 	location, err := time.LoadLocation(appConfig.Timezone)
 	if err != nil {
 		panic("failed to load timezone")
@@ -50,9 +45,16 @@ func main() {
 		panic("[PG] failed to connect: " + err.Error())
 	}
 
-	fbClient, err := firebase.NewClient(lg, rootCtx, fbConfig)
-	if err != nil {
-		panic("[FIREBASE] failed to connect: " + err.Error())
+	var fbClient *firebase.Client
+	if appConfig.CacheBackend == config.CacheBackendFirebase {
+		fbConfig, err := config.NewFirebaseConfig()
+		if err != nil {
+			panic("[CONFIG] failed to load: " + err.Error())
+		}
+		fbClient, err = firebase.NewClient(lg, rootCtx, fbConfig)
+		if err != nil {
+			panic("[FIREBASE] failed to connect: " + err.Error())
+		}
 	}
 
 	mailClient := sendgrid.NewClient(lg, appConfig)
@@ -0,0 +1,12 @@
+ALTER TABLE users
+    ADD COLUMN totp_secret VARCHAR(64) DEFAULT NULL,
+    ADD COLUMN totp_enabled_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+CREATE TABLE user_recovery_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS newsletter_invitations;
+DROP TABLE IF EXISTS newsletter_members;
@@ -0,0 +1,28 @@
+CREATE TABLE newsletter_members (
+    id UUID PRIMARY KEY,
+    newsletter_id UUID NOT NULL REFERENCES newsletters(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (newsletter_id, user_id)
+);
+
+-- exactly one owner per newsletter, newsletters.user_id keeps pointing to the owner
+CREATE UNIQUE INDEX newsletter_members_owner_idx ON newsletter_members (newsletter_id) WHERE role = 'owner';
+CREATE INDEX newsletter_members_user_id_idx ON newsletter_members (user_id);
+
+INSERT INTO newsletter_members (id, newsletter_id, user_id, role)
+SELECT uuid_generate_v4(), id, user_id, 'owner' FROM newsletters WHERE user_id IS NOT NULL;
+
+CREATE TABLE newsletter_invitations (
+    id UUID PRIMARY KEY,
+    newsletter_id UUID NOT NULL REFERENCES newsletters(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('editor', 'viewer')),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (newsletter_id, email)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_email_changes;
+
+ALTER TABLE newsletters DROP COLUMN IF EXISTS archived_at;
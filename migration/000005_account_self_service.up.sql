@@ -0,0 +1,9 @@
+ALTER TABLE newsletters ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+CREATE TABLE user_email_changes (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    new_email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS email_jobs_newsletter_id_idx;
+
+ALTER TABLE email_jobs DROP COLUMN IF EXISTS newsletter_id;
@@ -0,0 +1,7 @@
+ALTER TABLE email_jobs ADD COLUMN newsletter_id UUID REFERENCES newsletters(id) ON DELETE CASCADE;
+
+UPDATE email_jobs j SET newsletter_id = n.id
+FROM newsletters n
+WHERE j.params->>'newsletter_id' = n.public_id::text;
+
+CREATE INDEX email_jobs_newsletter_id_idx ON email_jobs(newsletter_id) WHERE sent = false;
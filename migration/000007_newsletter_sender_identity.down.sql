@@ -0,0 +1,5 @@
+ALTER TABLE newsletters
+    DROP COLUMN IF EXISTS from_name,
+    DROP COLUMN IF EXISTS from_address,
+    DROP COLUMN IF EXISTS reply_to,
+    DROP COLUMN IF EXISTS postal_address;
@@ -0,0 +1,5 @@
+ALTER TABLE newsletters
+    ADD COLUMN from_name VARCHAR(255) DEFAULT NULL,
+    ADD COLUMN from_address VARCHAR(255) DEFAULT NULL,
+    ADD COLUMN reply_to VARCHAR(255) DEFAULT NULL,
+    ADD COLUMN postal_address VARCHAR(500) DEFAULT NULL;
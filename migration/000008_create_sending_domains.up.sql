@@ -0,0 +1,19 @@
+CREATE TABLE sending_domains (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    domain VARCHAR(253) NOT NULL,
+    verification_token VARCHAR(64) NOT NULL,
+    dkim_selector VARCHAR(63) NOT NULL,
+    dkim_private_key TEXT NOT NULL,
+    dkim_public_key TEXT NOT NULL,
+    ownership_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    spf_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    dkim_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    dmarc_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    verified_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    checked_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT sending_domains_user_id_domain_key UNIQUE (user_id, domain)
+);
+
+CREATE INDEX sending_domains_verified_domain_idx ON sending_domains (domain) WHERE verified_at IS NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS newsletter_templates;
@@ -0,0 +1,8 @@
+CREATE TABLE newsletter_templates (
+    newsletter_id UUID NOT NULL REFERENCES newsletters(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    html TEXT NOT NULL,
+    text TEXT DEFAULT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (newsletter_id, name)
+);
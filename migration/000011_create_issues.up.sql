@@ -0,0 +1,14 @@
+CREATE TABLE issues (
+    id UUID PRIMARY KEY,
+    newsletter_id UUID NOT NULL REFERENCES newsletters(id) ON DELETE CASCADE,
+    slug VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    body_markdown TEXT NOT NULL,
+    body_html TEXT NOT NULL,
+    published_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (newsletter_id, slug)
+);
+
+CREATE INDEX issues_published_at_idx ON issues(newsletter_id, published_at DESC) WHERE published_at IS NOT NULL;
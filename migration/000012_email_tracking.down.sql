@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS email_events;
+
+ALTER TABLE newsletters DROP COLUMN IF EXISTS track_opens;
@@ -0,0 +1,13 @@
+ALTER TABLE newsletters ADD COLUMN track_opens BOOLEAN NOT NULL DEFAULT true;
+
+-- one row per email job and event type, repeated events only bump counters
+CREATE TABLE email_events (
+    email_job_id UUID NOT NULL REFERENCES email_jobs(id) ON DELETE CASCADE,
+    event_type VARCHAR(20) NOT NULL,
+    first_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    count INT NOT NULL DEFAULT 1,
+    prefetch_count INT NOT NULL DEFAULT 0,
+    user_agent_class VARCHAR(20) NOT NULL,
+    PRIMARY KEY (email_job_id, event_type)
+);
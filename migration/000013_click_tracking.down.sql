@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS email_clicks;
+
+ALTER TABLE newsletters DROP COLUMN IF EXISTS track_clicks;
@@ -0,0 +1,11 @@
+ALTER TABLE newsletters ADD COLUMN track_clicks BOOLEAN NOT NULL DEFAULT true;
+
+-- every click is kept, link index points to position of link in content of issue
+CREATE TABLE email_clicks (
+    id UUID PRIMARY KEY,
+    email_job_id UUID NOT NULL REFERENCES email_jobs(id) ON DELETE CASCADE,
+    link_index INT NOT NULL,
+    clicked_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX email_clicks_email_job_id_idx ON email_clicks(email_job_id);
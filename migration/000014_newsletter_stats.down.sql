@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS email_clicks_clicked_at_idx;
+DROP INDEX IF EXISTS email_events_read_at_idx;
+DROP INDEX IF EXISTS email_events_first_at_idx;
+DROP INDEX IF EXISTS email_jobs_issue_id_idx;
+DROP INDEX IF EXISTS email_jobs_sent_issue_idx;
+DROP INDEX IF EXISTS subscriptions_disabled_at_idx;
+DROP INDEX IF EXISTS subscriptions_created_at_idx;
+
+DROP TABLE IF EXISTS newsletter_daily_stats;
+
+ALTER TABLE email_events DROP COLUMN IF EXISTS read_at;
+DELETE FROM email_events WHERE user_agent_class IS NULL;
+ALTER TABLE email_events ALTER COLUMN user_agent_class SET NOT NULL;
@@ -0,0 +1,28 @@
+-- delivery events reported by mail provider have no user agent
+ALTER TABLE email_events ALTER COLUMN user_agent_class DROP NOT NULL;
+
+-- first open made by reader, prefetches do not count as read
+ALTER TABLE email_events ADD COLUMN read_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+UPDATE email_events SET read_at = first_at WHERE event_type = 'OPEN' AND count > prefetch_count;
+
+-- pre-aggregated counters of newsletter per day in UTC, recent days are recomputed periodically
+CREATE TABLE newsletter_daily_stats (
+    newsletter_id UUID NOT NULL REFERENCES newsletters(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    subscribed INT NOT NULL DEFAULT 0,
+    unsubscribed INT NOT NULL DEFAULT 0,
+    sent INT NOT NULL DEFAULT 0,
+    delivered INT NOT NULL DEFAULT 0,
+    bounced INT NOT NULL DEFAULT 0,
+    opened INT NOT NULL DEFAULT 0,
+    clicked INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (newsletter_id, day)
+);
+
+CREATE INDEX subscriptions_created_at_idx ON subscriptions(created_at);
+CREATE INDEX subscriptions_disabled_at_idx ON subscriptions(disabled_at) WHERE disabled_at IS NOT NULL;
+CREATE INDEX email_jobs_sent_issue_idx ON email_jobs(updated_at) WHERE message_type = 'ISSUE' AND sent = true;
+CREATE INDEX email_jobs_issue_id_idx ON email_jobs((params->>'issue_id')) WHERE message_type = 'ISSUE';
+CREATE INDEX email_events_first_at_idx ON email_events(first_at);
+CREATE INDEX email_events_read_at_idx ON email_events(read_at) WHERE read_at IS NOT NULL;
+CREATE INDEX email_clicks_clicked_at_idx ON email_clicks(clicked_at);
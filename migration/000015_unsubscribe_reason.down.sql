@@ -0,0 +1,3 @@
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS unsubscribe_comment,
+    DROP COLUMN IF EXISTS unsubscribe_reason;
@@ -0,0 +1,4 @@
+-- answer to survey after unsubscribing, cleared when subscription is enabled again
+ALTER TABLE subscriptions
+    ADD COLUMN unsubscribe_reason VARCHAR(30) DEFAULT NULL,
+    ADD COLUMN unsubscribe_comment VARCHAR(1000) DEFAULT NULL;
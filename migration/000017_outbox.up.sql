@@ -0,0 +1,15 @@
+-- domain events written in transaction of the change which raised them, dispatcher passes them to handlers
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    newsletter_public_id UUID NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    data JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT DEFAULT NULL,
+    dispatched_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX outbox_due_idx ON outbox(next_attempt_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS outbox_pending_aggregate_idx;
+ALTER TABLE outbox DROP COLUMN IF EXISTS aggregate_key;
@@ -0,0 +1,6 @@
+-- events of one aggregate are dispatched one by one, stored events are keyed by themselves so they stay independent
+ALTER TABLE outbox ADD COLUMN aggregate_key VARCHAR(320);
+UPDATE outbox SET aggregate_key = id::text;
+ALTER TABLE outbox ALTER COLUMN aggregate_key SET NOT NULL;
+
+CREATE INDEX outbox_pending_aggregate_idx ON outbox(aggregate_key, occurred_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,2 @@
+-- time step of the last accepted totp code, code of the same or earlier step is rejected as replay
+ALTER TABLE users ADD COLUMN totp_last_step BIGINT DEFAULT NULL;
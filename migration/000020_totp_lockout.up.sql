@@ -0,0 +1,5 @@
+-- failed second factor attempts lock totp login for a while, challenge id makes every challenge token single use
+ALTER TABLE users
+    ADD COLUMN totp_failed_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN totp_locked_until TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    ADD COLUMN totp_challenge_id UUID DEFAULT NULL;
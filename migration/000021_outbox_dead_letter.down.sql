@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS outbox_failed_idx;
+DROP INDEX IF EXISTS outbox_due_idx;
+DROP INDEX IF EXISTS outbox_pending_aggregate_idx;
+CREATE INDEX outbox_due_idx ON outbox(next_attempt_at) WHERE dispatched_at IS NULL;
+CREATE INDEX outbox_pending_aggregate_idx ON outbox(aggregate_key, occurred_at) WHERE dispatched_at IS NULL;
+ALTER TABLE outbox DROP COLUMN IF EXISTS failed_at;
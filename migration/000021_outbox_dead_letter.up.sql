@@ -0,0 +1,9 @@
+-- event failing every attempt is given up, it no longer holds back later events of its aggregate
+ALTER TABLE outbox ADD COLUMN failed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+DROP INDEX IF EXISTS outbox_due_idx;
+DROP INDEX IF EXISTS outbox_pending_aggregate_idx;
+CREATE INDEX outbox_due_idx ON outbox(next_attempt_at) WHERE dispatched_at IS NULL AND failed_at IS NULL;
+CREATE INDEX outbox_pending_aggregate_idx ON outbox(aggregate_key, occurred_at)
+    WHERE dispatched_at IS NULL AND failed_at IS NULL;
+CREATE INDEX outbox_failed_idx ON outbox(failed_at) WHERE failed_at IS NOT NULL;
@@ -0,0 +1,141 @@
+package cache_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/app/firebase"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	firebaseinfra "github.com/javor454/newsletter-assignment/internal/infrastructure/firebase"
+	"github.com/javor454/newsletter-assignment/test/helper"
+	"github.com/stretchr/testify/suite"
+)
+
+// writers is number of concurrent updates of the same subscriber, enough to interleave read-modify-write
+const writers = 20
+
+type SubscriptionCacheTestSuite struct {
+	suite.Suite
+	sc     *firebaseinfra.SubscriptionCacheManager
+	emails []*domain.Email
+}
+
+func (s *SubscriptionCacheTestSuite) SetupSuite() {
+	appConf := helper.NewAppConfig()
+	lg := logger.NewLogger(appConf)
+
+	fbClient, err := firebase.NewClient(lg, context.Background(), helper.NewFirebaseConfig())
+	if err != nil {
+		s.T().Fatal("failed to connect: " + err.Error())
+	}
+
+	s.sc = firebaseinfra.NewSubscriptionCacheManager(fbClient)
+}
+
+func (s *SubscriptionCacheTestSuite) Test_AddSubscribedNewsletter_ConcurrentKeepsAll() {
+	email := s.newEmail()
+	ids := newsletterIDs(writers)
+
+	s.concurrently(ids, func(id *domain.ID) error {
+		return s.sc.AddSubscribedNewsletter(context.Background(), email.String(), id.String())
+	})
+
+	s.ElementsMatch(toStrings(ids), s.cached(email))
+}
+
+func (s *SubscriptionCacheTestSuite) Test_RemoveSubscribedNewsletter_ConcurrentWithAdd() {
+	email := s.newEmail()
+	removed := newsletterIDs(writers)
+	added := newsletterIDs(writers)
+	for _, id := range removed {
+		s.Require().Nil(s.sc.AddSubscribedNewsletter(context.Background(), email.String(), id.String()))
+	}
+
+	s.concurrently(append(removed, added...), func(id *domain.ID) error {
+		for _, r := range removed {
+			if r == id {
+				return s.sc.RemoveSubscribedNewsletter(context.Background(), email, id)
+			}
+		}
+
+		return s.sc.AddSubscribedNewsletter(context.Background(), email.String(), id.String())
+	})
+
+	s.ElementsMatch(toStrings(added), s.cached(email))
+}
+
+func (s *SubscriptionCacheTestSuite) TearDownSuite() {
+	for _, email := range s.emails {
+		for _, id := range s.cached(email) {
+			pubID, err := domain.CreateIDFromExisting(id)
+			if err != nil {
+				s.T().Fatal(err)
+			}
+			if err := s.sc.RemoveSubscribedNewsletter(context.Background(), email, pubID); err != nil {
+				s.T().Fatal(err)
+			}
+		}
+	}
+}
+
+// concurrently runs fn for every id at once and requires all of them to succeed.
+func (s *SubscriptionCacheTestSuite) concurrently(ids []*domain.ID, fn func(id *domain.ID) error) {
+	var wg sync.WaitGroup
+	errs := make(chan error, len(ids))
+	start := make(chan struct{})
+
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id *domain.ID) {
+			defer wg.Done()
+			<-start
+			errs <- fn(id)
+		}(id)
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		s.Require().Nil(err)
+	}
+}
+
+func (s *SubscriptionCacheTestSuite) newEmail() *domain.Email {
+	email, err := domain.NewEmail(domain.NewID().String() + "@test.com")
+	s.Require().Nil(err)
+	s.emails = append(s.emails, email)
+
+	return email
+}
+
+func (s *SubscriptionCacheTestSuite) cached(email *domain.Email) []string {
+	subscribed, err := s.sc.GetSubscribedNewsletters(context.Background())
+	s.Require().Nil(err)
+
+	return subscribed[email.String()]
+}
+
+func newsletterIDs(n int) []*domain.ID {
+	ids := make([]*domain.ID, 0, n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, domain.NewID())
+	}
+
+	return ids
+}
+
+func toStrings(ids []*domain.ID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+
+	return values
+}
+
+func TestSubscriptionCacheSuite(t *testing.T) {
+	suite.Run(t, new(SubscriptionCacheTestSuite))
+}
@@ -0,0 +1,265 @@
+package controller_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/javor454/newsletter-assignment/app/config"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	pgapp "github.com/javor454/newsletter-assignment/app/pg"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/apikey"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/jwt"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/markdown"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/service"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/controller"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/test/helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type IssueTestSuite struct {
+	suite.Suite
+	lg                  logger.Logger
+	appConf             *config.AppConfig
+	pgConn              *sql.DB
+	c                   *controller.IssueController
+	am                  *middleware.AuthMiddleware
+	userIDs             []string
+	newsletterIDs       []string
+	newsletterPublicIDs []string
+}
+
+type issueRequest struct {
+	Title    string `json:"title"`
+	Markdown string `json:"markdown"`
+}
+
+type issueResponse struct {
+	ID          string  `json:"id"`
+	PublishedAt *string `json:"published_at,omitempty"`
+}
+
+type publishedIssueResponse struct {
+	Issue      issueResponse `json:"issue"`
+	Recipients int           `json:"recipients"`
+}
+
+func (s *IssueTestSuite) SetupSuite() {
+	s.appConf = helper.NewAppConfig()
+	location, err := time.LoadLocation(s.appConf.Timezone)
+	if err != nil {
+		panic("failed to load timezone")
+	}
+	time.Local = location
+	pgConfig := helper.NewPostgresConfig()
+	s.lg = logger.NewLogger(s.appConf)
+	pgConn, err := pgapp.NewConnection(s.lg, pgConfig)
+	if err != nil {
+		s.lg.WithError(err).Fatal("pg connection init failed")
+	}
+	s.pgConn = pgConn
+	if err := pgapp.MigrationsUp(s.lg, pgConfig, pgConn); err != nil {
+		s.lg.WithError(err).Fatal("pg migrations failed")
+	}
+
+	mr := service.NewMemberRepository(
+		pgConn,
+		operation.NewGetNewsletterRole(pgConn),
+		operation.NewGetNewsletterMembers(pgConn),
+		operation.NewGetInvitationByTokenHash(pgConn),
+		operation.NewDeleteMember(pgConn),
+	)
+	ir := pg.NewIssueRepository(
+		operation.NewCreateIssue(pgConn),
+		operation.NewUpdateIssue(pgConn),
+		operation.NewGetIssue(pgConn),
+		operation.NewGetIssues(pgConn),
+		operation.NewGetPublishedIssues(pgConn),
+		operation.NewGetPublishedIssue(pgConn),
+	)
+	ipr := service.NewIssuePublicationRepository(pgConn)
+	mdr := markdown.NewRenderer()
+
+	ks, err := jwt.NewKeySet(s.appConf.JwtSecret, s.appConf.JwtKeysDir, s.appConf.JwtActiveKeyID)
+	if err != nil {
+		s.lg.WithError(err).Fatal("jwt key set init failed")
+	}
+	tm := jwt.NewTokenManager(ks, s.appConf.Host, s.appConf.JwtAudience)
+
+	akr := pg.NewAPIKeyRepository(
+		operation.NewCreateAPIKey(pgConn),
+		operation.NewGetAPIKeysByUserID(pgConn),
+		operation.NewGetAPIKeyByPrefix(pgConn),
+		operation.NewUpdateAPIKeyLastUsed(pgConn),
+		operation.NewUpdateRevokeAPIKey(pgConn),
+	)
+
+	dth := handler.NewDecodeTokenHandler(tm)
+	aakh := handler.NewAuthenticateAPIKeyHandler(akr, time.Now)
+	cih := handler.NewCreateIssueHandler(mr, mdr, ir)
+	uih := handler.NewUpdateIssueHandler(mr, mdr, ir, ir)
+	gish := handler.NewGetIssuesHandler(mr, ir)
+	gih := handler.NewGetIssueHandler(mr, ir)
+	pih := handler.NewPublishIssueHandler(mr, ir, ipr)
+
+	s.am = middleware.NewAuthMiddleware(dth, aakh, s.lg)
+
+	s.c = controller.NewIssueController(s.lg, cih, uih, gish, gih, pih)
+	s.userIDs = make([]string, 0, 1)
+	s.newsletterIDs = make([]string, 0, 1)
+	s.newsletterPublicIDs = make([]string, 0, 1)
+}
+
+func (s *IssueTestSuite) Test_PublishIssue_APIKeyWithScope() {
+	const (
+		email    = "issue-api-key@test.com"
+		password = "P@$$w0rD"
+		uri      = "/api/v1/newsletters/:public_id/issues"
+	)
+
+	// fixtures
+	userID := uuid.New().String()
+	hash, err := helper.Encrypt(password)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if err := helper.CreateUser(userID, email, hash, s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
+	s.userIDs = append(s.userIDs, userID)
+
+	newsletterID := uuid.New().String()
+	newsletterPublicID := uuid.New().String()
+	if err := helper.CreateNewsletter(newsletterID, newsletterPublicID, userID, "issue newsletter", "", s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
+	s.newsletterIDs = append(s.newsletterIDs, newsletterID)
+	s.newsletterPublicIDs = append(s.newsletterPublicIDs, newsletterPublicID)
+
+	writeKey := s.createAPIKey(userID, domain.ScopeIssuesWrite)
+	readKey := s.createAPIKey(userID, domain.ScopeIssuesRead)
+
+	// setup
+	gin.SetMode(gin.TestMode)
+	send := func(method, path, key string, body any) (int, []byte) {
+		var reqBody io.Reader
+		if body != nil {
+			jsonBody, err := json.Marshal(body)
+			if err != nil {
+				s.T().Fatalf("error marshalling body: %s", err.Error())
+			}
+			reqBody = bytes.NewBuffer(jsonBody)
+		}
+		r, err := http.NewRequest(method, path, reqBody)
+		if err != nil {
+			s.T().Fatalf("error creating request: %s", err.Error())
+		}
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", fmt.Sprintf("ApiKey %s", key))
+
+		w := httptest.NewRecorder()
+		ctx, engine := gin.CreateTestContext(w)
+		ctx.Request = r
+		engine.Handle(
+			http.MethodPost,
+			uri,
+			s.am.HandleScoped(domain.ScopeIssuesWrite),
+			middleware.LoggingMiddleware(s.lg, []string{}),
+			s.c.CreateIssue,
+		)
+		engine.Handle(
+			http.MethodPost,
+			uri+"/:issue_id/publish",
+			s.am.HandleScoped(domain.ScopeIssuesWrite),
+			middleware.LoggingMiddleware(s.lg, []string{}),
+			s.c.PublishIssue,
+		)
+		engine.HandleContext(ctx)
+
+		res := w.Result()
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			s.T().Fatalf("reading body error %s", err.Error())
+		}
+		if err := res.Body.Close(); err != nil {
+			s.T().Fatalf("closing body error %s", err.Error())
+		}
+
+		return res.StatusCode, resBody
+	}
+
+	issuesPath := fmt.Sprintf("/api/v1/newsletters/%s/issues", newsletterPublicID)
+	code, body := send(http.MethodPost, issuesPath, writeKey, &issueRequest{Title: "First issue", Markdown: "# Hello"})
+	s.Require().Equal(http.StatusCreated, code, "create issue failed")
+
+	var created issueResponse
+	if err := json.Unmarshal(body, &created); err != nil {
+		s.T().Fatalf("error unmarshalling body: %s", err.Error())
+	}
+	publishPath := fmt.Sprintf("%s/%s/publish", issuesPath, created.ID)
+
+	code, _ = send(http.MethodPost, publishPath, readKey, nil)
+	s.Equal(http.StatusForbidden, code, "issue published without write scope")
+
+	code, body = send(http.MethodPost, publishPath, writeKey, nil)
+	s.Require().Equal(http.StatusOK, code, "publish issue failed")
+
+	var published publishedIssueResponse
+	if err := json.Unmarshal(body, &published); err != nil {
+		s.T().Fatalf("error unmarshalling body: %s", err.Error())
+	}
+	s.Equal(created.ID, published.Issue.ID, "issue id mismatch")
+	s.NotNil(published.Issue.PublishedAt, "issue is not published")
+	s.Equal(0, published.Recipients, "newsletter has no subscribers")
+}
+
+func (s *IssueTestSuite) createAPIKey(userID string, scope domain.Scope) string {
+	prefix, key, err := apikey.Generate()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if err := helper.CreateAPIKey(
+		uuid.New().String(),
+		userID,
+		prefix,
+		apikey.Hash(key),
+		[]string{string(scope)},
+		s.pgConn,
+	); err != nil {
+		s.T().Fatal(err)
+	}
+
+	return key
+}
+
+func (s *IssueTestSuite) TearDownSuite() {
+	if err := helper.RemoveOutboxEventsByNewsletterPublicID(s.newsletterPublicIDs, s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
+	if err := helper.RemoveNewsletterByID(s.newsletterIDs, s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
+	if err := helper.RemoveUsersByUserID(s.userIDs, s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
+	if err := s.pgConn.Close(); err != nil {
+		s.T().Fatalf("pgConn close failed: %s", err.Error())
+	}
+}
+
+func TestIssueSuite(t *testing.T) {
+	suite.Run(t, new(IssueTestSuite))
+}
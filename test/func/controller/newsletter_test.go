@@ -18,9 +18,12 @@ import (
 	"github.com/javor454/newsletter-assignment/app/logger"
 	pgapp "github.com/javor454/newsletter-assignment/app/pg"
 	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/apikey"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/jwt"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/service"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/controller"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
 	controllertest "github.com/javor454/newsletter-assignment/test/func/controller"
@@ -30,13 +33,15 @@ import (
 
 type NewsletterTestSuite struct {
 	suite.Suite
-	lg            logger.Logger
-	appConf       *config.AppConfig
-	pgConn        *sql.DB
-	c             *controller.NewsletterController
-	am            *middleware.AuthMiddleware
-	userIDs       []string
-	newsletterIDs []string
+	lg                  logger.Logger
+	appConf             *config.AppConfig
+	pgConn              *sql.DB
+	c                   *controller.NewsletterController
+	am                  *middleware.AuthMiddleware
+	userIDs             []string
+	newsletterIDs       []string
+	newsletterPublicIDs []string
+	subscriberEmails    []string
 }
 
 type newsletterRequest struct {
@@ -81,21 +86,47 @@ func (s *NewsletterTestSuite) SetupSuite() {
 	gn := operation.NewGetNewslettersByUserID(pgConn)
 	gns := operation.NewGetNewslettersBySubscriptionEmail(pgConn)
 	gnbp := operation.NewGetNewslettersByPublicID(pgConn)
+	un := operation.NewUpdateNewsletter(pgConn)
+
+	gnbpi := pg.NewNewsletterRepository(cn, gn, gns, gnbp, un, operation.NewGetNewslettersByPublicIDs(pgConn))
+	mr := service.NewMemberRepository(
+		pgConn,
+		operation.NewGetNewsletterRole(pgConn),
+		operation.NewGetNewsletterMembers(pgConn),
+		operation.NewGetInvitationByTokenHash(pgConn),
+		operation.NewDeleteMember(pgConn),
+	)
+	nrr := service.NewNewsletterRemovalRepository(pgConn, s.appConf.RemovalTimeout)
 
-	gnbpi := pg.NewNewsletterRepository(cn, gn, gns, gnbp)
+	ks, err := jwt.NewKeySet(s.appConf.JwtSecret, s.appConf.JwtKeysDir, s.appConf.JwtActiveKeyID)
+	if err != nil {
+		s.lg.WithError(err).Fatal("jwt key set init failed")
+	}
+	tm := jwt.NewTokenManager(ks, s.appConf.Host, s.appConf.JwtAudience)
 
-	tm := jwt.NewTokenManager(s.appConf.JwtSecret, s.appConf.Host)
+	akr := pg.NewAPIKeyRepository(
+		operation.NewCreateAPIKey(pgConn),
+		operation.NewGetAPIKeysByUserID(pgConn),
+		operation.NewGetAPIKeyByPrefix(pgConn),
+		operation.NewUpdateAPIKeyLastUsed(pgConn),
+		operation.NewUpdateRevokeAPIKey(pgConn),
+	)
 
 	dth := handler.NewDecodeTokenHandler(tm)
+	aakh := handler.NewAuthenticateAPIKeyHandler(akr, time.Now)
 	cnh := handler.NewCreateNewsletterHandler(gnbpi)
 	gnbuih := handler.NewGetNewslettersByUserIDHandler(gnbpi)
 	gnbpih := handler.NewGetNewsletterByPublicIDHandler(gnbpi)
+	unh := handler.NewUpdateNewsletterHandler(mr, gnbpi, gnbpi)
+	rnh := handler.NewRemoveNewsletterHandler(mr, nrr)
 
-	s.am = middleware.NewAuthMiddleware(dth, s.lg)
+	s.am = middleware.NewAuthMiddleware(dth, aakh, s.lg)
 
-	s.c = controller.NewNewsletterController(s.lg, cnh, gnbuih, gnbpih)
+	s.c = controller.NewNewsletterController(s.lg, cnh, gnbuih, gnbpih, unh, rnh)
 	s.userIDs = make([]string, 0, 10)
 	s.newsletterIDs = make([]string, 0, 10)
+	s.newsletterPublicIDs = make([]string, 0, 10)
+	s.subscriberEmails = make([]string, 0, 10)
 }
 
 func (s *NewsletterTestSuite) Test_CreateNewsletter_Success() {
@@ -134,7 +165,7 @@ func (s *NewsletterTestSuite) Test_CreateNewsletter_Success() {
 	}
 	s.userIDs = append(s.userIDs, userID)
 
-	token, err := helper.GenerateJWT(userID, s.appConf.JwtSecret, 5*time.Minute)
+	token, err := helper.GenerateJWT(userID, s.appConf, 5*time.Minute)
 	if err != nil {
 		s.T().Fatal(err)
 	}
@@ -177,6 +208,80 @@ func (s *NewsletterTestSuite) Test_CreateNewsletter_Success() {
 	s.True(newsletterRow[0].CreatedAt.After(beforeCreate) && newsletterRow[0].CreatedAt.Before(afterCreate), "invalid creation time")
 }
 
+func (s *NewsletterTestSuite) Test_CreateNewsletter_APIKeyMissingScope() {
+	const (
+		email    = "test9@test.com"
+		password = "P@$$w0rD"
+		uri      = "/api/v1/newsletters"
+	)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+
+	jsonBody, err := json.Marshal(&newsletterRequest{Name: "api key newsletter"})
+	if err != nil {
+		s.T().Fatalf("error marshalling body: %s", err.Error())
+	}
+
+	r, err := http.NewRequest(http.MethodPost, uri, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		s.T().Fatalf("error creating request: %s", err.Error())
+	}
+
+	userID := uuid.New().String()
+	hash, err := helper.Encrypt(password)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if err := helper.CreateUser(userID, email, hash, s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
+	s.userIDs = append(s.userIDs, userID)
+
+	prefix, key, err := apikey.Generate()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	if err := helper.CreateAPIKey(
+		uuid.New().String(),
+		userID,
+		prefix,
+		apikey.Hash(key),
+		[]string{string(domain.ScopeNewslettersRead)},
+		s.pgConn,
+	); err != nil {
+		s.T().Fatal(err)
+	}
+
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", fmt.Sprintf("ApiKey %s", key))
+
+	ctx, engine := gin.CreateTestContext(w)
+
+	ctx.Request = r
+
+	engine.Handle(
+		http.MethodPost,
+		uri,
+		s.am.HandleScoped(domain.ScopeNewslettersWrite),
+		middleware.LoggingMiddleware(s.lg, []string{}),
+		s.c.Create,
+	)
+	engine.HandleContext(ctx)
+
+	res := w.Result()
+
+	if res.StatusCode != http.StatusForbidden {
+		s.T().Fatalf("invalid status code: %d", res.StatusCode)
+	}
+
+	newsletterRow, err := helper.GetNewslettersByUserID(userID, s.pgConn)
+	if err != nil {
+		s.T().Fatal(err.Error())
+	}
+	s.Len(newsletterRow, 0, "newsletter created without scope")
+}
+
 func (s *NewsletterTestSuite) Test_GetNewsletterByUserID_Success() {
 	const (
 		email                 = "test4@test.com"
@@ -228,7 +333,7 @@ func (s *NewsletterTestSuite) Test_GetNewsletterByUserID_Success() {
 
 	s.newsletterIDs = append(s.newsletterIDs, newsletterID)
 
-	token, err := helper.GenerateJWT(userID, s.appConf.JwtSecret, 5*time.Minute)
+	token, err := helper.GenerateJWT(userID, s.appConf, 5*time.Minute)
 	if err != nil {
 		s.T().Fatalf("generating jwt error %s", err.Error())
 	}
@@ -335,7 +440,7 @@ func (s *NewsletterTestSuite) Test_GetNewsletterByPublicID_Success() {
 		s.T().Fatalf("error creating request: %s", err.Error())
 	}
 
-	token, err := helper.GenerateJWT(userID, s.appConf.JwtSecret, 5*time.Minute)
+	token, err := helper.GenerateJWT(userID, s.appConf, 5*time.Minute)
 	if err != nil {
 		s.T().Fatalf("generating jwt error %s", err.Error())
 	}
@@ -384,7 +489,158 @@ func (s *NewsletterTestSuite) Test_GetNewsletterByPublicID_Success() {
 	s.True(body.CreatedAt.After(beforeCreate) && body.CreatedAt.Before(afterCreate), "invalid creation time")
 }
 
+func (s *NewsletterTestSuite) Test_ArchiveNewsletter_HidesNewsletter() {
+	const (
+		email          = "test6@test.com"
+		password       = "P@$$w0rD"
+		uri            = "/api/v1/newsletters"
+		newsletterName = "archived newsletter"
+	)
+
+	// fixtures
+	userID := uuid.New().String()
+	hash, err := helper.Encrypt(password)
+	if err != nil {
+		s.T().Fatalf("encrypt error %s", err.Error())
+	}
+	if err := helper.CreateUser(userID, email, hash, s.pgConn); err != nil {
+		s.T().Fatalf("create user error %s", err.Error())
+	}
+	s.userIDs = append(s.userIDs, userID)
+
+	newsletterID := uuid.New().String()
+	newsletterPublicID := uuid.New().String()
+	if err := helper.CreateNewsletter(newsletterID, newsletterPublicID, userID, newsletterName, "", s.pgConn); err != nil {
+		s.T().Fatalf("creating newsletter error %s", err.Error())
+	}
+	s.newsletterIDs = append(s.newsletterIDs, newsletterID)
+
+	token, err := helper.GenerateJWT(userID, s.appConf, 5*time.Minute)
+	if err != nil {
+		s.T().Fatalf("generating jwt error %s", err.Error())
+	}
+
+	// setup
+	gin.SetMode(gin.TestMode)
+	send := func(method string, handle gin.HandlerFunc) int {
+		w := httptest.NewRecorder()
+		r, err := http.NewRequest(method, fmt.Sprintf("%s/%s", uri, newsletterPublicID), nil)
+		if err != nil {
+			s.T().Fatalf("error creating request: %s", err.Error())
+		}
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		ctx, engine := gin.CreateTestContext(w)
+		ctx.Request = r
+		engine.Handle(
+			method,
+			fmt.Sprintf("%s/%s", uri, ":public_id"),
+			s.am.Handle,
+			middleware.LoggingMiddleware(s.lg, []string{}),
+			handle,
+		)
+		engine.HandleContext(ctx)
+
+		return w.Result().StatusCode
+	}
+
+	s.Equal(http.StatusNoContent, send(http.MethodDelete, s.c.Remove), "archive failed")
+	s.Equal(http.StatusNotFound, send(http.MethodGet, s.c.GetNewsletterByPublicID), "archived newsletter is visible")
+	s.Equal(http.StatusNotFound, send(http.MethodDelete, s.c.Remove), "newsletter archived twice")
+}
+
+func (s *NewsletterTestSuite) Test_DeleteNewsletter_NotifiesActiveSubscribers() {
+	const (
+		email          = "test7@test.com"
+		password       = "P@$$w0rD"
+		uri            = "/api/v1/newsletters"
+		newsletterName = "deleted newsletter"
+		active         = "active-subscriber@test.com"
+		disabled       = "disabled-subscriber@test.com"
+	)
+
+	// fixtures
+	userID := uuid.New().String()
+	hash, err := helper.Encrypt(password)
+	if err != nil {
+		s.T().Fatalf("encrypt error %s", err.Error())
+	}
+	if err := helper.CreateUser(userID, email, hash, s.pgConn); err != nil {
+		s.T().Fatalf("create user error %s", err.Error())
+	}
+	s.userIDs = append(s.userIDs, userID)
+
+	newsletterID := uuid.New().String()
+	newsletterPublicID := uuid.New().String()
+	if err := helper.CreateNewsletter(newsletterID, newsletterPublicID, userID, newsletterName, "", s.pgConn); err != nil {
+		s.T().Fatalf("creating newsletter error %s", err.Error())
+	}
+	s.newsletterIDs = append(s.newsletterIDs, newsletterID)
+	s.newsletterPublicIDs = append(s.newsletterPublicIDs, newsletterPublicID)
+
+	if err := helper.CreateSubscription(uuid.New().String(), newsletterID, active, "cs", false, s.pgConn); err != nil {
+		s.T().Fatalf("creating subscription error %s", err.Error())
+	}
+	if err := helper.CreateSubscription(uuid.New().String(), newsletterID, disabled, "en", true, s.pgConn); err != nil {
+		s.T().Fatalf("creating subscription error %s", err.Error())
+	}
+	s.subscriberEmails = append(s.subscriberEmails, active, disabled)
+
+	token, err := helper.GenerateJWT(userID, s.appConf, 5*time.Minute)
+	if err != nil {
+		s.T().Fatalf("generating jwt error %s", err.Error())
+	}
+
+	// setup
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	path := fmt.Sprintf("%s/%s?hard_delete=true&notify=true", uri, newsletterPublicID)
+	r, err := http.NewRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		s.T().Fatalf("error creating request: %s", err.Error())
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	ctx, engine := gin.CreateTestContext(w)
+	ctx.Request = r
+	engine.Handle(
+		http.MethodDelete,
+		fmt.Sprintf("%s/%s", uri, ":public_id"),
+		s.am.Handle,
+		middleware.LoggingMiddleware(s.lg, []string{}),
+		s.c.Remove,
+	)
+	engine.HandleContext(ctx)
+
+	s.Require().Equal(http.StatusNoContent, w.Result().StatusCode, "delete failed")
+
+	params, err := helper.GetEmailJobParamsByEmail("NEWSLETTER_REMOVED", active, s.pgConn)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.Require().Len(params, 1, "active subscriber not notified")
+	s.JSONEq(
+		fmt.Sprintf(`{"email": %q, "newsletter_name": %q, "sender": {}, "locale": "cs"}`, active, newsletterName),
+		string(params[0]),
+		"notification params mismatch",
+	)
+
+	params, err = helper.GetEmailJobParamsByEmail("NEWSLETTER_REMOVED", disabled, s.pgConn)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.Empty(params, "disabled subscriber notified")
+}
+
 func (s *NewsletterTestSuite) TearDownSuite() {
+	if err := helper.RemoveEmailJobsByEmail(s.subscriberEmails, s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
+	if err := helper.RemoveOutboxEventsByNewsletterPublicID(s.newsletterPublicIDs, s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
 	if err := helper.RemoveNewsletterByID(s.newsletterIDs, s.pgConn); err != nil {
 		s.T().Fatal(err)
 	}
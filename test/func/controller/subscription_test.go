@@ -2,7 +2,6 @@ package controller_test
 
 import (
 	"bytes"
-	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -14,17 +13,17 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/javor454/newsletter-assignment/app/config"
-	"github.com/javor454/newsletter-assignment/app/firebase"
 	"github.com/javor454/newsletter-assignment/app/logger"
 	pgapp "github.com/javor454/newsletter-assignment/app/pg"
 	"github.com/javor454/newsletter-assignment/app/sendgrid"
 	"github.com/javor454/newsletter-assignment/internal/application/handler"
-	firebaseinfra "github.com/javor454/newsletter-assignment/internal/infrastructure/firebase"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/cache"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/jwt"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
 	sendgridinfra "github.com/javor454/newsletter-assignment/internal/infrastructure/sendgrid"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/service"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/tracking"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/controller"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
 	"github.com/javor454/newsletter-assignment/test/helper"
@@ -48,9 +47,7 @@ type subscribeRequest struct {
 }
 
 func (s *SubscriptionTestSuite) SetupSuite() {
-	ctx := context.Background()
 	s.appConf = helper.NewAppConfig()
-	fbConfig := helper.NewFirebaseConfig()
 	location, err := time.LoadLocation(s.appConf.Timezone)
 	if err != nil {
 		panic("failed to load timezone")
@@ -66,10 +63,6 @@ func (s *SubscriptionTestSuite) SetupSuite() {
 	if err := pgapp.MigrationsUp(s.lg, pgConfig, pgConn); err != nil {
 		s.lg.WithError(err).Fatal("pg migrations failed")
 	}
-	fbClient, err := firebase.NewClient(s.lg, ctx, fbConfig)
-	if err != nil {
-		panic("failed to connect: " + err.Error())
-	}
 	mailClient := sendgrid.NewClient(s.lg, s.appConf)
 
 	cn := operation.NewCreateNewsletter(pgConn)
@@ -77,25 +70,61 @@ func (s *SubscriptionTestSuite) SetupSuite() {
 	gns := operation.NewGetNewslettersBySubscriptionEmail(pgConn)
 	gnbp := operation.NewGetNewslettersByPublicID(pgConn)
 	gnibp := operation.NewGetNewsletterIDByPublicID(pgConn)
-	gu := operation.NewGetUnsentSubscribedEmailJobsOperation(pgConn)
+	gu := operation.NewGetUnsentEmailJobs(pgConn)
 	uo := operation.NewUpdateUnsentEmailJobs(pgConn)
-	uds := operation.NewUpdateDisableSubscription(pgConn)
 
-	sc := firebaseinfra.NewSubscriptionCacheManager(fbClient)
-	tm := jwt.NewTokenManager(s.appConf.JwtSecret, s.appConf.Host)
+	ks, err := jwt.NewKeySet(s.appConf.JwtSecret, s.appConf.JwtKeysDir, s.appConf.JwtActiveKeyID)
+	if err != nil {
+		s.lg.WithError(err).Fatal("jwt key set init failed")
+	}
+	tm := jwt.NewTokenManager(ks, s.appConf.Host, s.appConf.JwtAudience)
+
+	nr := pg.NewNewsletterRepository(
+		cn,
+		gn,
+		gns,
+		gnbp,
+		operation.NewUpdateNewsletter(pgConn),
+		operation.NewGetNewslettersByPublicIDs(pgConn),
+	)
+	ms := sendgridinfra.NewMailService(s.lg, s.appConf, mailClient, nil)
+	sr := service.NewSubscriberRepository(
+		s.lg,
+		s.pgConn,
+		gnibp,
+		gu,
+		ms,
+		uo,
+		s.appConf,
+		operation.NewGetNewsletterSender(pgConn),
+		operation.NewGetNewsletterTemplate(pgConn),
+		operation.NewGetSubscriptionLocale(pgConn),
+		operation.NewGetIssue(pgConn),
+		operation.NewGetNewsletterTracking(pgConn),
+		tracking.NewTracker(s.appConf.Host, s.appConf.TrackingSecret, time.Now),
+	)
 
-	nr := pg.NewNewsletterRepository(cn, gn, gns, gnbp)
-	ms := sendgridinfra.NewMailService(s.lg, s.appConf, mailClient)
-	sr := service.NewSubscriberRepository(s.lg, s.pgConn, gnibp, gu, ms, uo, s.appConf, uds, sc)
+	akr := pg.NewAPIKeyRepository(
+		operation.NewCreateAPIKey(pgConn),
+		operation.NewGetAPIKeysByUserID(pgConn),
+		operation.NewGetAPIKeyByPrefix(pgConn),
+		operation.NewUpdateAPIKeyLastUsed(pgConn),
+		operation.NewUpdateRevokeAPIKey(pgConn),
+	)
 
 	dth := handler.NewDecodeTokenHandler(tm)
-	unh := handler.NewUnsubscribeNewsletterHandler(sr, tm, sc)
+	aakh := handler.NewAuthenticateAPIKeyHandler(akr, time.Now)
+	unh := handler.NewUnsubscribeNewsletterHandler(sr, tm)
 	stnh := handler.NewSubscribeToNewsletterHandler(tm, sr)
-	gsnbeh := handler.NewGetNewslettersBySubscriptionEmailHandler(nr)
+	gsnbeh := handler.NewGetNewslettersBySubscriptionEmailHandler(s.lg, nr, cache.NewNoopSubscriptionCache(), nr)
+	surh := handler.NewSaveUnsubscribeReasonHandler(tm, pg.NewUnsubscribeReasonRepository(
+		operation.NewUpdateSubscriptionReason(pgConn),
+		operation.NewGetUnsubscribeComments(pgConn),
+	))
 
-	s.am = middleware.NewAuthMiddleware(dth, s.lg)
+	s.am = middleware.NewAuthMiddleware(dth, aakh, s.lg)
 
-	s.c = controller.NewSubscriptionController(s.lg, gsnbeh, stnh, unh)
+	s.c = controller.NewSubscriptionController(s.lg, gsnbeh, stnh, unh, surh)
 	s.userIDs = make([]string, 0, 2)
 	s.newsletterIDs = make([]string, 0, 10)
 	s.subscriptionIDs = make([]string, 0, 10)
@@ -254,7 +283,7 @@ func (s *SubscriptionTestSuite) Test_SubscribeToNewsletter_Success() {
 //
 // 	s.newsletterIDs = append(s.newsletterIDs, newsletterID)
 //
-// 	token, err := helper.GenerateJWT(userID, s.appConf.JwtSecret, 5*time.Minute)
+// 	token, err := helper.GenerateJWT(userID, s.appConf, 5*time.Minute)
 // 	if err != nil {
 // 		s.T().Fatalf("generating jwt error %s", err.Error())
 // 	}
@@ -19,8 +19,12 @@ import (
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/jwt"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg"
 	"github.com/javor454/newsletter-assignment/internal/infrastructure/pg/operation"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/secret"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/service"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/totp"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/controller"
 	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
 	"github.com/javor454/newsletter-assignment/test/helper"
 	"github.com/stretchr/testify/suite"
 )
@@ -59,14 +63,39 @@ func (s *UserTestSuite) SetupSuite() {
 
 	cuo := operation.NewCreateUser(pgConn)
 	gube := operation.NewGetUserByEmail(pgConn)
-
-	ur := pg.NewUserRepository(cuo, gube)
-	tm := jwt.NewTokenManager(s.appConf.JwtSecret, s.appConf.Host)
+	gubi := operation.NewGetUserByID(pgConn)
+	uuts := operation.NewUpdateUserTOTPSecret(pgConn)
+	guts := operation.NewGetUserTOTPSecret(pgConn)
+	uurc := operation.NewUpdateUseRecoveryCode(pgConn)
+	uutst := operation.NewUpdateUseTOTPStep(pgConn)
+	uutc := operation.NewUpdateUserTOTPChallenge(pgConn)
+	gutl := operation.NewGetUserTOTPLogin(pgConn)
+	uftl := operation.NewUpdateFailTOTPLogin(pgConn)
+	ufitl := operation.NewUpdateFinishTOTPLogin(pgConn)
+	uup := operation.NewUpdateUserPassword(pgConn)
+	ucec := operation.NewUpdateConfirmEmailChange(pgConn)
+
+	ur := pg.NewUserRepository(pgConn, cuo, gube, gubi, uuts, guts, uurc, uutst, uutc, gutl, uftl, ufitl, uup)
+	acr := service.NewAccountRepository(pgConn, ucec)
+	ks, err := jwt.NewKeySet(s.appConf.JwtSecret, s.appConf.JwtKeysDir, s.appConf.JwtActiveKeyID)
+	if err != nil {
+		s.lg.WithError(err).Fatal("jwt key set init failed")
+	}
+	tm := jwt.NewTokenManager(ks, s.appConf.Host, s.appConf.JwtAudience)
+	tg := totp.NewGenerator(s.appConf.AppName, time.Now)
 
 	ruh := handler.NewRegisterUserHandler(ur, tm)
-	luh := handler.NewLoginUserHandler(ur, tm)
+	luh := handler.NewLoginUserHandler(ur, tm, tm, ur)
+	vtlh := handler.NewVerifyTOTPLoginHandler(tm, ur, tg, tm)
+	eth := handler.NewEnrollTOTPHandler(ur, ur, tg)
+	cth := handler.NewConfirmTOTPHandler(ur, tg, totp.GenerateRecoveryCodes)
+
+	cph := handler.NewChangePasswordHandler(ur, ur, ur)
+	rech := handler.NewRequestEmailChangeHandler(ur, ur, acr, secret.GenerateToken)
+	cech := handler.NewConfirmEmailChangeHandler(acr)
+	dah := handler.NewDeleteAccountHandler(ur, ur, acr)
 
-	s.c = controller.NewUserController(s.lg, ruh, luh)
+	s.c = controller.NewUserController(s.lg, ruh, luh, vtlh, eth, cth, cph, rech, cech, dah)
 	s.userIDs = make([]string, 0, 10)
 }
 
@@ -192,6 +221,68 @@ func (s *UserTestSuite) Test_LoginUser_Success() {
 	s.Equal(http.StatusCreated, res.StatusCode, "invalid status code")
 }
 
+func (s *UserTestSuite) Test_ChangePassword_Success() {
+	const (
+		email       = "test3@test.com"
+		password    = "P@$$w0rD"
+		newPassword = "N3w-P@$$w0rD"
+		uri         = "/api/v1/users/password"
+	)
+
+	gin.SetMode(gin.TestMode)
+
+	hash, err := helper.Encrypt(password)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	userID := uuid.New().String()
+	if err := helper.CreateUser(userID, email, hash, s.pgConn); err != nil {
+		s.T().Fatal(err)
+	}
+	s.userIDs = append(s.userIDs, userID)
+
+	for _, tc := range []struct {
+		current  string
+		expected int
+	}{
+		{current: "wrong", expected: http.StatusUnauthorized},
+		{current: password, expected: http.StatusNoContent},
+	} {
+		w := httptest.NewRecorder()
+		jsonBody, err := json.Marshal(&request.ChangePasswordRequest{CurrentPassword: tc.current, NewPassword: newPassword})
+		if err != nil {
+			s.T().Fatalf("error marshalling body: %s", err.Error())
+		}
+
+		r, err := http.NewRequest(http.MethodPut, uri, bytes.NewBuffer(jsonBody))
+		if err != nil {
+			s.T().Fatalf("error creating request: %s", err.Error())
+		}
+		r.Header.Set("Content-Type", "application/json")
+
+		ctx, engine := gin.CreateTestContext(w)
+		ctx.Request = r
+
+		engine.Handle(
+			http.MethodPut,
+			uri,
+			middleware.LoggingMiddleware(s.lg, []string{}),
+			func(c *gin.Context) { c.Set(middleware.UserIDKey, userID) },
+			s.c.ChangePassword,
+		)
+		engine.HandleContext(ctx)
+
+		s.Equal(tc.expected, w.Result().StatusCode, "invalid status code")
+	}
+
+	userRow, err := helper.GetUserByEmail(email, s.pgConn)
+	if err != nil {
+		s.T().Fatal(err.Error())
+	}
+	s.True(helper.IsEqual(userRow.PasswordHash, newPassword), "password not changed")
+}
+
 func (s *UserTestSuite) TearDownSuite() {
 	if err := helper.RemoveUsersByUserID(s.userIDs, s.pgConn); err != nil {
 		s.T().Fatal(err)
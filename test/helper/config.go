@@ -3,6 +3,7 @@ package helper
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/javor454/newsletter-assignment/app/config"
 	"github.com/sirupsen/logrus"
@@ -23,6 +24,7 @@ func NewAppConfig() *config.AppConfig {
 		HttpPort:            FuncUserHttpPort,
 		LogLevel:            logrus.DebugLevel,
 		JwtSecret:           "0zinGG2-iDxTjLCmd4oqw29tBlhbzNITfUO-pIdyQcc=",
+		JwtAudience:         "newsletter-api",
 		CorsAllowedOrigins:  []string{"http://localhost"},
 		CorsAllowedHeaders:  []string{"authorization", "content-type"},
 		Timezone:            "Europe/Prague",
@@ -30,6 +32,13 @@ func NewAppConfig() *config.AppConfig {
 		SendGridTemplateDir: sendGridTemplateDir,
 		SendMail:            false,
 		Host:                "http://localhost",
+		AppName:             "newsletter-assignment",
+		SenderName:          "Jiri",
+		SenderAddress:       "javornicky.jiri@gmail.com",
+		SenderPostalAddress: "Vodickova 1, 110 00 Prague, Czech Republic",
+		SpfInclude:          "sendgrid.net",
+		TrackingSecret:      "tracking-secret",
+		RemovalTimeout:      5 * time.Second,
 	}
 }
 
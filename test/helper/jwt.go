@@ -5,19 +5,21 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/javor454/newsletter-assignment/app/config"
 )
 
-func GenerateJWT(subject, secret string, expiration time.Duration) (string, error) {
+func GenerateJWT(subject string, appConf *config.AppConfig, expiration time.Duration) (string, error) {
 	token := jwt.New(jwt.SigningMethodHS256)
 	claims := token.Claims.(jwt.MapClaims)
 	claims["sub"] = subject
-	claims["iss"] = "test"
+	claims["iss"] = appConf.Host
+	claims["aud"] = appConf.JwtAudience
 	claims["iat"] = time.Now().Unix()
 	if expiration > 0 {
 		claims["exp"] = time.Now().Add(expiration).Unix()
 	}
 
-	tokenStr, err := token.SignedString([]byte(secret))
+	tokenStr, err := token.SignedString([]byte(appConf.JwtSecret))
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
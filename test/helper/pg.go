@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
@@ -55,6 +56,23 @@ func CreateUser(id, email, passwordHash string, pgConn *sql.DB) error {
 	return nil
 }
 
+func CreateAPIKey(id, userID, prefix, keyHash string, scopes []string, pgConn *sql.DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const query = `
+		INSERT INTO api_keys(id, user_id, name, prefix, key_hash, scopes)
+		VALUES ($1, $2, 'test', $3, $4, $5);
+	`
+
+	_, err := pgConn.ExecContext(ctx, query, id, userID, prefix, keyHash, pq.Array(scopes))
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
 func RemoveUsersByUserID(ids []string, pgConn *sql.DB) error {
 	if len(ids) == 0 {
 		return nil
@@ -120,11 +138,16 @@ func CreateNewsletter(newsletterID, publicID, userID, name, description string,
 	defer cancel()
 
 	const query = `
-		INSERT INTO newsletters(id, public_id, user_id, name, description)
-		VALUES ($1, $2, $3, $4, $5);
+		WITH newsletter AS (
+			INSERT INTO newsletters(id, public_id, user_id, name, description)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, user_id
+		)
+		INSERT INTO newsletter_members(id, newsletter_id, user_id, role)
+		SELECT $6, id, user_id, 'owner' FROM newsletter;
 	`
 
-	_, err := pgConn.ExecContext(ctx, query, newsletterID, publicID, userID, name, description)
+	_, err := pgConn.ExecContext(ctx, query, newsletterID, publicID, userID, name, description, uuid.New().String())
 	if err != nil {
 		return fmt.Errorf("failed to create newsletter: %w", err)
 	}
@@ -164,6 +187,24 @@ func RemoveSubscriptionsByID(ids []string, pgConn *sql.DB) error {
 	return nil
 }
 
+// CreateSubscription subscribes email to newsletter, disabled subscription is created already unsubscribed.
+func CreateSubscription(id, newsletterID, email, locale string, disabled bool, pgConn *sql.DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const query = `
+		INSERT INTO subscriptions(id, subscriber_email, newsletter_id, token, locale, disabled_at)
+		VALUES ($1, $2, $3, $4, $5, CASE WHEN $6 THEN CURRENT_TIMESTAMP END);
+	`
+
+	_, err := pgConn.ExecContext(ctx, query, id, email, newsletterID, uuid.New().String(), locale, disabled)
+	if err != nil {
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	return nil
+}
+
 type SubscriptionRow struct {
 	ID              string     `json:"id"`
 	SubscriberEmail string     `json:"subscriber_email"`
@@ -210,3 +251,66 @@ func GetSubscriptionByNewsletterID(newsletterID string, pgConn *sql.DB) ([]*Subs
 
 	return subscriptions, nil
 }
+
+// RemoveOutboxEventsByNewsletterPublicID removes events of newsletters, outbox is not bound to them by foreign key.
+func RemoveOutboxEventsByNewsletterPublicID(publicIDs []string, pgConn *sql.DB) error {
+	if len(publicIDs) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const query = "DELETE FROM outbox WHERE newsletter_public_id = ANY($1);"
+	_, err := pgConn.ExecContext(ctx, query, pq.Array(publicIDs))
+	if err != nil {
+		return fmt.Errorf("failed to remove outbox events: %w", err)
+	}
+
+	return nil
+}
+
+// GetEmailJobParamsByEmail returns params of jobs of given type sent to email.
+func GetEmailJobParamsByEmail(messageType, email string, pgConn *sql.DB) ([][]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const query = "SELECT params FROM email_jobs WHERE message_type = $1 AND params->>'email' = $2;"
+
+	rows, err := pgConn.QueryContext(ctx, query, messageType, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email jobs: %w", err)
+	}
+
+	params := make([][]byte, 0, 1)
+	for rows.Next() {
+		var p []byte
+		if err := rows.Scan(&p); err != nil {
+			return nil, fmt.Errorf("failed to scan email jobs: %w", err)
+		}
+
+		params = append(params, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("get email jobs by email operation failed: %w", err)
+	}
+
+	return params, nil
+}
+
+// RemoveEmailJobsByEmail removes jobs sent to emails, jobs not bound to newsletter are not removed with it.
+func RemoveEmailJobsByEmail(emails []string, pgConn *sql.DB) error {
+	if len(emails) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const query = "DELETE FROM email_jobs WHERE params->>'email' = ANY($1);"
+	_, err := pgConn.ExecContext(ctx, query, pq.Array(emails))
+	if err != nil {
+		return fmt.Errorf("failed to remove email jobs: %w", err)
+	}
+
+	return nil
+}
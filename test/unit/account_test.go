@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAccountRepository struct {
+	user     *domain.User
+	password string
+	change   *domain.EmailChange
+	deleted  bool
+}
+
+func newFakeAccountRepository(email, password string) *fakeAccountRepository {
+	e, _ := domain.NewEmail(email)
+
+	return &fakeAccountRepository{
+		user:     domain.CreateUserFromExisting(domain.NewID(), e, nil, false),
+		password: password,
+	}
+}
+
+func (r *fakeAccountRepository) GetByID(_ context.Context, userID *domain.ID) (*domain.User, error) {
+	if r.deleted || userID.String() != r.user.ID().String() {
+		return nil, application.UserNotFoundError
+	}
+
+	return r.user, nil
+}
+
+func (r *fakeAccountRepository) GetByEmailAndPassword(
+	_ context.Context,
+	email *domain.Email,
+	pass *domain.Password,
+) (*domain.User, error) {
+	if email.String() != r.user.Email().String() {
+		return nil, application.UserNotFoundError
+	}
+	if pass.String() != r.password {
+		return nil, application.InvalidPasswordError
+	}
+
+	return r.user, nil
+}
+
+func (r *fakeAccountRepository) UpdatePassword(_ context.Context, _ *domain.ID, password *domain.Password) error {
+	r.password = password.String()
+
+	return nil
+}
+
+func (r *fakeAccountRepository) RequestEmailChange(_ context.Context, change *domain.EmailChange, _ string) error {
+	r.change = change
+
+	return nil
+}
+
+func (r *fakeAccountRepository) Delete(_ context.Context, _ *domain.ID) error {
+	r.deleted = true
+
+	return nil
+}
+
+func Test_ChangePassword_RequiresCurrentPassword(t *testing.T) {
+	repo := newFakeAccountRepository("owner@test.com", "old")
+	h := handler.NewChangePasswordHandler(repo, repo, repo)
+
+	err := h.Handle(context.Background(), repo.user.ID().String(), "wrong", "new")
+	assert.ErrorIs(t, err, application.InvalidPasswordError)
+	assert.Equal(t, "old", repo.password)
+
+	err = h.Handle(context.Background(), repo.user.ID().String(), "old", "new")
+	assert.Nil(t, err)
+	assert.Equal(t, "new", repo.password)
+}
+
+func Test_RequestEmailChange(t *testing.T) {
+	repo := newFakeAccountRepository("owner@test.com", "secret")
+	h := handler.NewRequestEmailChangeHandler(repo, repo, repo, func() (string, error) { return "token", nil })
+	userID := repo.user.ID().String()
+
+	assert.ErrorIs(t, h.Handle(context.Background(), userID, "invalid", "secret"), application.InvalidEmailError)
+	assert.ErrorIs(t, h.Handle(context.Background(), userID, "new@test.com", "wrong"), application.InvalidPasswordError)
+	assert.ErrorIs(t, h.Handle(context.Background(), userID, "owner@test.com", "secret"), application.EmailTakenError)
+	assert.Nil(t, repo.change)
+
+	assert.Nil(t, h.Handle(context.Background(), userID, "new@test.com", "secret"))
+	assert.Equal(t, "new@test.com", repo.change.Email().String())
+	assert.Equal(t, userID, repo.change.UserID().String())
+	assert.Equal(t, "owner@test.com", repo.user.Email().String(), "email changes only after verification")
+}
+
+func Test_DeleteAccount_RequiresPassword(t *testing.T) {
+	repo := newFakeAccountRepository("owner@test.com", "secret")
+	h := handler.NewDeleteAccountHandler(repo, repo, repo)
+	userID := repo.user.ID().String()
+
+	assert.ErrorIs(t, h.Handle(context.Background(), userID, "wrong"), application.InvalidPasswordError)
+	assert.False(t, repo.deleted)
+
+	assert.Nil(t, h.Handle(context.Background(), userID, "secret"))
+	assert.True(t, repo.deleted)
+}
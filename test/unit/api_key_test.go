@@ -0,0 +1,154 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/app/logger"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/apikey"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/middleware"
+	"github.com/javor454/newsletter-assignment/test/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_APIKey_Format(t *testing.T) {
+	prefix, key, err := apikey.Generate()
+	assert.Nil(t, err)
+	assert.Regexp(t, `^nl_[a-z2-7]{8}_[a-z2-7]{32}$`, key)
+
+	parsed, ok := apikey.Parse(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, parsed)
+
+	assert.True(t, apikey.Matches(key, apikey.Hash(key)))
+	assert.False(t, apikey.Matches(key+"x", apikey.Hash(key)))
+
+	for _, invalid := range []string{"", "nl_", "nl__secret", "gh_prefix_secret", "nl_prefix_secret_extra"} {
+		_, ok := apikey.Parse(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func Test_APIKey_Scopes(t *testing.T) {
+	scope, err := domain.NewScope("newsletters:write")
+	assert.Nil(t, err)
+	assert.Equal(t, domain.ScopeNewslettersWrite, scope)
+
+	_, err = domain.NewScope("admin")
+	assert.ErrorIs(t, err, application.InvalidScopeError)
+}
+
+type fakeAPIKeyRepository struct {
+	keys map[string]*domain.APIKey
+	used []string
+}
+
+func (r *fakeAPIKeyRepository) Create(_ context.Context, apiKey *domain.APIKey, key string) error {
+	r.keys[key] = apiKey
+
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) GetByKey(_ context.Context, key string) (*domain.APIKey, error) {
+	k, ok := r.keys[key]
+	if !ok {
+		return nil, application.InvalidAPIKeyError
+	}
+
+	return k, nil
+}
+
+func (r *fakeAPIKeyRepository) MarkUsed(_ context.Context, id *domain.ID) error {
+	r.used = append(r.used, id.String())
+
+	return nil
+}
+
+type fakeDecodeToken struct{}
+
+func (fakeDecodeToken) Handle(token string) (string, error) {
+	if token != "valid" {
+		return "", application.InvalidTokenError
+	}
+
+	return "user", nil
+}
+
+func Test_APIKey_CreateValidation(t *testing.T) {
+	repo := &fakeAPIKeyRepository{keys: make(map[string]*domain.APIKey)}
+	cakh := handler.NewCreateAPIKeyHandler(repo, apikey.Generate)
+	userID := domain.NewID().String()
+
+	_, err := cakh.Handle(context.Background(), userID, "ci", nil, nil)
+	assert.ErrorIs(t, err, application.InvalidScopeError)
+
+	_, err = cakh.Handle(context.Background(), userID, "ci", []string{"newsletters:delete"}, nil)
+	assert.ErrorIs(t, err, application.InvalidScopeError)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = cakh.Handle(context.Background(), userID, "ci", []string{"newsletters:read"}, &past)
+	assert.ErrorIs(t, err, application.InvalidExpirationError)
+
+	created, err := cakh.Handle(context.Background(), userID, "ci", []string{"newsletters:read"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, created.APIKey, repo.keys[created.Key])
+	assert.Equal(t, []domain.Scope{domain.ScopeNewslettersRead}, created.APIKey.Scopes())
+}
+
+func Test_APIKey_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now()
+	expiresAt := now.Add(-time.Minute)
+	userID := domain.NewID()
+	readKey := domain.NewAPIKey(userID, "read", "aaaaaaaa", []domain.Scope{domain.ScopeNewslettersRead}, nil)
+	expiredKey := domain.NewAPIKey(userID, "expired", "bbbbbbbb", []domain.Scope{domain.ScopeNewslettersRead}, &expiresAt)
+	repo := &fakeAPIKeyRepository{keys: map[string]*domain.APIKey{"read": readKey, "expired": expiredKey}}
+
+	am := middleware.NewAuthMiddleware(
+		fakeDecodeToken{},
+		handler.NewAuthenticateAPIKeyHandler(repo, func() time.Time { return now }),
+		logger.NewLogger(helper.NewAppConfig()),
+	)
+
+	engine := gin.New()
+	ok := func(c *gin.Context) { c.String(http.StatusOK, c.GetString(middleware.UserIDKey)) }
+	engine.GET("/read", am.HandleScoped(domain.ScopeNewslettersRead), ok)
+	engine.GET("/write", am.HandleScoped(domain.ScopeNewslettersWrite), ok)
+	engine.GET("/user", am.Handle, ok)
+
+	cases := []struct {
+		path          string
+		authorization string
+		status        int
+	}{
+		{"/read", "ApiKey read", http.StatusOK},
+		{"/read", "Bearer valid", http.StatusOK},
+		{"/write", "ApiKey read", http.StatusForbidden},
+		{"/write", "Bearer valid", http.StatusOK},
+		{"/user", "ApiKey read", http.StatusForbidden},
+		{"/user", "Bearer valid", http.StatusOK},
+		{"/read", "ApiKey expired", http.StatusUnauthorized},
+		{"/read", "ApiKey unknown", http.StatusUnauthorized},
+		{"/read", "Basic read", http.StatusUnauthorized},
+		{"/read", "", http.StatusUnauthorized},
+	}
+
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, c.path, nil)
+		r.Header.Set("Authorization", c.authorization)
+		engine.ServeHTTP(w, r)
+
+		assert.Equal(t, c.status, w.Code, "%s %s", c.path, c.authorization)
+	}
+
+	assert.Equal(t, []string{readKey.ID().String(), readKey.ID().String()}, repo.used, "last use recorded")
+}
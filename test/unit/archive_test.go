@@ -0,0 +1,214 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/controller"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeArchive struct {
+	newsletter *domain.Newsletter
+	issues     []*domain.Issue
+}
+
+func (f *fakeArchive) GetByPublicID(_ context.Context, publicID *domain.ID) (*domain.Newsletter, error) {
+	if publicID.String() != f.newsletter.PublicID().String() {
+		return nil, application.NewsletterNotFoundError
+	}
+
+	return f.newsletter, nil
+}
+
+func (f *fakeArchive) GetPublishedIssues(_ context.Context, _ *domain.ID, pageSize, pageNumber int) (*dto.IssuePage, error) {
+	var lastModified *time.Time
+	for _, i := range f.issues {
+		if lastModified == nil || i.UpdatedAt().After(*lastModified) {
+			updatedAt := i.UpdatedAt()
+			lastModified = &updatedAt
+		}
+	}
+
+	start := min((pageNumber-1)*pageSize, len(f.issues))
+	end := min(start+pageSize, len(f.issues))
+	totalPages := (len(f.issues) + pageSize - 1) / pageSize
+
+	return &dto.IssuePage{
+		Issues:       f.issues[start:end],
+		Pagination:   dto.NewPagination(pageNumber, pageSize, totalPages, len(f.issues)),
+		LastModified: lastModified,
+	}, nil
+}
+
+func (f *fakeArchive) GetPublishedIssue(_ context.Context, _ *domain.ID, slug *domain.Slug) (*domain.Issue, error) {
+	for _, i := range f.issues {
+		if i.Slug().String() == slug.String() {
+			return i, nil
+		}
+	}
+
+	return nil, application.IssueNotFoundError
+}
+
+func newTestArchive(t *testing.T) (*fakeArchive, *gin.Engine) {
+	description := "Weekly <news>"
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	archive := &fakeArchive{
+		newsletter: domain.CreateNewsletterFromExisting(domain.NewID(), domain.NewID(), "Tech & Co", &description, createdAt),
+	}
+	for n, title := range []string{"Second <issue>", "First issue"} {
+		slug, err := domain.NewSlug(domain.CreateSlugFromTitle(title).String())
+		assert.Nil(t, err)
+		publishedAt := createdAt.AddDate(0, 0, 7*(2-n))
+		archive.issues = append(archive.issues, domain.CreateIssueFromExisting(
+			domain.NewID(),
+			archive.newsletter.PublicID(),
+			slug,
+			title,
+			"**bold**",
+			"<p><strong>bold</strong></p>",
+			&publishedAt,
+			createdAt,
+			publishedAt,
+		))
+	}
+
+	c := controller.NewArchiveController(
+		newDiscardLogger(),
+		"http://localhost:8080",
+		handler.NewGetArchiveHandler(archive, archive),
+		handler.NewGetArchivedIssueHandler(archive, archive),
+	)
+	engine := gin.New()
+	engine.GET("api/v1/newsletters/:public_id/archive", c.GetArchive)
+	engine.GET("newsletters/:public_id", c.ArchivePage)
+	engine.GET("newsletters/:public_id/issues/:slug", c.IssuePage)
+	engine.GET("newsletters/:public_id/rss.xml", c.RSSFeed)
+	engine.GET("newsletters/:public_id/atom.xml", c.AtomFeed)
+
+	return archive, engine
+}
+
+func serveTestRequest(engine *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+	engine.ServeHTTP(w, r)
+
+	return w
+}
+
+func Test_ArchiveController_Pages(t *testing.T) {
+	archive, engine := newTestArchive(t)
+	archivePath := "/newsletters/" + archive.newsletter.PublicID().String()
+
+	w := serveTestRequest(engine, archivePath, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Mon, 15 Jan 2024 00:00:00 GMT", w.Header().Get("Last-Modified"))
+	assert.Contains(t, w.Body.String(), "<h1><a href=\"http://localhost:8080"+archivePath+"\">Tech &amp; Co</a></h1>")
+	assert.Contains(t, w.Body.String(), "<p>Weekly &lt;news&gt;</p>")
+	assert.Contains(t, w.Body.String(), "<a href=\"http://localhost:8080"+archivePath+"/issues/second-issue\">Second &lt;issue&gt;</a>")
+	assert.Contains(t, w.Body.String(), "15 January 2024")
+	assert.Less(t, strings.Index(w.Body.String(), "Second"), strings.Index(w.Body.String(), "First"), "newest first")
+
+	w = serveTestRequest(engine, archivePath+"/issues/first-issue", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<title>First issue</title>")
+	assert.Contains(t, w.Body.String(), "<p><strong>bold</strong></p>", "sanitized issue html is not escaped")
+
+	assert.Equal(t, http.StatusNotFound, serveTestRequest(engine, archivePath+"/issues/draft", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, serveTestRequest(engine, archivePath+"/issues/Not_Slug", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, serveTestRequest(engine, archivePath+"?page=0", nil).Code)
+	assert.Equal(t, http.StatusNotFound, serveTestRequest(engine, "/newsletters/"+domain.NewID().String(), nil).Code)
+}
+
+func Test_ArchiveController_ConditionalRequests(t *testing.T) {
+	archive, engine := newTestArchive(t)
+	path := "/newsletters/" + archive.newsletter.PublicID().String()
+
+	w := serveTestRequest(engine, path, nil)
+	etag := w.Header().Get("ETag")
+	lastModified := w.Header().Get("Last-Modified")
+	assert.NotEmpty(t, etag)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+	cases := map[string]struct {
+		headers map[string]string
+		status  int
+	}{
+		"matching etag":             {map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		"weak etag in list":         {map[string]string{"If-None-Match": `"other", W/` + etag}, http.StatusNotModified},
+		"stale etag":                {map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		"stale etag wins over date": {map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified}, http.StatusOK},
+		"not modified since":        {map[string]string{"If-Modified-Since": lastModified}, http.StatusNotModified},
+		"modified since":            {map[string]string{"If-Modified-Since": "Mon, 08 Jan 2024 00:00:00 GMT"}, http.StatusOK},
+		"invalid date":              {map[string]string{"If-Modified-Since": "yesterday"}, http.StatusOK},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := serveTestRequest(engine, path, tc.headers)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			if tc.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+
+	// edit of issue changes representation
+	archive.issues[1].Update("First issue, revised", "**bold**", "<p><strong>bold</strong></p>")
+	w = serveTestRequest(engine, path, map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
+
+func Test_ArchiveController_JSON(t *testing.T) {
+	archive, engine := newTestArchive(t)
+	path := "/api/v1/newsletters/" + archive.newsletter.PublicID().String() + "/archive?page_size=1&page_number=2"
+
+	w := serveTestRequest(engine, path, map[string]string{"Content-Type": "application/json"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+
+	var body struct {
+		Data []struct {
+			Slug string `json:"slug"`
+			URL  string `json:"url"`
+		} `json:"data"`
+		Pagination struct {
+			TotalItems  int  `json:"total_items"`
+			HasPrevious bool `json:"has_previous"`
+			HasNext     bool `json:"has_next"`
+		} `json:"pagination"`
+	}
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(t, body.Data, 1)
+	assert.Equal(t, "first-issue", body.Data[0].Slug)
+	assert.Equal(
+		t,
+		"http://localhost:8080/newsletters/"+archive.newsletter.PublicID().String()+"/issues/first-issue",
+		body.Data[0].URL,
+	)
+	assert.Equal(t, 2, body.Pagination.TotalItems)
+	assert.True(t, body.Pagination.HasPrevious)
+	assert.False(t, body.Pagination.HasNext)
+
+	w = serveTestRequest(engine, path, map[string]string{"Content-Type": "application/json", "If-None-Match": w.Header().Get("ETag")})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+}
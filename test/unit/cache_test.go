@@ -0,0 +1,200 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSubscribedNewslettersLoader struct {
+	subscribed map[string][]string
+	loads      int
+	// onLoad runs after subscriber was read, before result is returned
+	onLoad func()
+}
+
+func (f *fakeSubscribedNewslettersLoader) GetSubscribedNewslettersByEmail(_ context.Context, email string) ([]string, error) {
+	f.loads++
+	ids := slices.Clone(f.subscribed[email])
+	if f.onLoad != nil {
+		f.onLoad()
+	}
+
+	return ids, nil
+}
+
+func mustEmail(t *testing.T, value string) *domain.Email {
+	email, err := domain.NewEmail(value)
+	assert.Nil(t, err)
+
+	return email
+}
+
+func Test_MemorySubscriptionCache_ReadsThroughUntilExpired(t *testing.T) {
+	pubID := domain.NewID().String()
+	loader := &fakeSubscribedNewslettersLoader{subscribed: map[string][]string{"john@example.com": {pubID}}}
+	clock := &fakeClock{now: time.Now()}
+	c := cache.NewMemorySubscriptionCache(loader, time.Minute, 10, clock.Now)
+	email := mustEmail(t, "john@example.com")
+
+	for i := 0; i < 2; i++ {
+		ids, ok, err := c.GetSubscribedNewslettersByEmail(context.Background(), email)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []string{pubID}, ids)
+	}
+	assert.Equal(t, 1, loader.loads)
+
+	clock.now = clock.now.Add(time.Minute)
+	_, _, err := c.GetSubscribedNewslettersByEmail(context.Background(), email)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, loader.loads)
+}
+
+func Test_MemorySubscriptionCache_ChangesOnlyLoadedEntries(t *testing.T) {
+	kept, added := domain.NewID(), domain.NewID()
+	loader := &fakeSubscribedNewslettersLoader{subscribed: map[string][]string{"john@example.com": {kept.String()}}}
+	c := cache.NewMemorySubscriptionCache(loader, time.Minute, 10, time.Now)
+	john, jane := mustEmail(t, "john@example.com"), mustEmail(t, "jane@example.com")
+
+	_, _, err := c.GetSubscribedNewslettersByEmail(context.Background(), john)
+	assert.Nil(t, err)
+	assert.Nil(t, c.AddSubscribedNewsletter(context.Background(), john.String(), added.String()))
+	assert.Nil(t, c.AddSubscribedNewsletter(context.Background(), jane.String(), added.String()))
+	assert.Nil(t, c.RemoveSubscribedNewsletter(context.Background(), john, kept))
+
+	subscribed, err := c.GetSubscribedNewsletters(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{john.String(): {added.String()}}, subscribed)
+}
+
+func Test_MemorySubscriptionCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	loader := &fakeSubscribedNewslettersLoader{subscribed: map[string][]string{}}
+	c := cache.NewMemorySubscriptionCache(loader, time.Minute, 2, time.Now)
+	john, jane, jack := mustEmail(t, "john@example.com"), mustEmail(t, "jane@example.com"), mustEmail(t, "jack@example.com")
+
+	for _, email := range []*domain.Email{john, jane, john, jack} {
+		_, _, err := c.GetSubscribedNewslettersByEmail(context.Background(), email)
+		assert.Nil(t, err)
+	}
+
+	subscribed, err := c.GetSubscribedNewsletters(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, subscribed, 2)
+	assert.Contains(t, subscribed, john.String())
+	assert.Contains(t, subscribed, jack.String())
+}
+
+func Test_MemorySubscriptionCache_DropsLoadRacingWithChange(t *testing.T) {
+	pubID := domain.NewID()
+	loader := &fakeSubscribedNewslettersLoader{subscribed: map[string][]string{}}
+	c := cache.NewMemorySubscriptionCache(loader, time.Minute, 10, time.Now)
+	email := mustEmail(t, "john@example.com")
+	loader.onLoad = func() {
+		loader.onLoad = nil
+		loader.subscribed[email.String()] = []string{pubID.String()}
+		assert.Nil(t, c.AddSubscribedNewsletter(context.Background(), email.String(), pubID.String()))
+	}
+
+	ids, ok, err := c.GetSubscribedNewslettersByEmail(context.Background(), email)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, ids)
+
+	ids, _, err = c.GetSubscribedNewslettersByEmail(context.Background(), email)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{pubID.String()}, ids)
+	assert.Equal(t, 2, loader.loads)
+}
+
+type fakeCachedSubscribedNewsletters struct {
+	ids []string
+	ok  bool
+	err error
+}
+
+func (f *fakeCachedSubscribedNewsletters) GetSubscribedNewslettersByEmail(
+	_ context.Context,
+	_ *domain.Email,
+) ([]string, bool, error) {
+	return f.ids, f.ok, f.err
+}
+
+type fakeSubscribedNewsletterReader struct {
+	byPublicIDs     [][]*domain.ID
+	bySubscriptions int
+	// archived newsletters are left out like by postgres
+	archived map[string]bool
+}
+
+func (f *fakeSubscribedNewsletterReader) GetBySubscriptionEmail(
+	_ context.Context,
+	_ *domain.Email,
+	pageSize, pageNumber int,
+) ([]*domain.Newsletter, *dto.Pagination, error) {
+	f.bySubscriptions++
+
+	return []*domain.Newsletter{}, dto.NewPagination(pageNumber, pageSize, 0, 0), nil
+}
+
+func (f *fakeSubscribedNewsletterReader) GetByPublicIDs(
+	_ context.Context,
+	publicIDs []*domain.ID,
+) ([]*domain.Newsletter, error) {
+	f.byPublicIDs = append(f.byPublicIDs, publicIDs)
+	newsletters := make([]*domain.Newsletter, 0, len(publicIDs))
+	for _, id := range publicIDs {
+		if f.archived[id.String()] {
+			continue
+		}
+		newsletters = append(newsletters, domain.CreateNewsletterFromExisting(domain.NewID(), id, "name", nil, time.Now()))
+	}
+
+	return newsletters, nil
+}
+
+func Test_GetNewslettersBySubscriptionEmail_PagesCachedNewsletters(t *testing.T) {
+	ids := []string{domain.NewID().String(), domain.NewID().String(), domain.NewID().String(), domain.NewID().String()}
+	sorted := slices.Sorted(slices.Values(ids))
+	reader := &fakeSubscribedNewsletterReader{archived: map[string]bool{sorted[1]: true}}
+	h := handler.NewGetNewslettersBySubscriptionEmailHandler(
+		newDiscardLogger(),
+		reader,
+		&fakeCachedSubscribedNewsletters{ids: ids, ok: true},
+		reader,
+	)
+
+	newsletters, pagination, err := h.Handle(context.Background(), "john@example.com", 2, 2)
+	assert.Nil(t, err)
+
+	assert.Len(t, newsletters, 1)
+	assert.Equal(t, sorted[3], newsletters[0].PublicID().String())
+	assert.Equal(t, dto.NewPagination(2, 2, 2, 3), pagination, "archived newsletter still cached is not counted")
+	assert.Equal(t, 0, reader.bySubscriptions)
+}
+
+func Test_GetNewslettersBySubscriptionEmail_FallsBackToPostgres(t *testing.T) {
+	for name, cached := range map[string]*fakeCachedSubscribedNewsletters{
+		"miss":  {},
+		"error": {ok: true, err: errors.New("cache unavailable")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			reader := &fakeSubscribedNewsletterReader{}
+			h := handler.NewGetNewslettersBySubscriptionEmailHandler(newDiscardLogger(), reader, cached, reader)
+
+			_, _, err := h.Handle(context.Background(), "john@example.com", 10, 1)
+			assert.Nil(t, err)
+
+			assert.Equal(t, 1, reader.bySubscriptions)
+			assert.Empty(t, reader.byPublicIDs)
+		})
+	}
+}
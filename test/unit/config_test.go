@@ -2,6 +2,7 @@ package unit
 
 import (
 	"testing"
+	"time"
 
 	"github.com/javor454/newsletter-assignment/app/config"
 	"github.com/spf13/viper"
@@ -22,12 +23,32 @@ func Test_AppConfig_Success(t *testing.T) {
 	assert.Equal(t, 123, cf.HttpPort)
 	assert.Equal(t, "http://localhost", cf.Host)
 	assert.Equal(t, "jwt-secret", cf.JwtSecret)
+	assert.Equal(t, "", cf.JwtKeysDir)
+	assert.Equal(t, "newsletter-api", cf.JwtAudience)
 	assert.Equal(t, []string{"http://localhost"}, cf.CorsAllowedOrigins)
 	assert.Equal(t, []string{"authorization", "content-type"}, cf.CorsAllowedHeaders)
 	assert.Equal(t, "Europe/Prague", cf.Timezone)
 	assert.Equal(t, "sendgrid-api-key", cf.SendGridApiKey)
 	assert.Equal(t, "sendgrid-template-dir", cf.SendGridTemplateDir)
+	assert.Equal(t, 2*time.Second, cf.TemplateWatch)
 	assert.Equal(t, true, cf.SendMail)
+	assert.Equal(t, "newsletter-assignment", cf.AppName)
+	assert.Equal(t, "Newsletter", cf.SenderName)
+	assert.Equal(t, "newsletter@test.com", cf.SenderAddress)
+	assert.Equal(t, "Vodickova 1, Prague", cf.SenderPostalAddress)
+	assert.Equal(t, []string{"test.com", "news.test.com"}, cf.SenderDomains)
+	assert.Equal(t, "sendgrid.net", cf.SpfInclude)
+	assert.Equal(t, "tracking-secret", cf.TrackingSecret)
+	assert.Equal(t, "", cf.SmtpHost)
+	assert.Equal(t, "", cf.SendGridWebhookKey)
+	assert.False(t, cf.WebhookAllowPrivate)
+	assert.Equal(t, time.Hour, cf.CacheReconcile)
+	assert.Equal(t, "", cf.MaintenanceToken)
+	assert.Equal(t, config.CacheBackendFirebase, cf.CacheBackend)
+	assert.Equal(t, 5*time.Minute, cf.CacheMemoryTTL)
+	assert.Equal(t, 10000, cf.CacheMemoryMaxSize)
+	assert.Equal(t, "", cf.DkimKeyFile)
+	assert.Equal(t, 30*time.Second, cf.RemovalTimeout)
 }
 
 func Test_FirebaseConfig_Success(t *testing.T) {
@@ -77,6 +98,19 @@ func Test_AppConfig_Fail(t *testing.T) {
 			},
 			expectedErrMsg: "missing required environment variable: CONFIG_JWT_SECRET",
 		},
+		"jwt_active_key_id_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_JWT_KEYS_DIR", "jwt-keys-dir")
+				viper.Set("CONFIG_JWT_ACTIVE_KEY_ID", "")
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_JWT_ACTIVE_KEY_ID",
+		},
+		"jwt_audience_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_JWT_AUDIENCE", "")
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_JWT_AUDIENCE",
+		},
 		"cors_allowed_origins_empty": {
 			envSetFn: func() {
 				viper.Set("CONFIG_CORS_ALLOWED_ORIGINS", "")
@@ -113,6 +147,56 @@ func Test_AppConfig_Fail(t *testing.T) {
 			},
 			expectedErrMsg: "missing required environment variable: CONFIG_HOST",
 		},
+		"app_name_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_APP_NAME", "")
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_APP_NAME",
+		},
+		"sender_address_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_SENDER_ADDRESS", "")
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_SENDER_ADDRESS",
+		},
+		"sender_postal_address_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_SENDER_POSTAL_ADDRESS", "")
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_SENDER_POSTAL_ADDRESS",
+		},
+		"spf_include_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_SPF_INCLUDE", "")
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_SPF_INCLUDE",
+		},
+		"smtp_port_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_SMTP_HOST", "smtp.test.com")
+				viper.Set("CONFIG_SMTP_PORT", 0)
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_SMTP_PORT",
+		},
+		"dkim_selector_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_DKIM_KEY_FILE", "dkim.pem")
+				viper.Set("CONFIG_DKIM_SELECTOR", "")
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_DKIM_SELECTOR",
+		},
+		"tracking_secret_empty": {
+			envSetFn: func() {
+				viper.Set("CONFIG_TRACKING_SECRET", "")
+			},
+			expectedErrMsg: "missing required environment variable: CONFIG_TRACKING_SECRET",
+		},
+		"cache_backend_invalid": {
+			envSetFn: func() {
+				viper.Set("CONFIG_CACHE_BACKEND", "redis")
+			},
+			expectedErrMsg: `invalid cache backend "redis" in CONFIG_CACHE_BACKEND`,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -220,13 +304,30 @@ func initAppEnvVars() {
 	viper.Set("CONFIG_HTTP_PORT", 123)
 	viper.Set("CONFIG_LOG_LEVEL", "debug")
 	viper.Set("CONFIG_JWT_SECRET", "jwt-secret")
+	viper.Set("CONFIG_JWT_KEYS_DIR", "")
+	viper.Set("CONFIG_JWT_ACTIVE_KEY_ID", "")
+	viper.Set("CONFIG_JWT_AUDIENCE", "newsletter-api")
 	viper.Set("CONFIG_CORS_ALLOWED_ORIGINS", "http://localhost")
 	viper.Set("CONFIG_CORS_ALLOWED_HEADERS", "authorization content-type")
 	viper.Set("CONFIG_TIMEZONE", "Europe/Prague")
 	viper.Set("CONFIG_SENDGRID_API_KEY", "sendgrid-api-key")
 	viper.Set("CONFIG_SENDGRID_TEMPLATE_DIR", "sendgrid-template-dir")
+	viper.Set("CONFIG_TEMPLATE_WATCH_INTERVAL", "2s")
 	viper.Set("CONFIG_SEND_MAIL", "true")
 	viper.Set("CONFIG_HOST", "http://localhost")
+	viper.Set("CONFIG_APP_NAME", "newsletter-assignment")
+	viper.Set("CONFIG_SENDER_NAME", "Newsletter")
+	viper.Set("CONFIG_SENDER_ADDRESS", "newsletter@test.com")
+	viper.Set("CONFIG_SENDER_POSTAL_ADDRESS", "Vodickova 1, Prague")
+	viper.Set("CONFIG_SENDER_DOMAINS", "test.com news.test.com")
+	viper.Set("CONFIG_SPF_INCLUDE", "sendgrid.net")
+	viper.Set("CONFIG_SMTP_HOST", "")
+	viper.Set("CONFIG_SMTP_PORT", 0)
+	viper.Set("CONFIG_DKIM_KEY_FILE", "")
+	viper.Set("CONFIG_DKIM_SELECTOR", "")
+	viper.Set("CONFIG_TRACKING_SECRET", "tracking-secret")
+	viper.Set("CONFIG_CACHE_BACKEND", "")
+	viper.Set("CONFIG_CACHE_MEMORY_TTL", "")
 }
 
 func initFirebaseEnvVars() {
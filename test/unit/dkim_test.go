@@ -0,0 +1,116 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"flag"
+	"io"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/dkim"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/smtp"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var testKeyFile = filepath.Join("testdata", "dkim_private_key.pem")
+
+func Test_Relay_SignedMessageGolden(t *testing.T) {
+	relay := newTestRelay(t)
+
+	signed, err := relay.Sign(context.Background(), newTestMessage("newsletter@news.example.com"))
+	assert.Nil(t, err)
+
+	golden := filepath.Join("testdata", "signed_message.eml")
+	if *update {
+		assert.Nil(t, os.WriteFile(golden, signed, 0o644))
+	}
+	expected, err := os.ReadFile(golden)
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), string(signed))
+
+	assert.Nil(t, dkim.Verify(expected, testPublicKey(t)), "golden message has valid signature")
+}
+
+func Test_DKIM_Verify(t *testing.T) {
+	signed, err := newTestRelay(t).Sign(context.Background(), newTestMessage("newsletter@news.example.com"))
+	assert.Nil(t, err)
+	pub := testPublicKey(t)
+
+	refolded := bytes.Replace(signed, []byte("Subject: "), []byte("Subject:  \r\n\t"), 1)
+	assert.Nil(t, dkim.Verify(refolded, pub), "relaxed header canonicalization ignores folding")
+
+	padded := bytes.Replace(signed, []byte("--\r\n"), []byte("--  \r\n\r\n\r\n"), 1)
+	assert.Nil(t, dkim.Verify(padded, pub), "relaxed body canonicalization ignores trailing whitespace and lines")
+
+	tamperedBody := bytes.Replace(signed, []byte("Welcome"), []byte("Welcomf"), 1)
+	assert.NotNil(t, dkim.Verify(tamperedBody, pub), "changed body")
+
+	tamperedHeader := bytes.Replace(signed, []byte("Subject: "), []byte("Subject: Re: "), 1)
+	assert.NotNil(t, dkim.Verify(tamperedHeader, pub), "changed subject")
+
+	addedHeader := bytes.Replace(signed, []byte("Date: "), []byte("From: attacker@evil.com\r\nDate: "), 1)
+	assert.NotNil(t, dkim.Verify(addedHeader, pub), "from header added below signed one")
+
+	otherKey, _, err := dkim.GenerateKey()
+	assert.Nil(t, err)
+	other, err := dkim.NewSigner("news.example.com", "nl202409", otherKey)
+	assert.Nil(t, err)
+	message, err := newTestMessage("newsletter@news.example.com").Bytes()
+	assert.Nil(t, err)
+	otherSigned, err := other.Sign(message, time.Unix(1726833600, 0))
+	assert.Nil(t, err)
+	assert.NotNil(t, dkim.Verify(otherSigned, pub), "signed by different key")
+}
+
+func Test_Relay_UnknownDomainUnsigned(t *testing.T) {
+	data, err := newTestRelay(t).Sign(context.Background(), newTestMessage("newsletter@other.com"))
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(data, []byte("DKIM-Signature")))
+	assert.NotNil(t, dkim.Verify(data, testPublicKey(t)))
+}
+
+func newTestRelay(t *testing.T) *smtp.Relay {
+	keys, err := dkim.NewStaticKeys([]string{"news.example.com"}, "nl202409", testKeyFile)
+	assert.Nil(t, err)
+	lg := logrus.New()
+	lg.SetOutput(io.Discard)
+
+	return smtp.NewRelay(lg, "localhost", 25, "", "", func() time.Time {
+		return time.Unix(1726833600, 0)
+	}, keys)
+}
+
+func newTestMessage(from string) *smtp.Message {
+	return &smtp.Message{
+		From:      &mail.Address{Name: "Tiktok News", Address: from},
+		To:        &mail.Address{Address: "subscriber@test.com"},
+		ReplyTo:   &mail.Address{Name: "Tiktok News", Address: "editor@example.com"},
+		Subject:   "Subscribed to newsletter Tiktok News – weekly",
+		Text:      "Welcome, subscriber@test.com!\nYour link to unsubscribe is: http://localhost:8080/api/v1/newsletters/90c0a606-4429-44cc-9531-6f9cd038620a/subscriptions/subscriber@test.com?token=abc\n",
+		HTML:      "<html><body><h1>Welcome, subscriber@test.com!</h1><p>Price: 10 € = cheap</p></body></html>",
+		Date:      time.Date(2024, 9, 20, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+		MessageID: "<0f1e2d3c4b5a69788796a5b4c3d2e1f0@news.example.com>",
+	}
+}
+
+func testPublicKey(t *testing.T) string {
+	b, err := os.ReadFile(testKeyFile)
+	assert.Nil(t, err)
+	block, _ := pem.Decode(b)
+	pk, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	assert.Nil(t, err)
+	pub, err := x509.MarshalPKIXPublicKey(&pk.PublicKey)
+	assert.Nil(t, err)
+
+	return base64.StdEncoding.EncodeToString(pub)
+}
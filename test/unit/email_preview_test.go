@@ -0,0 +1,203 @@
+package unit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/app/config"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/sendgrid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeTemplateValidator struct {
+	err error
+}
+
+func (f *fakeTemplateValidator) ValidateTemplate(_, _, _ string) error {
+	return f.err
+}
+
+type fakeEmailPreviews struct {
+	locales   map[string]string
+	draft     *domain.TemplateOverride
+	issueID   *domain.ID
+	recipient *domain.Email
+	locale    *domain.Locale
+}
+
+func (f *fakeEmailPreviews) GetSubscriptionLocale(_ context.Context, _ *domain.ID, email *domain.Email) (*domain.Locale, error) {
+	locale, ok := f.locales[email.String()]
+	if !ok {
+		return nil, application.SubscriptionNotFoundError
+	}
+
+	return domain.NewLocale(locale)
+}
+
+func (f *fakeEmailPreviews) PreviewEmail(
+	_ context.Context,
+	_ *domain.ID,
+	name string,
+	draft *domain.TemplateOverride,
+	issueID *domain.ID,
+	recipient *domain.Email,
+	locale *domain.Locale,
+) (*dto.EmailPreview, error) {
+	f.draft, f.issueID, f.recipient, f.locale = draft, issueID, recipient, locale
+
+	return &dto.EmailPreview{Subject: name}, nil
+}
+
+func (f *fakeEmailPreviews) SendTestEmail(
+	_ context.Context,
+	_ *domain.ID,
+	_ string,
+	draft *domain.TemplateOverride,
+	issueID *domain.ID,
+	recipient *domain.Email,
+	locale *domain.Locale,
+) error {
+	f.draft, f.issueID, f.recipient, f.locale = draft, issueID, recipient, locale
+
+	return nil
+}
+
+func Test_PreviewEmail(t *testing.T) {
+	cases := map[string]struct {
+		role              domain.Role
+		html              string
+		issueID           string
+		validationErr     error
+		subscriberEmail   string
+		locale            string
+		expectedRecipient string
+		expectedLocale    string
+		expectedDraft     bool
+		expectedErr       error
+	}{
+		"sample data":             {role: domain.RoleEditor, expectedRecipient: "subscriber@example.com", expectedLocale: "en"},
+		"subscriber locale":       {role: domain.RoleOwner, subscriberEmail: "jan@example.com", expectedRecipient: "jan@example.com", expectedLocale: "cs"},
+		"explicit locale wins":    {role: domain.RoleEditor, subscriberEmail: "jan@example.com", locale: "en", expectedRecipient: "jan@example.com", expectedLocale: "en"},
+		"draft":                   {role: domain.RoleEditor, html: `{{.Link}}`, expectedRecipient: "subscriber@example.com", expectedLocale: "en", expectedDraft: true},
+		"issue":                   {role: domain.RoleEditor, issueID: "0b9c1a52-63f5-4c1e-9e34-5f2d3c2f4e7a", expectedRecipient: "subscriber@example.com", expectedLocale: "en"},
+		"invalid issue id":        {role: domain.RoleEditor, issueID: "abc", expectedErr: application.InvalidUUIDError},
+		"invalid draft":           {role: domain.RoleEditor, html: `{{.Link`, validationErr: application.InvalidTemplateError, expectedErr: application.InvalidTemplateError},
+		"unknown subscriber":      {role: domain.RoleEditor, subscriberEmail: "nobody@example.com", expectedErr: application.SubscriptionNotFoundError},
+		"unsupported locale":      {role: domain.RoleEditor, locale: "de", expectedErr: application.InvalidLocaleError},
+		"viewer can not preview":  {role: domain.RoleViewer, expectedErr: application.InsufficientRoleError},
+		"non member can not view": {expectedErr: application.NewsletterNotFoundError},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			members := newFakeMemberRepository()
+			user := members.addUser("editor@example.com", tc.role)
+			previews := &fakeEmailPreviews{locales: map[string]string{"jan@example.com": "cs"}}
+			h := handler.NewPreviewEmailHandler(members, &fakeTemplateValidator{err: tc.validationErr}, previews, previews)
+
+			preview, err := h.Handle(
+				context.Background(),
+				user.ID().String(),
+				domain.NewID().String(),
+				sendgrid.SubscribedTemplateName,
+				tc.html,
+				"",
+				tc.issueID,
+				tc.subscriberEmail,
+				tc.locale,
+			)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, previews.recipient)
+
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, sendgrid.SubscribedTemplateName, preview.Subject)
+			assert.Equal(t, tc.expectedRecipient, previews.recipient.String())
+			assert.Equal(t, tc.expectedLocale, previews.locale.String())
+			assert.Equal(t, tc.expectedDraft, previews.draft != nil)
+			if tc.issueID != "" {
+				assert.Equal(t, tc.issueID, previews.issueID.String())
+			} else {
+				assert.Nil(t, previews.issueID)
+			}
+		})
+	}
+}
+
+func Test_SendTestEmail_DeliversOnlyToCaller(t *testing.T) {
+	members := newFakeMemberRepository()
+	editor := members.addUser("editor@example.com", domain.RoleEditor)
+	viewer := members.addUser("viewer@example.com", domain.RoleViewer)
+	previews := &fakeEmailPreviews{}
+	h := handler.NewSendTestEmailHandler(members, &fakeTemplateValidator{}, members, previews)
+
+	err := h.Handle(context.Background(), viewer.ID().String(), domain.NewID().String(), "subscribed", "", "", "", "")
+	assert.ErrorIs(t, err, application.InsufficientRoleError)
+	assert.Nil(t, previews.recipient)
+
+	err = h.Handle(context.Background(), editor.ID().String(), domain.NewID().String(), "subscribed", "", "", "", "cs")
+	assert.Nil(t, err)
+	assert.Equal(t, "editor@example.com", previews.recipient.String())
+	assert.Equal(t, "cs", previews.locale.String())
+	assert.Nil(t, previews.draft)
+	assert.Nil(t, previews.issueID)
+
+	issueID := domain.NewID()
+	err = h.Handle(context.Background(), editor.ID().String(), domain.NewID().String(), "issue", "", "", issueID.String(), "")
+	assert.Nil(t, err)
+	assert.Equal(t, issueID.String(), previews.issueID.String())
+}
+
+func Test_MailService_Preview(t *testing.T) {
+	ms := sendgrid.NewMailService(
+		newDiscardLogger(),
+		&config.AppConfig{SendGridTemplateDir: filepath.Join("..", "..", "template"), Host: "http://localhost", HttpPort: 8080},
+		nil,
+		nil,
+	)
+	sender := &sendgrid.Sender{Name: "Tech News", Address: "news@example.com", PostalAddress: "Vodickova 1"}
+
+	for _, name := range []string{
+		sendgrid.SubscribedTemplateName,
+		sendgrid.InvitationTemplateName,
+		sendgrid.RemovedTemplateName,
+	} {
+		for _, locale := range domain.SupportedLocales() {
+			rendered, err := ms.Preview(sender, nil, nil, locale, name, "subscriber@example.com", "Tech", domain.NewID().String())
+			assert.Nil(t, err, "%s in %s", name, locale)
+			assert.NotEmpty(t, rendered.Subject)
+			assert.Contains(t, rendered.HTML, "Vodickova 1")
+			assert.Contains(t, rendered.Text, "Vodickova 1")
+		}
+	}
+
+	rendered, err := ms.Preview(sender, nil, nil, "en", sendgrid.SubscribedTemplateName, "subscriber@example.com", "Tech", "abc")
+	assert.Nil(t, err)
+	assert.Contains(t, rendered.HTML, "newsletter_public_id=abc&amp;token=preview")
+
+	_, err = ms.Preview(sender, nil, nil, "en", sendgrid.EmailChangeTemplateName, "subscriber@example.com", "", "")
+	assert.ErrorIs(t, err, application.TemplateNotFoundError)
+
+	_, err = ms.Preview(sender, nil, nil, "en", sendgrid.IssueTemplateName, "subscriber@example.com", "Tech", "abc")
+	assert.ErrorIs(t, err, application.IssueRequiredError)
+
+	issue := &sendgrid.Issue{
+		Title:         "Weekly digest",
+		Content:       `<p>Read <a href="http://localhost/t/c/token">the story</a></p>`,
+		TrackingPixel: "http://localhost/t/o/token",
+	}
+	rendered, err = ms.Preview(sender, nil, issue, "en", sendgrid.IssueTemplateName, "subscriber@example.com", "Tech", "abc")
+	assert.Nil(t, err)
+	assert.Contains(t, rendered.HTML, "Weekly digest")
+	assert.Contains(t, rendered.HTML, `<a href="http://localhost/t/c/token">the story</a>`)
+	assert.Contains(t, rendered.HTML, "http://localhost/t/o/token")
+	assert.Contains(t, rendered.HTML, "Vodickova 1")
+}
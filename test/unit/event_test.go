@@ -0,0 +1,161 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSubscribedNewsletterCache struct {
+	subscribed map[string][]string
+	// failing makes every change of cached subscriptions of email fail
+	failing string
+}
+
+func newFakeSubscribedNewsletterCache() *fakeSubscribedNewsletterCache {
+	return &fakeSubscribedNewsletterCache{subscribed: make(map[string][]string)}
+}
+
+func (f *fakeSubscribedNewsletterCache) GetSubscribedNewsletters(_ context.Context) (map[string][]string, error) {
+	subscribed := make(map[string][]string, len(f.subscribed))
+	for email, ids := range f.subscribed {
+		subscribed[email] = slices.Clone(ids)
+	}
+
+	return subscribed, nil
+}
+
+func (f *fakeSubscribedNewsletterCache) AddSubscribedNewsletter(_ context.Context, email, newsletterPublicID string) error {
+	if email == f.failing {
+		return errors.New("cache unavailable")
+	}
+	if !slices.Contains(f.subscribed[email], newsletterPublicID) {
+		f.subscribed[email] = append(f.subscribed[email], newsletterPublicID)
+	}
+
+	return nil
+}
+
+func (f *fakeSubscribedNewsletterCache) RemoveSubscribedNewsletter(
+	_ context.Context,
+	email *domain.Email,
+	newsletterPublicID *domain.ID,
+) error {
+	if email.String() == f.failing {
+		return errors.New("cache unavailable")
+	}
+	f.subscribed[email.String()] = slices.DeleteFunc(f.subscribed[email.String()], func(id string) bool {
+		return id == newsletterPublicID.String()
+	})
+	if len(f.subscribed[email.String()]) == 0 {
+		delete(f.subscribed, email.String())
+	}
+
+	return nil
+}
+
+func Test_Subscription_RaisesCreatedEvent(t *testing.T) {
+	pubID := domain.NewID()
+	email, err := domain.NewEmail("john@example.com")
+	assert.Nil(t, err)
+	locale, err := domain.NewLocale("cs")
+	assert.Nil(t, err)
+
+	subscription := domain.NewSubscription(pubID, email, "token", locale)
+
+	events := subscription.PullEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.EventSubscriptionCreated, events[0].Name())
+	assert.Equal(t, pubID.String(), events[0].NewsletterPublicID().String())
+	assert.Equal(t, map[string]string{"email": "john@example.com", "locale": "cs"}, events[0].Data())
+	assert.Empty(t, subscription.PullEvents())
+}
+
+func Test_Unsubscription_RaisesDisabledEvent(t *testing.T) {
+	pubID := domain.NewID()
+	email, err := domain.NewEmail("john@example.com")
+	assert.Nil(t, err)
+
+	events := domain.NewUnsubscription(pubID, email).PullEvents()
+
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.EventSubscriptionDisabled, events[0].Name())
+	assert.Equal(t, map[string]string{"email": "john@example.com"}, events[0].Data())
+}
+
+func Test_SubscriptionEvents_ShareAggregateKey(t *testing.T) {
+	pubID := domain.NewID()
+	email, err := domain.NewEmail("john@example.com")
+	assert.Nil(t, err)
+	locale, err := domain.NewLocale("cs")
+	assert.Nil(t, err)
+
+	created := domain.NewSubscription(pubID, email, "token", locale).PullEvents()[0]
+	disabled := domain.NewUnsubscription(pubID, email).PullEvents()[0]
+	other := domain.NewUnsubscription(domain.NewID(), email).PullEvents()[0]
+
+	assert.NotEmpty(t, created.AggregateKey())
+	assert.Equal(t, created.AggregateKey(), disabled.AggregateKey())
+	assert.NotEqual(t, created.AggregateKey(), other.AggregateKey())
+}
+
+func Test_Issue_PublishRaisesEventOnce(t *testing.T) {
+	slug, err := domain.NewSlug("first-issue")
+	assert.Nil(t, err)
+	issue := domain.NewIssue(domain.NewID(), slug, "First issue", "# Hi", "<h1>Hi</h1>")
+
+	assert.Nil(t, issue.Publish())
+	assert.NotNil(t, issue.Publish())
+
+	events := issue.PullEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.EventIssuePublished, events[0].Name())
+	assert.Equal(t, *issue.PublishedAt(), events[0].OccurredAt())
+	assert.Equal(t, map[string]string{
+		"issue_id": issue.ID().String(),
+		"title":    "First issue",
+		"slug":     "first-issue",
+	}, events[0].Data())
+}
+
+func Test_DeliveryEvent_BounceRaisesEventIdentifiedByJob(t *testing.T) {
+	jobID := domain.NewID()
+	pubID := domain.NewID()
+
+	bounce := domain.NewDeliveryEvent(jobID, domain.DeliveryEventBounced, time.Now())
+	bounce.AttributeToNewsletter(pubID, "john@example.com")
+	delivered := domain.NewDeliveryEvent(jobID, domain.DeliveryEventDelivered, time.Now())
+	delivered.AttributeToNewsletter(pubID, "john@example.com")
+
+	events := bounce.PullEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.EventEmailBounced, events[0].Name())
+	assert.Equal(t, jobID.String(), events[0].ID().String())
+	assert.Equal(t, pubID.String(), events[0].NewsletterPublicID().String())
+	assert.Empty(t, delivered.PullEvents())
+}
+
+func Test_CacheSubscription_FollowsEvents(t *testing.T) {
+	cache := newFakeSubscribedNewsletterCache()
+	pubID := domain.NewID()
+	data := map[string]string{"email": "john@example.com"}
+	key := "subscription/" + pubID.String() + "/john@example.com"
+	created := domain.NewEvent(domain.EventSubscriptionCreated, key, pubID, time.Now(), data)
+	disabled := domain.NewEvent(domain.EventSubscriptionDisabled, key, pubID, time.Now(), data)
+
+	h := handler.NewCacheSubscriptionHandler(cache)
+
+	assert.Nil(t, h.HandleEvent(context.Background(), created))
+	assert.Nil(t, h.HandleEvent(context.Background(), created))
+	assert.Equal(t, []string{pubID.String()}, cache.subscribed["john@example.com"])
+
+	assert.Nil(t, h.HandleEvent(context.Background(), disabled))
+	assert.Nil(t, h.HandleEvent(context.Background(), disabled))
+	assert.NotContains(t, cache.subscribed, "john@example.com")
+}
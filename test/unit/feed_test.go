@@ -0,0 +1,224 @@
+package unit
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/feed"
+	"github.com/stretchr/testify/assert"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type rssDocument struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Channel struct {
+		// namespaced field goes first, decoder fills first field matching local name
+		SelfLink struct {
+			XMLName xml.Name
+			Href    string `xml:"href,attr"`
+			Rel     string `xml:"rel,attr"`
+			Type    string `xml:"type,attr"`
+		} `xml:"http://www.w3.org/2005/Atom link"`
+		Title         string `xml:"title"`
+		Link          string `xml:"link"`
+		Description   string `xml:"description"`
+		LastBuildDate string `xml:"lastBuildDate"`
+		Items         []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Description string `xml:"description"`
+			PubDate     string `xml:"pubDate"`
+			GUID        struct {
+				IsPermaLink string `xml:"isPermaLink,attr"`
+				Value       string `xml:",chardata"`
+			} `xml:"guid"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Author  struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"link"`
+	Entries []struct {
+		ID        string `xml:"id"`
+		Title     string `xml:"title"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+		Link      struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Content struct {
+			Type  string `xml:"type,attr"`
+			Value string `xml:",chardata"`
+		} `xml:"content"`
+	} `xml:"entry"`
+}
+
+// parseRSS checks requirements of RSS 2.0 specification and RSS Advisory Board best practices.
+func parseRSS(t *testing.T, body []byte) *rssDocument {
+	t.Helper()
+	assert.True(t, strings.HasPrefix(string(body), xml.Header))
+
+	var d rssDocument
+	assert.Nil(t, xml.Unmarshal(body, &d))
+	assert.Equal(t, "2.0", d.Version)
+	assert.NotEmpty(t, d.Channel.Title)
+	assert.NotEmpty(t, d.Channel.Link)
+	assert.NotEmpty(t, d.Channel.Description, "channel description is required")
+	assertTime(t, time.RFC1123Z, d.Channel.LastBuildDate)
+	assert.Equal(t, atomNamespace, d.Channel.SelfLink.XMLName.Space)
+	assert.Equal(t, "self", d.Channel.SelfLink.Rel)
+	assert.Equal(t, "application/rss+xml", d.Channel.SelfLink.Type)
+
+	guids := make(map[string]bool)
+	for _, i := range d.Channel.Items {
+		assert.True(t, i.Title != "" || i.Description != "", "item needs title or description")
+		assert.NotEmpty(t, i.Link)
+		assertTime(t, time.RFC1123Z, i.PubDate)
+		assert.Equal(t, "false", i.GUID.IsPermaLink)
+		assert.True(t, strings.HasPrefix(i.GUID.Value, "urn:uuid:"))
+		assert.False(t, guids[i.GUID.Value], "guid must be unique")
+		guids[i.GUID.Value] = true
+	}
+
+	return &d
+}
+
+// parseAtom checks requirements of RFC 4287.
+func parseAtom(t *testing.T, body []byte) *atomDocument {
+	t.Helper()
+	assert.True(t, strings.HasPrefix(string(body), xml.Header))
+
+	var d atomDocument
+	assert.Nil(t, xml.Unmarshal(body, &d))
+	assert.True(t, strings.HasPrefix(d.ID, "urn:uuid:"))
+	assert.NotEmpty(t, d.Title)
+	assertTime(t, time.RFC3339, d.Updated)
+	assert.NotEmpty(t, d.Author.Name, "feed author is required when entries have none")
+
+	rels := make(map[string]string)
+	for _, l := range d.Links {
+		rels[l.Rel] = l.Type
+	}
+	assert.Equal(t, "application/atom+xml", rels["self"])
+	assert.Equal(t, "text/html", rels["alternate"])
+
+	ids := make(map[string]bool)
+	for _, e := range d.Entries {
+		assert.True(t, strings.HasPrefix(e.ID, "urn:uuid:"))
+		assert.False(t, ids[e.ID], "entry id must be unique")
+		ids[e.ID] = true
+		assert.NotEmpty(t, e.Title)
+		assertTime(t, time.RFC3339, e.Published)
+		assertTime(t, time.RFC3339, e.Updated)
+		assert.Equal(t, "alternate", e.Link.Rel)
+		assert.NotEmpty(t, e.Link.Href)
+		assert.Equal(t, "html", e.Content.Type)
+	}
+
+	return &d
+}
+
+func assertTime(t *testing.T, layout, value string) {
+	t.Helper()
+	_, err := time.Parse(layout, value)
+	assert.Nil(t, err, value)
+}
+
+func Test_Feed_Encode(t *testing.T) {
+	published := time.Date(2024, 3, 1, 8, 30, 0, 0, time.FixedZone("CET", 3600))
+	f := &feed.Feed{
+		ID:       "urn:uuid:90c0a606-4429-44cc-9531-6f9cd038620a",
+		Title:    "Tech & Co",
+		Author:   "Tech & Co",
+		Link:     "http://localhost:8080/newsletters/90c0a606-4429-44cc-9531-6f9cd038620a",
+		SelfLink: "http://localhost:8080/newsletters/90c0a606-4429-44cc-9531-6f9cd038620a/rss.xml",
+		Updated:  published.Add(time.Hour),
+		Entries: []*feed.Entry{{
+			ID:        "urn:uuid:1541c9c1-e43e-4527-850a-77f4e5be9599",
+			Title:     "Issue <1>",
+			Link:      "http://localhost:8080/newsletters/90c0a606-4429-44cc-9531-6f9cd038620a/issues/issue-1",
+			Content:   `<p style="margin:0;">Hello &amp; welcome</p>`,
+			Published: published,
+			Updated:   published.Add(time.Hour),
+		}},
+	}
+
+	body, err := feed.RSS(f)
+	assert.Nil(t, err)
+	rss := parseRSS(t, body)
+	assert.Equal(t, "Tech & Co", rss.Channel.Title)
+	assert.Equal(t, "Tech & Co", rss.Channel.Description, "title is used when description is missing")
+	assert.Equal(t, "Fri, 01 Mar 2024 07:30:00 +0000", rss.Channel.Items[0].PubDate)
+	assert.Equal(t, "Issue <1>", rss.Channel.Items[0].Title)
+	assert.Equal(t, f.Entries[0].Content, rss.Channel.Items[0].Description)
+	assert.NotContains(t, string(body), "<p style", "html is escaped")
+
+	body, err = feed.Atom(f)
+	assert.Nil(t, err)
+	atom := parseAtom(t, body)
+	assert.Equal(t, "2024-03-01T07:30:00Z", atom.Entries[0].Published)
+	assert.Equal(t, "2024-03-01T08:30:00Z", atom.Entries[0].Updated)
+	assert.Equal(t, f.Entries[0].Content, atom.Entries[0].Content.Value)
+
+	f.Entries = nil
+	body, err = feed.Atom(f)
+	assert.Nil(t, err)
+	assert.Empty(t, parseAtom(t, body).Entries)
+}
+
+func Test_ArchiveController_Feeds(t *testing.T) {
+	archive, engine := newTestArchive(t)
+	publicID := archive.newsletter.PublicID().String()
+
+	w := serveTestRequest(engine, "/newsletters/"+publicID+"/rss.xml", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/rss+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	rss := parseRSS(t, w.Body.Bytes())
+	assert.Equal(t, "http://localhost:8080/newsletters/"+publicID+"/rss.xml", rss.Channel.SelfLink.Href)
+	assert.Equal(t, "Weekly <news>", rss.Channel.Description)
+	assert.Len(t, rss.Channel.Items, 2)
+	assert.Equal(t, "urn:uuid:"+archive.issues[0].ID().String(), rss.Channel.Items[0].GUID.Value)
+	assert.Equal(t, "http://localhost:8080/newsletters/"+publicID+"/issues/second-issue", rss.Channel.Items[0].Link)
+
+	etag := w.Header().Get("ETag")
+	w = serveTestRequest(engine, "/newsletters/"+publicID+"/rss.xml", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = serveTestRequest(engine, "/newsletters/"+publicID+"/atom.xml", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	atom := parseAtom(t, w.Body.Bytes())
+	assert.Equal(t, "urn:uuid:"+publicID, atom.ID)
+	assert.Equal(t, "2024-01-15T00:00:00Z", atom.Updated)
+	assert.Len(t, atom.Entries, 2)
+
+	w = serveTestRequest(engine, "/newsletters/"+domain.NewID().String()+"/atom.xml", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// feeds are advertised on pages
+	w = serveTestRequest(engine, "/newsletters/"+publicID, nil)
+	assert.Contains(
+		t,
+		w.Body.String(),
+		`<link rel="alternate" type="application/atom+xml" title="Tech &amp; Co" href="http://localhost:8080/newsletters/`+publicID+`/atom.xml">`,
+	)
+}
@@ -0,0 +1,51 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/htmltext"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HTMLText_Convert(t *testing.T) {
+	cases := []struct {
+		name     string
+		document string
+		expected string
+	}{
+		{
+			name: "links become footnotes",
+			document: `<html><head><title>Ignored</title><style>p { color: red; }</style></head><body>
+				<h1>Welcome, John!</h1>
+				<p>Your link to unsubscribe is: <a href="https://example.com/unsubscribe?token=a&amp;b=c">HERE</a></p>
+				<p>Read <a href="https://example.com/archive">archive</a>.</p>
+			</body></html>`,
+			expected: "Welcome, John!\n\nYour link to unsubscribe is: HERE [1]\n\nRead archive [2].\n\n" +
+				"[1] https://example.com/unsubscribe?token=a&b=c\n[2] https://example.com/archive\n",
+		},
+		{
+			name:     "link with url as text is kept inline",
+			document: `<p>Visit <a href="https://example.com">https://example.com</a> or <a href="mailto:info@example.com">info@example.com</a></p>`,
+			expected: "Visit https://example.com or info@example.com\n",
+		},
+		{
+			name:     "lists, breaks and rules",
+			document: `<ul><li>first</li><li>second</li></ul>line<br>next<hr><p><small>Sender, Street 1</small></p>`,
+			expected: "- first\n- second\n\nline\nnext\n\n---\n\nSender, Street 1\n",
+		},
+		{
+			name:     "entities and whitespace",
+			document: "<p>Tom   &amp;\n\tJerry&#39;s <b>news</b></p><script>alert(1)</script>",
+			expected: "Tom & Jerry's news\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			text, err := htmltext.Convert(tc.document)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, text)
+		})
+	}
+}
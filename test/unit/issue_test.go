@@ -0,0 +1,155 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeIssues struct {
+	issues    map[string]*domain.Issue
+	published int
+}
+
+func newFakeIssues() *fakeIssues {
+	return &fakeIssues{issues: make(map[string]*domain.Issue)}
+}
+
+func (f *fakeIssues) CreateIssue(_ context.Context, issue *domain.Issue) error {
+	f.issues[issue.ID().String()] = issue
+
+	return nil
+}
+
+func (f *fakeIssues) GetIssue(_ context.Context, _, issueID *domain.ID) (*domain.Issue, error) {
+	issue, ok := f.issues[issueID.String()]
+	if !ok {
+		return nil, application.IssueNotFoundError
+	}
+
+	return issue, nil
+}
+
+func (f *fakeIssues) Publish(_ context.Context, _ *domain.Issue) (int, error) {
+	f.published++
+
+	return 3, nil
+}
+
+func Test_MarkdownRenderer_Sanitizes(t *testing.T) {
+	r := markdown.NewRenderer()
+
+	html, err := r.Render("Read [story](https://example.com/story) or [this](javascript:alert(1))\n\n" +
+		"<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>\n\n[relative](/admin) [mail](mailto:john@example.com)")
+	assert.Nil(t, err)
+
+	assert.Contains(
+		t,
+		html,
+		`<a href="https://example.com/story" rel="nofollow noopener" target="_blank" style="color:#1a73e8;text-decoration:underline;">story</a>`,
+	)
+	assert.Contains(t, html, `<a href="mailto:john@example.com"`)
+	assert.Contains(t, html, `<p style="margin:0 0 16px;line-height:1.6;">`)
+	assert.NotContains(t, html, "javascript:")
+	assert.NotContains(t, html, "<script")
+	assert.NotContains(t, html, "onerror")
+	assert.NotContains(t, html, `href="/admin"`)
+}
+
+func Test_MarkdownRenderer_InlinesStyles(t *testing.T) {
+	html, err := markdown.NewRenderer().Render("# Title\n\n| a |\n|---|\n| 1 |\n\n> quote")
+	assert.Nil(t, err)
+
+	assert.Contains(t, html, `<h1 style="font-size:26px;line-height:1.3;margin:0 0 16px;">Title</h1>`)
+	assert.Contains(t, html, `<td style="border:1px solid #dddddd;padding:6px 12px;">1</td>`)
+	assert.Contains(t, html, `<blockquote style="`)
+}
+
+func Test_Slug(t *testing.T) {
+	assert.Equal(t, "tydenni-prehled-42", domain.CreateSlugFromTitle("Týdenní přehled #42").String())
+	assert.Equal(t, "hello-world", domain.CreateSlugFromTitle("  Hello, World!  ").String())
+	assert.Equal(t, "issue", domain.CreateSlugFromTitle("!!!").String())
+
+	for _, value := range []string{"", "Upper", "two--dashes", "-leading", "trailing-", "space here"} {
+		_, err := domain.NewSlug(value)
+		assert.ErrorIs(t, err, application.InvalidSlugError, value)
+	}
+	slug, err := domain.NewSlug("weekly-digest-42")
+	assert.Nil(t, err)
+	assert.Equal(t, "weekly-digest-42", slug.String())
+}
+
+func Test_CreateIssue(t *testing.T) {
+	cases := map[string]struct {
+		role         domain.Role
+		slug         string
+		expectedSlug string
+		expectedErr  error
+	}{
+		"slug from title": {role: domain.RoleEditor, expectedSlug: "weekly-digest"},
+		"explicit slug":   {role: domain.RoleOwner, slug: "issue-1", expectedSlug: "issue-1"},
+		"invalid slug":    {role: domain.RoleEditor, slug: "Issue 1", expectedErr: application.InvalidSlugError},
+		"viewer":          {role: domain.RoleViewer, expectedErr: application.InsufficientRoleError},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			members := newFakeMemberRepository()
+			user := members.addUser("editor@example.com", tc.role)
+			issues := newFakeIssues()
+			h := handler.NewCreateIssueHandler(members, markdown.NewRenderer(), issues)
+
+			issue, err := h.Handle(
+				context.Background(),
+				user.ID().String(),
+				domain.NewID().String(),
+				"Weekly digest",
+				tc.slug,
+				"Hello **world**",
+			)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, issues.issues)
+
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedSlug, issue.Slug().String())
+			assert.Equal(t, "Hello **world**", issue.Markdown())
+			assert.Contains(t, issue.HTML(), "<strong>world</strong>")
+			assert.False(t, issue.IsPublished())
+		})
+	}
+}
+
+func Test_PublishIssue_OnlyOnce(t *testing.T) {
+	members := newFakeMemberRepository()
+	editor := members.addUser("editor@example.com", domain.RoleEditor)
+	viewer := members.addUser("viewer@example.com", domain.RoleViewer)
+	issues := newFakeIssues()
+	pubID := domain.NewID()
+	issue := domain.NewIssue(pubID, domain.CreateSlugFromTitle("First"), "First", "body", "<p>body</p>")
+	issues.issues[issue.ID().String()] = issue
+	h := handler.NewPublishIssueHandler(members, issues, issues)
+
+	_, err := h.Handle(context.Background(), viewer.ID().String(), pubID.String(), issue.ID().String())
+	assert.ErrorIs(t, err, application.InsufficientRoleError)
+
+	published, err := h.Handle(context.Background(), editor.ID().String(), pubID.String(), issue.ID().String())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, published.Recipients)
+	assert.True(t, published.Issue.IsPublished())
+
+	_, err = h.Handle(context.Background(), editor.ID().String(), pubID.String(), issue.ID().String())
+	assert.ErrorIs(t, err, application.IssueAlreadyPublishedError)
+	assert.Equal(t, 1, issues.published)
+
+	_, err = h.Handle(context.Background(), editor.ID().String(), pubID.String(), domain.NewID().String())
+	assert.ErrorIs(t, err, application.IssueNotFoundError)
+}
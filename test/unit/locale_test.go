@@ -0,0 +1,168 @@
+package unit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/mailtemplate"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/sendgrid"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/request"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSubscriptionTokens struct{}
+
+func (f *fakeSubscriptionTokens) GenerateSubscriptionToken(_ *domain.Email) (string, error) {
+	return "token", nil
+}
+
+type fakeSubscriptions struct {
+	subscription *domain.Subscription
+}
+
+func (f *fakeSubscriptions) Subscribe(_ context.Context, subscription *domain.Subscription) error {
+	f.subscription = subscription
+
+	return nil
+}
+
+func Test_Catalog_ShippedCatalogsAreComplete(t *testing.T) {
+	catalog, err := mailtemplate.LoadCatalog(filepath.Join("..", "..", "template", "locale"), "en")
+	assert.Nil(t, err)
+
+	assert.ElementsMatch(t, domain.SupportedLocales(), catalog.Locales())
+	assert.Empty(t, catalog.MissingKeys())
+
+	for _, locale := range catalog.Locales() {
+		for _, name := range []string{
+			sendgrid.SubscribedTemplateName,
+			sendgrid.InvitationTemplateName,
+			sendgrid.EmailChangeTemplateName,
+			sendgrid.RemovedTemplateName,
+			sendgrid.IssueTemplateName,
+		} {
+			subject, err := catalog.Translate(locale, name+".subject", map[string]any{"NewsletterName": "Tech", "Title": "News"})
+			assert.Nil(t, err, "%s of %s", name, locale)
+			assert.NotEmpty(t, subject)
+		}
+	}
+}
+
+func Test_Catalog_MissingKeysAndFallback(t *testing.T) {
+	dir := newTestTemplateDir(t, map[string]string{
+		"en.json": `{"greeting": "Hello {{.Name}}", "farewell": "Bye"}`,
+		"cs.json": `{"greeting": "Ahoj {{.Name}}"}`,
+		"de.json": `{"greeting": "Hallo", "farewell": "Tschuss", "extra": "Extra"}`,
+	})
+
+	catalog, err := mailtemplate.LoadCatalog(dir, "en")
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"cs": {"extra", "farewell"},
+		"en": {"extra"},
+	}, catalog.MissingKeys())
+
+	greeting, err := catalog.Translate("cs", "greeting", map[string]any{"Name": "Jan"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Ahoj Jan", greeting)
+
+	farewell, err := catalog.Translate("cs", "farewell", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bye", farewell)
+
+	_, err = catalog.Translate("cs", "extra", nil)
+	assert.ErrorContains(t, err, "message extra is missing in catalog en")
+
+	_, err = catalog.Translate("en", "greeting", map[string]any{})
+	assert.ErrorContains(t, err, "failed to render message greeting")
+}
+
+func Test_MailTemplate_RenderLocalized(t *testing.T) {
+	dir := newTestTemplateDir(t, map[string]string{
+		"welcome.html":    `<a href="{{.Link}}">Leave</a>{{.PostalAddress}}`,
+		"welcome.txt":     `Leave {{.Link}} {{.PostalAddress}}`,
+		"welcome.cs.html": `<a href="{{.Link}}">Odhlásit</a>{{.PostalAddress}}`,
+	})
+	r, err := mailtemplate.NewRegistry(newDiscardLogger(), dir, "en", testTemplateSpecs)
+	assert.Nil(t, err)
+
+	text, html, err := r.Render("welcome", "cs", nil, testTemplateData)
+	assert.Nil(t, err)
+	assert.Equal(t, `<a href="https://example.com/unsubscribe">Odhlásit</a>Vodickova 1`, html)
+	// english text template is not used for czech html
+	assert.Equal(t, "Odhlásit [1]Vodickova 1\n\n[1] https://example.com/unsubscribe\n", text)
+
+	text, html, err = r.Render("welcome", "de", nil, testTemplateData)
+	assert.Nil(t, err)
+	assert.Equal(t, `<a href="https://example.com/unsubscribe">Leave</a>Vodickova 1`, html)
+	assert.Equal(t, "Leave https://example.com/unsubscribe Vodickova 1", text)
+}
+
+func Test_MailTemplate_LocalizedVariantIsValidated(t *testing.T) {
+	dir := newTestTemplateDir(t, map[string]string{
+		"welcome.html":    `<a href="{{.Link}}">Leave</a>{{.PostalAddress}}`,
+		"welcome.cs.html": `<p>Ahoj</p>{{.PostalAddress}}`,
+	})
+
+	_, err := mailtemplate.NewRegistry(newDiscardLogger(), dir, "en", testTemplateSpecs)
+	assert.ErrorContains(t, err, "template welcome.cs is missing required variables: Link")
+}
+
+func Test_AcceptLanguageHeader_Locale(t *testing.T) {
+	cases := map[string]string{
+		"":                              "",
+		"cs-CZ,cs;q=0.9,en;q=0.8":       "cs",
+		"de-DE,en-US;q=0.7,cs;q=0.5":    "en",
+		"en;q=0.5,cs;q=0.8":             "cs",
+		"de, fr;q=0.9":                  "",
+		"invalid;;q=abc":                "",
+		"EN-gb":                         "en",
+		"sk, cs;q=0.4, *;q=0.1":         "cs",
+		"cs;q=0, en;q=0.1":              "en",
+		"fr-CA, fr;q=0.9, en-US;q=0.8,": "en",
+	}
+
+	for value, expected := range cases {
+		t.Run(value, func(t *testing.T) {
+			h := &request.AcceptLanguageHeader{Value: value}
+
+			assert.Equal(t, expected, h.Locale(domain.SupportedLocales()))
+		})
+	}
+}
+
+func Test_SubscribeToNewsletter_Locale(t *testing.T) {
+	cases := map[string]struct {
+		locale         string
+		expectedLocale string
+		expectedErr    error
+	}{
+		"default locale": {locale: "", expectedLocale: "en"},
+		"czech":          {locale: "cs", expectedLocale: "cs"},
+		"upper case":     {locale: "CS", expectedLocale: "cs"},
+		"unsupported":    {locale: "de", expectedErr: application.InvalidLocaleError},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			subscriptions := &fakeSubscriptions{}
+			h := handler.NewSubscribeToNewsletterHandler(&fakeSubscriptionTokens{}, subscriptions)
+
+			err := h.Handle(context.Background(), domain.NewID().String(), "john@example.com", tc.locale)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, subscriptions.subscription)
+
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedLocale, subscriptions.subscription.Locale().String())
+		})
+	}
+}
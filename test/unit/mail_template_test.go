@@ -0,0 +1,234 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/app/config"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/mailtemplate"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/sendgrid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTemplateSpecs = map[string]*mailtemplate.Variables{
+	"welcome": {
+		Required:    []string{"Link", "PostalAddress"},
+		Optional:    []string{"Recipient", "Items"},
+		Overridable: true,
+	},
+}
+
+var testTemplateData = map[string]any{
+	"Recipient":     "john@example.com",
+	"Link":          "https://example.com/unsubscribe",
+	"PostalAddress": "Vodickova 1",
+	"Items":         []map[string]string{{"Title": "first"}},
+}
+
+func Test_MailTemplate_RenderWithLayoutAndPartials(t *testing.T) {
+	dir := newTestTemplateDir(t, map[string]string{
+		"layout/base.html":    `<html><title>{{block "title" .}}News{{end}}</title><body>{{block "content" .}}{{end}}{{template "footer" .}}</body></html>`,
+		"partial/footer.html": `<p>{{.PostalAddress}}</p>`,
+		"partial/footer.txt":  `-- {{.PostalAddress}}`,
+		"welcome.html":        `{{define "title"}}Hi{{end}}{{define "content"}}<a href="{{.Link}}">Leave</a>{{range .Items}}<i>{{.Title}}</i>{{end}}{{end}}`,
+		"welcome.txt":         `Leave: {{.Link}} {{template "footer" .}}`,
+	})
+
+	r, err := mailtemplate.NewRegistry(newDiscardLogger(), dir, "en", testTemplateSpecs)
+	assert.Nil(t, err)
+
+	text, html, err := r.Render("welcome", "", nil, testTemplateData)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		`<html><title>Hi</title><body><a href="https://example.com/unsubscribe">Leave</a><i>first</i><p>Vodickova 1</p></body></html>`,
+		html,
+	)
+	assert.Equal(t, "Leave: https://example.com/unsubscribe -- Vodickova 1", text)
+}
+
+func Test_MailTemplate_RenderWithoutLayoutConvertsText(t *testing.T) {
+	dir := newTestTemplateDir(t, map[string]string{
+		"welcome.html": `<p><a href="{{.Link}}">Leave</a></p><p>{{.PostalAddress}}</p>`,
+	})
+
+	r, err := mailtemplate.NewRegistry(newDiscardLogger(), dir, "en", testTemplateSpecs)
+	assert.Nil(t, err)
+
+	text, html, err := r.Render("welcome", "", nil, testTemplateData)
+	assert.Nil(t, err)
+	assert.Equal(t, `<p><a href="https://example.com/unsubscribe">Leave</a></p><p>Vodickova 1</p>`, html)
+	assert.Equal(t, "Leave [1]\n\nVodickova 1\n\n[1] https://example.com/unsubscribe\n", text)
+}
+
+func Test_MailTemplate_LoadValidation(t *testing.T) {
+	cases := map[string]struct {
+		files       map[string]string
+		expectedErr string
+	}{
+		"missing template": {
+			files:       map[string]string{"other.html": `{{.Link}}`},
+			expectedErr: "template welcome is missing",
+		},
+		"missing required variable": {
+			files:       map[string]string{"welcome.html": `{{.Recipient}} {{.PostalAddress}}`},
+			expectedErr: "template welcome is missing required variables: Link",
+		},
+		"required variable only in unused partial": {
+			files: map[string]string{
+				"partial/unused.html": `{{.Link}}`,
+				"welcome.html":        `{{.PostalAddress}}`,
+			},
+			expectedErr: "template welcome is missing required variables: Link",
+		},
+		"unknown variable": {
+			files:       map[string]string{"welcome.html": `{{.Link}} {{.PostalAddress}} {{.Recipent}}`},
+			expectedErr: "template welcome references unknown variables: Recipent",
+		},
+		"unknown variable in text template": {
+			files: map[string]string{
+				"welcome.html": `{{.Link}} {{.PostalAddress}}`,
+				"welcome.txt":  `{{.Link}} {{.PostalAddress}} {{.Token}}`,
+			},
+			expectedErr: "template welcome.txt references unknown variables: Token",
+		},
+		"syntax error": {
+			files:       map[string]string{"welcome.html": `{{.Link}`},
+			expectedErr: "failed to parse template welcome",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := mailtemplate.NewRegistry(newDiscardLogger(), newTestTemplateDir(t, tc.files), "en", testTemplateSpecs)
+
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func Test_MailTemplate_Override(t *testing.T) {
+	dir := newTestTemplateDir(t, map[string]string{
+		"layout/base.html":    `<body>{{block "content" .}}{{end}}{{template "footer" .}}</body>`,
+		"partial/footer.html": `<p>{{.PostalAddress}}</p>`,
+		"welcome.html":        `{{define "content"}}<a href="{{.Link}}">Leave</a>{{end}}`,
+	})
+	r, err := mailtemplate.NewRegistry(newDiscardLogger(), dir, "en", testTemplateSpecs)
+	assert.Nil(t, err)
+
+	override := &mailtemplate.Override{HTML: `<h1>Hello {{.Recipient}}</h1><a href="{{.Link}}">Bye</a>`}
+	assert.Nil(t, r.Validate("welcome", override))
+
+	text, html, err := r.Render("welcome", "", override, testTemplateData)
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		`<body><h1>Hello john@example.com</h1><a href="https://example.com/unsubscribe">Bye</a><p>Vodickova 1</p></body>`,
+		html,
+	)
+	assert.Equal(t, "Hello john@example.com\n\nBye [1]\n\nVodickova 1\n\n[1] https://example.com/unsubscribe\n", text)
+
+	assert.ErrorContains(
+		t,
+		r.Validate("welcome", &mailtemplate.Override{HTML: `<h1>Hello</h1>`}),
+		"missing required variables: Link",
+	)
+	assert.ErrorContains(
+		t,
+		r.Validate("welcome", &mailtemplate.Override{HTML: `{{.Link}}`, Text: `{{.Link}} {{.PostalAddress}} {{.Secret}}`}),
+		"references unknown variables: Secret",
+	)
+	assert.ErrorContains(t, r.Validate("other", override), "can not be overridden")
+
+	// broken override falls back to default template
+	_, html, err = r.Render("welcome", "", &mailtemplate.Override{HTML: `{{.Link`}, testTemplateData)
+	assert.Nil(t, err)
+	assert.Equal(t, `<body><a href="https://example.com/unsubscribe">Leave</a><p>Vodickova 1</p></body>`, html)
+}
+
+func Test_MailTemplate_ReloadKeepsPreviousOnError(t *testing.T) {
+	dir := newTestTemplateDir(t, map[string]string{
+		"welcome.html": `<a href="{{.Link}}">v1</a>{{.PostalAddress}}`,
+	})
+	r, err := mailtemplate.NewRegistry(newDiscardLogger(), dir, "en", testTemplateSpecs)
+	assert.Nil(t, err)
+
+	writeTestTemplate(t, dir, "welcome.html", `broken {{.PostalAddress}}`)
+	assert.ErrorContains(t, r.Reload(), "missing required variables: Link")
+
+	_, html, err := r.Render("welcome", "", nil, testTemplateData)
+	assert.Nil(t, err)
+	assert.Equal(t, `<a href="https://example.com/unsubscribe">v1</a>Vodickova 1`, html)
+
+	writeTestTemplate(t, dir, "welcome.html", `<a href="{{.Link}}">v2</a>{{.PostalAddress}}`)
+	assert.Nil(t, r.Reload())
+
+	_, html, err = r.Render("welcome", "", nil, testTemplateData)
+	assert.Nil(t, err)
+	assert.Equal(t, `<a href="https://example.com/unsubscribe">v2</a>Vodickova 1`, html)
+}
+
+func Test_MailTemplate_WatchReloadsChanges(t *testing.T) {
+	dir := newTestTemplateDir(t, map[string]string{
+		"welcome.html": `<a href="{{.Link}}">v1</a>{{.PostalAddress}}`,
+	})
+	r, err := mailtemplate.NewRegistry(newDiscardLogger(), dir, "en", testTemplateSpecs)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r.Watch(ctx, 10*time.Millisecond)
+
+	writeTestTemplate(t, dir, "welcome.html", `<a href="{{.Link}}">version 2</a>{{.PostalAddress}}`)
+
+	assert.Eventually(t, func() bool {
+		_, html, err := r.Render("welcome", "", nil, testTemplateData)
+		return err == nil && html == `<a href="https://example.com/unsubscribe">version 2</a>Vodickova 1`
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_MailService_ShippedTemplatesAreValid(t *testing.T) {
+	ms := sendgrid.NewMailService(
+		newDiscardLogger(),
+		&config.AppConfig{SendGridTemplateDir: filepath.Join("..", "..", "template")},
+		nil,
+		nil,
+	)
+
+	assert.Nil(t, ms.ValidateTemplate(sendgrid.SubscribedTemplateName, `<a href="{{.Link}}">Unsubscribe</a>`, ""))
+
+	err := ms.ValidateTemplate(sendgrid.SubscribedTemplateName, `<p>No way out</p>`, "")
+	assert.True(t, errors.Is(err, application.InvalidTemplateError))
+
+	err = ms.ValidateTemplate(sendgrid.EmailChangeTemplateName, `<a href="{{.Link}}">Confirm</a>`, "")
+	assert.True(t, errors.Is(err, application.InvalidTemplateError))
+}
+
+func newTestTemplateDir(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		writeTestTemplate(t, dir, name, content)
+	}
+
+	return dir
+}
+
+func writeTestTemplate(t *testing.T, dir, name, content string) {
+	path := filepath.Join(dir, name)
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func newDiscardLogger() *logrus.Logger {
+	lg := logrus.New()
+	lg.SetOutput(io.Discard)
+
+	return lg
+}
@@ -0,0 +1,191 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMemberRepository struct {
+	newsletter  *domain.Newsletter
+	roles       map[string]domain.Role
+	invitations map[string]*domain.Invitation
+	users       map[string]*domain.User
+}
+
+func newFakeMemberRepository() *fakeMemberRepository {
+	return &fakeMemberRepository{
+		newsletter:  domain.NewNewsletter("team newsletter", nil),
+		roles:       make(map[string]domain.Role),
+		invitations: make(map[string]*domain.Invitation),
+		users:       make(map[string]*domain.User),
+	}
+}
+
+func (r *fakeMemberRepository) addUser(email string, role domain.Role) *domain.User {
+	e, _ := domain.NewEmail(email)
+	user := domain.CreateUserFromExisting(domain.NewID(), e, nil, false)
+	r.users[user.ID().String()] = user
+	if role != "" {
+		r.roles[user.ID().String()] = role
+	}
+
+	return user
+}
+
+func (r *fakeMemberRepository) GetRole(_ context.Context, _, userID *domain.ID) (domain.Role, error) {
+	role, ok := r.roles[userID.String()]
+	if !ok {
+		return "", application.NewsletterNotFoundError
+	}
+
+	return role, nil
+}
+
+func (r *fakeMemberRepository) GetByPublicID(_ context.Context, _ *domain.ID) (*domain.Newsletter, error) {
+	return r.newsletter, nil
+}
+
+func (r *fakeMemberRepository) GetByID(_ context.Context, userID *domain.ID) (*domain.User, error) {
+	return r.users[userID.String()], nil
+}
+
+func (r *fakeMemberRepository) Invite(_ context.Context, invitation *domain.Invitation, token string) error {
+	r.invitations[token] = invitation
+
+	return nil
+}
+
+func (r *fakeMemberRepository) GetInvitationByToken(_ context.Context, token string) (*domain.Invitation, error) {
+	invitation, ok := r.invitations[token]
+	if !ok {
+		return nil, application.InvitationNotFoundError
+	}
+
+	return invitation, nil
+}
+
+func (r *fakeMemberRepository) AcceptInvitation(_ context.Context, invitation *domain.Invitation, userID *domain.ID) error {
+	r.roles[userID.String()] = invitation.Role()
+	for token, i := range r.invitations {
+		if i == invitation {
+			delete(r.invitations, token)
+		}
+	}
+
+	return nil
+}
+
+func (r *fakeMemberRepository) RemoveMember(_ context.Context, _, userID *domain.ID) error {
+	if _, ok := r.roles[userID.String()]; !ok {
+		return application.MemberNotFoundError
+	}
+	delete(r.roles, userID.String())
+
+	return nil
+}
+
+func (r *fakeMemberRepository) TransferOwnership(_ context.Context, _, ownerID, newOwnerID *domain.ID) error {
+	if _, ok := r.roles[newOwnerID.String()]; !ok {
+		return application.MemberNotFoundError
+	}
+	r.roles[ownerID.String()] = domain.RoleEditor
+	r.roles[newOwnerID.String()] = domain.RoleOwner
+
+	return nil
+}
+
+func Test_Member_RolePermissions(t *testing.T) {
+	assert.True(t, domain.RoleOwner.CanManage())
+	assert.True(t, domain.RoleOwner.CanEdit())
+	assert.False(t, domain.RoleEditor.CanManage())
+	assert.True(t, domain.RoleEditor.CanEdit())
+	assert.False(t, domain.RoleViewer.CanEdit())
+	assert.True(t, domain.RoleViewer.CanView())
+
+	_, err := domain.NewRole("admin")
+	assert.ErrorIs(t, err, application.InvalidRoleError)
+}
+
+func Test_Member_InviteAndAccept(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeMemberRepository()
+	owner := repo.addUser("owner@test.com", domain.RoleOwner)
+	editor := repo.addUser("editor@test.com", "")
+	stranger := repo.addUser("stranger@test.com", "")
+	publicID := repo.newsletter.PublicID().String()
+
+	tokens := []string{"token-1", "token-2"}
+	generateToken := func() (string, error) {
+		token := tokens[0]
+		tokens = tokens[1:]
+
+		return token, nil
+	}
+	imh := handler.NewInviteMemberHandler(repo, repo, repo, generateToken)
+
+	err := imh.Handle(ctx, owner.ID().String(), publicID, "editor@test.com", "owner")
+	assert.ErrorIs(t, err, application.InvalidRoleError, "ownership is not granted by invitation")
+
+	err = imh.Handle(ctx, stranger.ID().String(), publicID, "editor@test.com", "editor")
+	assert.ErrorIs(t, err, application.NewsletterNotFoundError, "non-member does not see newsletter")
+
+	assert.Nil(t, imh.Handle(ctx, owner.ID().String(), publicID, "editor@test.com", "editor"))
+	assert.Equal(t, repo.newsletter.Name(), repo.invitations["token-1"].NewsletterName())
+
+	now := time.Now()
+	aih := handler.NewAcceptInvitationHandler(repo, repo, func() time.Time { return now })
+
+	_, err = aih.Handle(ctx, stranger.ID().String(), "token-1")
+	assert.ErrorIs(t, err, application.InvitationEmailMismatchError)
+
+	now = now.Add(domain.InvitationExpiration)
+	_, err = aih.Handle(ctx, editor.ID().String(), "token-1")
+	assert.ErrorIs(t, err, application.InvitationNotFoundError, "expired invitation")
+
+	now = time.Now()
+	invitation, err := aih.Handle(ctx, editor.ID().String(), "token-1")
+	assert.Nil(t, err)
+	assert.Equal(t, domain.RoleEditor, invitation.Role())
+	assert.Equal(t, domain.RoleEditor, repo.roles[editor.ID().String()])
+
+	err = imh.Handle(ctx, editor.ID().String(), publicID, "stranger@test.com", "viewer")
+	assert.ErrorIs(t, err, application.InsufficientRoleError, "editor can not invite")
+}
+
+func Test_Member_RemoveAndTransfer(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeMemberRepository()
+	owner := repo.addUser("owner@test.com", domain.RoleOwner)
+	editor := repo.addUser("editor@test.com", domain.RoleEditor)
+	viewer := repo.addUser("viewer@test.com", domain.RoleViewer)
+	publicID := repo.newsletter.PublicID().String()
+
+	rmh := handler.NewRemoveMemberHandler(repo, repo)
+	toh := handler.NewTransferOwnershipHandler(repo, repo)
+
+	err := rmh.Handle(ctx, editor.ID().String(), publicID, viewer.ID().String())
+	assert.ErrorIs(t, err, application.InsufficientRoleError, "editor can not remove others")
+
+	err = rmh.Handle(ctx, owner.ID().String(), publicID, owner.ID().String())
+	assert.ErrorIs(t, err, application.OwnerRemovalError)
+
+	assert.Nil(t, rmh.Handle(ctx, viewer.ID().String(), publicID, viewer.ID().String()), "member can leave")
+
+	err = toh.Handle(ctx, editor.ID().String(), publicID, editor.ID().String())
+	assert.ErrorIs(t, err, application.InsufficientRoleError)
+
+	err = toh.Handle(ctx, owner.ID().String(), publicID, viewer.ID().String())
+	assert.ErrorIs(t, err, application.MemberNotFoundError, "ownership only goes to members")
+
+	assert.Nil(t, toh.Handle(ctx, owner.ID().String(), publicID, editor.ID().String()))
+	assert.Equal(t, domain.RoleOwner, repo.roles[editor.ID().String()])
+	assert.Equal(t, domain.RoleEditor, repo.roles[owner.ID().String()])
+
+	assert.Nil(t, rmh.Handle(ctx, editor.ID().String(), publicID, owner.ID().String()), "new owner removes previous one")
+}
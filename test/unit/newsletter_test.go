@@ -0,0 +1,100 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeNewsletterRemoval struct {
+	archived []string
+	deleted  []string
+	notified bool
+}
+
+func (r *fakeNewsletterRemoval) Archive(_ context.Context, newsletterPublicID *domain.ID, notify bool) error {
+	r.archived = append(r.archived, newsletterPublicID.String())
+	r.notified = notify
+
+	return nil
+}
+
+func (r *fakeNewsletterRemoval) Delete(_ context.Context, newsletterPublicID *domain.ID, notify bool) error {
+	r.deleted = append(r.deleted, newsletterPublicID.String())
+	r.notified = notify
+
+	return nil
+}
+
+func Test_Newsletter_Update(t *testing.T) {
+	description := "description"
+	n := domain.NewNewsletter("name", &description)
+
+	renamed := "renamed"
+	n.Update(&renamed, nil)
+	assert.Equal(t, "renamed", n.Name())
+	assert.Equal(t, "description", *n.Description(), "nil keeps description")
+
+	empty := ""
+	n.Update(nil, &empty)
+	assert.Equal(t, "renamed", n.Name())
+	assert.Nil(t, n.Description(), "empty description is removed")
+}
+
+func Test_UpdateNewsletter_OwnerOnly(t *testing.T) {
+	repo := newFakeMemberRepository()
+	owner := repo.addUser("owner@test.com", domain.RoleOwner)
+	editor := repo.addUser("editor@test.com", domain.RoleEditor)
+	outsider := repo.addUser("outsider@test.com", "")
+	updates := &fakeNewsletterUpdates{}
+	h := handler.NewUpdateNewsletterHandler(repo, repo, updates)
+	pubID := repo.newsletter.PublicID().String()
+	name := "renamed"
+
+	_, err := h.Handle(context.Background(), editor.ID().String(), pubID, &name, nil)
+	assert.ErrorIs(t, err, application.InsufficientRoleError)
+	_, err = h.Handle(context.Background(), outsider.ID().String(), pubID, &name, nil)
+	assert.ErrorIs(t, err, application.NewsletterNotFoundError)
+	assert.Empty(t, updates.updated)
+
+	n, err := h.Handle(context.Background(), owner.ID().String(), pubID, &name, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "renamed", n.Name())
+	assert.Equal(t, []string{"renamed"}, updates.updated)
+}
+
+func Test_RemoveNewsletter(t *testing.T) {
+	repo := newFakeMemberRepository()
+	owner := repo.addUser("owner@test.com", domain.RoleOwner)
+	editor := repo.addUser("editor@test.com", domain.RoleEditor)
+	removal := &fakeNewsletterRemoval{}
+	h := handler.NewRemoveNewsletterHandler(repo, removal)
+	pubID := repo.newsletter.PublicID().String()
+
+	err := h.Handle(context.Background(), editor.ID().String(), pubID, false, false)
+	assert.ErrorIs(t, err, application.InsufficientRoleError)
+	assert.Empty(t, removal.archived)
+
+	assert.Nil(t, h.Handle(context.Background(), owner.ID().String(), pubID, false, true))
+	assert.Equal(t, []string{pubID}, removal.archived)
+	assert.Empty(t, removal.deleted)
+	assert.True(t, removal.notified)
+
+	assert.Nil(t, h.Handle(context.Background(), owner.ID().String(), pubID, true, false))
+	assert.Equal(t, []string{pubID}, removal.deleted)
+	assert.False(t, removal.notified)
+}
+
+type fakeNewsletterUpdates struct {
+	updated []string
+}
+
+func (r *fakeNewsletterUpdates) Update(_ context.Context, newsletter *domain.Newsletter) error {
+	r.updated = append(r.updated, newsletter.Name())
+
+	return nil
+}
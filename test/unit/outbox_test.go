@@ -0,0 +1,91 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/controller"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOutbox struct {
+	failed  []*dto.FailedEvent
+	limit   int
+	retried []string
+}
+
+func (f *fakeOutbox) GetFailedEvents(_ context.Context, limit int) ([]*dto.FailedEvent, error) {
+	f.limit = limit
+
+	return f.failed, nil
+}
+
+func (f *fakeOutbox) RetryFailedEvent(_ context.Context, eventID *domain.ID) error {
+	for i, e := range f.failed {
+		if e.ID == eventID.String() {
+			f.failed = append(f.failed[:i], f.failed[i+1:]...)
+			f.retried = append(f.retried, e.ID)
+
+			return nil
+		}
+	}
+
+	return application.FailedEventNotFoundError
+}
+
+func Test_MaintenanceController_FailedEvents(t *testing.T) {
+	lastError := "failed to set subscription cache"
+	event := &dto.FailedEvent{
+		ID:                 domain.NewID().String(),
+		Name:               string(domain.EventSubscriptionCreated),
+		AggregateKey:       "subscription",
+		NewsletterPublicID: domain.NewID().String(),
+		OccurredAt:         time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+		Attempts:           20,
+		LastError:          &lastError,
+		FailedAt:           time.Date(2024, 10, 1, 21, 0, 0, 0, time.UTC),
+	}
+	outbox := &fakeOutbox{failed: []*dto.FailedEvent{event}}
+	c := controller.NewMaintenanceController(
+		newDiscardLogger(),
+		"token",
+		nil,
+		handler.NewGetFailedEventsHandler(outbox),
+		handler.NewRetryFailedEventHandler(outbox),
+	)
+	engine := gin.New()
+	engine.GET("api/maintenance/events/failed", c.GetFailedEvents)
+	engine.POST("api/maintenance/events/failed/:event_id/retry", c.RetryFailedEvent)
+
+	w := serveTestRequest(engine, "/api/maintenance/events/failed", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listed []*response.FailedEvent
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 1)
+	assert.Equal(t, event.ID, listed[0].ID)
+	assert.Equal(t, 20, listed[0].Attempts)
+	assert.Equal(t, lastError, *listed[0].LastError)
+	assert.Equal(t, 100, outbox.limit)
+
+	retry := func(id string) int {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/maintenance/events/failed/"+id+"/retry", nil))
+
+		return w.Code
+	}
+	assert.Equal(t, http.StatusBadRequest, retry("abc"))
+	assert.Equal(t, http.StatusNotFound, retry(domain.NewID().String()))
+	assert.Equal(t, http.StatusNoContent, retry(event.ID))
+	assert.Equal(t, []string{event.ID}, outbox.retried)
+	assert.Equal(t, http.StatusNotFound, retry(event.ID), "retried event is no longer failed")
+}
@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/sender"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSenderIdentities struct {
+	updated []*domain.SenderIdentity
+}
+
+func (f *fakeSenderIdentities) UpdateSenderIdentity(
+	_ context.Context,
+	_ *domain.ID,
+	identity *domain.SenderIdentity,
+) error {
+	f.updated = append(f.updated, identity)
+
+	return nil
+}
+
+func Test_StaticDomains(t *testing.T) {
+	sd := sender.NewStaticDomains("newsletter@test.com", []string{"News.Test.com"})
+
+	for address, expected := range map[string]bool{
+		"newsletter@test.com":     true,
+		"anyone@news.test.com":    true,
+		"other@test.com":          false,
+		"newsletter@other.com":    false,
+		"newsletter@sub.test.com": false,
+	} {
+		email, err := domain.NewEmail(address)
+		assert.Nil(t, err)
+		verified, err := sd.IsVerifiedSenderAddress(context.Background(), domain.NewID(), email)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, verified, address)
+	}
+	assert.ElementsMatch(t, []string{"test.com", "news.test.com"}, sd.Domains(), "default sender domain is signed")
+}
+
+func Test_UpdateSenderIdentity(t *testing.T) {
+	repo := newFakeMemberRepository()
+	owner := repo.addUser("owner@test.com", domain.RoleOwner)
+	editor := repo.addUser("editor@test.com", domain.RoleEditor)
+	identities := &fakeSenderIdentities{}
+	sd := sender.NewStaticDomains("newsletter@test.com", []string{"news.test.com"})
+	h := handler.NewUpdateSenderIdentityHandler(repo, identities, sd)
+	pubID := repo.newsletter.PublicID().String()
+	const postal = "Vodickova 1, Prague"
+
+	_, err := h.Handle(context.Background(), editor.ID().String(), pubID, "News", "news@news.test.com", "", postal)
+	assert.ErrorIs(t, err, application.InsufficientRoleError)
+
+	_, err = h.Handle(context.Background(), owner.ID().String(), pubID, "News", "news@spoofed.com", "", postal)
+	assert.ErrorIs(t, err, application.UnverifiedSenderDomainError)
+
+	_, err = h.Handle(context.Background(), owner.ID().String(), pubID, "News", "someone@test.com", "", postal)
+	assert.ErrorIs(t, err, application.UnverifiedSenderDomainError, "other mailbox of default sender domain")
+
+	_, err = h.Handle(context.Background(), owner.ID().String(), pubID, "News", "news@news.test.com", "invalid", postal)
+	assert.ErrorIs(t, err, application.InvalidEmailError)
+	assert.Empty(t, identities.updated)
+
+	identity, err := h.Handle(context.Background(), owner.ID().String(), pubID, "News", "news@news.test.com", "editor@other.com", postal)
+	assert.Nil(t, err)
+	assert.Equal(t, "news@news.test.com", identity.FromAddress().String())
+	assert.Equal(t, "editor@other.com", identity.ReplyTo().String(), "reply to is not restricted to verified domains")
+	assert.Equal(t, postal, identity.PostalAddress())
+
+	identity, err = h.Handle(context.Background(), owner.ID().String(), pubID, "News", "newsletter@test.com", "", postal)
+	assert.Nil(t, err, "exact default sender is allowed")
+	assert.Equal(t, "newsletter@test.com", identity.FromAddress().String())
+
+	identity, err = h.Handle(context.Background(), owner.ID().String(), pubID, "", "", "", "")
+	assert.Nil(t, err, "empty identity resets to defaults")
+	assert.Nil(t, identity.FromAddress())
+	assert.Len(t, identities.updated, 3)
+}
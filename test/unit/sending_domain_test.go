@@ -0,0 +1,202 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/dkim"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/dns"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/sender"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResolver struct {
+	records map[string][]string
+	err     error
+}
+
+func (r *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	records, ok := r.records[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+
+	return append([]string(nil), records...), nil
+}
+
+type fakeSendingDomains struct {
+	domains map[string]*domain.SendingDomain
+}
+
+func (f *fakeSendingDomains) Get(_ context.Context, userID, id *domain.ID) (*domain.SendingDomain, error) {
+	sd, ok := f.domains[id.String()]
+	if !ok || sd.UserID().String() != userID.String() {
+		return nil, application.SendingDomainNotFoundError
+	}
+
+	return sd, nil
+}
+
+func (f *fakeSendingDomains) UpdateCheck(_ context.Context, sd *domain.SendingDomain) error {
+	f.domains[sd.ID().String()] = sd
+
+	return nil
+}
+
+func (f *fakeSendingDomains) IsVerifiedSenderAddress(
+	_ context.Context,
+	userID *domain.ID,
+	address *domain.Email,
+) (bool, error) {
+	for _, sd := range f.domains {
+		if sd.UserID().String() == userID.String() && sd.Name() == address.Domain() && sd.IsVerified() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func Test_NewSendingDomain(t *testing.T) {
+	sd, err := domain.NewSendingDomain(domain.NewID(), " News.Example.com. ", "token", "private", "public")
+	assert.Nil(t, err)
+	assert.Equal(t, "news.example.com", sd.Name())
+	assert.False(t, sd.IsVerified())
+
+	for _, name := range []string{"", "localhost", "-bad.example.com", "a..example.com", "example.com/path", "news@example.com"} {
+		_, err := domain.NewSendingDomain(domain.NewID(), name, "token", "private", "public")
+		assert.ErrorIs(t, err, application.InvalidDomainError, name)
+	}
+}
+
+func Test_Checker(t *testing.T) {
+	sd := newTestSendingDomain(t, domain.NewID())
+	resolver := &fakeResolver{records: publishedRecords(sd)}
+	checker := dns.NewChecker(resolver, "sendgrid.net")
+
+	check, err := checker.Check(context.Background(), sd)
+	assert.Nil(t, err)
+	assert.True(t, check.Ownership && check.SPF && check.DKIM && check.DMARC)
+
+	resolver.records["news.example.com"] = []string{"v=spf1 include:_spf.google.com ~all"}
+	resolver.records["_newsletter-verification.news.example.com"] = []string{"newsletter-verification=other"}
+	resolver.records[sd.DKIMSelector()+"._domainkey.news.example.com"] = []string{"v=DKIM1; k=rsa; p=AAAA"}
+	delete(resolver.records, "_dmarc.news.example.com")
+	check, err = checker.Check(context.Background(), sd)
+	assert.Nil(t, err)
+	assert.False(t, check.SPF, "spf without mail provider")
+	assert.False(t, check.Ownership, "different token")
+	assert.False(t, check.DKIM, "different key")
+	assert.False(t, check.DMARC, "missing record is not an error")
+
+	resolver.records["news.example.com"] = []string{"v=spf1 include:sendgrid.net ~all", "v=spf1 -all"}
+	check, err = checker.Check(context.Background(), sd)
+	assert.Nil(t, err)
+	assert.False(t, check.SPF, "multiple spf policies are invalid")
+
+	resolver.err = errors.New("i/o timeout")
+	_, err = checker.Check(context.Background(), sd)
+	assert.NotNil(t, err, "resolver failure is reported")
+}
+
+func Test_Checker_SplitsDKIMRecord(t *testing.T) {
+	sd := newTestSendingDomain(t, domain.NewID())
+	checker := dns.NewChecker(&fakeResolver{}, "sendgrid.net")
+
+	var value string
+	for _, r := range checker.Records(sd) {
+		if r.Purpose == dto.RecordPurposeDKIM {
+			value = r.Value
+		} else {
+			assert.NotContains(t, r.Value, `"`, "short record is not split")
+		}
+	}
+
+	var joined string
+	for _, part := range strings.SplitAfter(value, `" `) {
+		unquoted, err := strconv.Unquote(strings.TrimSpace(part))
+		assert.Nil(t, err)
+		assert.LessOrEqual(t, len(unquoted), 255)
+		joined += unquoted
+	}
+	assert.Greater(t, len(joined), 255)
+	assert.Equal(t, "v=DKIM1; k=rsa; p="+sd.DKIMPublicKey(), joined)
+}
+
+func Test_VerifySendingDomain(t *testing.T) {
+	owner := domain.NewID()
+	sd := newTestSendingDomain(t, owner)
+	repo := &fakeSendingDomains{domains: map[string]*domain.SendingDomain{sd.ID().String(): sd}}
+	resolver := &fakeResolver{records: publishedRecords(sd)}
+	now := time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC)
+	h := handler.NewVerifySendingDomainHandler(repo, repo, dns.NewChecker(resolver, "sendgrid.net"), func() time.Time {
+		return now
+	})
+	verifier := sender.NewDomainVerifiers(sender.NewStaticDomains("newsletter@test.com", nil), repo)
+
+	_, err := h.Handle(context.Background(), domain.NewID().String(), sd.ID().String())
+	assert.ErrorIs(t, err, application.SendingDomainNotFoundError, "domain of other user")
+
+	ok, err := verifier.IsVerifiedSenderAddress(context.Background(), owner, mustEmail(t, "news@news.example.com"))
+	assert.Nil(t, err)
+	assert.False(t, ok, "unverified domain can not be used")
+
+	res, err := h.Handle(context.Background(), owner.String(), sd.ID().String())
+	assert.Nil(t, err)
+	assert.True(t, res.Domain.IsVerified())
+	for _, r := range res.Records {
+		assert.True(t, r.Verified, r.Purpose)
+	}
+	verifiedAt := *res.Domain.VerifiedAt()
+
+	ok, err = verifier.IsVerifiedSenderAddress(context.Background(), owner, mustEmail(t, "news@news.example.com"))
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = verifier.IsVerifiedSenderAddress(context.Background(), domain.NewID(), mustEmail(t, "news@news.example.com"))
+	assert.Nil(t, err)
+	assert.False(t, ok, "domain is verified only for its owner")
+
+	now = now.Add(time.Hour)
+	res, err = h.Handle(context.Background(), owner.String(), sd.ID().String())
+	assert.Nil(t, err)
+	assert.Equal(t, verifiedAt, *res.Domain.VerifiedAt(), "repeated check keeps original verification time")
+	assert.Equal(t, now, *res.Domain.CheckedAt())
+
+	delete(resolver.records, "_dmarc.news.example.com")
+	res, err = h.Handle(context.Background(), owner.String(), sd.ID().String())
+	assert.Nil(t, err)
+	assert.False(t, res.Domain.IsVerified(), "removed record revokes verification")
+	assert.True(t, res.Domain.DKIMVerified())
+	assert.False(t, res.Domain.DMARCVerified())
+}
+
+func newTestSendingDomain(t *testing.T, userID *domain.ID) *domain.SendingDomain {
+	privateKey, publicKey, err := dkim.GenerateKey()
+	assert.Nil(t, err)
+	sd, err := domain.NewSendingDomain(userID, "news.example.com", "token", privateKey, publicKey)
+	assert.Nil(t, err)
+
+	return sd
+}
+
+// publishedRecords returns records as owner would publish them next to unrelated ones.
+func publishedRecords(sd *domain.SendingDomain) map[string][]string {
+	return map[string][]string{
+		"_newsletter-verification.news.example.com":        {"newsletter-verification=" + sd.VerificationToken()},
+		"news.example.com":                                 {"google-site-verification=abc", "v=spf1 include:sendgrid.net ~all"},
+		sd.DKIMSelector() + "._domainkey.news.example.com": {"v=DKIM1; k=rsa; p=" + sd.DKIMPublicKey()},
+		"_dmarc.news.example.com":                          {"v=DMARC1; p=none; rua=mailto:dmarc@example.com"},
+	}
+}
@@ -0,0 +1,235 @@
+package unit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/sendgrid"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDeliveryEvents struct {
+	events  []*domain.DeliveryEvent
+	missing *domain.ID
+}
+
+func (f *fakeDeliveryEvents) RecordDeliveryEvent(_ context.Context, event *domain.DeliveryEvent) error {
+	if f.missing != nil && event.EmailJobID().String() == f.missing.String() {
+		return application.EmailJobNotFoundError
+	}
+	f.events = append(f.events, event)
+
+	return nil
+}
+
+type fakeNewsletterStats struct {
+	period *domain.StatsPeriod
+}
+
+func (f *fakeNewsletterStats) GetNewsletterStats(
+	_ context.Context,
+	_ *domain.ID,
+	period *domain.StatsPeriod,
+) (*dto.NewsletterStats, error) {
+	f.period = period
+
+	return dto.NewNewsletterStats(period, 0, nil), nil
+}
+
+func date(value string) *time.Time {
+	d, _ := time.Parse(time.DateOnly, value)
+
+	return &d
+}
+
+func Test_StatsPeriod(t *testing.T) {
+	// Friday
+	now := time.Date(2024, 9, 20, 15, 30, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		interval      domain.StatsInterval
+		from, to      *time.Time
+		expectedFrom  string
+		expectedTo    string
+		expectedCount int
+	}{
+		"default days": {
+			interval: domain.StatsIntervalDay, expectedFrom: "2024-08-22", expectedTo: "2024-09-20", expectedCount: 30,
+		},
+		"default weeks start on monday": {
+			interval: domain.StatsIntervalWeek, expectedFrom: "2024-02-26", expectedTo: "2024-09-20", expectedCount: 30,
+		},
+		"default months": {
+			interval: domain.StatsIntervalMonth, expectedFrom: "2022-04-01", expectedTo: "2024-09-20", expectedCount: 30,
+		},
+		"from is aligned to bucket": {
+			interval:      domain.StatsIntervalMonth,
+			from:          date("2024-07-15"),
+			to:            date("2024-09-01"),
+			expectedFrom:  "2024-07-01",
+			expectedTo:    "2024-09-01",
+			expectedCount: 3,
+		},
+		"single day": {
+			interval:      domain.StatsIntervalDay,
+			from:          date("2024-02-29"),
+			to:            date("2024-02-29"),
+			expectedFrom:  "2024-02-29",
+			expectedTo:    "2024-02-29",
+			expectedCount: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := domain.NewStatsPeriod(tc.interval, tc.from, tc.to, now)
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expectedFrom, p.From().Format(time.DateOnly))
+			assert.Equal(t, tc.expectedTo, p.To().Format(time.DateOnly))
+			assert.Len(t, p.Buckets(), tc.expectedCount)
+		})
+	}
+
+	_, err := domain.NewStatsPeriod(domain.StatsIntervalDay, date("2024-09-21"), date("2024-09-20"), now)
+	assert.ErrorIs(t, err, application.InvalidStatsPeriodError, "start after end")
+
+	_, err = domain.NewStatsPeriod(domain.StatsIntervalDay, date("2020-01-01"), nil, now)
+	assert.ErrorIs(t, err, application.InvalidStatsPeriodError, "too many buckets")
+
+	_, err = domain.NewStatsPeriod(domain.StatsIntervalMonth, date("2020-01-01"), nil, now)
+	assert.Nil(t, err, "same range in months is fine")
+}
+
+func Test_NewNewsletterStats_FillsGapsAndCountsSubscribers(t *testing.T) {
+	period, err := domain.NewStatsPeriod(domain.StatsIntervalWeek, date("2024-09-02"), date("2024-09-22"), time.Now())
+	assert.Nil(t, err)
+
+	stats := dto.NewNewsletterStats(period, 100, map[time.Time]*dto.StatsCounts{
+		*date("2024-09-02"): {Subscribed: 10, Unsubscribed: 2, Sent: 108, Delivered: 100, Bounced: 8, Opened: 50},
+		*date("2024-09-16"): {Subscribed: 1, Unsubscribed: 5, Clicked: 7},
+	})
+
+	assert.Len(t, stats.Buckets, 3)
+	assert.Equal(t, []int{108, 108, 104}, []int{
+		stats.Buckets[0].Subscribers,
+		stats.Buckets[1].Subscribers,
+		stats.Buckets[2].Subscribers,
+	})
+	assert.Equal(t, *date("2024-09-09"), stats.Buckets[1].Start)
+	assert.Equal(t, dto.StatsCounts{}, stats.Buckets[1].StatsCounts)
+	assert.Equal(t, 104, stats.Subscribers)
+	assert.Equal(t, dto.StatsCounts{
+		Subscribed:   11,
+		Unsubscribed: 7,
+		Sent:         108,
+		Delivered:    100,
+		Bounced:      8,
+		Opened:       50,
+		Clicked:      7,
+	}, stats.Totals)
+}
+
+func Test_GetNewsletterStats_Validation(t *testing.T) {
+	members := newFakeMemberRepository()
+	viewer := members.addUser("viewer@example.com", domain.RoleViewer)
+	stats := &fakeNewsletterStats{}
+	now := time.Date(2024, 9, 20, 15, 30, 0, 0, time.UTC)
+	h := handler.NewGetNewsletterStatsHandler(members, stats, func() time.Time { return now })
+	pubID := members.newsletter.PublicID().String()
+
+	_, err := h.Handle(context.Background(), viewer.ID().String(), pubID, "year", "", "")
+	assert.ErrorIs(t, err, application.InvalidStatsIntervalError)
+
+	_, err = h.Handle(context.Background(), viewer.ID().String(), pubID, "day", "20.9.2024", "")
+	assert.ErrorIs(t, err, application.InvalidStatsPeriodError)
+
+	res, err := h.Handle(context.Background(), viewer.ID().String(), pubID, "", "", "2024-09-01")
+	assert.Nil(t, err)
+	assert.Equal(t, domain.StatsIntervalDay, stats.period.Interval())
+	assert.Equal(t, "2024-09-01", stats.period.To().Format(time.DateOnly))
+	assert.Len(t, res.Buckets, 30)
+}
+
+func Test_EventWebhook_VerifiesAndParsesEvents(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	assert.Nil(t, err)
+	webhook, err := sendgrid.NewEventWebhook(base64.StdEncoding.EncodeToString(der))
+	assert.Nil(t, err)
+
+	delivered, bounced := domain.NewID(), domain.NewID()
+	payload := []byte(`[
+		{"event": "processed", "timestamp": 1726819200, "email_job_id": "` + delivered.String() + `"},
+		{"event": "delivered", "timestamp": 1726819200, "email_job_id": "` + delivered.String() + `"},
+		{"event": "dropped", "timestamp": 1726819300, "email_job_id": "` + bounced.String() + `"},
+		{"event": "delivered", "timestamp": 1726819300},
+		{"event": "bounce", "timestamp": 1726819300, "email_job_id": "not-uuid"}
+	]`)
+	timestamp := "1726819400"
+	sign := func(payload []byte) string {
+		digest := sha256.Sum256(append([]byte(timestamp), payload...))
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		assert.Nil(t, err)
+
+		return base64.StdEncoding.EncodeToString(sig)
+	}
+
+	events, err := webhook.ParseDeliveryEvents(sign(payload), timestamp, payload)
+	assert.Nil(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, delivered.String(), events[0].EmailJobID().String())
+	assert.Equal(t, domain.DeliveryEventDelivered, events[0].Type())
+	assert.True(t, time.Unix(1726819200, 0).Equal(events[0].OccurredAt()))
+	assert.Equal(t, bounced.String(), events[1].EmailJobID().String())
+	assert.Equal(t, domain.DeliveryEventBounced, events[1].Type())
+
+	_, err = webhook.ParseDeliveryEvents(sign(payload), "1726819401", payload)
+	assert.ErrorIs(t, err, application.InvalidWebhookSignatureError, "other timestamp")
+	_, err = webhook.ParseDeliveryEvents(sign([]byte(`[]`)), timestamp, payload)
+	assert.ErrorIs(t, err, application.InvalidWebhookSignatureError, "other payload")
+	_, err = webhook.ParseDeliveryEvents("", timestamp, payload)
+	assert.ErrorIs(t, err, application.InvalidWebhookSignatureError, "missing signature")
+
+	unconfigured, err := sendgrid.NewEventWebhook("")
+	assert.Nil(t, err)
+	_, err = unconfigured.ParseDeliveryEvents(sign(payload), timestamp, payload)
+	assert.ErrorIs(t, err, application.InvalidWebhookSignatureError, "webhook without key")
+
+	_, err = sendgrid.NewEventWebhook("bm90IGEga2V5")
+	assert.NotNil(t, err)
+}
+
+func Test_RecordDeliveryEvents_SkipsDeletedJobs(t *testing.T) {
+	deleted, kept := domain.NewID(), domain.NewID()
+	events := &fakeDeliveryEvents{missing: deleted}
+	parser := &fakeDeliveryEventParser{events: []*domain.DeliveryEvent{
+		domain.NewDeliveryEvent(deleted, domain.DeliveryEventDelivered, time.Now()),
+		domain.NewDeliveryEvent(kept, domain.DeliveryEventBounced, time.Now()),
+	}}
+	h := handler.NewRecordDeliveryEventsHandler(parser, events)
+
+	assert.Nil(t, h.Handle(context.Background(), "signature", "timestamp", []byte(`[]`)))
+	assert.Len(t, events.events, 1)
+	assert.Equal(t, kept.String(), events.events[0].EmailJobID().String())
+}
+
+type fakeDeliveryEventParser struct {
+	events []*domain.DeliveryEvent
+}
+
+func (f *fakeDeliveryEventParser) ParseDeliveryEvents(_, _ string, _ []byte) ([]*domain.DeliveryEvent, error) {
+	return f.events, nil
+}
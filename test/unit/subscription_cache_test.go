@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSubscribedNewsletters struct {
+	subscribed map[string][]string
+}
+
+func (f *fakeSubscribedNewsletters) GetSubscribedNewsletters(_ context.Context) (map[string][]string, error) {
+	return f.subscribed, nil
+}
+
+func Test_ReconcileSubscriptionCache_RepairsDriftBothWays(t *testing.T) {
+	kept, missing, stale := domain.NewID().String(), domain.NewID().String(), domain.NewID().String()
+	active := &fakeSubscribedNewsletters{subscribed: map[string][]string{
+		"john@example.com": {kept, missing},
+		"jane@example.com": {missing},
+	}}
+	cache := newFakeSubscribedNewsletterCache()
+	cache.subscribed["john@example.com"] = []string{kept, stale}
+	cache.subscribed["gone@example.com"] = []string{stale}
+
+	h := handler.NewReconcileSubscriptionCacheHandler(newDiscardLogger(), active, cache, time.Now)
+
+	report, err := h.Handle(context.Background())
+	assert.Nil(t, err)
+
+	assert.Equal(t, 3, report.Subscribers)
+	assert.Equal(t, 3, report.Subscriptions)
+	assert.Equal(t, 3, report.Cached)
+	assert.Equal(t, 2, report.Missing)
+	assert.Equal(t, 2, report.Stale)
+	assert.Equal(t, 4, report.Repaired)
+	assert.Equal(t, 2, report.Added)
+	assert.Equal(t, 2, report.Removed)
+	assert.Equal(t, 0, report.Failed)
+	assert.ElementsMatch(t, []string{kept, missing}, cache.subscribed["john@example.com"])
+	assert.Equal(t, []string{missing}, cache.subscribed["jane@example.com"])
+	assert.NotContains(t, cache.subscribed, "gone@example.com")
+
+	report, err = h.Handle(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.Missing+report.Stale)
+}
+
+func Test_ReconcileSubscriptionCache_CountsFailedRepairs(t *testing.T) {
+	pubID := domain.NewID().String()
+	active := &fakeSubscribedNewsletters{subscribed: map[string][]string{
+		"john@example.com": {pubID},
+		"jane@example.com": {pubID},
+	}}
+	cache := newFakeSubscribedNewsletterCache()
+	cache.failing = "jane@example.com"
+
+	h := handler.NewReconcileSubscriptionCacheHandler(newDiscardLogger(), active, cache, time.Now)
+
+	report, err := h.Handle(context.Background())
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, report.Missing)
+	assert.Equal(t, 1, report.Repaired)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, []string{pubID}, cache.subscribed["john@example.com"])
+}
+
+func Test_ReconcileSubscriptionCache_LogsAccumulatedCounters(t *testing.T) {
+	pubID := domain.NewID().String()
+	active := &fakeSubscribedNewsletters{subscribed: map[string][]string{"john@example.com": {pubID}}}
+	cache := newFakeSubscribedNewsletterCache()
+	cache.subscribed["gone@example.com"] = []string{pubID}
+	lg, hook := test.NewNullLogger()
+
+	h := handler.NewReconcileSubscriptionCacheHandler(lg, active, cache, time.Now)
+
+	_, err := h.Handle(context.Background())
+	assert.Nil(t, err)
+	cache.subscribed = map[string][]string{}
+	_, err = h.Handle(context.Background())
+	assert.Nil(t, err)
+
+	entry := hook.LastEntry()
+	assert.Equal(t, "[CACHE] Subscription cache reconciled", entry.Message)
+	assert.Equal(t, 1, entry.Data["added"])
+	assert.Equal(t, 0, entry.Data["removed"])
+	assert.Equal(t, 2, entry.Data["runs"])
+	assert.Equal(t, 2, entry.Data["total_added"])
+	assert.Equal(t, 1, entry.Data["total_removed"])
+	assert.Equal(t, 0, entry.Data["total_failed"])
+}
+
+func Test_UnsubscribeNewsletter_RaisesDisabledEvent(t *testing.T) {
+	repo := &fakeUnsubscribeRepository{}
+	h := handler.NewUnsubscribeNewsletterHandler(repo, &fakeUnsubscribeTokens{})
+	pubID := domain.NewID()
+
+	assert.Nil(t, h.Handle(context.Background(), pubID.String(), "valid"))
+
+	assert.Len(t, repo.unsubscriptions, 1)
+	events := repo.unsubscriptions[0].PullEvents()
+	assert.Len(t, events, 1)
+	assert.Equal(t, domain.EventSubscriptionDisabled, events[0].Name())
+}
+
+type fakeUnsubscribeRepository struct {
+	unsubscriptions []*domain.Unsubscription
+}
+
+func (f *fakeUnsubscribeRepository) Unsubscribe(_ context.Context, unsubscription *domain.Unsubscription) error {
+	f.unsubscriptions = append(f.unsubscriptions, unsubscription)
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package unit
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/jwt"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/response"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testIssuer   = "http://localhost"
+	testAudience = "newsletter-api"
+	testSecret   = "jwt-secret"
+)
+
+func Test_TokenManager_AsymmetricRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	writeRSAKey(t, dir, "rsa-1")
+	writeEd25519Key(t, dir, "ed-1")
+
+	user := newTestUser()
+
+	for _, kid := range []string{"rsa-1", "ed-1"} {
+		ks, err := jwt.NewKeySet("", dir, kid)
+		assert.Nil(t, err)
+		tm := jwt.NewTokenManager(ks, testIssuer, testAudience)
+
+		token, err := tm.GenerateUserToken(user)
+		assert.Nil(t, err)
+
+		parsed, _, err := gojwt.NewParser().ParseUnverified(token, gojwt.MapClaims{})
+		assert.Nil(t, err)
+		assert.Equal(t, kid, parsed.Header["kid"])
+
+		subject, err := tm.ParseToken(token)
+		assert.Nil(t, err)
+		assert.Equal(t, user.ID().String(), subject)
+	}
+}
+
+func Test_TokenManager_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	oldKey := writeRSAKey(t, dir, "2024-01")
+
+	oldKs, err := jwt.NewKeySet(testSecret, dir, "2024-01")
+	assert.Nil(t, err)
+	oldToken, err := jwt.NewTokenManager(oldKs, testIssuer, testAudience).GenerateUserToken(newTestUser())
+	assert.Nil(t, err)
+
+	legacyKs, err := jwt.NewKeySet(testSecret, "", "")
+	assert.Nil(t, err)
+	legacyToken, err := jwt.NewTokenManager(legacyKs, testIssuer, testAudience).GenerateUserToken(newTestUser())
+	assert.Nil(t, err)
+
+	// rotate: new active key, old one kept as public key only
+	assert.Nil(t, os.Remove(filepath.Join(dir, "2024-01.pem")))
+	writePublicKey(t, dir, "2024-01", &oldKey.PublicKey)
+	writeEd25519Key(t, dir, "2024-06")
+
+	ks, err := jwt.NewKeySet(testSecret, dir, "2024-06")
+	assert.Nil(t, err)
+	tm := jwt.NewTokenManager(ks, testIssuer, testAudience)
+
+	_, err = tm.ParseToken(oldToken)
+	assert.Nil(t, err, "token signed by retired key is still valid")
+	_, err = tm.ParseToken(legacyToken)
+	assert.Nil(t, err, "token signed by shared secret is still valid")
+
+	jwks := response.CreateJWKSResponseFromDto(ks.PublicKeys())
+	assert.Len(t, jwks.Keys, 2, "shared secret is never published")
+	assert.Equal(t, "2024-01", jwks.Keys[0].KeyID)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.Equal(t, "2024-06", jwks.Keys[1].KeyID)
+	assert.Equal(t, "OKP", jwks.Keys[1].KeyType)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+	assert.Equal(t, "EdDSA", jwks.Keys[1].Algorithm)
+
+	_, err = jwt.NewKeySet(testSecret, dir, "2024-01")
+	assert.NotNil(t, err, "public-only key can not sign")
+
+	_, err = jwt.NewKeySet("", t.TempDir(), "missing")
+	assert.NotNil(t, err, "active key has to exist")
+}
+
+func Test_TokenManager_ClaimsValidation(t *testing.T) {
+	dir := t.TempDir()
+	writeRSAKey(t, dir, "rsa-1")
+	ks, err := jwt.NewKeySet("", dir, "rsa-1")
+	assert.Nil(t, err)
+	tm := jwt.NewTokenManager(ks, testIssuer, testAudience)
+	user := newTestUser()
+
+	token, err := jwt.NewTokenManager(ks, "http://evil", testAudience).GenerateUserToken(user)
+	assert.Nil(t, err)
+	_, err = tm.ParseToken(token)
+	assert.NotNil(t, err, "foreign issuer rejected")
+
+	token, err = jwt.NewTokenManager(ks, testIssuer, "other-service").GenerateUserToken(user)
+	assert.Nil(t, err)
+	_, err = tm.ParseToken(token)
+	assert.NotNil(t, err, "foreign audience rejected")
+
+	otherDir := t.TempDir()
+	writeRSAKey(t, otherDir, "rsa-1")
+	otherKs, err := jwt.NewKeySet("", otherDir, "rsa-1")
+	assert.Nil(t, err)
+	token, err = jwt.NewTokenManager(otherKs, testIssuer, testAudience).GenerateUserToken(user)
+	assert.Nil(t, err)
+	_, err = tm.ParseToken(token)
+	assert.NotNil(t, err, "signature by different key with same kid rejected")
+
+	challengeID := domain.NewID()
+	challenge, err := tm.GenerateChallengeToken(user, challengeID)
+	assert.Nil(t, err)
+	_, err = tm.ParseToken(challenge)
+	assert.NotNil(t, err, "challenge token is not user token")
+	subject, parsedChallengeID, err := tm.ParseChallengeToken(challenge)
+	assert.Nil(t, err)
+	assert.Equal(t, user.ID().String(), subject)
+	assert.Equal(t, challengeID.String(), parsedChallengeID)
+	userToken, err := tm.GenerateUserToken(user)
+	assert.Nil(t, err)
+	_, _, err = tm.ParseChallengeToken(userToken)
+	assert.NotNil(t, err, "user token is not challenge token")
+
+	expired := gojwt.NewWithClaims(gojwt.SigningMethodHS256, gojwt.MapClaims{
+		"sub": user.ID().String(),
+		"iss": testIssuer,
+		"aud": testAudience,
+		"exp": time.Now().Add(-time.Minute).Unix(),
+	})
+	expiredStr, err := expired.SignedString([]byte(testSecret))
+	assert.Nil(t, err)
+	legacyKs, err := jwt.NewKeySet(testSecret, "", "")
+	assert.Nil(t, err)
+	_, err = jwt.NewTokenManager(legacyKs, testIssuer, testAudience).ParseToken(expiredStr)
+	assert.NotNil(t, err, "expired token rejected")
+}
+
+func newTestUser() *domain.User {
+	email, _ := domain.NewEmail("test@test.com")
+
+	return domain.CreateUserFromExisting(domain.NewID(), email, nil, false)
+}
+
+func writeRSAKey(t *testing.T, dir, kid string) *rsa.PrivateKey {
+	pk, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	writePEM(t, filepath.Join(dir, kid+".pem"), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(pk))
+
+	return pk
+}
+
+func writeEd25519Key(t *testing.T, dir, kid string) {
+	_, pk, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	b, err := x509.MarshalPKCS8PrivateKey(pk)
+	assert.Nil(t, err)
+	writePEM(t, filepath.Join(dir, kid+".pem"), "PRIVATE KEY", b)
+}
+
+func writePublicKey(t *testing.T, dir, kid string, pub any) {
+	b, err := x509.MarshalPKIXPublicKey(pub)
+	assert.Nil(t, err)
+	writePEM(t, filepath.Join(dir, kid+".pub.pem"), "PUBLIC KEY", b)
+}
+
+func writePEM(t *testing.T, path, blockType string, b []byte) {
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: b}), 0o600)
+	assert.Nil(t, err)
+}
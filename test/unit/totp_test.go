@@ -0,0 +1,379 @@
+package unit
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/dto"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+// base32 of RFC 6238 SHA1 test secret "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func Test_TOTP_RFC6238Vectors(t *testing.T) {
+	// last 6 digits of RFC 6238 appendix B SHA1 values
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1111111111: "050471",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range vectors {
+		clock := &fakeClock{now: time.Unix(unix, 0)}
+		g := totp.NewGenerator("newsletter", clock.Now)
+
+		code, err := g.GenerateCode(rfcSecret, clock.now)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, code, "time %d", unix)
+		step, ok := g.Validate(rfcSecret, expected)
+		assert.True(t, ok, "time %d", unix)
+		assert.Equal(t, unix/30, step, "time %d", unix)
+	}
+}
+
+func Test_TOTP_ValidateSkew(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1111111109, 0)}
+	g := totp.NewGenerator("newsletter", clock.Now)
+
+	code, err := g.GenerateCode(rfcSecret, clock.now)
+	assert.Nil(t, err)
+
+	clock.now = clock.now.Add(30 * time.Second)
+	step, ok := g.Validate(rfcSecret, code)
+	assert.True(t, ok, "previous step accepted")
+	assert.Equal(t, int64(1111111109/30), step, "step of code, not of current time")
+
+	clock.now = clock.now.Add(30 * time.Second)
+	_, ok = g.Validate(rfcSecret, code)
+	assert.False(t, ok, "two steps old code rejected")
+
+	_, ok = g.Validate(rfcSecret, "12345")
+	assert.False(t, ok, "short code rejected")
+	_, ok = g.Validate("not base32!", code)
+	assert.False(t, ok, "invalid secret rejected")
+}
+
+func Test_TOTP_ProvisioningURI(t *testing.T) {
+	g := totp.NewGenerator("newsletter-assignment", time.Now)
+
+	secret, err := g.GenerateSecret()
+	assert.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	uri, err := url.Parse(g.ProvisioningURI("test@test.com", secret))
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/newsletter-assignment:test@test.com", uri.Path)
+	assert.Equal(t, secret, uri.Query().Get("secret"))
+	assert.Equal(t, "newsletter-assignment", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
+
+func Test_TOTP_RecoveryCodes(t *testing.T) {
+	codes, err := totp.GenerateRecoveryCodes(10)
+	assert.Nil(t, err)
+	assert.Len(t, codes, 10)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.False(t, seen[code], "duplicate recovery code")
+		seen[code] = true
+	}
+
+	assert.Equal(t, totp.HashRecoveryCode(codes[0]), totp.HashRecoveryCode(strings.ToUpper(codes[0])))
+	assert.Equal(t, totp.HashRecoveryCode(codes[0]), totp.HashRecoveryCode(strings.ReplaceAll(codes[0], "-", "")))
+	assert.NotEqual(t, totp.HashRecoveryCode(codes[0]), totp.HashRecoveryCode(codes[1]))
+}
+
+type fakeTOTPUserRepository struct {
+	user           *domain.User
+	secret         string
+	recoveryCodes  map[string]bool
+	lastStep       *int64
+	challengeID    string
+	failedAttempts int
+	lockedUntil    time.Time
+	clock          *fakeClock
+}
+
+func (r *fakeTOTPUserRepository) GetByEmailAndPassword(_ context.Context, _ *domain.Email, _ *domain.Password) (*domain.User, error) {
+	return r.user, nil
+}
+
+func (r *fakeTOTPUserRepository) GetByID(_ context.Context, _ *domain.ID) (*domain.User, error) {
+	return r.user, nil
+}
+
+func (r *fakeTOTPUserRepository) GetTOTPSecret(_ context.Context, _ *domain.ID) (string, bool, error) {
+	return r.secret, r.user.TOTPEnabled(), nil
+}
+
+func (r *fakeTOTPUserRepository) UseRecoveryCode(_ context.Context, _ *domain.ID, code string) error {
+	hash := totp.HashRecoveryCode(code)
+	if !r.recoveryCodes[hash] {
+		return application.InvalidTOTPCodeError
+	}
+	delete(r.recoveryCodes, hash)
+
+	return nil
+}
+
+func (r *fakeTOTPUserRepository) UseTOTPStep(_ context.Context, _ *domain.ID, step int64) error {
+	if r.lastStep != nil && *r.lastStep >= step {
+		return application.InvalidTOTPCodeError
+	}
+	r.lastStep = &step
+
+	return nil
+}
+
+func (r *fakeTOTPUserRepository) EnableTOTP(_ context.Context, _ *domain.ID, _ []string) error {
+	r.user = domain.CreateUserFromExisting(r.user.ID(), r.user.Email(), nil, true)
+
+	return nil
+}
+
+func (r *fakeTOTPUserRepository) StartTOTPChallenge(_ context.Context, _, challengeID *domain.ID) error {
+	r.challengeID = challengeID.String()
+
+	return nil
+}
+
+func (r *fakeTOTPUserRepository) GetTOTPLoginState(_ context.Context, _ *domain.ID) (*dto.TOTPLoginState, error) {
+	return &dto.TOTPLoginState{ChallengeID: r.challengeID, Locked: r.clock.now.Before(r.lockedUntil)}, nil
+}
+
+func (r *fakeTOTPUserRepository) FailTOTPLogin(_ context.Context, _ *domain.ID, maxAttempts int, lockout time.Duration) error {
+	r.failedAttempts++
+	if r.failedAttempts >= maxAttempts {
+		r.failedAttempts = 0
+		r.lockedUntil = r.clock.now.Add(lockout)
+		r.challengeID = ""
+	}
+
+	return nil
+}
+
+func (r *fakeTOTPUserRepository) FinishTOTPLogin(_ context.Context, _, challengeID *domain.ID) error {
+	if r.challengeID != challengeID.String() {
+		return application.InvalidTokenError
+	}
+	r.failedAttempts = 0
+	r.lockedUntil = time.Time{}
+	r.challengeID = ""
+
+	return nil
+}
+
+type fakeTokenManager struct{}
+
+func (fakeTokenManager) GenerateUserToken(user *domain.User) (string, error) {
+	return "user:" + user.ID().String(), nil
+}
+
+func (fakeTokenManager) GenerateChallengeToken(user *domain.User, challengeID *domain.ID) (string, error) {
+	return "challenge:" + user.ID().String() + ":" + challengeID.String(), nil
+}
+
+func (fakeTokenManager) ParseChallengeToken(tokenStr string) (string, string, error) {
+	claims, ok := strings.CutPrefix(tokenStr, "challenge:")
+	if !ok {
+		return "", "", application.InvalidTokenError
+	}
+	subject, challengeID, ok := strings.Cut(claims, ":")
+	if !ok {
+		return "", "", application.InvalidTokenError
+	}
+
+	return subject, challengeID, nil
+}
+
+func Test_TOTP_TwoStepLogin(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(1234567890, 0)}
+	g := totp.NewGenerator("newsletter", clock.Now)
+
+	email, _ := domain.NewEmail("test@test.com")
+	user := domain.CreateUserFromExisting(domain.NewID(), email, nil, true)
+	repo := &fakeTOTPUserRepository{
+		user:          user,
+		secret:        rfcSecret,
+		recoveryCodes: map[string]bool{totp.HashRecoveryCode("abcde-fghij"): true},
+		clock:         clock,
+	}
+
+	luh := handler.NewLoginUserHandler(repo, fakeTokenManager{}, fakeTokenManager{}, repo)
+	vtlh := handler.NewVerifyTOTPLoginHandler(fakeTokenManager{}, repo, g, fakeTokenManager{})
+
+	login := func() string {
+		result, err := luh.Handle(ctx, "test@test.com", "P@$$w0rD")
+		assert.Nil(t, err)
+		assert.True(t, result.ChallengeRequired)
+		assert.True(t, strings.HasPrefix(result.Token, "challenge:"+user.ID().String()+":"))
+
+		return result.Token
+	}
+
+	challenge := login()
+
+	_, err := vtlh.Handle(ctx, challenge, "000000", "")
+	assert.ErrorIs(t, err, application.InvalidTOTPCodeError)
+
+	_, err = vtlh.Handle(ctx, "user:"+user.ID().String(), "005924", "")
+	assert.ErrorIs(t, err, application.InvalidTokenError)
+
+	token, err := vtlh.Handle(ctx, challenge, "005924", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "user:"+user.ID().String(), token)
+
+	_, err = vtlh.Handle(ctx, challenge, "", "abcde-fghij")
+	assert.ErrorIs(t, err, application.InvalidTokenError, "challenge is single use")
+
+	_, err = vtlh.Handle(ctx, login(), "005924", "")
+	assert.ErrorIs(t, err, application.InvalidTOTPCodeError, "totp code is single use")
+
+	previous, err := g.GenerateCode(rfcSecret, clock.now.Add(-30*time.Second))
+	assert.Nil(t, err)
+	_, err = vtlh.Handle(ctx, login(), previous, "")
+	assert.ErrorIs(t, err, application.InvalidTOTPCodeError, "code older than used one is rejected")
+
+	clock.now = clock.now.Add(30 * time.Second)
+	next, err := g.GenerateCode(rfcSecret, clock.now)
+	assert.Nil(t, err)
+	_, err = vtlh.Handle(ctx, login(), next, "")
+	assert.Nil(t, err, "code of next step is accepted")
+
+	token, err = vtlh.Handle(ctx, login(), "", "ABCDE-FGHIJ")
+	assert.Nil(t, err)
+	assert.Equal(t, "user:"+user.ID().String(), token)
+
+	_, err = vtlh.Handle(ctx, login(), "", "abcde-fghij")
+	assert.ErrorIs(t, err, application.InvalidTOTPCodeError, "recovery code is single use")
+}
+
+func Test_TOTP_OnlyLastChallengeAccepted(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(1234567890, 0)}
+	g := totp.NewGenerator("newsletter", clock.Now)
+
+	email, _ := domain.NewEmail("test@test.com")
+	user := domain.CreateUserFromExisting(domain.NewID(), email, nil, true)
+	repo := &fakeTOTPUserRepository{user: user, secret: rfcSecret, clock: clock}
+
+	luh := handler.NewLoginUserHandler(repo, fakeTokenManager{}, fakeTokenManager{}, repo)
+	vtlh := handler.NewVerifyTOTPLoginHandler(fakeTokenManager{}, repo, g, fakeTokenManager{})
+
+	first, err := luh.Handle(ctx, "test@test.com", "P@$$w0rD")
+	assert.Nil(t, err)
+	second, err := luh.Handle(ctx, "test@test.com", "P@$$w0rD")
+	assert.Nil(t, err)
+
+	_, err = vtlh.Handle(ctx, first.Token, "005924", "")
+	assert.ErrorIs(t, err, application.InvalidTokenError, "older challenge is replaced by new login")
+
+	_, err = vtlh.Handle(ctx, "challenge:"+user.ID().String()+":not-an-id", "005924", "")
+	assert.ErrorIs(t, err, application.InvalidTokenError)
+
+	_, err = vtlh.Handle(ctx, second.Token, "005924", "")
+	assert.Nil(t, err)
+}
+
+func Test_TOTP_LockAfterFailedAttempts(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(1234567890, 0)}
+	g := totp.NewGenerator("newsletter", clock.Now)
+
+	email, _ := domain.NewEmail("test@test.com")
+	user := domain.CreateUserFromExisting(domain.NewID(), email, nil, true)
+	repo := &fakeTOTPUserRepository{
+		user:          user,
+		secret:        rfcSecret,
+		recoveryCodes: map[string]bool{totp.HashRecoveryCode("abcde-fghij"): true},
+		clock:         clock,
+	}
+
+	luh := handler.NewLoginUserHandler(repo, fakeTokenManager{}, fakeTokenManager{}, repo)
+	vtlh := handler.NewVerifyTOTPLoginHandler(fakeTokenManager{}, repo, g, fakeTokenManager{})
+
+	result, err := luh.Handle(ctx, "test@test.com", "P@$$w0rD")
+	assert.Nil(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err = vtlh.Handle(ctx, result.Token, "000000", "")
+		assert.ErrorIs(t, err, application.InvalidTOTPCodeError, "attempt %d", i+1)
+	}
+
+	_, err = vtlh.Handle(ctx, result.Token, "005924", "")
+	assert.ErrorIs(t, err, application.TOTPLockedError, "valid code rejected while locked")
+
+	result, err = luh.Handle(ctx, "test@test.com", "P@$$w0rD")
+	assert.Nil(t, err)
+	_, err = vtlh.Handle(ctx, result.Token, "", "abcde-fghij")
+	assert.ErrorIs(t, err, application.TOTPLockedError, "new challenge does not lift lock")
+
+	clock.now = clock.now.Add(15 * time.Minute)
+	code, err := g.GenerateCode(rfcSecret, clock.now)
+	assert.Nil(t, err)
+	_, err = vtlh.Handle(ctx, result.Token, code, "")
+	assert.Nil(t, err, "login possible after lockout passes")
+	assert.Equal(t, 0, repo.failedAttempts)
+}
+
+func Test_TOTP_LoginWithoutTOTP(t *testing.T) {
+	email, _ := domain.NewEmail("test@test.com")
+	user := domain.CreateUserFromExisting(domain.NewID(), email, nil, false)
+	repo := &fakeTOTPUserRepository{user: user}
+
+	luh := handler.NewLoginUserHandler(repo, fakeTokenManager{}, fakeTokenManager{}, repo)
+
+	result, err := luh.Handle(context.Background(), "test@test.com", "P@$$w0rD")
+	assert.Nil(t, err)
+	assert.False(t, result.ChallengeRequired)
+	assert.Equal(t, "user:"+user.ID().String(), result.Token)
+}
+
+func Test_TOTP_ConfirmRejectsReplayedCode(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Unix(1234567890, 0)}
+	g := totp.NewGenerator("newsletter", clock.Now)
+
+	email, _ := domain.NewEmail("test@test.com")
+	user := domain.CreateUserFromExisting(domain.NewID(), email, nil, false)
+	step := clock.now.Unix() / 30
+	repo := &fakeTOTPUserRepository{user: user, secret: rfcSecret, lastStep: &step}
+
+	cth := handler.NewConfirmTOTPHandler(repo, g, totp.GenerateRecoveryCodes)
+
+	_, err := cth.Handle(ctx, user.ID().String(), "005924")
+	assert.ErrorIs(t, err, application.InvalidTOTPCodeError, "code of used step is rejected")
+	assert.False(t, repo.user.TOTPEnabled())
+
+	clock.now = clock.now.Add(30 * time.Second)
+	code, err := g.GenerateCode(rfcSecret, clock.now)
+	assert.Nil(t, err)
+	codes, err := cth.Handle(ctx, user.ID().String(), code)
+	assert.Nil(t, err)
+	assert.Len(t, codes, 10)
+	assert.True(t, repo.user.TOTPEnabled())
+}
@@ -0,0 +1,278 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/javor454/newsletter-assignment/internal/infrastructure/tracking"
+	"github.com/javor454/newsletter-assignment/internal/ui/http/controller"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+type fakeTracking struct {
+	settings *domain.TrackingSettings
+	opens    []*domain.EmailOpen
+	clicks   []*domain.EmailClick
+}
+
+func (f *fakeTracking) UpdateTrackingSettings(_ context.Context, _ *domain.ID, settings *domain.TrackingSettings) error {
+	f.settings = settings
+
+	return nil
+}
+
+func (f *fakeTracking) RecordEmailOpen(_ context.Context, open *domain.EmailOpen) error {
+	f.opens = append(f.opens, open)
+
+	return nil
+}
+
+func (f *fakeTracking) RecordEmailClick(_ context.Context, click *domain.EmailClick) error {
+	f.clicks = append(f.clicks, click)
+
+	return nil
+}
+
+func Test_Tracker_OpenToken(t *testing.T) {
+	sentAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	tracker := tracking.NewTracker("http://localhost:8080", "secret", func() time.Time { return sentAt })
+	jobID := domain.NewID().String()
+
+	url := tracker.OpenPixelURL(jobID)
+	assert.True(t, strings.HasPrefix(url, "http://localhost:8080/api/v1/track/open/"+jobID+".1709280000."))
+
+	token := url[strings.LastIndex(url, "/")+1:]
+	parsedID, parsedSentAt, err := tracker.ParseOpenToken(token)
+	assert.Nil(t, err)
+	assert.Equal(t, jobID, parsedID)
+	assert.True(t, sentAt.Equal(parsedSentAt))
+
+	parts := strings.Split(token, ".")
+	for name, forged := range map[string]string{
+		"other job":      domain.NewID().String() + "." + parts[1] + "." + parts[2],
+		"other time":     parts[0] + ".1709290000." + parts[2],
+		"no signature":   parts[0] + "." + parts[1],
+		"empty":          "",
+		"garbage":        "a.b.c",
+		"trailing parts": token + ".x",
+	} {
+		_, _, err := tracker.ParseOpenToken(forged)
+		assert.ErrorIs(t, err, application.InvalidTrackingTokenError, name)
+	}
+
+	other := tracking.NewTracker("http://localhost:8080", "other-secret", time.Now)
+	_, _, err = other.ParseOpenToken(token)
+	assert.ErrorIs(t, err, application.InvalidTrackingTokenError, "token signed by other secret")
+}
+
+func Test_Tracker_ClickToken(t *testing.T) {
+	tracker := tracking.NewTracker("http://localhost:8080", "secret", time.Now)
+	jobID := domain.NewID().String()
+	target := "https://example.com/article?id=1&ref=news"
+
+	link, err := url.Parse(tracker.ClickURL(jobID, 2, target))
+	assert.Nil(t, err)
+	assert.Equal(t, target, link.Query().Get("url"))
+
+	token := link.Path[strings.LastIndex(link.Path, "/")+1:]
+	parsedID, index, err := tracker.ParseClickToken(token, target)
+	assert.Nil(t, err)
+	assert.Equal(t, jobID, parsedID)
+	assert.Equal(t, 2, index)
+
+	parts := strings.Split(token, ".")
+	for name, forged := range map[string]struct{ token, target string }{
+		"other target": {token: token, target: "https://evil.example.com"},
+		"other index":  {token: parts[0] + ".3." + parts[2], target: target},
+		"other job":    {token: domain.NewID().String() + "." + parts[1] + "." + parts[2], target: target},
+		"open token":   {token: parts[0] + "." + parts[1], target: target},
+		"empty target": {token: token, target: ""},
+	} {
+		_, _, err := tracker.ParseClickToken(forged.token, forged.target)
+		assert.ErrorIs(t, err, application.InvalidTrackingTokenError, name)
+	}
+}
+
+func Test_Tracker_RewriteLinks(t *testing.T) {
+	tracker := tracking.NewTracker("http://localhost:8080", "secret", time.Now)
+	jobID := domain.NewID().String()
+	unsubscribe := "http://localhost:8080/api/v1/unsubscribe?token=abc"
+
+	content, err := tracker.RewriteLinks(
+		`<p><a href="https://example.com/a" style="color:red">A</a> <a href="mailto:editor@example.com">Mail</a></p>`+
+			`<p><a href="`+unsubscribe+`">Leave</a> <a href="http://example.com/b">B</a> <a>No link</a></p>`,
+		jobID,
+	)
+	assert.Nil(t, err)
+
+	doc, err := html.Parse(strings.NewReader(content))
+	assert.Nil(t, err)
+	var hrefs []string
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			for _, attr := range n.Attr {
+				if attr.Key == "href" {
+					hrefs = append(hrefs, attr.Val)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			collect(c)
+		}
+	}
+	collect(doc)
+
+	assert.Equal(t, []string{
+		tracker.ClickURL(jobID, 0, "https://example.com/a"),
+		"mailto:editor@example.com",
+		unsubscribe,
+		tracker.ClickURL(jobID, 3, "http://example.com/b"),
+	}, hrefs)
+	assert.Contains(t, content, `style="color:red"`)
+}
+
+func Test_TrackClick_RedirectsOnlySignedLinks(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	tracker := tracking.NewTracker("http://localhost:8080", "secret", time.Now)
+	clicks := &fakeTracking{}
+	c := controller.NewTrackingController(
+		newDiscardLogger(),
+		nil,
+		nil,
+		nil,
+		handler.NewRecordEmailClickHandler(tracker, clicks, func() time.Time { return now }),
+	)
+	engine := gin.New()
+	engine.GET("api/v1/track/click/:token", c.TrackClick)
+
+	jobID := domain.NewID().String()
+	target := "https://example.com/article?id=1"
+	link := tracker.ClickURL(jobID, 1, target)
+	path := link[strings.Index(link, "/api"):]
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, target, w.Header().Get("Location"))
+
+	forged := path[:strings.Index(path, "?")] + "?url=" + url.QueryEscape("https://evil.example.com")
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, forged, nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+
+	assert.Len(t, clicks.clicks, 1, "forged link is not recorded")
+	assert.Equal(t, jobID, clicks.clicks[0].EmailJobID().String())
+	assert.Equal(t, 1, clicks.clicks[0].LinkIndex())
+	assert.True(t, now.Equal(clicks.clicks[0].ClickedAt()))
+}
+
+func Test_ClassifyUserAgent(t *testing.T) {
+	cases := map[string]domain.UserAgentClass{
+		"Mozilla/5.0": domain.UserAgentPrivacyProxy,
+		"Mozilla/5.0 (Windows NT 5.1; rv:11.0) Gecko Firefox/11.0 (via ggpht.com GoogleImageProxy)":              domain.UserAgentProxy,
+		"YahooMailProxy; https://help.yahoo.com/kb/yahoo-mail-proxy-SLN28749.html":                               domain.UserAgentProxy,
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile": domain.UserAgentMobile,
+		"Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Mobile Safari":      domain.UserAgentMobile,
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko)":               domain.UserAgentDesktop,
+		"Microsoft Office/16.0 (Windows NT 10.0; Microsoft Outlook 16.0.17029; Pro)":                             domain.UserAgentDesktop,
+		"Mozilla/5.0 (compatible; Barracuda scanner)":                                                            domain.UserAgentBot,
+		"curl/8.4.0":   domain.UserAgentBot,
+		"":             domain.UserAgentUnknown,
+		"SomeClient/1": domain.UserAgentUnknown,
+	}
+
+	for userAgent, expected := range cases {
+		assert.Equal(t, expected, domain.ClassifyUserAgent(userAgent), userAgent)
+	}
+}
+
+func Test_EmailOpen_Prefetched(t *testing.T) {
+	sentAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	desktop := "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko)"
+
+	cases := map[string]struct {
+		userAgent string
+		after     time.Duration
+		expected  bool
+	}{
+		"reader":                   {userAgent: desktop, after: time.Hour, expected: false},
+		"webmail proxy":            {userAgent: "GoogleImageProxy", after: time.Minute, expected: false},
+		"apple privacy protection": {userAgent: "Mozilla/5.0", after: time.Hour, expected: true},
+		"link scanner":             {userAgent: "Mozilla/5.0 (compatible; Barracuda scanner)", after: time.Hour, expected: true},
+		"right after sending":      {userAgent: desktop, after: 3 * time.Second, expected: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			open := domain.NewEmailOpen(domain.NewID(), tc.userAgent, sentAt, sentAt.Add(tc.after))
+
+			assert.Equal(t, tc.expected, open.Prefetched())
+		})
+	}
+}
+
+func Test_TrackOpen_AlwaysReturnsPixel(t *testing.T) {
+	sentAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	tracker := tracking.NewTracker("http://localhost:8080", "secret", func() time.Time { return sentAt })
+	opens := &fakeTracking{}
+	c := controller.NewTrackingController(
+		newDiscardLogger(),
+		nil,
+		nil,
+		handler.NewRecordEmailOpenHandler(tracker, opens, func() time.Time { return sentAt.Add(time.Hour) }),
+		nil,
+	)
+	engine := gin.New()
+	engine.GET("api/v1/track/open/:token", c.TrackOpen)
+
+	jobID := domain.NewID().String()
+	url := tracker.OpenPixelURL(jobID)
+	for _, path := range []string{url[strings.Index(url, "/api"):], "/api/v1/track/open/forged.1.x"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Mobile")
+		engine.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/gif", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Cache-Control"), "no-store")
+		assert.True(t, strings.HasPrefix(w.Body.String(), "GIF89a"))
+	}
+
+	assert.Len(t, opens.opens, 1, "forged token is not recorded")
+	assert.Equal(t, jobID, opens.opens[0].EmailJobID().String())
+	assert.Equal(t, domain.UserAgentMobile, opens.opens[0].UserAgentClass())
+	assert.False(t, opens.opens[0].Prefetched())
+}
+
+func Test_UpdateTrackingSettings_OnlyOwner(t *testing.T) {
+	members := newFakeMemberRepository()
+	owner := members.addUser("owner@example.com", domain.RoleOwner)
+	editor := members.addUser("editor@example.com", domain.RoleEditor)
+	settings := &fakeTracking{}
+	h := handler.NewUpdateTrackingSettingsHandler(members, settings)
+	pubID := members.newsletter.PublicID().String()
+
+	_, err := h.Handle(context.Background(), editor.ID().String(), pubID, false, true)
+	assert.ErrorIs(t, err, application.InsufficientRoleError)
+	assert.Nil(t, settings.settings)
+
+	updated, err := h.Handle(context.Background(), owner.ID().String(), pubID, false, true)
+	assert.Nil(t, err)
+	assert.False(t, updated.Opens())
+	assert.True(t, updated.Clicks())
+	assert.False(t, settings.settings.Opens())
+	assert.True(t, settings.settings.Clicks())
+}
@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/javor454/newsletter-assignment/internal/application"
+	"github.com/javor454/newsletter-assignment/internal/application/handler"
+	"github.com/javor454/newsletter-assignment/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeUnsubscribeTokens struct{}
+
+func (f *fakeUnsubscribeTokens) ParseToken(token string) (string, error) {
+	if token != "valid" {
+		return "", errors.New("invalid token")
+	}
+
+	return "test@test.com", nil
+}
+
+type fakeUnsubscribeReasons struct {
+	email  *domain.Email
+	reason *domain.UnsubscribeReason
+}
+
+func (f *fakeUnsubscribeReasons) SaveUnsubscribeReason(
+	_ context.Context,
+	email *domain.Email,
+	_ *domain.ID,
+	reason *domain.UnsubscribeReason,
+) error {
+	f.email = email
+	f.reason = reason
+
+	return nil
+}
+
+func Test_NewUnsubscribeReason(t *testing.T) {
+	for _, code := range domain.UnsubscribeReasonCodes() {
+		reason, err := domain.NewUnsubscribeReason(code, "")
+		assert.Nil(t, err, code)
+		assert.Equal(t, code, reason.Code())
+		assert.Empty(t, reason.Comment())
+	}
+
+	reason, err := domain.NewUnsubscribeReason("other", "  Moved to competitor \n")
+	assert.Nil(t, err)
+	assert.Equal(t, "Moved to competitor", reason.Comment())
+
+	_, err = domain.NewUnsubscribeReason("", "")
+	assert.ErrorIs(t, err, application.InvalidUnsubscribeReasonError)
+	_, err = domain.NewUnsubscribeReason("bored", "")
+	assert.ErrorIs(t, err, application.InvalidUnsubscribeReasonError)
+
+	_, err = domain.NewUnsubscribeReason("other", strings.Repeat("ž", 1000))
+	assert.Nil(t, err)
+	_, err = domain.NewUnsubscribeReason("other", strings.Repeat("ž", 1001))
+	assert.ErrorIs(t, err, application.InvalidUnsubscribeReasonError)
+}
+
+func Test_SaveUnsubscribeReason(t *testing.T) {
+	ctx := context.Background()
+	pubID := domain.NewID().String()
+	reasons := &fakeUnsubscribeReasons{}
+	h := handler.NewSaveUnsubscribeReasonHandler(&fakeUnsubscribeTokens{}, reasons)
+
+	err := h.Handle(ctx, pubID, "forged", "other", "")
+	assert.ErrorIs(t, err, application.InvalidTokenError)
+	err = h.Handle(ctx, "not-uuid", "valid", "other", "")
+	assert.ErrorIs(t, err, application.InvalidUUIDError)
+	err = h.Handle(ctx, pubID, "valid", "bored", "")
+	assert.ErrorIs(t, err, application.InvalidUnsubscribeReasonError)
+	assert.Nil(t, reasons.reason)
+
+	err = h.Handle(ctx, pubID, "valid", "too_frequent", "Every day is too much")
+	assert.Nil(t, err)
+	assert.Equal(t, "test@test.com", reasons.email.String())
+	assert.Equal(t, "too_frequent", reasons.reason.Code())
+	assert.Equal(t, "Every day is too much", reasons.reason.Comment())
+}